@@ -6,4 +6,9 @@ const (
 	// Routes with higher weight implies higher priority, and are evaluated before routes with lower weight.
 	// By default, routes have a weight of 0.
 	RoutePrecedenceWeight = "kgateway.dev/route-weight"
+
+	// UDPSessionIdleTimeout is an annotation that can be set on a UDPRoute to specify how long a UDP
+	// session may be idle before it is removed, as a duration string such as "30s" or "5m".
+	// By default, sessions are removed after 60s of inactivity.
+	UDPSessionIdleTimeout = "kgateway.dev/udp-session-idle-timeout"
 )
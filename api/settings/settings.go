@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -238,6 +239,33 @@ type Settings struct {
 	// EnableExperimentalGatewayAPIFeatures enables kgateway to support experimental features and APIs
 	EnableExperimentalGatewayAPIFeatures bool `split_words:"true" default:"true"`
 
+	// EnableBackendHealthStatus enables periodic collection of per-cluster endpoint health from gateway proxies.
+	// The aggregated health is reported as a condition on Backend status and on BackendConfigPolicy ancestor status.
+	// Requires the stats of the gateway proxies to be enabled in their GatewayParameters; proxies without
+	// a stats port are not collected from.
+	EnableBackendHealthStatus bool `split_words:"true" default:"false"`
+
+	// BackendHealthStatusInterval is how often endpoint health is collected from gateway proxies
+	// when EnableBackendHealthStatus is set.
+	BackendHealthStatusInterval time.Duration `split_words:"true" default:"30s"`
+
+	// EnableJwksStatus enables periodic probing of the remote JWKS of JWT GatewayExtensions by the controller.
+	// Whether the JWKS could be fetched and contained valid keys is reported as a condition on GatewayExtension status.
+	// The controller fetches them from the referenced backends, with the TLS settings the proxies use for them.
+	EnableJwksStatus bool `split_words:"true" default:"false"`
+
+	// JwksStatusInterval is how often remote JWKS are probed when EnableJwksStatus is set.
+	JwksStatusInterval time.Duration `split_words:"true" default:"1m"`
+
+	// AuthVerifierService is the Service of the kgateway auth verifier (cmd/authverifier), as namespace/name.
+	// The auth verifier verifies the passwords of basic auth policies with the Kgateway verifier, SHA256 API keys
+	// and API consumers. The proxies reach it through the cluster of the Service, which must be secured with mTLS
+	// by a BackendConfigPolicy. Policies requiring the auth verifier are rejected when it is not set.
+	AuthVerifierService string `split_words:"true"`
+
+	// AuthVerifierServicePort is the port of the AuthVerifierService.
+	AuthVerifierServicePort uint32 `split_words:"true" default:"9000"`
+
 	// GatewayClassParametersRefs configures the GatewayParameters references to set on the default GatewayClasses.
 	// Format: JSON map where keys are GatewayClass names and values are objects with "name" (required),
 	// "namespace" (required), "group" (optional), and "kind" (optional) fields.
@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/require"
@@ -51,6 +52,12 @@ func allEnvVarsSet() map[string]string {
 		"KGW_XDS_AUTH":                                 "false",
 		"KGW_XDS_TLS":                                  "true",
 		"KGW_ENABLE_EXPERIMENTAL_GATEWAY_API_FEATURES": "false",
+		"KGW_ENABLE_BACKEND_HEALTH_STATUS":             "true",
+		"KGW_BACKEND_HEALTH_STATUS_INTERVAL":           "10s",
+		"KGW_ENABLE_JWKS_STATUS":                       "true",
+		"KGW_JWKS_STATUS_INTERVAL":                     "5m",
+		"KGW_AUTH_VERIFIER_SERVICE":                    "kgateway-system/auth-verifier",
+		"KGW_AUTH_VERIFIER_SERVICE_PORT":               "9443",
 	}
 }
 
@@ -105,6 +112,11 @@ func TestSettings(t *testing.T) {
 				XdsTLS:                               false,
 				EnableExperimentalGatewayAPIFeatures: true,
 				GatewayClassParametersRefs:           GatewayClassParametersRefs{},
+				EnableBackendHealthStatus:            false,
+				BackendHealthStatusInterval:          30 * time.Second,
+				EnableJwksStatus:                     false,
+				JwksStatusInterval:                   time.Minute,
+				AuthVerifierServicePort:              9000,
 			},
 		},
 		{
@@ -143,6 +155,12 @@ func TestSettings(t *testing.T) {
 				XdsAuth:                              false,
 				XdsTLS:                               true,
 				EnableExperimentalGatewayAPIFeatures: false,
+				EnableBackendHealthStatus:            true,
+				BackendHealthStatusInterval:          10 * time.Second,
+				EnableJwksStatus:                     true,
+				JwksStatusInterval:                   5 * time.Minute,
+				AuthVerifierService:                  "kgateway-system/auth-verifier",
+				AuthVerifierServicePort:              9443,
 				GatewayClassParametersRefs: GatewayClassParametersRefs{
 					"kgateway": {
 						Name:      "custom-gwp",
@@ -235,6 +253,11 @@ func TestSettings(t *testing.T) {
 				XdsTLS:                               false,
 				EnableExperimentalGatewayAPIFeatures: true,
 				GatewayClassParametersRefs:           GatewayClassParametersRefs{},
+				EnableBackendHealthStatus:            false,
+				BackendHealthStatusInterval:          30 * time.Second,
+				EnableJwksStatus:                     false,
+				JwksStatusInterval:                   time.Minute,
+				AuthVerifierServicePort:              9000,
 			},
 		},
 	}
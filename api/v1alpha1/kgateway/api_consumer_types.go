@@ -0,0 +1,134 @@
+package kgateway
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// +kubebuilder:rbac:groups=gateway.kgateway.dev,resources=apiconsumers,verbs=get;list;watch
+
+// +kubebuilder:printcolumn:name="Plan",type=string,JSONPath=".spec.plan.name",description="Plan of the API consumer"
+
+// APIConsumer is a named consumer of the APIs exposed by the gateway. It groups the credentials the
+// consumer authenticates with, and the plan applied to its requests by the TrafficPolicies selecting
+// it with apiConsumers.
+//
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:metadata:labels={app=kgateway,app.kubernetes.io/name=kgateway}
+// +kubebuilder:resource:categories=kgateway
+type APIConsumer struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +required
+	Spec APIConsumerSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+type APIConsumerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []APIConsumer `json:"items"`
+}
+
+// APIConsumerSpec describes the desired state of an APIConsumer.
+type APIConsumerSpec struct {
+	// credentials lists the principals the consumer authenticates as. A request resolves to the
+	// first consumer, in name order, holding the principal it is authenticated as.
+	// +required
+	Credentials APIConsumerCredentials `json:"credentials"`
+
+	// plan is applied to the requests of the consumer.
+	// +optional
+	Plan *APIConsumerPlan `json:"plan,omitempty"`
+}
+
+// APIConsumerCredentials lists the principals of a consumer, as authenticated by the
+// TrafficPolicy authentication policies.
+// +kubebuilder:validation:AtLeastOneOf=apiKeys;jwt;basicAuth;mtls
+type APIConsumerCredentials struct {
+	// apiKeys are the client identifiers of the API keys of the consumer, which default to the name
+	// of the Secret entry holding the key. See APIKeyAuthentication.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=64
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=253
+	APIKeys []string `json:"apiKeys,omitempty"`
+
+	// jwt lists the JWT principals of the consumer, matched against the claims of the token
+	// validated by the jwt policy.
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	JWT []APIConsumerJWTPrincipal `json:"jwt,omitempty"`
+
+	// basicAuth are the usernames of the consumer, authenticated by the basicAuth policy.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=64
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=253
+	BasicAuth []string `json:"basicAuth,omitempty"`
+
+	// mtls are the identities of the client certificates of the consumer. The identity of a
+	// certificate is its first URI SAN, or its first DNS SAN, or its subject otherwise.
+	// Client certificates must be validated by the Gateway listener.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=64
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=1024
+	MTLS []string `json:"mtls,omitempty"`
+}
+
+// APIConsumerJWTPrincipal matches the claims of a validated JWT.
+// +kubebuilder:validation:AtLeastOneOf=subject;clientId
+type APIConsumerJWTPrincipal struct {
+	// issuer matches the 'iss' claim. Any issuer matches if unset.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Issuer *string `json:"issuer,omitempty"`
+
+	// subject matches the 'sub' claim.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Subject *string `json:"subject,omitempty"`
+
+	// clientId matches the 'client_id' claim, or the 'azp' claim if the token has no 'client_id' claim.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	ClientID *string `json:"clientId,omitempty"`
+}
+
+// APIConsumerPlan is the plan applied to the requests of a consumer.
+type APIConsumerPlan struct {
+	// name of the plan. It is emitted as dynamic metadata along with the name of the consumer.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// rateLimit is the quota of the consumer, enforced locally by each gateway proxy on each route
+	// or virtual host the selecting TrafficPolicy applies to. Requests of the consumer over quota are
+	// rejected with a 429 status code.
+	// +optional
+	RateLimit *TokenBucket `json:"rateLimit,omitempty"`
+
+	// allowedRoutes are the names of the HTTPRoutes, in the namespace of the consumer, the consumer
+	// may access. Requests to other routes are rejected with a 403 status code. The consumer may
+	// access all routes if unset.
+	// Routes are only known when the selecting TrafficPolicy is attached to HTTPRoutes, so consumers
+	// with allowed routes are rejected by TrafficPolicies attached to Gateways.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=64
+	AllowedRoutes []gwv1.ObjectName `json:"allowedRoutes,omitempty"`
+
+	// requestHeaders are set on the requests of the consumer, overwriting the headers set by the client.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	RequestHeaders []gwv1.HTTPHeader `json:"requestHeaders,omitempty"`
+}
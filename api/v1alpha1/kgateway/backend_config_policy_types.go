@@ -104,6 +104,124 @@ type BackendConfigPolicySpec struct {
 	// See [Envoy documentation](https://www.envoyproxy.io/docs/envoy/latest/intro/arch_overview/upstream/circuit_breaking) for more details.
 	// +optional
 	CircuitBreakers *CircuitBreakers `json:"circuitBreakers,omitempty"`
+
+	// UpstreamProxyProtocol configures the proxy to send a PROXY protocol header on new
+	// connections to the backend, so that the backend can see the original client address.
+	// If TLS is also configured, the PROXY protocol header is sent before the TLS handshake.
+	// See [Envoy documentation](https://www.envoyproxy.io/docs/envoy/latest/api-v3/extensions/transport_sockets/proxy_protocol/v3/upstream_proxy_protocol.proto) for more details.
+	// +optional
+	UpstreamProxyProtocol *UpstreamProxyProtocol `json:"upstreamProxyProtocol,omitempty"`
+
+	// Preconnect configures the proxy to establish connections to the backend ahead of
+	// the requests that need them, hiding the connection and TLS handshake latency.
+	// See [Envoy documentation](https://www.envoyproxy.io/docs/envoy/latest/api-v3/config/cluster/v3/cluster.proto#envoy-v3-api-msg-config-cluster-v3-cluster-preconnectpolicy) for more details.
+	// +optional
+	Preconnect *Preconnect `json:"preconnect,omitempty"`
+}
+
+// Preconnect configures connection warming for a backend. Ratios are decimal
+// numbers between 1 and 3, where 1 disables preconnecting.
+type Preconnect struct {
+	// PerUpstreamRatio is the number of connections kept established to each host,
+	// relative to the number needed for the requests in flight. For example, 1.5
+	// keeps 3 connections ready for 2 in-flight HTTP/1.1 requests.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self.matches('^[0-9]+(\\\\.[0-9]+)?$') && double(self) >= 1.0 && double(self) <= 3.0",message="perUpstreamRatio must be a decimal number between 1 and 3"
+	PerUpstreamRatio *string `json:"perUpstreamRatio,omitempty"`
+
+	// PredictiveRatio is the number of connections established across the backend,
+	// relative to the number needed for the requests in flight, before the load balancer
+	// has picked a host. This warms connections to new hosts, such as after a scale-out.
+	// Only applies to load balancers that pick hosts independently of the request,
+	// such as round robin and random.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self.matches('^[0-9]+(\\\\.[0-9]+)?$') && double(self) >= 1.0 && double(self) <= 3.0",message="predictiveRatio must be a decimal number between 1 and 3"
+	PredictiveRatio *string `json:"predictiveRatio,omitempty"`
+}
+
+// UpstreamProxyProtocol configures the PROXY protocol header sent to backends.
+// +kubebuilder:validation:XValidation:rule="(!has(self.passThroughTLVs) && !has(self.addedTLVs)) || self.version == 'V2'",message="TLVs are only supported with PROXY protocol version V2"
+type UpstreamProxyProtocol struct {
+	// Version is the PROXY protocol version to use.
+	// Defaults to V1.
+	// +optional
+	// +kubebuilder:default=V1
+	Version ProxyProtocolVersion `json:"version,omitempty"`
+
+	// PassThroughTLVs configures which TLVs received in the downstream PROXY protocol
+	// header are forwarded to the backend. This requires the PROXY protocol listener
+	// filter to be enabled on the Gateway with a ListenerPolicy.
+	// If unset, no TLVs are forwarded.
+	// +optional
+	PassThroughTLVs *ProxyProtocolPassThroughTLVs `json:"passThroughTLVs,omitempty"`
+
+	// AddedTLVs are custom TLVs added to the PROXY protocol header sent to the backend.
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=map
+	// +listMapKey=type
+	AddedTLVs []ProxyProtocolTLV `json:"addedTLVs,omitempty"`
+
+	// AllowUnspecifiedAddress allows the connection to proceed when the downstream
+	// address is not an IP address (for example a pipe). In that case, the PROXY protocol
+	// header is sent with the unspecified address (LOCAL command for V2, UNKNOWN for V1).
+	// If unset, defaults to false and such connections fail.
+	// +optional
+	AllowUnspecifiedAddress *bool `json:"allowUnspecifiedAddress,omitempty"`
+}
+
+// ProxyProtocolVersion is the version of the PROXY protocol.
+// +kubebuilder:validation:Enum=V1;V2
+type ProxyProtocolVersion string
+
+const (
+	// ProxyProtocolVersionV1 is the human readable PROXY protocol version 1.
+	ProxyProtocolVersionV1 ProxyProtocolVersion = "V1"
+	// ProxyProtocolVersionV2 is the binary PROXY protocol version 2.
+	ProxyProtocolVersionV2 ProxyProtocolVersion = "V2"
+)
+
+// ProxyProtocolPassThroughTLVs configures which downstream TLVs are passed through to the backend.
+// +kubebuilder:validation:XValidation:rule="self.matchType == 'IncludeAll' ? !has(self.types) : has(self.types)",message="types must be set if and only if matchType is Include"
+type ProxyProtocolPassThroughTLVs struct {
+	// MatchType determines whether all TLVs or only the listed types are passed through.
+	// +required
+	MatchType ProxyProtocolTLVMatchType `json:"matchType"`
+
+	// Types are the TLV types to pass through when MatchType is Include.
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:Minimum=0
+	// +kubebuilder:validation:items:Maximum=255
+	Types []int32 `json:"types,omitempty"`
+}
+
+// ProxyProtocolTLVMatchType is the match type used when passing through TLVs.
+// +kubebuilder:validation:Enum=IncludeAll;Include
+type ProxyProtocolTLVMatchType string
+
+const (
+	// ProxyProtocolTLVMatchIncludeAll passes through all TLVs.
+	ProxyProtocolTLVMatchIncludeAll ProxyProtocolTLVMatchType = "IncludeAll"
+	// ProxyProtocolTLVMatchInclude passes through only the listed TLV types.
+	ProxyProtocolTLVMatchInclude ProxyProtocolTLVMatchType = "Include"
+)
+
+// ProxyProtocolTLV is a single Type-Length-Value entry added to the PROXY protocol header.
+type ProxyProtocolTLV struct {
+	// Type is the TLV type.
+	// +required
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=255
+	Type int32 `json:"type"`
+
+	// Value is the TLV value. It is sent as the raw bytes of the string.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=1024
+	Value string `json:"value"`
 }
 
 // CircuitBreakers contains the options to configure circuit breaker thresholds for the default priority.
@@ -188,6 +306,14 @@ type CommonHttpProtocolOptions struct {
 	// +optional
 	// +kubebuilder:validation:Minimum=0
 	MaxRequestsPerConnection *int32 `json:"maxRequestsPerConnection,omitempty"`
+
+	// The maximum duration of an upstream connection. When reached, the connection is
+	// drained: HTTP/2 connections are sent a GOAWAY and closed once their in-flight
+	// streams complete, and HTTP/1.1 connections are closed after the current request.
+	// If not specified, connections are not closed based on their age.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	MaxConnectionDuration *metav1.Duration `json:"maxConnectionDuration,omitempty"`
 }
 type Http2ProtocolOptions struct {
 	// InitialStreamWindowSize is the initial window size for the stream.
@@ -215,6 +341,34 @@ type Http2ProtocolOptions struct {
 	// When enabled, only the offending stream is terminated.
 	// +optional
 	OverrideStreamErrorOnInvalidHttpMessage *bool `json:"overrideStreamErrorOnInvalidHttpMessage,omitempty"`
+
+	// ConnectionKeepalive configures HTTP/2 PINGs on the upstream connections, so that
+	// dead connections are detected and closed instead of being reused.
+	// +optional
+	ConnectionKeepalive *Http2ConnectionKeepalive `json:"connectionKeepalive,omitempty"`
+}
+
+// Http2ConnectionKeepalive configures HTTP/2 keepalive PINGs.
+// +kubebuilder:validation:AtLeastOneOf=interval;connectionIdleInterval
+type Http2ConnectionKeepalive struct {
+	// Interval is the time between PINGs sent on the connection.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1ms')",message="interval must be at least 1ms"
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Timeout is how long to wait for a PING response before closing the connection.
+	// +required
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1ms')",message="timeout must be at least 1ms"
+	Timeout metav1.Duration `json:"timeout"`
+
+	// ConnectionIdleInterval sends a PING before a request is sent on a connection that
+	// has been idle for this long, so that requests are not sent on stale connections.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1ms')",message="connectionIdleInterval must be at least 1ms"
+	ConnectionIdleInterval *metav1.Duration `json:"connectionIdleInterval,omitempty"`
 }
 
 // See [Envoy documentation](https://www.envoyproxy.io/docs/envoy/latest/api-v3/config/core/v3/address.proto#envoy-v3-api-msg-config-core-v3-tcpkeepalive) for more details.
@@ -334,6 +488,7 @@ type TLSFiles struct {
 }
 
 // +kubebuilder:validation:ExactlyOneOf=leastRequest;roundRobin;ringHash;maglev;random
+// +kubebuilder:validation:XValidation:rule="!(has(self.localZonePreference) && has(self.localityWeights))",message="localZonePreference and localityWeights are mutually exclusive"
 type LoadBalancer struct {
 	// HealthyPanicThreshold configures envoy's panic threshold percentage between 0-100. Once the number of non-healthy hosts
 	// reaches this percentage, envoy disregards health information.
@@ -376,6 +531,26 @@ type LoadBalancer struct {
 	// +kubebuilder:validation:Enum=WeightedLb
 	LocalityType *LocalityType `json:"localityType,omitempty"`
 
+	// LocalZonePreference keeps a share of the traffic in the zone of the proxy, while still
+	// spreading the rest to the other zones. Unlike a PreferSameZone trafficDistribution,
+	// endpoints in other zones are not held back as failover priorities; kgateway computes
+	// static locality weights for each proxy from the zone the proxy runs in.
+	// This is not Envoy zone aware routing: the weights are computed from the number of
+	// endpoints known to kgateway in each zone, not from the traffic or health of the proxies.
+	// Setting this enables locality weighted load balancing.
+	// +optional
+	LocalZonePreference *LocalZonePreference `json:"localZonePreference,omitempty"`
+
+	// LocalityWeights sets explicit load balancing weights for the localities of the backend's
+	// endpoints. The first entry matching a locality is used; localities matching no entry keep
+	// a weight equal to the sum of their endpoint weights.
+	// Setting this enables locality weighted load balancing.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
+	LocalityWeights []LocalityWeight `json:"localityWeights,omitempty"`
+
 	// If set to true, the load balancer will drain connections when the host set changes.
 	//
 	// Ring Hash or Maglev can be used to ensure that clients with the same key
@@ -482,6 +657,62 @@ type (
 	}
 )
 
+// LocalZonePreference configures the locality weights that prefer the zone of the proxy.
+// Each locality in the zone of the proxy gets a weight of
+// `endpoints * (100 - localPercentage) + localPercentage * totalEndpoints * endpoints / localEndpoints`,
+// and every other locality a weight of `endpoints * (100 - localPercentage)`, with a minimum of 1.
+// Weights are computed per priority. Envoy scales each locality weight by the share of its
+// healthy endpoints, so the other zones take over when the endpoints of the local zone are unhealthy.
+type LocalZonePreference struct {
+	// LocalPercentage is the percentage of requests kept in the zone of the proxy. The remaining
+	// requests are spread across all zones in proportion to their number of endpoints.
+	// Defaults to 100, with which the other zones keep a weight of 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	LocalPercentage *int32 `json:"localPercentage,omitempty"`
+
+	// MinEndpoints is the minimum number of endpoints the backend must have for the local zone
+	// to be preferred. The localities of smaller backends keep their default weights.
+	// Defaults to 6.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MinEndpoints *int32 `json:"minEndpoints,omitempty"`
+
+	// FailWithoutLocalEndpoints, when true, sends no endpoints to the proxies in a zone without
+	// endpoints for the backend, so that their requests fail instead of leaving the zone. This
+	// only depends on the endpoints known to kgateway: the requests are still sent to the other
+	// zones when the endpoints of the local zone exist but are unhealthy.
+	// Defaults to false, with which proxies in a zone without endpoints use the default weights.
+	// +optional
+	FailWithoutLocalEndpoints *bool `json:"failWithoutLocalEndpoints,omitempty"`
+}
+
+// LocalityWeight assigns a load balancing weight to the localities it matches.
+// +kubebuilder:validation:XValidation:rule="!has(self.subZone) || has(self.zone)",message="zone must be set when subZone is set"
+type LocalityWeight struct {
+	// Region of the locality.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Region string `json:"region"`
+
+	// Zone of the locality. If unset, all zones in the region match.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Zone *string `json:"zone,omitempty"`
+
+	// SubZone of the locality. If unset, all sub-zones in the zone match.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	SubZone *string `json:"subZone,omitempty"`
+
+	// Weight is the load balancing weight of the matching localities.
+	// +required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000000
+	Weight int32 `json:"weight"`
+}
+
 type LocalityType string
 
 const (
@@ -494,7 +725,7 @@ const (
 // HealthCheck contains the options to configure the health check.
 // See [Envoy documentation](https://www.envoyproxy.io/docs/envoy/latest/api-v3/config/core/v3/health_check.proto) for more details.
 
-// +kubebuilder:validation:XValidation:rule="has(self.http) != has(self.grpc)",message="exactly one of http or grpc must be set"
+// +kubebuilder:validation:ExactlyOneOf=http;grpc;tcp
 type HealthCheck struct {
 	// Timeout is time to wait for a health check response. If the timeout is reached the
 	// health check attempt will be considered a failure.
@@ -529,6 +760,38 @@ type HealthCheck struct {
 	// Grpc contains the options to configure the gRPC health check.
 	// +optional
 	Grpc *HealthCheckGrpc `json:"grpc,omitempty"`
+
+	// Tcp contains the options to configure the TCP health check.
+	// +optional
+	Tcp *HealthCheckTcp `json:"tcp,omitempty"`
+
+	// NoTrafficInterval is the interval between health checks while the backend has not
+	// received traffic yet. If unset, Envoy defaults to 60s.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	NoTrafficInterval *metav1.Duration `json:"noTrafficInterval,omitempty"`
+
+	// UnhealthyInterval is the interval between health checks of hosts marked unhealthy.
+	// If unset, Interval is used.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	UnhealthyInterval *metav1.Duration `json:"unhealthyInterval,omitempty"`
+
+	// UnhealthyEdgeInterval is the interval until the next health check after a host is
+	// marked unhealthy. If unset, UnhealthyInterval is used.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	UnhealthyEdgeInterval *metav1.Duration `json:"unhealthyEdgeInterval,omitempty"`
+
+	// HealthyEdgeInterval is the interval until the next health check after a host is
+	// marked healthy. If unset, Interval is used.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	HealthyEdgeInterval *metav1.Duration `json:"healthyEdgeInterval,omitempty"`
+
+	// TLSOptions configures the TLS connection of health checks, when the backend uses TLS.
+	// +optional
+	TLSOptions *HealthCheckTLSOptions `json:"tlsOptions,omitempty"`
 }
 type HealthCheckHttp struct {
 	// Host is the value of the host header in the HTTP health check request. If
@@ -546,6 +809,67 @@ type HealthCheckHttp struct {
 	// +optional
 	// +kubebuilder:validation:Enum=GET;HEAD;POST;PUT;DELETE;OPTIONS;TRACE;PATCH
 	Method *string `json:"method,omitempty"`
+
+	// ExpectedStatuses are the ranges of HTTP response statuses considered healthy.
+	// If unset, only 200 is considered healthy.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	ExpectedStatuses []HealthCheckStatusRange `json:"expectedStatuses,omitempty"`
+
+	// RequestHeadersToAdd are headers added to the health check requests.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	RequestHeadersToAdd []gwv1.HTTPHeader `json:"requestHeadersToAdd,omitempty"`
+}
+
+// HealthCheckStatusRange is a range of HTTP response statuses.
+// +kubebuilder:validation:XValidation:rule="self.start < self.end",message="start must be less than end"
+type HealthCheckStatusRange struct {
+	// Start of the range, inclusive.
+	// +required
+	// +kubebuilder:validation:Minimum=100
+	// +kubebuilder:validation:Maximum=599
+	Start int32 `json:"start"`
+
+	// End of the range, exclusive.
+	// +required
+	// +kubebuilder:validation:Minimum=101
+	// +kubebuilder:validation:Maximum=600
+	End int32 `json:"end"`
+}
+
+// HealthCheckTcp configures a TCP health check. If neither Send nor Receive is
+// set, a successful connection is considered healthy.
+type HealthCheckTcp struct {
+	// Send is the hex encoded payload sent on the connection.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9a-fA-F]{2})+$`
+	// +kubebuilder:validation:MaxLength=4096
+	Send *string `json:"send,omitempty"`
+
+	// Receive are the hex encoded payloads expected in the response. The response is
+	// considered healthy when it contains all of them, in order.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:Pattern=`^([0-9a-fA-F]{2})+$`
+	// +kubebuilder:validation:items:MaxLength=4096
+	Receive []string `json:"receive,omitempty"`
+}
+
+// HealthCheckTLSOptions configures the TLS connection of health checks.
+type HealthCheckTLSOptions struct {
+	// ALPNProtocols are the ALPN protocols offered in health check connections.
+	// If unset, the ALPN protocols of the backend are used.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	ALPNProtocols []string `json:"alpnProtocols,omitempty"`
 }
 
 type HealthCheckGrpc struct {
@@ -563,6 +887,8 @@ type HealthCheckGrpc struct {
 // OutlierDetection contains the options to configure passive health checks.
 // See [Envoy documentation](https://www.envoyproxy.io/docs/envoy/latest/intro/arch_overview/upstream/outlier#outlier-detection) for more details.
 
+// +kubebuilder:validation:XValidation:rule="!has(self.consecutiveLocalOriginFailure) || (has(self.splitExternalLocalOriginErrors) && self.splitExternalLocalOriginErrors)",message="consecutiveLocalOriginFailure requires splitExternalLocalOriginErrors to be true"
+// +kubebuilder:validation:XValidation:rule="!has(self.maxEjectionTime) || !has(self.baseEjectionTime) || duration(self.maxEjectionTime) >= duration(self.baseEjectionTime)",message="maxEjectionTime must not be less than baseEjectionTime"
 type OutlierDetection struct {
 	// The number of consecutive server-side error responses (for HTTP traffic,
 	// 5xx responses; for TCP traffic, connection failures; etc.) before an
@@ -599,6 +925,102 @@ type OutlierDetection struct {
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=100
 	MaxEjectionPercent *int32 `json:"maxEjectionPercent,omitempty"`
+
+	// The maximum time that a host is ejected for, capping the growth of
+	// BaseEjectionTime with repeated ejections. If unset, Envoy defaults to 300s.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	MaxEjectionTime *metav1.Duration `json:"maxEjectionTime,omitempty"`
+
+	// The number of consecutive gateway failures (502, 503 and 504 responses, and
+	// connection failures, resets and timeouts) before an ejection occurs.
+	// If unset, gateway failures are not used for ejection.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ConsecutiveGatewayFailure *int32 `json:"consecutiveGatewayFailure,omitempty"`
+
+	// SplitExternalLocalOriginErrors tracks errors originating locally (connection
+	// failures, resets and timeouts) separately from the responses of the backend.
+	// Defaults to false.
+	// +optional
+	SplitExternalLocalOriginErrors *bool `json:"splitExternalLocalOriginErrors,omitempty"`
+
+	// The number of consecutive locally originated failures before an ejection occurs.
+	// Requires SplitExternalLocalOriginErrors to be true.
+	// If unset, locally originated failures are not used for ejection.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ConsecutiveLocalOriginFailure *int32 `json:"consecutiveLocalOriginFailure,omitempty"`
+
+	// SuccessRate configures ejection of hosts whose success rate is far below the
+	// average of the backend. Envoy enables success rate ejection with default settings
+	// when this is unset.
+	// +optional
+	SuccessRate *OutlierDetectionSuccessRate `json:"successRate,omitempty"`
+
+	// FailurePercentage configures ejection of hosts whose failure percentage
+	// exceeds a threshold. If unset, failure percentage ejection is disabled.
+	// +optional
+	FailurePercentage *OutlierDetectionFailurePercentage `json:"failurePercentage,omitempty"`
+}
+
+// OutlierDetectionSuccessRate configures success rate based outlier detection.
+type OutlierDetectionSuccessRate struct {
+	// The % chance that a host is ejected when detected as an outlier.
+	// Set to 0 to disable success rate ejection. Defaults to 100.
+	// When SplitExternalLocalOriginErrors is true, it also applies to the success
+	// rate of the locally originated errors.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	EnforcingPercent *int32 `json:"enforcingPercent,omitempty"`
+
+	// The number of hosts that must have enough request volume for success rate
+	// ejection to be performed. Defaults to 5.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MinimumHosts *int32 `json:"minimumHosts,omitempty"`
+
+	// The minimum number of requests in an interval for a host to be included in
+	// success rate ejection. Defaults to 100.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	RequestVolume *int32 `json:"requestVolume,omitempty"`
+
+	// StdevFactor, divided by 1000, is the number of standard deviations below the
+	// average success rate at which a host is ejected. Defaults to 1900, i.e. 1.9.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	StdevFactor *int32 `json:"stdevFactor,omitempty"`
+}
+
+// OutlierDetectionFailurePercentage configures failure percentage based outlier detection.
+type OutlierDetectionFailurePercentage struct {
+	// The failure percentage at or above which a host is ejected. Defaults to 85.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Threshold *int32 `json:"threshold,omitempty"`
+
+	// The % chance that a host is ejected when its failure percentage reaches the
+	// threshold. Defaults to 100. When SplitExternalLocalOriginErrors is true, it
+	// also applies to the failure percentage of the locally originated errors.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	EnforcingPercent *int32 `json:"enforcingPercent,omitempty"`
+
+	// The number of hosts that must have enough request volume for failure
+	// percentage ejection to be performed. Defaults to 5.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MinimumHosts *int32 `json:"minimumHosts,omitempty"`
+
+	// The minimum number of requests in an interval for a host to be included in
+	// failure percentage ejection. Defaults to 50.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	RequestVolume *int32 `json:"requestVolume,omitempty"`
 }
 
 // +kubebuilder:validation:ExactlyOneOf=header;cookie;sourceIP
@@ -38,16 +38,19 @@ const (
 	BackendTypeStatic BackendType = "Static"
 	// BackendTypeDynamicForwardProxy is the type for dynamic forward proxy backends.
 	BackendTypeDynamicForwardProxy BackendType = "DynamicForwardProxy"
+	// BackendTypeRedis is the type for Redis backends.
+	BackendTypeRedis BackendType = "Redis"
 )
 
 // BackendSpec defines the desired state of Backend.
 // +kubebuilder:validation:XValidation:message="aws backend must be specified when type is 'AWS'",rule="self.type == 'AWS' ? has(self.aws) : true"
 // +kubebuilder:validation:XValidation:message="static backend must be specified when type is 'Static'",rule="self.type == 'Static' ? has(self.static) : true"
 // +kubebuilder:validation:XValidation:message="dynamicForwardProxy backend must be specified when type is 'DynamicForwardProxy'",rule="self.type == 'DynamicForwardProxy' ? has(self.dynamicForwardProxy) : true"
-// +kubebuilder:validation:ExactlyOneOf=aws;static;dynamicForwardProxy
+// +kubebuilder:validation:XValidation:message="redis backend must be specified when type is 'Redis'",rule="self.type == 'Redis' ? has(self.redis) : true"
+// +kubebuilder:validation:ExactlyOneOf=aws;static;dynamicForwardProxy;redis
 type BackendSpec struct {
 	// Type indicates the type of the backend to be used.
-	// +kubebuilder:validation:Enum=AWS;Static;DynamicForwardProxy
+	// +kubebuilder:validation:Enum=AWS;Static;DynamicForwardProxy;Redis
 	// +required
 	Type BackendType `json:"type"`
 	// Aws is the AWS backend configuration.
@@ -60,6 +63,10 @@ type BackendSpec struct {
 	// DynamicForwardProxy is the dynamic forward proxy backend configuration.
 	// +optional
 	DynamicForwardProxy *DynamicForwardProxyBackend `json:"dynamicForwardProxy,omitempty"`
+	// Redis is the Redis backend configuration.
+	// The Redis backend type is only supported with envoy-based gateways, it is not supported in agentgateway.
+	// +optional
+	Redis *RedisBackend `json:"redis,omitempty"`
 }
 
 // AppProtocol defines the application protocol to use when communicating with the backend.
@@ -85,13 +92,115 @@ type DynamicForwardProxyBackend struct {
 	// The hostname will be used for SNI and auto SAN validation.
 	// +optional
 	EnableTls *bool `json:"enableTls,omitempty"`
+
+	// AllowedHosts restricts the destinations of the forward proxy to the hosts matching any of the
+	// given matches. The port of the host is ignored when matching. Requests to other destinations
+	// receive the DenyResponse. When omitted, any destination is allowed.
+	// The hosts are matched against the host of the request, so routes to the backend cannot rewrite
+	// the host with a URLRewrite hostname or autoHostRewrite. Such routes are replaced with a 500 response.
+	// +optional
+	// +kubebuilder:validation:MaxItems=64
+	AllowedHosts []DynamicForwardProxyHostMatch `json:"allowedHosts,omitempty"`
+
+	// DenyResponse is the response sent for requests to destinations that are not allowed by AllowedHosts.
+	// Defaults to a 403 response.
+	// +optional
+	DenyResponse *DynamicForwardProxyDenyResponse `json:"denyResponse,omitempty"`
+
+	// DNSCache configures the cache of resolved destination hosts.
+	// +optional
+	DNSCache *DynamicForwardProxyDNSCache `json:"dnsCache,omitempty"`
+}
+
+// DynamicForwardProxyHostMatchType specifies the semantics of how a destination host is matched.
+type DynamicForwardProxyHostMatchType string
+
+const (
+	// DynamicForwardProxyHostMatchExact matches the host exactly.
+	DynamicForwardProxyHostMatchExact DynamicForwardProxyHostMatchType = "Exact"
+	// DynamicForwardProxyHostMatchSuffix matches the hosts ending with the value at a label boundary.
+	// `example.com` matches `example.com` and its subdomains, but not `evilexample.com`, and
+	// `.example.com` only matches the subdomains of `example.com`.
+	DynamicForwardProxyHostMatchSuffix DynamicForwardProxyHostMatchType = "Suffix"
+	// DynamicForwardProxyHostMatchRegularExpression matches the hosts with the RE2 regular expression
+	// in value. The expression must match the whole host.
+	DynamicForwardProxyHostMatchRegularExpression DynamicForwardProxyHostMatchType = "RegularExpression"
+)
+
+// DynamicForwardProxyHostMatch matches the destination host of a dynamic forward proxy request.
+type DynamicForwardProxyHostMatch struct {
+	// Type specifies how to match the host. Defaults to Exact.
+	// +optional
+	// +kubebuilder:validation:Enum=Exact;Suffix;RegularExpression
+	// +kubebuilder:default=Exact
+	Type DynamicForwardProxyHostMatchType `json:"type,omitempty"`
+
+	// Value is the value to match the host against.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Value string `json:"value"`
+}
+
+// DynamicForwardProxyDenyResponse is the response sent for requests to destinations that are not allowed.
+type DynamicForwardProxyDenyResponse struct {
+	// StatusCode is the status code of the response. Defaults to 403.
+	// +optional
+	// +kubebuilder:validation:Minimum=400
+	// +kubebuilder:validation:Maximum=599
+	StatusCode *int32 `json:"statusCode,omitempty"`
+
+	// Body is the body of the response, sent with the `text/plain` content type.
+	// +optional
+	// +kubebuilder:validation:MaxLength=4096
+	Body *string `json:"body,omitempty"`
+}
+
+// DynamicForwardProxyDNSCache configures the cache of resolved destination hosts. Each destination host
+// is resolved and load balanced independently, and is evicted from the cache once it is unused for HostTTL.
+type DynamicForwardProxyDNSCache struct {
+	// MaxHosts is the maximum number of destination hosts in the cache. Requests to new hosts fail once
+	// the cache is full. Defaults to 1024.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxHosts *int32 `json:"maxHosts,omitempty"`
+
+	// HostTTL is the duration after which an unused destination host is evicted from the cache.
+	// Defaults to 5m.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1ms')",message="hostTTL must be at least 1ms."
+	HostTTL *metav1.Duration `json:"hostTTL,omitempty"`
+
+	// RefreshRate is the interval at which the addresses of the cached hosts are resolved again.
+	// Defaults to 5s.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1ms')",message="refreshRate must be at least 1ms."
+	RefreshRate *metav1.Duration `json:"refreshRate,omitempty"`
+
+	// RespectDNSTTL uses the TTL of the DNS records as the refresh interval of the hosts instead of RefreshRate.
+	// +optional
+	RespectDNSTTL *bool `json:"respectDNSTTL,omitempty"`
+
+	// PreresolvedHosts are resolved and added to the cache on startup, so that the first requests to them
+	// do not wait for DNS resolution.
+	// +optional
+	// +kubebuilder:validation:MaxItems=64
+	PreresolvedHosts []Host `json:"preresolvedHosts,omitempty"`
 }
 
 // AwsBackend is the AWS backend configuration.
+// +kubebuilder:validation:ExactlyOneOf=lambda;service
 type AwsBackend struct {
 	// Lambda configures the AWS lambda service.
-	// +required
-	Lambda AwsLambda `json:"lambda"`
+	// +optional
+	Lambda AwsLambda `json:"lambda,omitzero"`
+
+	// Service configures an arbitrary AWS service, such as S3, API Gateway, OpenSearch or SQS.
+	// Requests are forwarded to the service endpoint and signed with AWS Signature Version 4.
+	// +optional
+	Service *AwsService `json:"service,omitempty"`
 
 	// AccountId is the AWS account ID to use for the backend.
 	// +required
@@ -153,6 +262,12 @@ const (
 )
 
 // AwsLambda configures the AWS lambda service.
+//
+// Response streaming is only supported through a FunctionURL whose invoke mode is RESPONSE_STREAM.
+// Functions invoked through the Lambda Invoke API have their responses returned once complete, as
+// InvokeWithResponseStream is not supported.
+// +kubebuilder:validation:AtMostOneOf=endpointURL;functionURL
+// +kubebuilder:validation:XValidation:message="invocationMode 'Async' is not supported with functionURL",rule="has(self.functionURL) ? !(has(self.invocationMode) && self.invocationMode == 'Async') : true"
 type AwsLambda struct {
 	// EndpointURL is the URL or domain for the Lambda service. This is primarily
 	// useful for testing and development purposes. When omitted, the default
@@ -161,11 +276,24 @@ type AwsLambda struct {
 	// +kubebuilder:validation:Pattern="^https?://[-a-zA-Z0-9@:%.+~#?&/=]+$"
 	// +kubebuilder:validation:MaxLength=2048
 	EndpointURL *string `json:"endpointURL,omitempty"`
+	// FunctionURL is the Lambda function URL to invoke, e.g. `https://abcdefg.lambda-url.us-east-1.on.aws`.
+	// When set, requests are forwarded to the function URL as HTTP requests signed with AWS Signature
+	// Version 4, so the function URL must use the AWS_IAM auth type. The function URL transforms the request
+	// into an event and the function response back into an HTTP response itself, so PayloadTransformMode
+	// cannot be None. The responses are proxied as they are received, so the responses of a function URL
+	// whose invoke mode is RESPONSE_STREAM are streamed to the client.
+	// +optional
+	// +kubebuilder:validation:Pattern="^https?://[-a-zA-Z0-9@:%.+~#?&/=]+$"
+	// +kubebuilder:validation:MaxLength=2048
+	FunctionURL *string `json:"functionURL,omitempty"`
 	// FunctionName is the name of the Lambda function to invoke.
 	// +required
 	// +kubebuilder:validation:Pattern="^[A-Za-z0-9-_]{1,140}$"
 	FunctionName string `json:"functionName"`
 	// InvocationMode defines how to invoke the Lambda function.
+	// Async is not supported with a FunctionURL. There is no response streaming mode, as
+	// InvokeWithResponseStream is not supported: to stream responses, use a FunctionURL whose
+	// invoke mode is RESPONSE_STREAM.
 	// Defaults to Sync.
 	// +optional
 	// +kubebuilder:validation:Enum=Sync;Async
@@ -179,12 +307,40 @@ type AwsLambda struct {
 	// +kubebuilder:default=$LATEST
 	Qualifier string `json:"qualifier,omitempty"`
 	// PayloadTransformation specifies payload transformation mode before it is sent to the Lambda function.
+	// None is not supported with a FunctionURL, as the function URL performs the transformation,
+	// and Envoy is ignored.
 	// Defaults to Envoy.
 	// +optional
 	// +kubebuilder:default=Envoy
 	PayloadTransformMode AWSLambdaPayloadTransformMode `json:"payloadTransformMode,omitempty"`
 }
 
+// AwsService configures an arbitrary AWS service whose requests are signed with AWS Signature Version 4.
+// +kubebuilder:validation:XValidation:message="endpointURL must be specified for the execute-api, es and aoss services",rule="has(self.endpointURL) || !(self.name in ['execute-api', 'es', 'aoss'])"
+type AwsService struct {
+	// Name is the AWS service name used as the signing name, e.g. `s3`, `execute-api`, `es`, `aoss` or `sqs`.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern="^[a-z0-9-]+$"
+	Name string `json:"name"`
+
+	// EndpointURL is the URL of the service endpoint, e.g. `https://my-api.execute-api.us-east-1.amazonaws.com`
+	// or `https://search-my-domain.us-east-1.es.amazonaws.com`. When omitted, the regional endpoint
+	// `https://<name>.<region>.amazonaws.com` is used. The execute-api, es and aoss services have no
+	// regional endpoint, so it is required for them.
+	// The host header of the requests is rewritten to the endpoint hostname before they are signed.
+	// +optional
+	// +kubebuilder:validation:Pattern="^https?://[-a-zA-Z0-9@:%.+~#?&/=]+$"
+	// +kubebuilder:validation:MaxLength=2048
+	EndpointURL *string `json:"endpointURL,omitempty"`
+
+	// UnsignedPayload signs requests without hashing their body, so that the body does not need to be
+	// buffered. Only services that accept the `UNSIGNED-PAYLOAD` content hash, such as S3, support it.
+	// +optional
+	UnsignedPayload *bool `json:"unsignedPayload,omitempty"`
+}
+
 // AWSLambdaPayloadTransformMode defines the transformation mode for the payload in the request
 // before it is sent to the AWS Lambda function.
 //
@@ -227,6 +383,132 @@ type Host struct {
 	Port gwv1.PortNumber `json:"port"`
 }
 
+// RedisBackend proxies the Redis protocol to a set of Redis servers. TCPRoutes with a single
+// Redis backend use the Envoy redis_proxy filter, which decodes each command and routes it based on its key.
+// See [Envoy documentation](https://www.envoyproxy.io/docs/envoy/latest/intro/arch_overview/other_protocols/redis) for more details.
+// +kubebuilder:validation:XValidation:message="readPolicy requires cluster to be set",rule="has(self.readPolicy) && self.readPolicy != 'Primary' ? has(self.cluster) : true"
+type RedisBackend struct {
+	// RedisUpstream holds the Redis servers that receive the commands whose key matches no prefix route.
+	RedisUpstream `json:",inline"`
+
+	// PrefixRoutes route the commands whose key starts with the prefix to other Redis servers.
+	// When several prefixes match a key, the longest one wins.
+	// +optional
+	// +listType=map
+	// +listMapKey=prefix
+	// +kubebuilder:validation:MaxItems=64
+	PrefixRoutes []RedisPrefixRoute `json:"prefixRoutes,omitempty"`
+
+	// CaseInsensitivePrefixes makes the prefix routes ignore the case of the keys.
+	// +optional
+	CaseInsensitivePrefixes *bool `json:"caseInsensitivePrefixes,omitempty"`
+
+	// ReadPolicy selects the nodes of a Redis Cluster that serve the read commands.
+	// Only applies when Cluster is set. Defaults to Primary.
+	// +optional
+	ReadPolicy *RedisReadPolicy `json:"readPolicy,omitempty"`
+
+	// OpTimeout is the timeout of each command. Defaults to 5s.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1ms')",message="opTimeout must be at least 1ms"
+	OpTimeout *metav1.Duration `json:"opTimeout,omitempty"`
+
+	// EnableCommandStats emits the count and latency of each Redis command, such as GET and SET.
+	// +optional
+	EnableCommandStats *bool `json:"enableCommandStats,omitempty"`
+
+	// AuthSecretRef references a Secret in the namespace of the Backend holding the credentials
+	// the proxy uses to authenticate to all the Redis servers of the backend. The `password` key is
+	// required, and the `username` key is used for Redis ACL authentication when present.
+	// +optional
+	AuthSecretRef *corev1.LocalObjectReference `json:"authSecretRef,omitempty"`
+}
+
+// RedisUpstream is a set of Redis servers.
+type RedisUpstream struct {
+	// Hosts are the Redis servers. When Cluster is set, they are the seed nodes
+	// used to discover the topology of the Redis Cluster.
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Hosts []Host `json:"hosts"`
+
+	// Cluster treats the hosts as the nodes of a Redis Cluster. The proxy discovers the
+	// topology of the cluster and sends each command to the node owning the slot of its key.
+	// +optional
+	Cluster *RedisCluster `json:"cluster,omitempty"`
+}
+
+// RedisCluster configures the topology discovery of a Redis Cluster.
+type RedisCluster struct {
+	// RefreshRate is the interval between topology discoveries. Defaults to 5s.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1ms')",message="refreshRate must be at least 1ms"
+	RefreshRate *metav1.Duration `json:"refreshRate,omitempty"`
+
+	// RefreshTimeout is the timeout of a topology discovery. Defaults to 3s.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1ms')",message="refreshTimeout must be at least 1ms"
+	RefreshTimeout *metav1.Duration `json:"refreshTimeout,omitempty"`
+}
+
+// RedisReadPolicy selects the nodes of a Redis Cluster that serve the read commands.
+// +kubebuilder:validation:Enum=Primary;PreferPrimary;Replica;PreferReplica;Any
+type RedisReadPolicy string
+
+const (
+	// RedisReadPolicyPrimary reads from the primary nodes.
+	RedisReadPolicyPrimary RedisReadPolicy = "Primary"
+	// RedisReadPolicyPreferPrimary reads from the primary nodes, and from the replicas when the primary is unavailable.
+	RedisReadPolicyPreferPrimary RedisReadPolicy = "PreferPrimary"
+	// RedisReadPolicyReplica reads from the replica nodes.
+	RedisReadPolicyReplica RedisReadPolicy = "Replica"
+	// RedisReadPolicyPreferReplica reads from the replica nodes, and from the primary when no replica is available.
+	RedisReadPolicyPreferReplica RedisReadPolicy = "PreferReplica"
+	// RedisReadPolicyAny reads from any node.
+	RedisReadPolicyAny RedisReadPolicy = "Any"
+)
+
+// RedisPrefixRoute routes the commands whose key starts with a prefix.
+type RedisPrefixRoute struct {
+	// Prefix is the prefix of the keys routed to the hosts.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Prefix string `json:"prefix"`
+
+	// RemovePrefix removes the prefix from the keys before sending the commands to the hosts.
+	// +optional
+	RemovePrefix *bool `json:"removePrefix,omitempty"`
+
+	// RedisUpstream holds the Redis servers that receive the commands.
+	RedisUpstream `json:",inline"`
+
+	// Mirrors copy the commands to other Redis servers. The responses of the mirrors are ignored.
+	// +optional
+	// +kubebuilder:validation:MaxItems=4
+	Mirrors []RedisRequestMirror `json:"mirrors,omitempty"`
+}
+
+// RedisRequestMirror copies the commands of a prefix route to other Redis servers.
+type RedisRequestMirror struct {
+	// RedisUpstream holds the Redis servers that receive the copies.
+	RedisUpstream `json:",inline"`
+
+	// Percentage is the percentage of the commands that are copied. Defaults to 100.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percentage *int32 `json:"percentage,omitempty"`
+
+	// ExcludeReadCommands only copies the write commands.
+	// +optional
+	ExcludeReadCommands *bool `json:"excludeReadCommands,omitempty"`
+}
+
 // BackendStatus defines the observed state of Backend.
 type BackendStatus struct {
 	// Conditions is the list of conditions for the backend.
@@ -8,16 +8,18 @@ import (
 
 // BasicAuthPolicy configures HTTP basic authentication using the Authorization header.
 // Basic authentication validates requests against username/password pairs provided either inline or via a Kubernetes secret.
-// The credentials must be in htpasswd SHA-1 format.
+// The credentials must be in htpasswd format, with password hashes supported by the configured verifier.
 //
 // +kubebuilder:validation:ExactlyOneOf=users;secretRef;disable
+// +kubebuilder:validation:XValidation:rule="!has(self.disable) || !has(self.verifier)",message="verifier cannot be set when disable is set"
 type BasicAuthPolicy struct {
 	// Users provides an inline list of username/password pairs in htpasswd format.
 	// Each entry should be formatted as "username:hashed_password".
-	// The only supported hash format is SHA-1
+	// The supported hash formats depend on the verifier.
 	//
 	// Example entries:
 	//   - "user1:{SHA}d95o2uzYI7q7tY7bHI4U1xBug7s="
+	//   - "user2:$2y$05$r3J4d3VepzFkedkd/q1vI.pBYIpSqjfN0qOARV3ScUHysatnS0cL2" (requires the Kgateway verifier)
 	//
 	// +optional
 	// +kubebuilder:validation:MinItems=1
@@ -29,12 +31,40 @@ type BasicAuthPolicy struct {
 	// +optional
 	SecretRef *SecretReference `json:"secretRef,omitempty"`
 
+	// Verifier selects where the passwords are verified.
+	//
+	// Envoy verifies the passwords in the proxy and only supports SHA-1 hashes ("{SHA}").
+	//
+	// Kgateway verifies the passwords with the kgateway auth verifier, a separately deployed
+	// service the proxy calls through ext_authz, configured with the controller
+	// KGW_AUTH_VERIFIER_SERVICE setting. It supports SHA-1, bcrypt ("$2a$", "$2b$", "$2y$"), APR1 ("$apr1$"),
+	// SHA-256-crypt ("$5$") and SHA-512-crypt ("$6$") hashes, with at most 100000 rounds.
+	// Credentials longer than 256 bytes are rejected.
+	//
+	// Users with hashes the verifier does not support are rejected, making the policy invalid, as
+	// is the policy when the auth verifier is not configured.
+	// Defaults to Envoy.
+	//
+	// +optional
+	Verifier *BasicAuthVerifier `json:"verifier,omitempty"`
+
 	// Disable basic auth.
 	// Can be used to disable basic auth policies applied at a higher level in the config hierarchy.
 	// +optional
 	Disable *shared.PolicyDisable `json:"disable,omitempty"`
 }
 
+// BasicAuthVerifier selects where basic auth passwords are verified.
+// +kubebuilder:validation:Enum=Envoy;Kgateway
+type BasicAuthVerifier string
+
+const (
+	// BasicAuthVerifierEnvoy verifies the passwords in the proxy with the Envoy basic_auth filter.
+	BasicAuthVerifierEnvoy BasicAuthVerifier = "Envoy"
+	// BasicAuthVerifierKgateway verifies the passwords with the kgateway auth verifier.
+	BasicAuthVerifierKgateway BasicAuthVerifier = "Kgateway"
+)
+
 // SecretReference identifies a Kubernetes secret containing authentication data.
 type SecretReference struct {
 	// Name of the secret containing htpasswd data.
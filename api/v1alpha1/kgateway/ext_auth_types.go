@@ -1,6 +1,8 @@
 package kgateway
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
 )
 
@@ -11,6 +13,7 @@ import (
 // https://raw.githubusercontent.com/envoyproxy/envoy/f910f4abea24904aff04ec33a00147184ea7cffa/api/envoy/extensions/filters/http/ext_authz/v3/ext_authz.proto
 //
 // +kubebuilder:validation:ExactlyOneOf=extensionRef;disable
+// +kubebuilder:validation:XValidation:rule="!has(self.disable) || (!has(self.failOpen) && !has(self.decisionCache))",message="failOpen and decisionCache cannot be set when disable is set"
 type ExtAuthPolicy struct {
 	// ExtensionRef references the GatewayExtension that should be used for auth.
 	// +optional
@@ -25,12 +28,36 @@ type ExtAuthPolicy struct {
 	// +optional
 	ContextExtensions map[string]string `json:"contextExtensions,omitempty"`
 
+	// FailOpen overrides the failOpen setting of the GatewayExtension for the targeted routes,
+	// determining if requests are allowed when the ext auth service is unavailable.
+	// +optional
+	FailOpen *bool `json:"failOpen,omitempty"`
+
+	// DecisionCache overrides the decisionCache of the GatewayExtension for the targeted routes.
+	// The GatewayExtension must configure a decisionCache.
+	// +optional
+	DecisionCache *ExtAuthDecisionCachePolicy `json:"decisionCache,omitempty"`
+
 	// Disable all external auth filters.
 	// Can be used to disable external auth policies applied at a higher level in the config hierarchy.
 	// +optional
 	Disable *shared.PolicyDisable `json:"disable,omitempty"`
 }
 
+// ExtAuthDecisionCachePolicy overrides the decision cache of an ext auth service for a route.
+// +kubebuilder:validation:ExactlyOneOf=ttl;disable
+type ExtAuthDecisionCachePolicy struct {
+	// TTL overrides how long the decisions made for the route are cached.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid ttl value"
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1s') && duration(self) <= duration('1h')",message="ttl must be between 1s and 1h"
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// Disable the decision cache for the route, so that every request is sent to the ext auth service.
+	// +optional
+	Disable *shared.PolicyDisable `json:"disable,omitempty"`
+}
+
 // ExtAuthBufferSettings configures how the request body should be buffered.
 type ExtAuthBufferSettings struct {
 	// MaxRequestBytes sets the maximum size of a message body to buffer.
@@ -81,6 +108,16 @@ type ExtAuthProvider struct {
 	// +optional
 	HeadersToForward []string `json:"headersToForward,omitempty"`
 
+	// MetadataContextNamespaces lists the dynamic metadata namespaces forwarded to the external
+	// authorization service. For example, envoy.filters.http.jwt_authn forwards the claims of the
+	// JWTs validated by a TrafficPolicy jwtAuth, under the payload key.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=256
+	MetadataContextNamespaces []string `json:"metadataContextNamespaces,omitempty"`
+
 	// FailOpen determines if requests are allowed when the ext auth service is unavailable.
 	// Defaults to false, meaning requests will be denied if the ext auth service is unavailable.
 	// +optional
@@ -112,4 +149,72 @@ type ExtAuthProvider struct {
 	// +optional
 	// +kubebuilder:validation:MinLength=1
 	StatPrefix *string `json:"statPrefix,omitempty"`
+
+	// DecisionCache caches the decisions of the ext auth service in the gateway proxies, so that
+	// requests repeating a recent decision are not sent to the ext auth service.
+	// +optional
+	DecisionCache *ExtAuthDecisionCache `json:"decisionCache,omitempty"`
+}
+
+// ExtAuthDecisionCache configures the caching of the decisions of an ext auth service.
+//
+// Decisions are cached by each proxy worker, keyed by the route and the configured request
+// attributes, so the ext auth service must decide on these attributes only. Only allowed
+// decisions are cached, along with the request header changes and the dynamic metadata of the
+// decision, which are applied to the requests hitting the cache. The header changes are the headers
+// added or removed by the ext auth service, and the AuthHeaders. A decision is cached once the
+// response to the allowed request starts. Requests allowed by failOpen are not cached.
+// Until then, the decision, including the values of the headers set by the ext auth service, is
+// kept in the dynamic metadata of the request, where the filters handling the request, such as
+// ext_proc, can read it.
+type ExtAuthDecisionCache struct {
+	// TTL is how long decisions are cached.
+	// +required
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid ttl value"
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1s') && duration(self) <= duration('1h')",message="ttl must be between 1s and 1h"
+	TTL metav1.Duration `json:"ttl"`
+
+	// MaxEntries bounds the number of decisions cached by each proxy worker. The cache is
+	// flushed when full.
+	// Defaults to 10000.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000000
+	MaxEntries *int32 `json:"maxEntries,omitempty"`
+
+	// Key selects the request attributes, in addition to the route, decisions are cached by.
+	// +required
+	Key ExtAuthDecisionCacheKey `json:"key"`
+
+	// AuthHeaders are the request headers the ext auth service sets, overwrites or removes, such as
+	// x-user-id. Requests hitting the cache get the values these headers had after the cached
+	// decision, or have them removed, whatever values they carry. The headersToBackend of an HTTP
+	// ext auth service are included. Only the additions and removals of other headers are cached,
+	// so headers whose values the ext auth service overwrites must be listed.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=256
+	AuthHeaders []string `json:"authHeaders,omitempty"`
+}
+
+// ExtAuthDecisionCacheKey selects the request attributes ext auth decisions are cached by.
+type ExtAuthDecisionCacheKey struct {
+	// Headers are the request headers whose values are part of the key, such as authorization.
+	// Requests carrying none of these headers are not cached.
+	// +required
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=256
+	Headers []string `json:"headers"`
+
+	// PathPrefixSegments is the number of leading request path segments that are part of the key.
+	// For example, 2 keys the path /api/v1/users/42 as /api/v1. The path is not part of the key if unset.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=32
+	PathPrefixSegments *int32 `json:"pathPrefixSegments,omitempty"`
 }
@@ -40,16 +40,17 @@ type NamedJWTProvider struct {
 }
 
 // GatewayExtensionSpec defines the desired state of GatewayExtension.
-// +kubebuilder:validation:ExactlyOneOf=extAuth;extProc;rateLimit;jwt;oauth2
+// +kubebuilder:validation:ExactlyOneOf=extAuth;extProc;rateLimit;jwt;oauth2;oauth2Introspection
 // +kubebuilder:validation:XValidation:message="extAuth must be set when type is ExtAuth",rule="has(self.type) && self.type == 'ExtAuth' ? has(self.extAuth) : true"
 // +kubebuilder:validation:XValidation:message="extProc must be set when type is ExtProc",rule="has(self.type) && self.type == 'ExtProc' ? has(self.extProc) : true"
 // +kubebuilder:validation:XValidation:message="rateLimit must be set when type is RateLimit",rule="has(self.type) && self.type == 'RateLimit' ? has(self.rateLimit) : true"
 // +kubebuilder:validation:XValidation:message="JWT must be set when type is JWT",rule="has(self.type) && self.type == 'JWT' ? has(self.jwt) : true"
 // +kubebuilder:validation:XValidation:message="oauth2 must be set when type is OAuth2",rule="has(self.type) && self.type == 'OAuth2' ? has(self.oauth2) : true"
+// +kubebuilder:validation:XValidation:message="oauth2Introspection must be set when type is OAuth2Introspection",rule="has(self.type) && self.type == 'OAuth2Introspection' ? has(self.oauth2Introspection) : true"
 type GatewayExtensionSpec struct {
 	// Deprecated: Setting this field has no effect.
 	// Type indicates the type of the GatewayExtension to be used.
-	// +kubebuilder:validation:Enum=ExtAuth;ExtProc;RateLimit;JWT;OAuth2
+	// +kubebuilder:validation:Enum=ExtAuth;ExtProc;RateLimit;JWT;OAuth2;OAuth2Introspection
 	// +optional
 	Type *GatewayExtensionType `json:"type,omitempty"`
 
@@ -72,6 +73,10 @@ type GatewayExtensionSpec struct {
 	// OAuth2 configuration for OAuth2 extension type.
 	// +optional
 	OAuth2 *OAuth2Provider `json:"oauth2,omitempty"`
+
+	// OAuth2Introspection configuration for OAuth2Introspection extension type.
+	// +optional
+	OAuth2Introspection *OAuth2IntrospectionProvider `json:"oauth2Introspection,omitempty"`
 }
 
 type JWT struct {
@@ -119,6 +124,8 @@ const (
 	GatewayExtensionTypeJWT GatewayExtensionType = "JWT"
 	// GatewayExtensionTypeOAuth2 is the type for OAuth2 extensions.
 	GatewayExtensionTypeOAuth2 GatewayExtensionType = "OAuth2"
+	// GatewayExtensionTypeOAuth2Introspection is the type for OAuth2 token introspection extensions.
+	GatewayExtensionTypeOAuth2Introspection GatewayExtensionType = "OAuth2Introspection"
 )
 
 const HTTPDefaultTimeout = 2 * time.Second
@@ -270,6 +277,21 @@ const (
 	XRateLimitHeaderDraftV03 XRateLimitHeadersStandard = "DraftVersion03"
 )
 
+const (
+	// GatewayExtensionConditionJWKSResolved reports whether the remote JWKS of the JWT providers
+	// could be fetched and contained valid keys when last probed by the controller.
+	GatewayExtensionConditionJWKSResolved = "JWKSResolved"
+
+	// GatewayExtensionReasonJWKSResolved is used when all the remote JWKS were fetched and parsed.
+	GatewayExtensionReasonJWKSResolved = "Resolved"
+	// GatewayExtensionReasonJWKSFetchFailed is used when a remote JWKS could not be fetched.
+	GatewayExtensionReasonJWKSFetchFailed = "FetchFailed"
+	// GatewayExtensionReasonJWKSInvalidKeys is used when a remote JWKS did not contain valid keys.
+	GatewayExtensionReasonJWKSInvalidKeys = "InvalidKeys"
+	// GatewayExtensionReasonJWKSPending is used when some remote JWKS have not been probed yet.
+	GatewayExtensionReasonJWKSPending = "Pending"
+)
+
 // GatewayExtensionStatus defines the observed state of GatewayExtension.
 type GatewayExtensionStatus struct {
 	// Conditions is the list of conditions for the GatewayExtension.
@@ -10,6 +10,7 @@ import (
 
 // JWTAuthentication defines the providers used to configure JWT authentication
 // +kubebuilder:validation:ExactlyOneOf=extensionRef;disable
+// +kubebuilder:validation:XValidation:rule="!has(self.disable) || (!has(self.requiredScopes) && !has(self.claims) && !has(self.routeMatch))",message="requiredScopes, claims and routeMatch cannot be set when disable is set"
 type JWTAuthentication struct {
 	// ExtensionRef references a GatewayExtension that provides the jwt providers
 	// +optional
@@ -19,6 +20,60 @@ type JWTAuthentication struct {
 	// Can be used to disable JWT policies applied at a higher level in the config hierarchy.
 	// +optional
 	Disable *shared.PolicyDisable `json:"disable,omitempty"`
+
+	// RequiredScopes is the list of scopes that the validated JWT must grant.
+	// The scopes are read from the 'scope' claim, either as a space-delimited string
+	// or as an array. Requests missing any of the scopes are denied with a 403.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=256
+	// +optional
+	RequiredScopes []string `json:"requiredScopes,omitempty"`
+
+	// Claims is the list of claim requirements that the validated JWT must satisfy.
+	// All the requirements must be satisfied, otherwise the request is denied with a 403.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	// +optional
+	Claims []JWTClaimMatch `json:"claims,omitempty"`
+
+	// RouteMatch restricts the targeted route rules to requests whose validated JWT
+	// matches all of the claim matches. Requests that do not match fall through to the
+	// next matching route rule, e.g. to route tokens with 'tier=premium' to a dedicated backend.
+	//
+	// Claims are only available once a JWT policy has validated the token, so JWT authentication
+	// must also apply to the requests before the route is selected, e.g. through a JWT policy
+	// attached to the Gateway or to the route rule that the requests fall through to.
+	// Can only be used when targeting HTTPRoute resources.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	RouteMatch []JWTClaimMatch `json:"routeMatch,omitempty"`
+}
+
+// JWTClaimMatch matches the value of a claim in the validated JWT payload.
+// +kubebuilder:validation:ExactlyOneOf=exact;contains
+type JWTClaimMatch struct {
+	// Path is the path to the claim in the JWT payload. Each element is the key of a nested
+	// object, for example ["tier"] for a top level claim or ["realm_access", "roles"] for a
+	// nested one. Keys are used verbatim, so they may contain dots.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=256
+	// +required
+	Path []string `json:"path"`
+
+	// Exact requires the claim to be a string equal to this value.
+	// +kubebuilder:validation:MaxLength=1024
+	// +optional
+	Exact *string `json:"exact,omitempty"`
+
+	// Contains requires the claim to be an array that contains this string value.
+	// +kubebuilder:validation:MaxLength=1024
+	// +optional
+	Contains *string `json:"contains,omitempty"`
 }
 
 // JWTProvider configures the JWT Provider
@@ -117,9 +172,9 @@ type JWKS struct {
 	RemoteJWKS *RemoteJWKS `json:"remote,omitempty"`
 }
 
-// LocalJWKS configures getting the public keys to validate the JWT from a Kubernetes ConfigMap,
-// or inline (raw string) JWKS.
-// +kubebuilder:validation:ExactlyOneOf=inline;configMapRef
+// LocalJWKS configures getting the public keys to validate the JWT from a Kubernetes ConfigMap
+// or Secret, or inline (raw string) JWKS.
+// +kubebuilder:validation:ExactlyOneOf=inline;configMapRef;secretRef
 type LocalJWKS struct {
 	// Inline is the JWKS as the raw, inline JWKS string
 	// This can be an individual key, a key set or a pem block public key
@@ -132,6 +187,11 @@ type LocalJWKS struct {
 	// The ConfigMap must have a data key named 'jwks' that contains the JWKS.
 	// +optional
 	ConfigMapRef *corev1.LocalObjectReference `json:"configMapRef,omitempty"`
+
+	// SecretRef configures storing the JWK in a Kubernetes Secret in the same namespace as the GatewayExtension.
+	// The Secret must have a data key named 'jwks' that contains the JWKS.
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
 }
 
 type RemoteJWKS struct {
@@ -152,4 +212,25 @@ type RemoteJWKS struct {
 	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
 	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1ms')",message="cacheDuration must be at least 1ms."
 	CacheDuration *metav1.Duration `json:"cacheDuration,omitempty"`
+
+	// AsyncFetch configures fetching the JWKS in the background when the listener is created,
+	// and refreshing it before it expires, instead of fetching it on the first request that needs it.
+	// +optional
+	AsyncFetch *JWKSAsyncFetch `json:"asyncFetch,omitempty"`
+}
+
+// JWKSAsyncFetch configures fetching a remote JWKS in the background.
+type JWKSAsyncFetch struct {
+	// FastListener activates the listener without waiting for the first JWKS fetch to complete.
+	// Requests received before the JWKS is fetched fail JWT verification.
+	// If unset or false, the listener is only activated once the first fetch completes, successfully or not.
+	// +optional
+	FastListener *bool `json:"fastListener,omitempty"`
+
+	// FailedRefetchDuration is the duration after which a failed JWKS fetch is retried.
+	// If unspecified, the default is 1 second.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1ms')",message="failedRefetchDuration must be at least 1ms."
+	FailedRefetchDuration *metav1.Duration `json:"failedRefetchDuration,omitempty"`
 }
@@ -112,6 +112,42 @@ type ListenerConfig struct {
 	// that should map 1-to-1 with a given HTTP listener, such as the Envoy health check HTTP filter.
 	// +optional
 	HTTPSettings *HTTPSettings `json:"httpSettings,omitempty"`
+
+	// TCPSettings is intended to be used for configuring the Envoy `TcpProxy` network filter of the filter chains
+	// generated for TCPRoute and TLSRoute (passthrough) traffic.
+	// +optional
+	TCPSettings *TCPSettings `json:"tcpSettings,omitempty"`
+}
+
+// TCPSettings configures the Envoy `TcpProxy` network filter.
+// See here for more information: https://www.envoyproxy.io/docs/envoy/latest/api-v3/extensions/filters/network/tcp_proxy/v3/tcp_proxy.proto
+type TCPSettings struct {
+	// AccessLog contains various settings for Envoy's access logging service. An entry is emitted for each
+	// TCP connection once it closes.
+	// Format strings may use the connection-level command operators, such as %BYTES_RECEIVED%, %BYTES_SENT%,
+	// %UPSTREAM_HOST%, %UPSTREAM_CLUSTER%, %DOWNSTREAM_REMOTE_ADDRESS%, %REQUESTED_SERVER_NAME%, %DURATION%
+	// and %RESPONSE_FLAGS%. HTTP-only operators, such as %REQ(...)% and %RESPONSE_CODE%, are rendered as `-`.
+	// The statusCodeFilter, headerFilter and grpcStatusFilter filters are HTTP-only and are rejected, and the
+	// additional headers and trailers of a gRPC sink are ignored.
+	// See here for more information: https://www.envoyproxy.io/docs/envoy/latest/configuration/observability/access_log/usage#tcp
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	AccessLog []AccessLog `json:"accessLog,omitempty"`
+
+	// IdleTimeout is the idle timeout for connections. The connection is closed when no bytes have been sent
+	// or received on either the downstream or the upstream connection for this long. If unspecified, Envoy's
+	// default of 1 hour is used. A value of 0s disables the idle timeout.
+	// See here for more information: https://www.envoyproxy.io/docs/envoy/latest/api-v3/extensions/filters/network/tcp_proxy/v3/tcp_proxy.proto#envoy-v3-api-field-extensions-filters-network-tcp-proxy-v3-tcpproxy-idle-timeout
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty"`
+
+	// MaxConnectionDuration is the maximum duration of a downstream connection, regardless of activity.
+	// Once it is reached, the connection is closed. If unspecified, connections have no maximum duration.
+	// See here for more information: https://www.envoyproxy.io/docs/envoy/latest/api-v3/extensions/filters/network/tcp_proxy/v3/tcp_proxy.proto#envoy-v3-api-field-extensions-filters-network-tcp-proxy-v3-tcpproxy-max-downstream-connection-duration
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	MaxConnectionDuration *metav1.Duration `json:"maxConnectionDuration,omitempty"`
 }
 
 // ProxyProtocolConfig configures the PROXY protocol listener filter.
@@ -194,8 +230,84 @@ type HTTPSettings struct {
 	// sure it did not come from the client.
 	// +optional
 	EarlyRequestHeaderModifier *gwv1.HTTPHeaderFilter `json:"earlyRequestHeaderModifier,omitempty"`
+
+	// LocalReply maps the responses generated by Envoy, such as "no healthy upstream", to custom
+	// status codes, bodies and content types.
+	// See here for more information: https://www.envoyproxy.io/docs/envoy/latest/configuration/http/http_conn_man/local_reply
+	// +optional
+	LocalReply *LocalReply `json:"localReply,omitempty"`
+
+	// NormalizePath determines whether the request path is normalized according to RFC 3986 before it is
+	// used for routing and processing, e.g. `/a/../b` becomes `/b`. Defaults to true.
+	// See here for more information: https://www.envoyproxy.io/docs/envoy/latest/api-v3/extensions/filters/network/http_connection_manager/v3/http_connection_manager.proto#envoy-v3-api-field-extensions-filters-network-http-connection-manager-v3-httpconnectionmanager-normalize-path
+	// +optional
+	NormalizePath *bool `json:"normalizePath,omitempty"`
+
+	// MergeSlashes determines whether adjacent slashes in the request path are merged into one, e.g.
+	// `//a///b` becomes `/a/b`. Defaults to true.
+	// See here for more information: https://www.envoyproxy.io/docs/envoy/latest/api-v3/extensions/filters/network/http_connection_manager/v3/http_connection_manager.proto#envoy-v3-api-field-extensions-filters-network-http-connection-manager-v3-httpconnectionmanager-merge-slashes
+	// +optional
+	MergeSlashes *bool `json:"mergeSlashes,omitempty"`
+
+	// PathWithEscapedSlashesAction determines the action for requests whose path contains escaped slashes
+	// (`%2F`, `%2f`, `%5C` or `%5c`). If unspecified, the path is kept unchanged.
+	// See here for more information: https://www.envoyproxy.io/docs/envoy/latest/api-v3/extensions/filters/network/http_connection_manager/v3/http_connection_manager.proto#envoy-v3-api-field-extensions-filters-network-http-connection-manager-v3-httpconnectionmanager-path-with-escaped-slashes-action
+	// +kubebuilder:validation:Enum=KeepUnchanged;RejectRequest;UnescapeAndRedirect;UnescapeAndForward
+	// +optional
+	PathWithEscapedSlashesAction *PathWithEscapedSlashesAction `json:"pathWithEscapedSlashesAction,omitempty"`
+
+	// HeadersWithUnderscoresAction determines the action for requests with header names that contain
+	// underscores. If unspecified, such headers are allowed.
+	// See here for more information: https://www.envoyproxy.io/docs/envoy/latest/api-v3/config/core/v3/protocol.proto#envoy-v3-api-field-config-core-v3-httpprotocoloptions-headers-with-underscores-action
+	// +kubebuilder:validation:Enum=Allow;RejectRequest;DropHeader
+	// +optional
+	HeadersWithUnderscoresAction *HeadersWithUnderscoresAction `json:"headersWithUnderscoresAction,omitempty"`
+
+	// MaxRequestHeadersKb is the maximum size of the request headers in KiB. Requests that exceed it are
+	// rejected with a 431 response. If unspecified, Envoy's default of 60 KiB is used.
+	// See here for more information: https://www.envoyproxy.io/docs/envoy/latest/api-v3/extensions/filters/network/http_connection_manager/v3/http_connection_manager.proto#envoy-v3-api-field-extensions-filters-network-http-connection-manager-v3-httpconnectionmanager-max-request-headers-kb
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=8192
+	// +optional
+	MaxRequestHeadersKb *int32 `json:"maxRequestHeadersKb,omitempty"`
+
+	// MaxHeadersCount is the maximum number of headers of a request or response. Requests that exceed it
+	// are rejected with a 431 response. If unspecified, Envoy's default of 100 is used.
+	// See here for more information: https://www.envoyproxy.io/docs/envoy/latest/api-v3/config/core/v3/protocol.proto#envoy-v3-api-field-config-core-v3-httpprotocoloptions-max-headers-count
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxHeadersCount *int32 `json:"maxHeadersCount,omitempty"`
+
+	// Connect enables HTTP CONNECT requests on the listener, so that it can be used as an explicit forward
+	// proxy, e.g. through the `HTTPS_PROXY` environment variable of workloads. The requests are handled by
+	// the HTTPRoute rules that match the `CONNECT` method. As CONNECT requests have no path, the path
+	// matches of these rules are ignored, and their hostnames match the requested authority.
+	// See here for more information: https://www.envoyproxy.io/docs/envoy/latest/intro/arch_overview/http/upgrades#connect-support
+	// +optional
+	Connect *HTTPConnect `json:"connect,omitempty"`
+}
+
+// HTTPConnect configures how HTTP CONNECT requests are proxied.
+type HTTPConnect struct {
+	// Mode determines how CONNECT requests are proxied. Defaults to Terminate.
+	// +optional
+	// +kubebuilder:validation:Enum=Terminate;Forward
+	// +kubebuilder:default=Terminate
+	Mode HTTPConnectMode `json:"mode,omitempty"`
 }
 
+// HTTPConnectMode determines how CONNECT requests are proxied.
+type HTTPConnectMode string
+
+const (
+	// HTTPConnectModeTerminate terminates the CONNECT request and tunnels the raw TCP stream to the backend
+	// of the route. Use a DynamicForwardProxy backend to tunnel to the requested authority.
+	HTTPConnectModeTerminate HTTPConnectMode = "Terminate"
+	// HTTPConnectModeForward forwards the CONNECT request to the backend of the route, e.g. an upstream
+	// corporate proxy, which then establishes the tunnel.
+	HTTPConnectModeForward HTTPConnectMode = "Forward"
+)
+
 // AccessLog represents the top-level access log configuration.
 type AccessLog struct {
 	// Output access logs to local file
@@ -780,6 +892,32 @@ const (
 	PassThroughServerHeaderTransformation ServerHeaderTransformation = "PassThrough"
 )
 
+// PathWithEscapedSlashesAction determines the action for requests whose path contains escaped slashes.
+type PathWithEscapedSlashesAction string
+
+const (
+	// KeepUnchangedPathWithEscapedSlashesAction forwards the path unchanged.
+	KeepUnchangedPathWithEscapedSlashesAction PathWithEscapedSlashesAction = "KeepUnchanged"
+	// RejectRequestPathWithEscapedSlashesAction rejects the request with a 400 response.
+	RejectRequestPathWithEscapedSlashesAction PathWithEscapedSlashesAction = "RejectRequest"
+	// UnescapeAndRedirectPathWithEscapedSlashesAction unescapes the slashes and redirects the client to the new path.
+	UnescapeAndRedirectPathWithEscapedSlashesAction PathWithEscapedSlashesAction = "UnescapeAndRedirect"
+	// UnescapeAndForwardPathWithEscapedSlashesAction unescapes the slashes and forwards the request with the new path.
+	UnescapeAndForwardPathWithEscapedSlashesAction PathWithEscapedSlashesAction = "UnescapeAndForward"
+)
+
+// HeadersWithUnderscoresAction determines the action for requests with header names that contain underscores.
+type HeadersWithUnderscoresAction string
+
+const (
+	// AllowHeadersWithUnderscoresAction allows headers with underscores.
+	AllowHeadersWithUnderscoresAction HeadersWithUnderscoresAction = "Allow"
+	// RejectRequestHeadersWithUnderscoresAction rejects the request with a 400 response.
+	RejectRequestHeadersWithUnderscoresAction HeadersWithUnderscoresAction = "RejectRequest"
+	// DropHeaderHeadersWithUnderscoresAction drops the headers with underscores and forwards the request.
+	DropHeaderHeadersWithUnderscoresAction HeadersWithUnderscoresAction = "DropHeader"
+)
+
 // EnvoyHealthCheck represents configuration for Envoy's health check filter.
 // The filter will be configured in No pass through mode, and will only match requests with the specified path.
 type EnvoyHealthCheck struct {
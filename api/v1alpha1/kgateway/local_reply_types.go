@@ -0,0 +1,99 @@
+package kgateway
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// LocalReply configures how responses generated by Envoy itself, such as "no healthy upstream"
+// or "upstream request timeout", are rewritten before they are sent to the client.
+// See here for more information: https://www.envoyproxy.io/docs/envoy/latest/configuration/http/http_conn_man/local_reply
+type LocalReply struct {
+	// Mappers is the ordered list of mappers applied to local replies. The first mapper whose
+	// match succeeds rewrites the reply; the remaining mappers are skipped.
+	// +kubebuilder:validation:MaxItems=32
+	// +optional
+	Mappers []LocalReplyMapper `json:"mappers,omitempty"`
+
+	// Body is the default body of the local replies that are not rewritten by a mapper with a body.
+	// +optional
+	Body *LocalReplyBody `json:"body,omitempty"`
+}
+
+// LocalReplyMapper rewrites the local replies that match its conditions.
+// +kubebuilder:validation:AtLeastOneOf=statusCode;body
+type LocalReplyMapper struct {
+	// Match specifies the conditions a local reply must meet for the mapper to apply.
+	// +required
+	Match LocalReplyMatch `json:"match"`
+
+	// StatusCode replaces the status code of the local reply.
+	// +kubebuilder:validation:Minimum=200
+	// +kubebuilder:validation:Maximum=599
+	// +optional
+	StatusCode *int32 `json:"statusCode,omitempty"`
+
+	// Body replaces the body of the local reply.
+	// +optional
+	Body *LocalReplyBody `json:"body,omitempty"`
+}
+
+// LocalReplyMatch specifies the conditions a local reply must meet. All the specified conditions must match.
+// +kubebuilder:validation:MinProperties=1
+type LocalReplyMatch struct {
+	// StatusCode matches the status code of the local reply.
+	// +optional
+	StatusCode *StatusCodeFilter `json:"statusCode,omitempty"`
+
+	// ResponseFlags matches local replies that have any of the given Envoy response flags, e.g. `UH`
+	// (no healthy upstream), `UF` (upstream connection failure) or `UT` (upstream request timeout).
+	// See here for more information: https://www.envoyproxy.io/docs/envoy/latest/configuration/observability/access_log/usage#config-access-log-format-response-flags
+	// +kubebuilder:validation:MaxItems=32
+	// +optional
+	ResponseFlags []string `json:"responseFlags,omitempty"`
+
+	// Headers matches the headers of the request. All the headers must match.
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	Headers []gwv1.HTTPHeaderMatch `json:"headers,omitempty"`
+}
+
+// LocalReplyBody is the body of a local reply. Text and ConfigMap bodies are Envoy format strings, so they
+// may use command operators such as %LOCAL_REPLY_BODY% (the original body), %RESPONSE_CODE%,
+// %RESPONSE_CODE_DETAILS% or %REQ(x-request-id)%.
+// See here for more information: https://www.envoyproxy.io/docs/envoy/latest/configuration/observability/access_log/usage#command-operators
+// +kubebuilder:validation:ExactlyOneOf=text;json;configMapRef
+type LocalReplyBody struct {
+	// Text is an inline body.
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	Text *string `json:"text,omitempty"`
+
+	// JSON formats the body as the given JSON object, in which string values are format strings.
+	// The content type defaults to `application/json`.
+	// +optional
+	JSON *runtime.RawExtension `json:"json,omitempty"`
+
+	// ConfigMapRef reads the body from a key of a ConfigMap in the same namespace as the policy.
+	// +optional
+	ConfigMapRef *LocalReplyConfigMapRef `json:"configMapRef,omitempty"`
+
+	// ContentType is the content type of the body. Defaults to `text/plain` for text and ConfigMap bodies,
+	// and to `application/json` for JSON bodies.
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	ContentType *string `json:"contentType,omitempty"`
+}
+
+// LocalReplyConfigMapRef references a key of a ConfigMap.
+type LocalReplyConfigMapRef struct {
+	// Name of the ConfigMap.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Name string `json:"name"`
+
+	// Key of the ConfigMap data that holds the body.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Key string `json:"key"`
+}
@@ -2,6 +2,7 @@ package kgateway
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
@@ -178,3 +179,68 @@ type OAuth2Policy struct {
 	// +required
 	ExtensionRef shared.NamespacedObjectReference `json:"extensionRef"`
 }
+
+// OAuth2IntrospectionProvider specifies the configuration for validating opaque access tokens
+// using OAuth 2.0 Token Introspection.
+// Refer to https://datatracker.ietf.org/doc/html/rfc7662 for more details.
+type OAuth2IntrospectionProvider struct {
+	// BackendRef specifies the Backend serving the introspection endpoint.
+	// +required
+	BackendRef gwv1.BackendRef `json:"backendRef"`
+
+	// IntrospectionEndpoint specifies the URL of the introspection endpoint on the authorization server.
+	// Only the host and path of the URL are used; the connection is made to BackendRef.
+	// Refer to https://datatracker.ietf.org/doc/html/rfc7662#section-2 for more details.
+	// +required
+	//
+	// +kubebuilder:validation:Pattern=`^https?://([a-zA-Z0-9]([a-zA-Z0-9\-]{0,61}[a-zA-Z0-9])?\.)*[a-zA-Z0-9]([a-zA-Z0-9\-]{0,61}[a-zA-Z0-9])?(:[0-9]{1,5})?(/[a-zA-Z0-9\-._~!$&'()*+,;=:@%]*)*/?$`
+	IntrospectionEndpoint string `json:"introspectionEndpoint"`
+
+	// Credentials specifies the client credentials used to authenticate to the introspection endpoint
+	// using HTTP Basic authentication. The client secret is delivered to the proxies over SDS.
+	// +required
+	Credentials OAuth2Credentials `json:"credentials"`
+
+	// Timeout specifies the timeout for a request to the introspection endpoint.
+	// Defaults to 1s.
+	// +optional
+	//
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid timeout value"
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1ms')",message="timeout must be at least 1ms."
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// MaxCacheDuration caps how long an active introspection result is cached by each proxy worker.
+	// Active results are cached until the token expires as indicated by its "exp" claim, but never
+	// longer than this duration. Results without an "exp" claim are cached for this duration.
+	// Inactive results are never cached. Set to 0s to disable caching.
+	// Defaults to 5m.
+	// +optional
+	//
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	MaxCacheDuration *metav1.Duration `json:"maxCacheDuration,omitempty"`
+
+	// ClaimsToHeaders copies claims from the introspection response to headers sent upstream.
+	// Only string, number and boolean claims are copied. Incoming headers with the same names
+	// are removed so that clients cannot spoof them.
+	// +optional
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	ClaimsToHeaders []JWTClaimToHeader `json:"claimsToHeaders,omitempty"`
+}
+
+// OAuth2IntrospectionPolicy specifies the token introspection policy to apply to requests.
+// Requests must carry an access token in the Authorization header using the Bearer scheme.
+type OAuth2IntrospectionPolicy struct {
+	// ExtensionRef specifies the GatewayExtension that should be used for token introspection.
+	// +required
+	ExtensionRef shared.NamespacedObjectReference `json:"extensionRef"`
+
+	// RequiredScopes specifies the scopes that must all be present in the "scope" claim of the
+	// introspection response. Requests with tokens missing any of them are rejected with 403.
+	// +optional
+	//
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:MinLength=1
+	RequiredScopes []string `json:"requiredScopes,omitempty"`
+}
@@ -41,6 +41,7 @@ type TrafficPolicyList struct {
 
 // TrafficPolicySpec defines the desired state of a traffic policy.
 // +kubebuilder:validation:XValidation:rule="!has(self.autoHostRewrite) || ((has(self.targetRefs) && self.targetRefs.all(r, r.kind == 'HTTPRoute')) || (has(self.targetSelectors) && self.targetSelectors.all(r, r.kind == 'HTTPRoute')))",message="autoHostRewrite can only be used when targeting HTTPRoute resources"
+// +kubebuilder:validation:XValidation:rule="!has(self.jwt) || !has(self.jwt.routeMatch) || ((has(self.targetRefs) && self.targetRefs.all(r, r.kind == 'HTTPRoute')) || (has(self.targetSelectors) && self.targetSelectors.all(r, r.kind == 'HTTPRoute')))",message="jwt.routeMatch can only be used when targeting HTTPRoute resources"
 // +kubebuilder:validation:XValidation:rule="has(self.retry) && has(self.timeouts) ? (has(self.retry.perTryTimeout) && has(self.timeouts.request) ? duration(self.retry.perTryTimeout) < duration(self.timeouts.request) : true) : true",message="retry.perTryTimeout must be less than timeouts.request"
 // +kubebuilder:validation:XValidation:rule="has(self.retry) && has(self.targetRefs) ? self.targetRefs.all(r, (r.kind == 'Gateway' ? has(r.sectionName) : true )) : true",message="targetRefs[].sectionName must be set when targeting Gateway resources with retry policy"
 // +kubebuilder:validation:XValidation:rule="has(self.retry) && has(self.targetSelectors) ? self.targetSelectors.all(r, (r.kind == 'Gateway' ? has(r.sectionName) : true )) : true",message="targetSelectors[].sectionName must be set when targeting Gateway resources with retry policy"
@@ -150,6 +151,15 @@ type TrafficPolicySpec struct {
 	// malicious social engineering.
 	// +optional
 	OAuth2 *OAuth2Policy `json:"oauth2,omitempty"`
+
+	// OAuth2Introspection validates opaque access tokens using OAuth 2.0 Token Introspection.
+	// +optional
+	OAuth2Introspection *OAuth2IntrospectionPolicy `json:"oauth2Introspection,omitempty"`
+
+	// APIConsumers resolves the principals authenticated by the apiKeyAuthentication, jwt and basicAuth
+	// policies, or by client certificates, to APIConsumers and applies their plans.
+	// +optional
+	APIConsumers *APIConsumerPolicy `json:"apiConsumers,omitempty"`
 }
 
 // URLRewrite specifies URL rewrite rules using regular expressions.
@@ -323,8 +333,12 @@ type RateLimitPolicy struct {
 	// Descriptors define the dimensions for rate limiting.
 	// These values are passed to the rate limit service which applies configured limits based on them.
 	// Each descriptor represents a single rate limit rule with one or more entries.
+	//
+	// When the descriptors set their limit, each descriptor is sent to the rate limit service
+	// separately. Otherwise, the entries of all the descriptors are sent as a single descriptor.
 	// +required
 	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:XValidation:message="either all or none of the descriptors must set limit",rule="self.all(d, has(d.limit)) || self.all(d, !has(d.limit))"
 	Descriptors []RateLimitDescriptor `json:"descriptors"`
 
 	// ExtensionRef references a GatewayExtension that provides the global rate limit service.
@@ -340,10 +354,42 @@ type RateLimitDescriptor struct {
 	// +required
 	// +kubebuilder:validation:MinItems=1
 	Entries []RateLimitDescriptorEntry `json:"entries"`
+
+	// Limit is the limit enforced for this descriptor by the kgateway rate limit server, which
+	// reads it from the TrafficPolicy. Other rate limit services ignore it.
+	//
+	// Entries without a static value, such as Header or RemoteAddress entries, are limited
+	// per distinct value. Descriptors with the same entries in the same TrafficPolicy share
+	// their counters, and the lowest limit applies.
+	// +optional
+	Limit *RateLimitDescriptorLimit `json:"limit,omitempty"`
+}
+
+// RateLimitUnit is the unit of time of a rate limit.
+// +kubebuilder:validation:Enum=Second;Minute;Hour;Day
+type RateLimitUnit string
+
+const (
+	RateLimitUnitSecond RateLimitUnit = "Second"
+	RateLimitUnitMinute RateLimitUnit = "Minute"
+	RateLimitUnitHour   RateLimitUnit = "Hour"
+	RateLimitUnitDay    RateLimitUnit = "Day"
+)
+
+// RateLimitDescriptorLimit defines the number of requests allowed per unit of time.
+type RateLimitDescriptorLimit struct {
+	// RequestsPerUnit is the number of requests allowed per unit of time.
+	// +required
+	// +kubebuilder:validation:Minimum=1
+	RequestsPerUnit int32 `json:"requestsPerUnit"`
+
+	// Unit is the unit of time of the limit.
+	// +required
+	Unit RateLimitUnit `json:"unit"`
 }
 
 // RateLimitDescriptorEntryType defines the type of a rate limit descriptor entry.
-// +kubebuilder:validation:Enum=Generic;Header;RemoteAddress;Path
+// +kubebuilder:validation:Enum=Generic;Header;RemoteAddress;Path;APIKeyMetadata
 type RateLimitDescriptorEntryType string
 
 const (
@@ -358,11 +404,15 @@ const (
 
 	// RateLimitDescriptorEntryTypePath represents a descriptor entry that uses the request path as its value.
 	RateLimitDescriptorEntryTypePath RateLimitDescriptorEntryType = "Path"
+
+	// RateLimitDescriptorEntryTypeAPIKeyMetadata represents a descriptor entry that uses a metadata value of the
+	// API key authenticating the request. See APIKeyAuthentication.Metadata.
+	RateLimitDescriptorEntryTypeAPIKeyMetadata RateLimitDescriptorEntryType = "APIKeyMetadata"
 )
 
 // RateLimitDescriptorEntry defines a single entry in a rate limit descriptor.
 // Only one entry type may be specified.
-// +kubebuilder:validation:XValidation:message="exactly one entry type must be specified",rule="(has(self.type) && (self.type == 'Generic' && has(self.generic) && !has(self.header)) || (self.type == 'Header' && has(self.header) && !has(self.generic)) || (self.type == 'RemoteAddress' && !has(self.generic) && !has(self.header)) || (self.type == 'Path' && !has(self.generic) && !has(self.header)))"
+// +kubebuilder:validation:XValidation:message="exactly one entry type must be specified",rule="has(self.generic) == (self.type == 'Generic') && has(self.header) == (self.type == 'Header') && has(self.apiKeyMetadata) == (self.type == 'APIKeyMetadata')"
 type RateLimitDescriptorEntry struct {
 	// Type specifies what kind of rate limit descriptor entry this is.
 	// +required
@@ -378,6 +428,15 @@ type RateLimitDescriptorEntry struct {
 	// +optional
 	// +kubebuilder:validation:MinLength=1
 	Header *string `json:"header,omitempty"`
+
+	// APIKeyMetadata specifies the name of the API key metadata to use as the descriptor value, with the same
+	// name as descriptor key. Metadata is only emitted for API keys with metadata or a SHA256 key format, see
+	// APIKeyAuthentication. Requests without this metadata do not produce the descriptor.
+	// This field must be specified when Type is APIKeyMetadata.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	APIKeyMetadata *string `json:"apiKeyMetadata,omitempty"`
 }
 
 // RateLimitDescriptorEntryGeneric defines a generic key-value descriptor entry.
@@ -530,6 +589,102 @@ type APIKeyAuthentication struct {
 	//
 	// +optional
 	SecretSelector *LabelSelector `json:"secretSelector,omitempty"`
+
+	// keyFormat specifies how the API keys are stored in the Secrets.
+	// With Plaintext (default), each Secret value is the API key.
+	// With SHA256, each Secret value is the hex encoded SHA-256 digest of the API key, so that raw
+	// keys are never stored. A digest can be computed with:
+	//
+	//   echo -n "k-123" | sha256sum
+	//
+	// SHA256 keys and metadata are verified by the kgateway auth verifier through the ext_authz
+	// filter instead of the Envoy API key auth filter.
+	// +optional
+	KeyFormat *APIKeyFormat `json:"keyFormat,omitempty"`
+
+	// metadata lists the metadata attached to each API key, read from the labels or data fields of
+	// the Secret the key is stored in. Once a request is authenticated, the metadata of its key is
+	// emitted as dynamic metadata, along with the client identifier under the "client" name, and
+	// can be used in global rate limit descriptors with the APIKeyMetadata entry type. Metadata can
+	// also be set as request headers. Configured headers are always overwritten or removed, so
+	// clients cannot set them.
+	//
+	// Example:
+	//
+	//   metadata:
+	//   - name: plan
+	//     label: example.com/plan
+	//   - name: owner
+	//     field: owner
+	//     header: x-api-key-owner
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	Metadata []APIKeyMetadata `json:"metadata,omitempty"`
+}
+
+// APIKeyFormat is the format of the API keys stored in Secrets.
+// +kubebuilder:validation:Enum=Plaintext;SHA256
+type APIKeyFormat string
+
+const (
+	// APIKeyFormatPlaintext stores the API keys as is.
+	APIKeyFormatPlaintext APIKeyFormat = "Plaintext"
+
+	// APIKeyFormatSHA256 stores the hex encoded SHA-256 digests of the API keys.
+	APIKeyFormatSHA256 APIKeyFormat = "SHA256"
+)
+
+// APIKeyMetadata is a metadata entry attached to the API keys of a Secret.
+// +kubebuilder:validation:ExactlyOneOf=label;field
+type APIKeyMetadata struct {
+	// name of the metadata entry. "client" overrides the client identifier, which defaults to the
+	// name of the Secret entry holding the key.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_-]+$`
+	Name string `json:"name"`
+
+	// label is the Secret label holding the metadata value.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=317
+	Label *string `json:"label,omitempty"`
+
+	// field is the Secret data field holding the metadata value. The field is not read as an API key.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Field *string `json:"field,omitempty"`
+
+	// header is the request header the metadata value is set to.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Header *string `json:"header,omitempty"`
+}
+
+// APIConsumerPolicy applies the plans of APIConsumers to the requests of their principals.
+//
+// Consumers are resolved by the kgateway auth verifier. API keys resolve to consumers through the
+// client identifier of the keys verified by the auth verifier, which verifies all the API keys of
+// the TrafficPolicies merged with the one setting apiConsumers. The API keys of a TrafficPolicy
+// applied at another level, e.g. attached to the Gateway while apiConsumers is set for the HTTPRoute,
+// only resolve when that policy sets keyFormat SHA256, metadata or apiConsumers.
+// Basic auth usernames only resolve to consumers when basicAuth applies to the route.
+type APIConsumerPolicy struct {
+	// consumerSelector selects the APIConsumers in the namespace of the policy.
+	// All APIConsumers in the namespace are selected if unset.
+	// +optional
+	ConsumerSelector *LabelSelector `json:"consumerSelector,omitempty"`
+
+	// requireConsumer rejects the requests that do not resolve to a consumer with a 403 status code.
+	// Defaults to true.
+	// +optional
+	RequireConsumer *bool `json:"requireConsumer,omitempty"`
 }
 
 // LabelSelector selects resources using label selectors.
@@ -8,11 +8,212 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	apisv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/apis/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIConsumer) DeepCopyInto(out *APIConsumer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIConsumer.
+func (in *APIConsumer) DeepCopy() *APIConsumer {
+	if in == nil {
+		return nil
+	}
+	out := new(APIConsumer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *APIConsumer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIConsumerCredentials) DeepCopyInto(out *APIConsumerCredentials) {
+	*out = *in
+	if in.APIKeys != nil {
+		in, out := &in.APIKeys, &out.APIKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = make([]APIConsumerJWTPrincipal, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MTLS != nil {
+		in, out := &in.MTLS, &out.MTLS
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIConsumerCredentials.
+func (in *APIConsumerCredentials) DeepCopy() *APIConsumerCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(APIConsumerCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIConsumerJWTPrincipal) DeepCopyInto(out *APIConsumerJWTPrincipal) {
+	*out = *in
+	if in.Issuer != nil {
+		in, out := &in.Issuer, &out.Issuer
+		*out = new(string)
+		**out = **in
+	}
+	if in.Subject != nil {
+		in, out := &in.Subject, &out.Subject
+		*out = new(string)
+		**out = **in
+	}
+	if in.ClientID != nil {
+		in, out := &in.ClientID, &out.ClientID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIConsumerJWTPrincipal.
+func (in *APIConsumerJWTPrincipal) DeepCopy() *APIConsumerJWTPrincipal {
+	if in == nil {
+		return nil
+	}
+	out := new(APIConsumerJWTPrincipal)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIConsumerList) DeepCopyInto(out *APIConsumerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]APIConsumer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIConsumerList.
+func (in *APIConsumerList) DeepCopy() *APIConsumerList {
+	if in == nil {
+		return nil
+	}
+	out := new(APIConsumerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *APIConsumerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIConsumerPlan) DeepCopyInto(out *APIConsumerPlan) {
+	*out = *in
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(TokenBucket)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedRoutes != nil {
+		in, out := &in.AllowedRoutes, &out.AllowedRoutes
+		*out = make([]v1.ObjectName, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequestHeaders != nil {
+		in, out := &in.RequestHeaders, &out.RequestHeaders
+		*out = make([]v1.HTTPHeader, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIConsumerPlan.
+func (in *APIConsumerPlan) DeepCopy() *APIConsumerPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(APIConsumerPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIConsumerPolicy) DeepCopyInto(out *APIConsumerPolicy) {
+	*out = *in
+	if in.ConsumerSelector != nil {
+		in, out := &in.ConsumerSelector, &out.ConsumerSelector
+		*out = new(LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequireConsumer != nil {
+		in, out := &in.RequireConsumer, &out.RequireConsumer
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIConsumerPolicy.
+func (in *APIConsumerPolicy) DeepCopy() *APIConsumerPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(APIConsumerPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIConsumerSpec) DeepCopyInto(out *APIConsumerSpec) {
+	*out = *in
+	in.Credentials.DeepCopyInto(&out.Credentials)
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(APIConsumerPlan)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIConsumerSpec.
+func (in *APIConsumerSpec) DeepCopy() *APIConsumerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APIConsumerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIKeyAuthentication) DeepCopyInto(out *APIKeyAuthentication) {
 	*out = *in
@@ -35,7 +236,7 @@ func (in *APIKeyAuthentication) DeepCopyInto(out *APIKeyAuthentication) {
 	}
 	if in.SecretRef != nil {
 		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(apisv1.SecretObjectReference)
+		*out = new(v1.SecretObjectReference)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.SecretSelector != nil {
@@ -43,6 +244,18 @@ func (in *APIKeyAuthentication) DeepCopyInto(out *APIKeyAuthentication) {
 		*out = new(LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.KeyFormat != nil {
+		in, out := &in.KeyFormat, &out.KeyFormat
+		*out = new(APIKeyFormat)
+		**out = **in
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make([]APIKeyMetadata, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeyAuthentication.
@@ -55,6 +268,36 @@ func (in *APIKeyAuthentication) DeepCopy() *APIKeyAuthentication {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIKeyMetadata) DeepCopyInto(out *APIKeyMetadata) {
+	*out = *in
+	if in.Label != nil {
+		in, out := &in.Label, &out.Label
+		*out = new(string)
+		**out = **in
+	}
+	if in.Field != nil {
+		in, out := &in.Field, &out.Field
+		*out = new(string)
+		**out = **in
+	}
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeyMetadata.
+func (in *APIKeyMetadata) DeepCopy() *APIKeyMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(APIKeyMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIKeySource) DeepCopyInto(out *APIKeySource) {
 	*out = *in
@@ -352,6 +595,11 @@ func (in *AwsAuth) DeepCopy() *AwsAuth {
 func (in *AwsBackend) DeepCopyInto(out *AwsBackend) {
 	*out = *in
 	in.Lambda.DeepCopyInto(&out.Lambda)
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(AwsService)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Auth != nil {
 		in, out := &in.Auth, &out.Auth
 		*out = new(AwsAuth)
@@ -377,6 +625,11 @@ func (in *AwsLambda) DeepCopyInto(out *AwsLambda) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.FunctionURL != nil {
+		in, out := &in.FunctionURL, &out.FunctionURL
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsLambda.
@@ -389,6 +642,31 @@ func (in *AwsLambda) DeepCopy() *AwsLambda {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AwsService) DeepCopyInto(out *AwsService) {
+	*out = *in
+	if in.EndpointURL != nil {
+		in, out := &in.EndpointURL, &out.EndpointURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.UnsignedPayload != nil {
+		in, out := &in.UnsignedPayload, &out.UnsignedPayload
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsService.
+func (in *AwsService) DeepCopy() *AwsService {
+	if in == nil {
+		return nil
+	}
+	out := new(AwsService)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Backend) DeepCopyInto(out *Backend) {
 	*out = *in
@@ -492,7 +770,7 @@ func (in *BackendConfigPolicySpec) DeepCopyInto(out *BackendConfigPolicySpec) {
 	}
 	if in.ConnectTimeout != nil {
 		in, out := &in.ConnectTimeout, &out.ConnectTimeout
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.PerConnectionBufferLimitBytes != nil {
@@ -545,6 +823,16 @@ func (in *BackendConfigPolicySpec) DeepCopyInto(out *BackendConfigPolicySpec) {
 		*out = new(CircuitBreakers)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.UpstreamProxyProtocol != nil {
+		in, out := &in.UpstreamProxyProtocol, &out.UpstreamProxyProtocol
+		*out = new(UpstreamProxyProtocol)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Preconnect != nil {
+		in, out := &in.Preconnect, &out.Preconnect
+		*out = new(Preconnect)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendConfigPolicySpec.
@@ -607,6 +895,11 @@ func (in *BackendSpec) DeepCopyInto(out *BackendSpec) {
 		*out = new(DynamicForwardProxyBackend)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(RedisBackend)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendSpec.
@@ -624,7 +917,7 @@ func (in *BackendStatus) DeepCopyInto(out *BackendStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -647,7 +940,7 @@ func (in *BackoffStrategy) DeepCopyInto(out *BackoffStrategy) {
 	out.BaseInterval = in.BaseInterval
 	if in.MaxInterval != nil {
 		in, out := &in.MaxInterval, &out.MaxInterval
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 }
@@ -675,6 +968,11 @@ func (in *BasicAuthPolicy) DeepCopyInto(out *BasicAuthPolicy) {
 		*out = new(SecretReference)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Verifier != nil {
+		in, out := &in.Verifier, &out.Verifier
+		*out = new(BasicAuthVerifier)
+		**out = **in
+	}
 	if in.Disable != nil {
 		in, out := &in.Disable, &out.Disable
 		*out = new(shared.PolicyDisable)
@@ -856,7 +1154,7 @@ func (in *CommonGrpcService) DeepCopyInto(out *CommonGrpcService) {
 	}
 	if in.Timeout != nil {
 		in, out := &in.Timeout, &out.Timeout
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.InitialMetadata != nil {
@@ -888,7 +1186,7 @@ func (in *CommonHttpProtocolOptions) DeepCopyInto(out *CommonHttpProtocolOptions
 	*out = *in
 	if in.IdleTimeout != nil {
 		in, out := &in.IdleTimeout, &out.IdleTimeout
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.MaxHeadersCount != nil {
@@ -898,7 +1196,7 @@ func (in *CommonHttpProtocolOptions) DeepCopyInto(out *CommonHttpProtocolOptions
 	}
 	if in.MaxStreamDuration != nil {
 		in, out := &in.MaxStreamDuration, &out.MaxStreamDuration
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.MaxRequestsPerConnection != nil {
@@ -906,6 +1204,11 @@ func (in *CommonHttpProtocolOptions) DeepCopyInto(out *CommonHttpProtocolOptions
 		*out = new(int32)
 		**out = **in
 	}
+	if in.MaxConnectionDuration != nil {
+		in, out := &in.MaxConnectionDuration, &out.MaxConnectionDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonHttpProtocolOptions.
@@ -968,7 +1271,7 @@ func (in *Cookie) DeepCopyInto(out *Cookie) {
 	}
 	if in.TTL != nil {
 		in, out := &in.TTL, &out.TTL
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.Secure != nil {
@@ -1003,7 +1306,7 @@ func (in *CorsPolicy) DeepCopyInto(out *CorsPolicy) {
 	*out = *in
 	if in.HTTPCORSFilter != nil {
 		in, out := &in.HTTPCORSFilter, &out.HTTPCORSFilter
-		*out = new(apisv1.HTTPCORSFilter)
+		*out = new(v1.HTTPCORSFilter)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Disable != nil {
@@ -1251,6 +1554,21 @@ func (in *DynamicForwardProxyBackend) DeepCopyInto(out *DynamicForwardProxyBacke
 		*out = new(bool)
 		**out = **in
 	}
+	if in.AllowedHosts != nil {
+		in, out := &in.AllowedHosts, &out.AllowedHosts
+		*out = make([]DynamicForwardProxyHostMatch, len(*in))
+		copy(*out, *in)
+	}
+	if in.DenyResponse != nil {
+		in, out := &in.DenyResponse, &out.DenyResponse
+		*out = new(DynamicForwardProxyDenyResponse)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNSCache != nil {
+		in, out := &in.DNSCache, &out.DNSCache
+		*out = new(DynamicForwardProxyDNSCache)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicForwardProxyBackend.
@@ -1264,51 +1582,133 @@ func (in *DynamicForwardProxyBackend) DeepCopy() *DynamicForwardProxyBackend {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EnvironmentResourceDetectorConfig) DeepCopyInto(out *EnvironmentResourceDetectorConfig) {
+func (in *DynamicForwardProxyDNSCache) DeepCopyInto(out *DynamicForwardProxyDNSCache) {
 	*out = *in
+	if in.MaxHosts != nil {
+		in, out := &in.MaxHosts, &out.MaxHosts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HostTTL != nil {
+		in, out := &in.HostTTL, &out.HostTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RefreshRate != nil {
+		in, out := &in.RefreshRate, &out.RefreshRate
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RespectDNSTTL != nil {
+		in, out := &in.RespectDNSTTL, &out.RespectDNSTTL
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PreresolvedHosts != nil {
+		in, out := &in.PreresolvedHosts, &out.PreresolvedHosts
+		*out = make([]Host, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentResourceDetectorConfig.
-func (in *EnvironmentResourceDetectorConfig) DeepCopy() *EnvironmentResourceDetectorConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicForwardProxyDNSCache.
+func (in *DynamicForwardProxyDNSCache) DeepCopy() *DynamicForwardProxyDNSCache {
 	if in == nil {
 		return nil
 	}
-	out := new(EnvironmentResourceDetectorConfig)
+	out := new(DynamicForwardProxyDNSCache)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EnvoyBootstrap) DeepCopyInto(out *EnvoyBootstrap) {
+func (in *DynamicForwardProxyDenyResponse) DeepCopyInto(out *DynamicForwardProxyDenyResponse) {
 	*out = *in
-	if in.LogLevel != nil {
-		in, out := &in.LogLevel, &out.LogLevel
-		*out = new(string)
+	if in.StatusCode != nil {
+		in, out := &in.StatusCode, &out.StatusCode
+		*out = new(int32)
 		**out = **in
 	}
-	if in.ComponentLogLevels != nil {
-		in, out := &in.ComponentLogLevels, &out.ComponentLogLevels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Body != nil {
+		in, out := &in.Body, &out.Body
+		*out = new(string)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyBootstrap.
-func (in *EnvoyBootstrap) DeepCopy() *EnvoyBootstrap {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicForwardProxyDenyResponse.
+func (in *DynamicForwardProxyDenyResponse) DeepCopy() *DynamicForwardProxyDenyResponse {
 	if in == nil {
 		return nil
 	}
-	out := new(EnvoyBootstrap)
+	out := new(DynamicForwardProxyDenyResponse)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EnvoyContainer) DeepCopyInto(out *EnvoyContainer) {
+func (in *DynamicForwardProxyHostMatch) DeepCopyInto(out *DynamicForwardProxyHostMatch) {
 	*out = *in
-	if in.Bootstrap != nil {
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicForwardProxyHostMatch.
+func (in *DynamicForwardProxyHostMatch) DeepCopy() *DynamicForwardProxyHostMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(DynamicForwardProxyHostMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentResourceDetectorConfig) DeepCopyInto(out *EnvironmentResourceDetectorConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentResourceDetectorConfig.
+func (in *EnvironmentResourceDetectorConfig) DeepCopy() *EnvironmentResourceDetectorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentResourceDetectorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyBootstrap) DeepCopyInto(out *EnvoyBootstrap) {
+	*out = *in
+	if in.LogLevel != nil {
+		in, out := &in.LogLevel, &out.LogLevel
+		*out = new(string)
+		**out = **in
+	}
+	if in.ComponentLogLevels != nil {
+		in, out := &in.ComponentLogLevels, &out.ComponentLogLevels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyBootstrap.
+func (in *EnvoyBootstrap) DeepCopy() *EnvoyBootstrap {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyBootstrap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyContainer) DeepCopyInto(out *EnvoyContainer) {
+	*out = *in
+	if in.Bootstrap != nil {
 		in, out := &in.Bootstrap, &out.Bootstrap
 		*out = new(EnvoyBootstrap)
 		(*in).DeepCopyInto(*out)
@@ -1384,6 +1784,83 @@ func (in *ExtAuthBufferSettings) DeepCopy() *ExtAuthBufferSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtAuthDecisionCache) DeepCopyInto(out *ExtAuthDecisionCache) {
+	*out = *in
+	out.TTL = in.TTL
+	if in.MaxEntries != nil {
+		in, out := &in.MaxEntries, &out.MaxEntries
+		*out = new(int32)
+		**out = **in
+	}
+	in.Key.DeepCopyInto(&out.Key)
+	if in.AuthHeaders != nil {
+		in, out := &in.AuthHeaders, &out.AuthHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtAuthDecisionCache.
+func (in *ExtAuthDecisionCache) DeepCopy() *ExtAuthDecisionCache {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtAuthDecisionCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtAuthDecisionCacheKey) DeepCopyInto(out *ExtAuthDecisionCacheKey) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PathPrefixSegments != nil {
+		in, out := &in.PathPrefixSegments, &out.PathPrefixSegments
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtAuthDecisionCacheKey.
+func (in *ExtAuthDecisionCacheKey) DeepCopy() *ExtAuthDecisionCacheKey {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtAuthDecisionCacheKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtAuthDecisionCachePolicy) DeepCopyInto(out *ExtAuthDecisionCachePolicy) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Disable != nil {
+		in, out := &in.Disable, &out.Disable
+		*out = new(shared.PolicyDisable)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtAuthDecisionCachePolicy.
+func (in *ExtAuthDecisionCachePolicy) DeepCopy() *ExtAuthDecisionCachePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtAuthDecisionCachePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExtAuthPolicy) DeepCopyInto(out *ExtAuthPolicy) {
 	*out = *in
@@ -1404,6 +1881,16 @@ func (in *ExtAuthPolicy) DeepCopyInto(out *ExtAuthPolicy) {
 			(*out)[key] = val
 		}
 	}
+	if in.FailOpen != nil {
+		in, out := &in.FailOpen, &out.FailOpen
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DecisionCache != nil {
+		in, out := &in.DecisionCache, &out.DecisionCache
+		*out = new(ExtAuthDecisionCachePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Disable != nil {
 		in, out := &in.Disable, &out.Disable
 		*out = new(shared.PolicyDisable)
@@ -1439,6 +1926,11 @@ func (in *ExtAuthProvider) DeepCopyInto(out *ExtAuthProvider) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.MetadataContextNamespaces != nil {
+		in, out := &in.MetadataContextNamespaces, &out.MetadataContextNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.WithRequestBody != nil {
 		in, out := &in.WithRequestBody, &out.WithRequestBody
 		*out = new(ExtAuthBufferSettings)
@@ -1449,6 +1941,11 @@ func (in *ExtAuthProvider) DeepCopyInto(out *ExtAuthProvider) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.DecisionCache != nil {
+		in, out := &in.DecisionCache, &out.DecisionCache
+		*out = new(ExtAuthDecisionCache)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtAuthProvider.
@@ -1472,7 +1969,7 @@ func (in *ExtGrpcService) DeepCopyInto(out *ExtGrpcService) {
 	}
 	if in.RequestTimeout != nil {
 		in, out := &in.RequestTimeout, &out.RequestTimeout
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.Retry != nil {
@@ -1498,7 +1995,7 @@ func (in *ExtHttpService) DeepCopyInto(out *ExtHttpService) {
 	in.BackendRef.DeepCopyInto(&out.BackendRef)
 	if in.RequestTimeout != nil {
 		in, out := &in.RequestTimeout, &out.RequestTimeout
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.AuthorizationRequest != nil {
@@ -1569,12 +2066,12 @@ func (in *ExtProcProvider) DeepCopyInto(out *ExtProcProvider) {
 	}
 	if in.MessageTimeout != nil {
 		in, out := &in.MessageTimeout, &out.MessageTimeout
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.MaxMessageTimeout != nil {
 		in, out := &in.MaxMessageTimeout, &out.MaxMessageTimeout
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.StatPrefix != nil {
@@ -1791,6 +2288,11 @@ func (in *GatewayExtensionSpec) DeepCopyInto(out *GatewayExtensionSpec) {
 		*out = new(OAuth2Provider)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OAuth2Introspection != nil {
+		in, out := &in.OAuth2Introspection, &out.OAuth2Introspection
+		*out = new(OAuth2IntrospectionProvider)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayExtensionSpec.
@@ -1808,7 +2310,7 @@ func (in *GatewayExtensionStatus) DeepCopyInto(out *GatewayExtensionStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1974,6 +2476,21 @@ func (in *GrpcStatusFilter) DeepCopy() *GrpcStatusFilter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPConnect) DeepCopyInto(out *HTTPConnect) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPConnect.
+func (in *HTTPConnect) DeepCopy() *HTTPConnect {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPConnect)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPListenerPolicy) DeepCopyInto(out *HTTPListenerPolicy) {
 	*out = *in
@@ -2098,12 +2615,12 @@ func (in *HTTPSettings) DeepCopyInto(out *HTTPSettings) {
 	}
 	if in.StreamIdleTimeout != nil {
 		in, out := &in.StreamIdleTimeout, &out.StreamIdleTimeout
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.IdleTimeout != nil {
 		in, out := &in.IdleTimeout, &out.IdleTimeout
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.HealthCheck != nil {
@@ -2128,9 +2645,49 @@ func (in *HTTPSettings) DeepCopyInto(out *HTTPSettings) {
 	}
 	if in.EarlyRequestHeaderModifier != nil {
 		in, out := &in.EarlyRequestHeaderModifier, &out.EarlyRequestHeaderModifier
-		*out = new(apisv1.HTTPHeaderFilter)
+		*out = new(v1.HTTPHeaderFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LocalReply != nil {
+		in, out := &in.LocalReply, &out.LocalReply
+		*out = new(LocalReply)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NormalizePath != nil {
+		in, out := &in.NormalizePath, &out.NormalizePath
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MergeSlashes != nil {
+		in, out := &in.MergeSlashes, &out.MergeSlashes
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PathWithEscapedSlashesAction != nil {
+		in, out := &in.PathWithEscapedSlashesAction, &out.PathWithEscapedSlashesAction
+		*out = new(PathWithEscapedSlashesAction)
+		**out = **in
+	}
+	if in.HeadersWithUnderscoresAction != nil {
+		in, out := &in.HeadersWithUnderscoresAction, &out.HeadersWithUnderscoresAction
+		*out = new(HeadersWithUnderscoresAction)
+		**out = **in
+	}
+	if in.MaxRequestHeadersKb != nil {
+		in, out := &in.MaxRequestHeadersKb, &out.MaxRequestHeadersKb
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxHeadersCount != nil {
+		in, out := &in.MaxHeadersCount, &out.MaxHeadersCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Connect != nil {
+		in, out := &in.Connect, &out.Connect
+		*out = new(HTTPConnect)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPSettings.
@@ -2279,6 +2836,36 @@ func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
 		*out = new(HealthCheckGrpc)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Tcp != nil {
+		in, out := &in.Tcp, &out.Tcp
+		*out = new(HealthCheckTcp)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NoTrafficInterval != nil {
+		in, out := &in.NoTrafficInterval, &out.NoTrafficInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.UnhealthyInterval != nil {
+		in, out := &in.UnhealthyInterval, &out.UnhealthyInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.UnhealthyEdgeInterval != nil {
+		in, out := &in.UnhealthyEdgeInterval, &out.UnhealthyEdgeInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.HealthyEdgeInterval != nil {
+		in, out := &in.HealthyEdgeInterval, &out.HealthyEdgeInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TLSOptions != nil {
+		in, out := &in.TLSOptions, &out.TLSOptions
+		*out = new(HealthCheckTLSOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheck.
@@ -2329,6 +2916,16 @@ func (in *HealthCheckHttp) DeepCopyInto(out *HealthCheckHttp) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ExpectedStatuses != nil {
+		in, out := &in.ExpectedStatuses, &out.ExpectedStatuses
+		*out = make([]HealthCheckStatusRange, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequestHeadersToAdd != nil {
+		in, out := &in.RequestHeadersToAdd, &out.RequestHeadersToAdd
+		*out = make([]v1.HTTPHeader, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckHttp.
@@ -2341,6 +2938,66 @@ func (in *HealthCheckHttp) DeepCopy() *HealthCheckHttp {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckStatusRange) DeepCopyInto(out *HealthCheckStatusRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckStatusRange.
+func (in *HealthCheckStatusRange) DeepCopy() *HealthCheckStatusRange {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckStatusRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckTLSOptions) DeepCopyInto(out *HealthCheckTLSOptions) {
+	*out = *in
+	if in.ALPNProtocols != nil {
+		in, out := &in.ALPNProtocols, &out.ALPNProtocols
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckTLSOptions.
+func (in *HealthCheckTLSOptions) DeepCopy() *HealthCheckTLSOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckTLSOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckTcp) DeepCopyInto(out *HealthCheckTcp) {
+	*out = *in
+	if in.Send != nil {
+		in, out := &in.Send, &out.Send
+		*out = new(string)
+		**out = **in
+	}
+	if in.Receive != nil {
+		in, out := &in.Receive, &out.Receive
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckTcp.
+func (in *HealthCheckTcp) DeepCopy() *HealthCheckTcp {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckTcp)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Host) DeepCopyInto(out *Host) {
 	*out = *in
@@ -2386,6 +3043,32 @@ func (in *Http1ProtocolOptions) DeepCopy() *Http1ProtocolOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Http2ConnectionKeepalive) DeepCopyInto(out *Http2ConnectionKeepalive) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	out.Timeout = in.Timeout
+	if in.ConnectionIdleInterval != nil {
+		in, out := &in.ConnectionIdleInterval, &out.ConnectionIdleInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Http2ConnectionKeepalive.
+func (in *Http2ConnectionKeepalive) DeepCopy() *Http2ConnectionKeepalive {
+	if in == nil {
+		return nil
+	}
+	out := new(Http2ConnectionKeepalive)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Http2ProtocolOptions) DeepCopyInto(out *Http2ProtocolOptions) {
 	*out = *in
@@ -2409,6 +3092,11 @@ func (in *Http2ProtocolOptions) DeepCopyInto(out *Http2ProtocolOptions) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ConnectionKeepalive != nil {
+		in, out := &in.ConnectionKeepalive, &out.ConnectionKeepalive
+		*out = new(Http2ConnectionKeepalive)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Http2ProtocolOptions.
@@ -2563,6 +3251,31 @@ func (in *JWKS) DeepCopy() *JWKS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWKSAsyncFetch) DeepCopyInto(out *JWKSAsyncFetch) {
+	*out = *in
+	if in.FastListener != nil {
+		in, out := &in.FastListener, &out.FastListener
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FailedRefetchDuration != nil {
+		in, out := &in.FailedRefetchDuration, &out.FailedRefetchDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWKSAsyncFetch.
+func (in *JWKSAsyncFetch) DeepCopy() *JWKSAsyncFetch {
+	if in == nil {
+		return nil
+	}
+	out := new(JWKSAsyncFetch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JWT) DeepCopyInto(out *JWT) {
 	*out = *in
@@ -2603,18 +3316,67 @@ func (in *JWTAuthentication) DeepCopyInto(out *JWTAuthentication) {
 		*out = new(shared.PolicyDisable)
 		**out = **in
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTAuthentication.
-func (in *JWTAuthentication) DeepCopy() *JWTAuthentication {
-	if in == nil {
-		return nil
+	if in.RequiredScopes != nil {
+		in, out := &in.RequiredScopes, &out.RequiredScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Claims != nil {
+		in, out := &in.Claims, &out.Claims
+		*out = make([]JWTClaimMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RouteMatch != nil {
+		in, out := &in.RouteMatch, &out.RouteMatch
+		*out = make([]JWTClaimMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTAuthentication.
+func (in *JWTAuthentication) DeepCopy() *JWTAuthentication {
+	if in == nil {
+		return nil
 	}
 	out := new(JWTAuthentication)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTClaimMatch) DeepCopyInto(out *JWTClaimMatch) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exact != nil {
+		in, out := &in.Exact, &out.Exact
+		*out = new(string)
+		**out = **in
+	}
+	if in.Contains != nil {
+		in, out := &in.Contains, &out.Contains
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTClaimMatch.
+func (in *JWTClaimMatch) DeepCopy() *JWTClaimMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTClaimMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JWTClaimToHeader) DeepCopyInto(out *JWTClaimToHeader) {
 	*out = *in
@@ -2834,6 +3596,11 @@ func (in *ListenerConfig) DeepCopyInto(out *ListenerConfig) {
 		*out = new(HTTPSettings)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TCPSettings != nil {
+		in, out := &in.TCPSettings, &out.TCPSettings
+		*out = new(TCPSettings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerConfig.
@@ -2970,7 +3737,7 @@ func (in *LoadBalancer) DeepCopyInto(out *LoadBalancer) {
 	}
 	if in.UpdateMergeWindow != nil {
 		in, out := &in.UpdateMergeWindow, &out.UpdateMergeWindow
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.LeastRequest != nil {
@@ -3003,6 +3770,18 @@ func (in *LoadBalancer) DeepCopyInto(out *LoadBalancer) {
 		*out = new(LocalityType)
 		**out = **in
 	}
+	if in.LocalZonePreference != nil {
+		in, out := &in.LocalZonePreference, &out.LocalZonePreference
+		*out = new(LocalZonePreference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LocalityWeights != nil {
+		in, out := &in.LocalityWeights, &out.LocalityWeights
+		*out = make([]LocalityWeight, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.CloseConnectionsOnHostSetChange != nil {
 		in, out := &in.CloseConnectionsOnHostSetChange, &out.CloseConnectionsOnHostSetChange
 		*out = new(bool)
@@ -3152,6 +3931,11 @@ func (in *LocalJWKS) DeepCopyInto(out *LocalJWKS) {
 		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalJWKS.
@@ -3184,6 +3968,196 @@ func (in *LocalRateLimitPolicy) DeepCopy() *LocalRateLimitPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalReply) DeepCopyInto(out *LocalReply) {
+	*out = *in
+	if in.Mappers != nil {
+		in, out := &in.Mappers, &out.Mappers
+		*out = make([]LocalReplyMapper, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Body != nil {
+		in, out := &in.Body, &out.Body
+		*out = new(LocalReplyBody)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalReply.
+func (in *LocalReply) DeepCopy() *LocalReply {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalReply)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalReplyBody) DeepCopyInto(out *LocalReplyBody) {
+	*out = *in
+	if in.Text != nil {
+		in, out := &in.Text, &out.Text
+		*out = new(string)
+		**out = **in
+	}
+	if in.JSON != nil {
+		in, out := &in.JSON, &out.JSON
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(LocalReplyConfigMapRef)
+		**out = **in
+	}
+	if in.ContentType != nil {
+		in, out := &in.ContentType, &out.ContentType
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalReplyBody.
+func (in *LocalReplyBody) DeepCopy() *LocalReplyBody {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalReplyBody)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalReplyConfigMapRef) DeepCopyInto(out *LocalReplyConfigMapRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalReplyConfigMapRef.
+func (in *LocalReplyConfigMapRef) DeepCopy() *LocalReplyConfigMapRef {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalReplyConfigMapRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalReplyMapper) DeepCopyInto(out *LocalReplyMapper) {
+	*out = *in
+	in.Match.DeepCopyInto(&out.Match)
+	if in.StatusCode != nil {
+		in, out := &in.StatusCode, &out.StatusCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Body != nil {
+		in, out := &in.Body, &out.Body
+		*out = new(LocalReplyBody)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalReplyMapper.
+func (in *LocalReplyMapper) DeepCopy() *LocalReplyMapper {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalReplyMapper)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalReplyMatch) DeepCopyInto(out *LocalReplyMatch) {
+	*out = *in
+	if in.StatusCode != nil {
+		in, out := &in.StatusCode, &out.StatusCode
+		*out = new(StatusCodeFilter)
+		**out = **in
+	}
+	if in.ResponseFlags != nil {
+		in, out := &in.ResponseFlags, &out.ResponseFlags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]v1.HTTPHeaderMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalReplyMatch.
+func (in *LocalReplyMatch) DeepCopy() *LocalReplyMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalReplyMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalZonePreference) DeepCopyInto(out *LocalZonePreference) {
+	*out = *in
+	if in.LocalPercentage != nil {
+		in, out := &in.LocalPercentage, &out.LocalPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinEndpoints != nil {
+		in, out := &in.MinEndpoints, &out.MinEndpoints
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailWithoutLocalEndpoints != nil {
+		in, out := &in.FailWithoutLocalEndpoints, &out.FailWithoutLocalEndpoints
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalZonePreference.
+func (in *LocalZonePreference) DeepCopy() *LocalZonePreference {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalZonePreference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalityWeight) DeepCopyInto(out *LocalityWeight) {
+	*out = *in
+	if in.Zone != nil {
+		in, out := &in.Zone, &out.Zone
+		*out = new(string)
+		**out = **in
+	}
+	if in.SubZone != nil {
+		in, out := &in.SubZone, &out.SubZone
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalityWeight.
+func (in *LocalityWeight) DeepCopy() *LocalityWeight {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalityWeight)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetadataKey) DeepCopyInto(out *MetadataKey) {
 	*out = *in
@@ -3356,7 +4330,7 @@ func (in *OAuth2DenyRedirectMatcher) DeepCopyInto(out *OAuth2DenyRedirectMatcher
 	*out = *in
 	if in.Headers != nil {
 		in, out := &in.Headers, &out.Headers
-		*out = make([]apisv1.HTTPHeaderMatch, len(*in))
+		*out = make([]v1.HTTPHeaderMatch, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -3373,6 +4347,59 @@ func (in *OAuth2DenyRedirectMatcher) DeepCopy() *OAuth2DenyRedirectMatcher {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuth2IntrospectionPolicy) DeepCopyInto(out *OAuth2IntrospectionPolicy) {
+	*out = *in
+	in.ExtensionRef.DeepCopyInto(&out.ExtensionRef)
+	if in.RequiredScopes != nil {
+		in, out := &in.RequiredScopes, &out.RequiredScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuth2IntrospectionPolicy.
+func (in *OAuth2IntrospectionPolicy) DeepCopy() *OAuth2IntrospectionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuth2IntrospectionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuth2IntrospectionProvider) DeepCopyInto(out *OAuth2IntrospectionProvider) {
+	*out = *in
+	in.BackendRef.DeepCopyInto(&out.BackendRef)
+	out.Credentials = in.Credentials
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxCacheDuration != nil {
+		in, out := &in.MaxCacheDuration, &out.MaxCacheDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ClaimsToHeaders != nil {
+		in, out := &in.ClaimsToHeaders, &out.ClaimsToHeaders
+		*out = make([]JWTClaimToHeader, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuth2IntrospectionProvider.
+func (in *OAuth2IntrospectionProvider) DeepCopy() *OAuth2IntrospectionProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuth2IntrospectionProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OAuth2Policy) DeepCopyInto(out *OAuth2Policy) {
 	*out = *in
@@ -3465,92 +4492,192 @@ func (in *OpenTelemetryAccessLogService) DeepCopyInto(out *OpenTelemetryAccessLo
 		*out = new(bool)
 		**out = **in
 	}
-	if in.Attributes != nil {
-		in, out := &in.Attributes, &out.Attributes
-		*out = new(KeyAnyValueList)
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = new(KeyAnyValueList)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceAttributes != nil {
+		in, out := &in.ResourceAttributes, &out.ResourceAttributes
+		*out = new(KeyAnyValueList)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryAccessLogService.
+func (in *OpenTelemetryAccessLogService) DeepCopy() *OpenTelemetryAccessLogService {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenTelemetryAccessLogService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenTelemetryTracingConfig) DeepCopyInto(out *OpenTelemetryTracingConfig) {
+	*out = *in
+	in.GrpcService.DeepCopyInto(&out.GrpcService)
+	if in.ServiceName != nil {
+		in, out := &in.ServiceName, &out.ServiceName
+		*out = new(string)
+		**out = **in
+	}
+	if in.ResourceDetectors != nil {
+		in, out := &in.ResourceDetectors, &out.ResourceDetectors
+		*out = make([]ResourceDetector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Sampler != nil {
+		in, out := &in.Sampler, &out.Sampler
+		*out = new(Sampler)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryTracingConfig.
+func (in *OpenTelemetryTracingConfig) DeepCopy() *OpenTelemetryTracingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenTelemetryTracingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutlierDetection) DeepCopyInto(out *OutlierDetection) {
+	*out = *in
+	if in.Consecutive5xx != nil {
+		in, out := &in.Consecutive5xx, &out.Consecutive5xx
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.BaseEjectionTime != nil {
+		in, out := &in.BaseEjectionTime, &out.BaseEjectionTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxEjectionPercent != nil {
+		in, out := &in.MaxEjectionPercent, &out.MaxEjectionPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxEjectionTime != nil {
+		in, out := &in.MaxEjectionTime, &out.MaxEjectionTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ConsecutiveGatewayFailure != nil {
+		in, out := &in.ConsecutiveGatewayFailure, &out.ConsecutiveGatewayFailure
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SplitExternalLocalOriginErrors != nil {
+		in, out := &in.SplitExternalLocalOriginErrors, &out.SplitExternalLocalOriginErrors
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ConsecutiveLocalOriginFailure != nil {
+		in, out := &in.ConsecutiveLocalOriginFailure, &out.ConsecutiveLocalOriginFailure
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SuccessRate != nil {
+		in, out := &in.SuccessRate, &out.SuccessRate
+		*out = new(OutlierDetectionSuccessRate)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ResourceAttributes != nil {
-		in, out := &in.ResourceAttributes, &out.ResourceAttributes
-		*out = new(KeyAnyValueList)
+	if in.FailurePercentage != nil {
+		in, out := &in.FailurePercentage, &out.FailurePercentage
+		*out = new(OutlierDetectionFailurePercentage)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryAccessLogService.
-func (in *OpenTelemetryAccessLogService) DeepCopy() *OpenTelemetryAccessLogService {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutlierDetection.
+func (in *OutlierDetection) DeepCopy() *OutlierDetection {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenTelemetryAccessLogService)
+	out := new(OutlierDetection)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenTelemetryTracingConfig) DeepCopyInto(out *OpenTelemetryTracingConfig) {
+func (in *OutlierDetectionFailurePercentage) DeepCopyInto(out *OutlierDetectionFailurePercentage) {
 	*out = *in
-	in.GrpcService.DeepCopyInto(&out.GrpcService)
-	if in.ServiceName != nil {
-		in, out := &in.ServiceName, &out.ServiceName
-		*out = new(string)
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
+		*out = new(int32)
 		**out = **in
 	}
-	if in.ResourceDetectors != nil {
-		in, out := &in.ResourceDetectors, &out.ResourceDetectors
-		*out = make([]ResourceDetector, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.EnforcingPercent != nil {
+		in, out := &in.EnforcingPercent, &out.EnforcingPercent
+		*out = new(int32)
+		**out = **in
 	}
-	if in.Sampler != nil {
-		in, out := &in.Sampler, &out.Sampler
-		*out = new(Sampler)
-		(*in).DeepCopyInto(*out)
+	if in.MinimumHosts != nil {
+		in, out := &in.MinimumHosts, &out.MinimumHosts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RequestVolume != nil {
+		in, out := &in.RequestVolume, &out.RequestVolume
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryTracingConfig.
-func (in *OpenTelemetryTracingConfig) DeepCopy() *OpenTelemetryTracingConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutlierDetectionFailurePercentage.
+func (in *OutlierDetectionFailurePercentage) DeepCopy() *OutlierDetectionFailurePercentage {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenTelemetryTracingConfig)
+	out := new(OutlierDetectionFailurePercentage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OutlierDetection) DeepCopyInto(out *OutlierDetection) {
+func (in *OutlierDetectionSuccessRate) DeepCopyInto(out *OutlierDetectionSuccessRate) {
 	*out = *in
-	if in.Consecutive5xx != nil {
-		in, out := &in.Consecutive5xx, &out.Consecutive5xx
+	if in.EnforcingPercent != nil {
+		in, out := &in.EnforcingPercent, &out.EnforcingPercent
 		*out = new(int32)
 		**out = **in
 	}
-	if in.Interval != nil {
-		in, out := &in.Interval, &out.Interval
-		*out = new(v1.Duration)
+	if in.MinimumHosts != nil {
+		in, out := &in.MinimumHosts, &out.MinimumHosts
+		*out = new(int32)
 		**out = **in
 	}
-	if in.BaseEjectionTime != nil {
-		in, out := &in.BaseEjectionTime, &out.BaseEjectionTime
-		*out = new(v1.Duration)
+	if in.RequestVolume != nil {
+		in, out := &in.RequestVolume, &out.RequestVolume
+		*out = new(int32)
 		**out = **in
 	}
-	if in.MaxEjectionPercent != nil {
-		in, out := &in.MaxEjectionPercent, &out.MaxEjectionPercent
+	if in.StdevFactor != nil {
+		in, out := &in.StdevFactor, &out.StdevFactor
 		*out = new(int32)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutlierDetection.
-func (in *OutlierDetection) DeepCopy() *OutlierDetection {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutlierDetectionSuccessRate.
+func (in *OutlierDetectionSuccessRate) DeepCopy() *OutlierDetectionSuccessRate {
 	if in == nil {
 		return nil
 	}
-	out := new(OutlierDetection)
+	out := new(OutlierDetectionSuccessRate)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -3692,6 +4819,31 @@ func (in *Port) DeepCopy() *Port {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Preconnect) DeepCopyInto(out *Preconnect) {
+	*out = *in
+	if in.PerUpstreamRatio != nil {
+		in, out := &in.PerUpstreamRatio, &out.PerUpstreamRatio
+		*out = new(string)
+		**out = **in
+	}
+	if in.PredictiveRatio != nil {
+		in, out := &in.PredictiveRatio, &out.PredictiveRatio
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Preconnect.
+func (in *Preconnect) DeepCopy() *Preconnect {
+	if in == nil {
+		return nil
+	}
+	out := new(Preconnect)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProcessingMode) DeepCopyInto(out *ProcessingMode) {
 	*out = *in
@@ -3747,6 +4899,41 @@ func (in *ProxyProtocolConfig) DeepCopy() *ProxyProtocolConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyProtocolPassThroughTLVs) DeepCopyInto(out *ProxyProtocolPassThroughTLVs) {
+	*out = *in
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyProtocolPassThroughTLVs.
+func (in *ProxyProtocolPassThroughTLVs) DeepCopy() *ProxyProtocolPassThroughTLVs {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyProtocolPassThroughTLVs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyProtocolTLV) DeepCopyInto(out *ProxyProtocolTLV) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyProtocolTLV.
+func (in *ProxyProtocolTLV) DeepCopy() *ProxyProtocolTLV {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyProtocolTLV)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RateLimit) DeepCopyInto(out *RateLimit) {
 	*out = *in
@@ -3782,6 +4969,11 @@ func (in *RateLimitDescriptor) DeepCopyInto(out *RateLimitDescriptor) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Limit != nil {
+		in, out := &in.Limit, &out.Limit
+		*out = new(RateLimitDescriptorLimit)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitDescriptor.
@@ -3807,6 +4999,11 @@ func (in *RateLimitDescriptorEntry) DeepCopyInto(out *RateLimitDescriptorEntry)
 		*out = new(string)
 		**out = **in
 	}
+	if in.APIKeyMetadata != nil {
+		in, out := &in.APIKeyMetadata, &out.APIKeyMetadata
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitDescriptorEntry.
@@ -3834,6 +5031,21 @@ func (in *RateLimitDescriptorEntryGeneric) DeepCopy() *RateLimitDescriptorEntryG
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitDescriptorLimit) DeepCopyInto(out *RateLimitDescriptorLimit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitDescriptorLimit.
+func (in *RateLimitDescriptorLimit) DeepCopy() *RateLimitDescriptorLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitDescriptorLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RateLimitPolicy) DeepCopyInto(out *RateLimitPolicy) {
 	*out = *in
@@ -3874,15 +5086,174 @@ func (in *RateLimitProvider) DeepCopy() *RateLimitProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisBackend) DeepCopyInto(out *RedisBackend) {
+	*out = *in
+	in.RedisUpstream.DeepCopyInto(&out.RedisUpstream)
+	if in.PrefixRoutes != nil {
+		in, out := &in.PrefixRoutes, &out.PrefixRoutes
+		*out = make([]RedisPrefixRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CaseInsensitivePrefixes != nil {
+		in, out := &in.CaseInsensitivePrefixes, &out.CaseInsensitivePrefixes
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReadPolicy != nil {
+		in, out := &in.ReadPolicy, &out.ReadPolicy
+		*out = new(RedisReadPolicy)
+		**out = **in
+	}
+	if in.OpTimeout != nil {
+		in, out := &in.OpTimeout, &out.OpTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.EnableCommandStats != nil {
+		in, out := &in.EnableCommandStats, &out.EnableCommandStats
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AuthSecretRef != nil {
+		in, out := &in.AuthSecretRef, &out.AuthSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisBackend.
+func (in *RedisBackend) DeepCopy() *RedisBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisCluster) DeepCopyInto(out *RedisCluster) {
+	*out = *in
+	if in.RefreshRate != nil {
+		in, out := &in.RefreshRate, &out.RefreshRate
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RefreshTimeout != nil {
+		in, out := &in.RefreshTimeout, &out.RefreshTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisCluster.
+func (in *RedisCluster) DeepCopy() *RedisCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisPrefixRoute) DeepCopyInto(out *RedisPrefixRoute) {
+	*out = *in
+	if in.RemovePrefix != nil {
+		in, out := &in.RemovePrefix, &out.RemovePrefix
+		*out = new(bool)
+		**out = **in
+	}
+	in.RedisUpstream.DeepCopyInto(&out.RedisUpstream)
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]RedisRequestMirror, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisPrefixRoute.
+func (in *RedisPrefixRoute) DeepCopy() *RedisPrefixRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisPrefixRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisRequestMirror) DeepCopyInto(out *RedisRequestMirror) {
+	*out = *in
+	in.RedisUpstream.DeepCopyInto(&out.RedisUpstream)
+	if in.Percentage != nil {
+		in, out := &in.Percentage, &out.Percentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ExcludeReadCommands != nil {
+		in, out := &in.ExcludeReadCommands, &out.ExcludeReadCommands
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisRequestMirror.
+func (in *RedisRequestMirror) DeepCopy() *RedisRequestMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisRequestMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisUpstream) DeepCopyInto(out *RedisUpstream) {
+	*out = *in
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]Host, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(RedisCluster)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisUpstream.
+func (in *RedisUpstream) DeepCopy() *RedisUpstream {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisUpstream)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoteJWKS) DeepCopyInto(out *RemoteJWKS) {
 	*out = *in
 	in.BackendRef.DeepCopyInto(&out.BackendRef)
 	if in.CacheDuration != nil {
 		in, out := &in.CacheDuration, &out.CacheDuration
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.AsyncFetch != nil {
+		in, out := &in.AsyncFetch, &out.AsyncFetch
+		*out = new(JWKSAsyncFetch)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteJWKS.
@@ -3985,17 +5356,17 @@ func (in *Retry) DeepCopyInto(out *Retry) {
 	}
 	if in.PerTryTimeout != nil {
 		in, out := &in.PerTryTimeout, &out.PerTryTimeout
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.StatusCodes != nil {
 		in, out := &in.StatusCodes, &out.StatusCodes
-		*out = make([]apisv1.HTTPRouteRetryStatusCode, len(*in))
+		*out = make([]v1.HTTPRouteRetryStatusCode, len(*in))
 		copy(*out, *in)
 	}
 	if in.BackoffBaseInterval != nil {
 		in, out := &in.BackoffBaseInterval, &out.BackoffBaseInterval
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 }
@@ -4016,7 +5387,7 @@ func (in *RetryBackoff) DeepCopyInto(out *RetryBackoff) {
 	out.BaseInterval = in.BaseInterval
 	if in.MaxInterval != nil {
 		in, out := &in.MaxInterval, &out.MaxInterval
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 }
@@ -4136,7 +5507,7 @@ func (in *SecretReference) DeepCopyInto(out *SecretReference) {
 	*out = *in
 	if in.Namespace != nil {
 		in, out := &in.Namespace, &out.Namespace
-		*out = new(apisv1.Namespace)
+		*out = new(v1.Namespace)
 		**out = **in
 	}
 	if in.Key != nil {
@@ -4256,7 +5627,7 @@ func (in *SlowStart) DeepCopyInto(out *SlowStart) {
 	*out = *in
 	if in.Window != nil {
 		in, out := &in.Window, &out.Window
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.Aggression != nil {
@@ -4417,12 +5788,12 @@ func (in *TCPKeepalive) DeepCopyInto(out *TCPKeepalive) {
 	}
 	if in.KeepAliveTime != nil {
 		in, out := &in.KeepAliveTime, &out.KeepAliveTime
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.KeepAliveInterval != nil {
 		in, out := &in.KeepAliveInterval, &out.KeepAliveInterval
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 }
@@ -4437,6 +5808,38 @@ func (in *TCPKeepalive) DeepCopy() *TCPKeepalive {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TCPSettings) DeepCopyInto(out *TCPSettings) {
+	*out = *in
+	if in.AccessLog != nil {
+		in, out := &in.AccessLog, &out.AccessLog
+		*out = make([]AccessLog, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxConnectionDuration != nil {
+		in, out := &in.MaxConnectionDuration, &out.MaxConnectionDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TCPSettings.
+func (in *TCPSettings) DeepCopy() *TCPSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(TCPSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLS) DeepCopyInto(out *TLS) {
 	*out = *in
@@ -4452,7 +5855,7 @@ func (in *TLS) DeepCopyInto(out *TLS) {
 	}
 	if in.WellKnownCACertificates != nil {
 		in, out := &in.WellKnownCACertificates, &out.WellKnownCACertificates
-		*out = new(apisv1.WellKnownCACertificatesType)
+		*out = new(v1.WellKnownCACertificatesType)
 		**out = **in
 	}
 	if in.InsecureSkipVerify != nil {
@@ -4827,6 +6230,16 @@ func (in *TrafficPolicySpec) DeepCopyInto(out *TrafficPolicySpec) {
 		*out = new(OAuth2Policy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OAuth2Introspection != nil {
+		in, out := &in.OAuth2Introspection, &out.OAuth2Introspection
+		*out = new(OAuth2IntrospectionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIConsumers != nil {
+		in, out := &in.APIConsumers, &out.APIConsumers
+		*out = new(APIConsumerPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficPolicySpec.
@@ -4938,3 +6351,33 @@ func (in *UpgradeConfig) DeepCopy() *UpgradeConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpstreamProxyProtocol) DeepCopyInto(out *UpstreamProxyProtocol) {
+	*out = *in
+	if in.PassThroughTLVs != nil {
+		in, out := &in.PassThroughTLVs, &out.PassThroughTLVs
+		*out = new(ProxyProtocolPassThroughTLVs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AddedTLVs != nil {
+		in, out := &in.AddedTLVs, &out.AddedTLVs
+		*out = make([]ProxyProtocolTLV, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowUnspecifiedAddress != nil {
+		in, out := &in.AllowUnspecifiedAddress, &out.AllowUnspecifiedAddress
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpstreamProxyProtocol.
+func (in *UpstreamProxyProtocol) DeepCopy() *UpstreamProxyProtocol {
+	if in == nil {
+		return nil
+	}
+	out := new(UpstreamProxyProtocol)
+	in.DeepCopyInto(out)
+	return out
+}
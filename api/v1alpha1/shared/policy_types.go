@@ -31,6 +31,18 @@ const (
 	//
 	PolicyConditionAttached PolicyConditionType = "Attached"
 
+	// PolicyConditionEndpointsHealthy reports the endpoint health of the targeted backend, as seen by
+	// the gateway proxies. It is only set when backend health status reporting is enabled.
+	//
+	// Possible reasons for this condition to be True are:
+	// * EndpointsHealthy
+	//
+	// Possible reasons for this condition to be False are:
+	// * EndpointsUnhealthy
+	// * NoEndpoints
+	//
+	PolicyConditionEndpointsHealthy PolicyConditionType = "EndpointsHealthy"
+
 	// PolicyReasonValid is used with the "Accepted" condition when the policy
 	// has been accepted by the system.
 	PolicyReasonValid PolicyConditionReason = "Valid"
@@ -55,6 +67,18 @@ const (
 	// PolicyReasonPending is used with the "Accepted" or "Attached" condition when the policy has been referenced but not yet fully processed by the controller.
 	PolicyReasonPending PolicyConditionReason = "Pending"
 
+	// PolicyReasonEndpointsHealthy is used with the "EndpointsHealthy" condition when all
+	// endpoints of the backend are healthy and none are ejected.
+	PolicyReasonEndpointsHealthy PolicyConditionReason = "EndpointsHealthy"
+
+	// PolicyReasonEndpointsUnhealthy is used with the "EndpointsHealthy" condition when some
+	// endpoints of the backend fail health checks or are ejected by outlier detection.
+	PolicyReasonEndpointsUnhealthy PolicyConditionReason = "EndpointsUnhealthy"
+
+	// PolicyReasonNoEndpoints is used with the "EndpointsHealthy" condition when the backend
+	// has no endpoints.
+	PolicyReasonNoEndpoints PolicyConditionReason = "NoEndpoints"
+
 	// PolicyReasonPartiallyValid is used with the "Accepted" condition when the policy has been accepted by the system,
 	// but some of the referenced resources are not valid.
 	PolicyReasonPartiallyValid PolicyConditionReason = "PartiallyValid"
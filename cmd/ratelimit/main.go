@@ -0,0 +1,9 @@
+package main
+
+import (
+	"github.com/kgateway-dev/kgateway/v2/pkg/ratelimit"
+)
+
+func main() {
+	ratelimit.RunMain()
+}
@@ -6,6 +6,7 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -60,6 +61,14 @@ type jwksHttpClientImpl struct {
 	Client *http.Client
 }
 
+// maxJwksSize bounds the size of a fetched jwks.
+const maxJwksSize = 1 << 20
+
+// NewJwksHttpClient returns a JwksHttpClient fetching jwks with the given http client.
+func NewJwksHttpClient(client *http.Client) JwksHttpClient {
+	return &jwksHttpClientImpl{Client: client}
+}
+
 func NewJwksFetcher(cache *jwksCache) *JwksFetcher {
 	toret := &JwksFetcher{
 		cache:             cache,
@@ -217,12 +226,11 @@ func (c *jwksHttpClientImpl) FetchJwks(ctx context.Context, jwksURL string) (jos
 	log := log.FromContext(ctx)
 	log.Info("fetching jwks", "url", jwksURL)
 
-	request, err := http.NewRequest(http.MethodGet, jwksURL, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURL, nil)
 	if err != nil {
 		return jose.JSONWebKeySet{}, fmt.Errorf("could not build request to get JWKS: %w", err)
 	}
 
-	// TODO (dmitri-d) control the size here maybe?
 	response, err := c.Client.Do(request)
 	if err != nil {
 		return jose.JSONWebKeySet{}, err
@@ -233,8 +241,16 @@ func (c *jwksHttpClientImpl) FetchJwks(ctx context.Context, jwksURL string) (jos
 		return jose.JSONWebKeySet{}, fmt.Errorf("unexpected status code from jwks endpoint at %s: %d", jwksURL, response.StatusCode)
 	}
 
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxJwksSize+1))
+	if err != nil {
+		return jose.JSONWebKeySet{}, err
+	}
+	if len(body) > maxJwksSize {
+		return jose.JSONWebKeySet{}, fmt.Errorf("jwks exceeds %d bytes", maxJwksSize)
+	}
+
 	var jwks jose.JSONWebKeySet
-	if err := json.NewDecoder(response.Body).Decode(&jwks); err != nil {
+	if err := json.Unmarshal(body, &jwks); err != nil {
 		return jose.JSONWebKeySet{}, fmt.Errorf("could not decode jwks: %w", err)
 	}
 
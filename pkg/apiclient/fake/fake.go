@@ -99,7 +99,8 @@ func fakeKgwClient(objects ...client.Object) *fake.Clientset {
 func filterObjects(objects ...client.Object) (istio []client.Object, kgw []client.Object) {
 	for _, obj := range objects {
 		switch obj.(type) {
-		case *kgateway.Backend,
+		case *kgateway.APIConsumer,
+			*kgateway.Backend,
 			*kgateway.BackendConfigPolicy,
 			*kgateway.DirectResponse,
 			*kgateway.GatewayExtension,
@@ -121,6 +121,19 @@ func RegisterTypes() {
 			return c.(Client).Kgateway().GatewayKgateway().GatewayExtensions(namespace)
 		},
 	)
+	kubeclient.Register(
+		wellknown.APIConsumerGVR,
+		wellknown.APIConsumerGVK,
+		func(c kubeclient.ClientGetter, namespace string, o metav1.ListOptions) (runtime.Object, error) {
+			return c.(Client).Kgateway().GatewayKgateway().APIConsumers(namespace).List(context.Background(), o)
+		},
+		func(c kubeclient.ClientGetter, namespace string, o metav1.ListOptions) (watch.Interface, error) {
+			return c.(Client).Kgateway().GatewayKgateway().APIConsumers(namespace).Watch(context.Background(), o)
+		},
+		func(c kubeclient.ClientGetter, namespace string) kubetypes.WriteAPI[*kgateway.APIConsumer] {
+			return c.(Client).Kgateway().GatewayKgateway().APIConsumers(namespace)
+		},
+	)
 	kubeclient.Register(
 		wellknown.AgentgatewayPolicyGVR,
 		wellknown.AgentgatewayPolicyGVK,
@@ -0,0 +1,135 @@
+package authverifier
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/kelseyhightower/envconfig"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/apiconsumer"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/apikeyauth"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/authverifier"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/basicauth"
+	"github.com/kgateway-dev/kgateway/v2/pkg/logging"
+)
+
+var logger = logging.New("auth_verifier")
+
+// The auth verifier serves the kgateway verifiers of BasicAuth policies with the Kgateway
+// verifier, hashed API keys and API consumers. It is deployed separately from the controller, so
+// that authentication does not depend on the control plane, and is referenced by the controller
+// AuthVerifierService setting. The proxies reach it through the cluster of its Service, and must
+// present a client certificate signed by the configured CA.
+
+// Config is read from the environment variables prefixed with AUTHVERIFIER_, for example
+// AUTHVERIFIER_TLS_CERT_FILE. The files are usually mounted from a kubernetes.io/tls Secret, whose
+// certificate is also used by the proxies as their client certificate.
+type Config struct {
+	ServerAddress string `split_words:"true" default:"0.0.0.0:9000"`
+	TLSCertFile   string `envconfig:"TLS_CERT_FILE" default:"/etc/authverifier/tls/tls.crt"`
+	TLSKeyFile    string `envconfig:"TLS_KEY_FILE" default:"/etc/authverifier/tls/tls.key"`
+	// TLSClientCAFile is the CA verifying the client certificates of the proxies.
+	TLSClientCAFile string `envconfig:"TLS_CLIENT_CA_FILE" default:"/etc/authverifier/tls/ca.crt"`
+}
+
+func RunMain() {
+	var c Config
+	if err := envconfig.Process("authverifier", &c); err != nil {
+		log.Fatalf("failed to process env config: %v", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	if err := Run(ctx, c, logger); err != nil {
+		log.Fatalf("failed to run auth verifier: %v", err)
+	}
+}
+
+// Run serves the verifiers until the context is done.
+func Run(ctx context.Context, c Config, logger *slog.Logger) error {
+	tlsConfig, err := serverTLSConfig(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	grpcServer := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	authverifier.Register(grpcServer, basicauth.NewServer(), apikeyauth.NewServer(), apiconsumer.NewServer())
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+
+	lis, err := net.Listen("tcp", c.ServerAddress)
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		grpcServer.GracefulStop()
+	}()
+	logger.Info("auth verifier listening", "address", c.ServerAddress)
+	return grpcServer.Serve(lis)
+}
+
+// serverTLSConfig requires client certificates signed by the client CA. The certificate and the CA
+// are reloaded when the mounted Secret is rotated.
+func serverTLSConfig(ctx context.Context, c Config) (*tls.Config, error) {
+	if c.TLSCertFile == "" || c.TLSKeyFile == "" || c.TLSClientCAFile == "" {
+		return nil, errors.New("the TLS certificate, key and client CA files are required")
+	}
+	watcher, err := certwatcher.New(c.TLSCertFile, c.TLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	go func() {
+		if err := watcher.Start(ctx); err != nil {
+			logger.Error("failed to watch TLS certificate", "error", err)
+		}
+	}()
+	// fail at startup rather than on the first connection
+	if _, err := loadClientCAs(c.TLSClientCAFile); err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// proxies keep their connections open, so the CA is only read on new connections
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			pool, err := loadClientCAs(c.TLSClientCAFile)
+			if err != nil {
+				return nil, err
+			}
+			return &tls.Config{
+				MinVersion:     tls.VersionTLS12,
+				GetCertificate: watcher.GetCertificate,
+				ClientAuth:     tls.RequireAndVerifyClientCert,
+				ClientCAs:      pool,
+				NextProtos:     []string{"h2"},
+			}, nil
+		},
+	}, nil
+}
+
+func loadClientCAs(file string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificate found in client CA file %s", file)
+	}
+	return pool, nil
+}
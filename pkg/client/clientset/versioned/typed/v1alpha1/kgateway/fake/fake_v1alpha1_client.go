@@ -12,6 +12,10 @@ type FakeGatewayKgateway struct {
 	*testing.Fake
 }
 
+func (c *FakeGatewayKgateway) APIConsumers(namespace string) kgateway.APIConsumerInterface {
+	return newFakeAPIConsumers(c, namespace)
+}
+
 func (c *FakeGatewayKgateway) Backends(namespace string) kgateway.BackendInterface {
 	return newFakeBackends(c, namespace)
 }
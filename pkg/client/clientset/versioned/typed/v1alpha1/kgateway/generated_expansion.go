@@ -2,6 +2,8 @@
 
 package kgateway
 
+type APIConsumerExpansion interface{}
+
 type BackendExpansion interface{}
 
 type BackendConfigPolicyExpansion interface{}
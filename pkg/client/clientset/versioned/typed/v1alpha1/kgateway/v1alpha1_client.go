@@ -12,6 +12,7 @@ import (
 
 type GatewayKgatewayInterface interface {
 	RESTClient() rest.Interface
+	APIConsumersGetter
 	BackendsGetter
 	BackendConfigPoliciesGetter
 	DirectResponsesGetter
@@ -27,6 +28,10 @@ type GatewayKgatewayClient struct {
 	restClient rest.Interface
 }
 
+func (c *GatewayKgatewayClient) APIConsumers(namespace string) APIConsumerInterface {
+	return newAPIConsumers(c, namespace)
+}
+
 func (c *GatewayKgatewayClient) Backends(namespace string) BackendInterface {
 	return newBackends(c, namespace)
 }
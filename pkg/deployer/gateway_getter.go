@@ -32,8 +32,12 @@ func DefaultGatewayIRGetter(gw *gwv1.Gateway, commonCollections *collections.Com
 
 func GatewayIRFrom(gw *gwv1.Gateway, controllerNameGuess string) *ir.GatewayForDeployer {
 	ports := sets.New[int32]()
+	udpPorts := sets.New[int32]()
 	for _, l := range gw.Spec.Listeners {
 		ports.Insert(l.Port)
+		if l.Protocol == gwv1.UDPProtocolType {
+			udpPorts.Insert(l.Port)
+		}
 	}
 	return &ir.GatewayForDeployer{
 		ObjectSource: ir.ObjectSource{
@@ -44,5 +48,6 @@ func GatewayIRFrom(gw *gwv1.Gateway, controllerNameGuess string) *ir.GatewayForD
 		},
 		ControllerName: controllerNameGuess,
 		Ports:          smallset.New(ports.UnsortedList()...),
+		UDPPorts:       smallset.New(udpPorts.UnsortedList()...),
 	}
 }
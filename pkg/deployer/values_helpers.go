@@ -49,7 +49,11 @@ func GetPortsValues(gw *ir.GatewayForDeployer, gwp *kgateway.GatewayParameters,
 			logger.Error("skipping port", "gateway", gw.ResourceName(), "error", err)
 			continue
 		}
-		gwPorts = AppendPortValue(gwPorts, port, portName, gwp)
+		protocol := corev1.ProtocolTCP
+		if gw.UDPPorts.Contains(port) {
+			protocol = corev1.ProtocolUDP
+		}
+		gwPorts = appendPortValue(gwPorts, port, portName, protocol, gwp)
 	}
 
 	// Add ports from GatewayParameters.Service.Ports
@@ -87,12 +91,16 @@ func SanitizePortName(name string) string {
 }
 
 func AppendPortValue(gwPorts []HelmPort, port int32, name string, gwp *kgateway.GatewayParameters) []HelmPort {
+	return appendPortValue(gwPorts, port, name, corev1.ProtocolTCP, gwp)
+}
+
+func appendPortValue(gwPorts []HelmPort, port int32, name string, proto corev1.Protocol, gwp *kgateway.GatewayParameters) []HelmPort {
 	if slices.IndexFunc(gwPorts, func(p HelmPort) bool { return *p.Port == port }) != -1 {
 		return gwPorts
 	}
 
 	portName := SanitizePortName(name)
-	protocol := "TCP"
+	protocol := string(proto)
 
 	// Search for static NodePort set from the GatewayParameters spec
 	// If not found the default value of `nil` will not render anything.
@@ -4,10 +4,13 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"istio.io/istio/pkg/util/smallset"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
 )
 
 func TestComponentLogLevelsToString(t *testing.T) {
@@ -232,3 +235,22 @@ func TestSetLoadBalancerIPFromGateway(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPortsValuesUDP(t *testing.T) {
+	gw := &ir.GatewayForDeployer{
+		Ports:    smallset.New[int32](8080, 5353),
+		UDPPorts: smallset.New[int32](5353),
+	}
+
+	ports := GetPortsValues(gw, nil, false)
+	assert.Len(t, ports, 2)
+
+	protocols := map[int32]string{}
+	for _, p := range ports {
+		protocols[*p.Port] = *p.Protocol
+	}
+	assert.Equal(t, map[int32]string{
+		5353: string(corev1.ProtocolUDP),
+		8080: string(corev1.ProtocolTCP),
+	}, protocols)
+}
@@ -0,0 +1,433 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	envoytypev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"sigs.k8s.io/yaml"
+)
+
+// JWTMetadataNamespace is the dynamic metadata namespace the JWT filter writes the validated
+// claims to, under the JWTPayloadKey key. The GatewayExtension must forward it to the server
+// with metadataContextNamespaces.
+const (
+	JWTMetadataNamespace = "envoy.filters.http.jwt_authn"
+	JWTPayloadKey        = "payload"
+)
+
+// Decision is the decision of the server when no rule matches a request.
+type Decision string
+
+const (
+	DecisionAllow Decision = "Allow"
+	DecisionDeny  Decision = "Deny"
+)
+
+// Config is the configuration of the rules, usually mounted from a ConfigMap.
+//
+// Rules are evaluated in order, and the first rule whose When expression is true decides: the
+// request is allowed if its Allow expression is true, and denied otherwise. Requests matching
+// no rule get the DefaultDecision.
+//
+// Expressions are CEL expressions with the following variables:
+//   - request: method, scheme, host, path, query, protocol, id, headers (lowercase names) and time
+//   - source, destination: address, port and principal of the peers
+//   - jwt: the claims of the JWT validated by the JWT filter, empty if there is none
+//   - metadata: the dynamic metadata forwarded by the proxy, keyed by namespace
+//   - context: the context extensions of the route, set by the TrafficPolicy extAuth
+//   - now: the time of the request, e.g. now.getHours("Europe/Paris")
+//
+// Accessing a missing key is an evaluation error, which denies the request: optional keys are
+// tested with has(), in or the optional syntax, e.g. jwt.?groups.orValue([]).
+type Config struct {
+	// Rules are evaluated in order.
+	Rules []Rule `json:"rules"`
+	// DefaultDecision applies to requests matching no rule. Defaults to Deny.
+	DefaultDecision Decision `json:"defaultDecision,omitempty"`
+	// DenyResponse is the response of denied requests, unless their rule overrides it.
+	// Defaults to a 403 with an empty body.
+	DenyResponse *DenyResponse `json:"denyResponse,omitempty"`
+}
+
+// Rule decides the requests matching its When expression.
+type Rule struct {
+	// Name identifies the rule in errors.
+	Name string `json:"name"`
+	// When is a boolean expression selecting the requests the rule decides. Defaults to all requests.
+	When string `json:"when,omitempty"`
+	// Allow is a boolean expression allowing the request. Defaults to true.
+	Allow string `json:"allow,omitempty"`
+	// RequestHeaders are set on allowed requests, with values computed by string expressions.
+	RequestHeaders map[string]string `json:"requestHeaders,omitempty"`
+	// RemoveRequestHeaders are removed from allowed requests.
+	RemoveRequestHeaders []string `json:"removeRequestHeaders,omitempty"`
+	// ResponseHeaders are added to the responses of allowed requests, with values computed by string expressions.
+	ResponseHeaders map[string]string `json:"responseHeaders,omitempty"`
+	// DenyResponse overrides the response of the requests the rule denies.
+	DenyResponse *DenyResponse `json:"denyResponse,omitempty"`
+}
+
+// DenyResponse is the response sent to the client when a request is denied.
+type DenyResponse struct {
+	// Status is the HTTP status code. Defaults to 403.
+	Status int32 `json:"status,omitempty"`
+	// Body is the response body.
+	Body string `json:"body,omitempty"`
+	// Headers are added to the response.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// Engine evaluates compiled rules against ext_authz check requests.
+type Engine struct {
+	rules           []*rule
+	defaultDecision Decision
+	denyResponse    *DenyResponse
+}
+
+type rule struct {
+	name                 string
+	when                 cel.Program
+	allow                cel.Program
+	requestHeaders       []header
+	removeRequestHeaders []string
+	responseHeaders      []header
+	denyResponse         *DenyResponse
+}
+
+type header struct {
+	name  string
+	value cel.Program
+}
+
+func newEnv() (*cel.Env, error) {
+	anyMap := cel.MapType(cel.StringType, cel.DynType)
+	return cel.NewEnv(
+		cel.Variable("request", anyMap),
+		cel.Variable("source", anyMap),
+		cel.Variable("destination", anyMap),
+		cel.Variable("jwt", anyMap),
+		cel.Variable("metadata", anyMap),
+		cel.Variable("context", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("now", cel.TimestampType),
+		cel.OptionalTypes(),
+		ext.Strings(),
+	)
+}
+
+// Parse parses and compiles a YAML or JSON rules configuration.
+func Parse(data []byte) (*Engine, error) {
+	var cfg Config
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid rules configuration: %w", err)
+	}
+	return Compile(&cfg)
+}
+
+// Compile compiles a rules configuration.
+func Compile(cfg *Config) (*Engine, error) {
+	env, err := newEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Engine{
+		defaultDecision: DecisionDeny,
+		denyResponse:    cfg.DenyResponse,
+	}
+	switch cfg.DefaultDecision {
+	case "", DecisionDeny:
+	case DecisionAllow:
+		e.defaultDecision = DecisionAllow
+	default:
+		return nil, fmt.Errorf("invalid default decision %q, must be %s or %s", cfg.DefaultDecision, DecisionAllow, DecisionDeny)
+	}
+	if err := validateDenyResponse(cfg.DenyResponse); err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for i, r := range cfg.Rules {
+		compiled, err := compileRule(env, r)
+		if err != nil {
+			name := r.Name
+			if name == "" {
+				name = fmt.Sprintf("#%d", i)
+			}
+			errs = append(errs, fmt.Errorf("rule %s: %w", name, err))
+			continue
+		}
+		e.rules = append(e.rules, compiled)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return e, nil
+}
+
+func compileRule(env *cel.Env, r Rule) (*rule, error) {
+	if err := validateDenyResponse(r.DenyResponse); err != nil {
+		return nil, err
+	}
+	out := &rule{
+		name:         r.Name,
+		denyResponse: r.DenyResponse,
+	}
+	var err error
+	if r.When != "" {
+		if out.when, err = compile(env, "when", r.When, cel.BoolType); err != nil {
+			return nil, err
+		}
+	}
+	if r.Allow != "" {
+		if out.allow, err = compile(env, "allow", r.Allow, cel.BoolType); err != nil {
+			return nil, err
+		}
+	}
+	if out.requestHeaders, err = compileHeaders(env, r.RequestHeaders); err != nil {
+		return nil, err
+	}
+	if out.responseHeaders, err = compileHeaders(env, r.ResponseHeaders); err != nil {
+		return nil, err
+	}
+	for _, name := range r.RemoveRequestHeaders {
+		out.removeRequestHeaders = append(out.removeRequestHeaders, strings.ToLower(name))
+	}
+	return out, nil
+}
+
+func compileHeaders(env *cel.Env, headers map[string]string) ([]header, error) {
+	var out []header
+	for _, name := range slices.Sorted(maps.Keys(headers)) {
+		prg, err := compile(env, "header "+name, headers[name], cel.StringType)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, header{name: strings.ToLower(name), value: prg})
+	}
+	return out, nil
+}
+
+func compile(env *cel.Env, field, expression string, outputType *cel.Type) (cel.Program, error) {
+	ast, iss := env.Compile(expression)
+	if iss.Err() != nil {
+		return nil, fmt.Errorf("%s: %w", field, iss.Err())
+	}
+	if !ast.OutputType().IsExactType(outputType) && !ast.OutputType().IsExactType(cel.DynType) {
+		return nil, fmt.Errorf("%s: expression must return a %s, got %s", field, outputType, ast.OutputType())
+	}
+	prg, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", field, err)
+	}
+	return prg, nil
+}
+
+func validateDenyResponse(r *DenyResponse) error {
+	if r == nil || r.Status == 0 {
+		return nil
+	}
+	if r.Status < 200 || r.Status > 599 {
+		return fmt.Errorf("invalid deny response status %d", r.Status)
+	}
+	return nil
+}
+
+// Check decides a check request. Requests failing the evaluation of a rule are denied, and the
+// evaluation error is returned along with the deny response.
+func (e *Engine) Check(req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+	vars := activation(req)
+	for _, r := range e.rules {
+		if r.when != nil {
+			matched, err := evalBool(r.when, vars)
+			if err != nil {
+				return e.denied(r.denyResponse), fmt.Errorf("rule %s: when: %w", r.name, err)
+			}
+			if !matched {
+				continue
+			}
+		}
+		if r.allow != nil {
+			allowed, err := evalBool(r.allow, vars)
+			if err != nil {
+				return e.denied(r.denyResponse), fmt.Errorf("rule %s: allow: %w", r.name, err)
+			}
+			if !allowed {
+				return e.denied(r.denyResponse), nil
+			}
+		}
+		resp, err := r.allowed(vars)
+		if err != nil {
+			return e.denied(r.denyResponse), fmt.Errorf("rule %s: %w", r.name, err)
+		}
+		return resp, nil
+	}
+
+	if e.defaultDecision == DecisionAllow {
+		return allowed(&authv3.OkHttpResponse{}), nil
+	}
+	return e.denied(nil), nil
+}
+
+func (r *rule) allowed(vars map[string]any) (*authv3.CheckResponse, error) {
+	ok := &authv3.OkHttpResponse{
+		HeadersToRemove: r.removeRequestHeaders,
+	}
+	for _, h := range r.requestHeaders {
+		value, err := evalString(h.value, vars)
+		if err != nil {
+			return nil, fmt.Errorf("header %s: %w", h.name, err)
+		}
+		ok.Headers = append(ok.Headers, headerValueOption(h.name, value))
+	}
+	for _, h := range r.responseHeaders {
+		value, err := evalString(h.value, vars)
+		if err != nil {
+			return nil, fmt.Errorf("header %s: %w", h.name, err)
+		}
+		ok.ResponseHeadersToAdd = append(ok.ResponseHeadersToAdd, headerValueOption(h.name, value))
+	}
+	return allowed(ok), nil
+}
+
+func allowed(ok *authv3.OkHttpResponse) *authv3.CheckResponse {
+	return &authv3.CheckResponse{
+		Status: &status.Status{Code: int32(codes.OK)},
+		HttpResponse: &authv3.CheckResponse_OkResponse{
+			OkResponse: ok,
+		},
+	}
+}
+
+// denied returns the deny response of a rule, defaulting to the deny response of the configuration.
+func (e *Engine) denied(r *DenyResponse) *authv3.CheckResponse {
+	if r == nil {
+		r = e.denyResponse
+	}
+	httpStatus := int32(http.StatusForbidden)
+	denied := &authv3.DeniedHttpResponse{}
+	if r != nil {
+		if r.Status != 0 {
+			httpStatus = r.Status
+		}
+		denied.Body = r.Body
+		for _, name := range slices.Sorted(maps.Keys(r.Headers)) {
+			denied.Headers = append(denied.Headers, headerValueOption(name, r.Headers[name]))
+		}
+	}
+	denied.Status = &envoytypev3.HttpStatus{Code: envoytypev3.StatusCode(httpStatus)}
+
+	code := codes.PermissionDenied
+	if httpStatus == http.StatusUnauthorized {
+		code = codes.Unauthenticated
+	}
+	return &authv3.CheckResponse{
+		Status: &status.Status{Code: int32(code)},
+		HttpResponse: &authv3.CheckResponse_DeniedResponse{
+			DeniedResponse: denied,
+		},
+	}
+}
+
+func headerValueOption(name, value string) *envoycorev3.HeaderValueOption {
+	return &envoycorev3.HeaderValueOption{
+		Header: &envoycorev3.HeaderValue{
+			Key:   name,
+			Value: value,
+		},
+		AppendAction: envoycorev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+	}
+}
+
+func evalBool(prg cel.Program, vars map[string]any) (bool, error) {
+	out, _, err := prg.Eval(vars)
+	if err != nil {
+		return false, err
+	}
+	b, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression returned %s, not a bool", out.Type())
+	}
+	return b, nil
+}
+
+func evalString(prg cel.Program, vars map[string]any) (string, error) {
+	out, _, err := prg.Eval(vars)
+	if err != nil {
+		return "", err
+	}
+	s, ok := out.Value().(string)
+	if !ok {
+		return "", fmt.Errorf("expression returned %s, not a string", out.Type())
+	}
+	return s, nil
+}
+
+// activation builds the variables of the expressions from the attributes of a check request.
+func activation(req *authv3.CheckRequest) map[string]any {
+	attrs := req.GetAttributes()
+	httpReq := attrs.GetRequest().GetHttp()
+
+	now := time.Now()
+	if t := attrs.GetRequest().GetTime(); t != nil {
+		now = t.AsTime()
+	}
+	headers := httpReq.GetHeaders()
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	request := map[string]any{
+		"method":   httpReq.GetMethod(),
+		"scheme":   httpReq.GetScheme(),
+		"host":     httpReq.GetHost(),
+		"path":     httpReq.GetPath(),
+		"query":    httpReq.GetQuery(),
+		"protocol": httpReq.GetProtocol(),
+		"id":       httpReq.GetId(),
+		"headers":  headers,
+		"time":     now,
+	}
+
+	metadata := map[string]any{}
+	for namespace, values := range attrs.GetMetadataContext().GetFilterMetadata() {
+		metadata[namespace] = values.AsMap()
+	}
+	jwt := map[string]any{}
+	if payload := attrs.GetMetadataContext().GetFilterMetadata()[JWTMetadataNamespace].GetFields()[JWTPayloadKey].GetStructValue(); payload != nil {
+		jwt = payload.AsMap()
+	}
+	extensions := attrs.GetContextExtensions()
+	if extensions == nil {
+		extensions = map[string]string{}
+	}
+
+	return map[string]any{
+		"request":     request,
+		"source":      peer(attrs.GetSource()),
+		"destination": peer(attrs.GetDestination()),
+		"jwt":         jwt,
+		"metadata":    metadata,
+		"context":     extensions,
+		"now":         now,
+	}
+}
+
+func peer(p *authv3.AttributeContext_Peer) map[string]any {
+	socket := p.GetAddress().GetSocketAddress()
+	return map[string]any{
+		"address":   socket.GetAddress(),
+		"port":      int64(socket.GetPortValue()),
+		"principal": p.GetPrincipal(),
+	}
+}
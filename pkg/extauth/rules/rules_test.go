@@ -0,0 +1,282 @@
+package rules
+
+import (
+	"testing"
+	"time"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const testRules = `
+denyResponse:
+  status: 401
+  body: unauthorized
+rules:
+- name: health
+  when: request.path == "/healthz"
+- name: admin
+  when: request.path.startsWith("/admin")
+  allow: >-
+    has(jwt.roles) && "admin" in jwt.roles &&
+    now.getHours("UTC") >= 9 && now.getHours("UTC") < 18
+  requestHeaders:
+    X-User: jwt.sub
+  removeRequestHeaders:
+  - Authorization
+  responseHeaders:
+    x-rule: '"admin"'
+  denyResponse:
+    status: 403
+    body: '{"error":"admins only during business hours"}'
+    headers:
+      content-type: application/json
+- name: mesh
+  when: context.?app.orValue("") == "payments"
+  allow: source.principal.startsWith("spiffe://cluster.local/ns/payments/")
+- name: api-key
+  when: '"x-api-key" in request.headers'
+  allow: request.headers["x-api-key"] == "secret" && request.method in ["GET", "HEAD"]
+`
+
+type request struct {
+	method     string
+	path       string
+	headers    map[string]string
+	claims     map[string]any
+	principal  string
+	extensions map[string]string
+	hour       int
+}
+
+func checkRequest(t *testing.T, r request) *authv3.CheckRequest {
+	metadata := map[string]*structpb.Struct{}
+	if r.claims != nil {
+		s, err := structpb.NewStruct(map[string]any{JWTPayloadKey: r.claims})
+		require.NoError(t, err)
+		metadata[JWTMetadataNamespace] = s
+	}
+	method := r.method
+	if method == "" {
+		method = "GET"
+	}
+	return &authv3.CheckRequest{
+		Attributes: &authv3.AttributeContext{
+			Source: &authv3.AttributeContext_Peer{Principal: r.principal},
+			Request: &authv3.AttributeContext_Request{
+				Time: timestamppb.New(time.Date(2025, 1, 6, r.hour, 0, 0, 0, time.UTC)),
+				Http: &authv3.AttributeContext_HttpRequest{
+					Method:  method,
+					Path:    r.path,
+					Headers: r.headers,
+				},
+			},
+			ContextExtensions: r.extensions,
+			MetadataContext:   &envoycorev3.Metadata{FilterMetadata: metadata},
+		},
+	}
+}
+
+func headers(options []*envoycorev3.HeaderValueOption) map[string]string {
+	out := map[string]string{}
+	for _, h := range options {
+		out[h.GetHeader().GetKey()] = h.GetHeader().GetValue()
+	}
+	return out
+}
+
+func TestCheck(t *testing.T) {
+	admin := map[string]any{"sub": "alice", "roles": []any{"admin"}}
+
+	tests := []struct {
+		name                string
+		request             request
+		wantCode            codes.Code
+		wantStatus          int32
+		wantBody            string
+		wantHeaders         map[string]string
+		wantRemoved         []string
+		wantResponseHeaders map[string]string
+	}{
+		{
+			name:     "rule without allow expression",
+			request:  request{path: "/healthz"},
+			wantCode: codes.OK,
+		},
+		{
+			name:                "admin during business hours",
+			request:             request{path: "/admin/users", claims: admin, hour: 10},
+			wantCode:            codes.OK,
+			wantHeaders:         map[string]string{"x-user": "alice"},
+			wantRemoved:         []string{"authorization"},
+			wantResponseHeaders: map[string]string{"x-rule": "admin"},
+		},
+		{
+			name:        "admin outside business hours",
+			request:     request{path: "/admin/users", claims: admin, hour: 20},
+			wantCode:    codes.PermissionDenied,
+			wantStatus:  403,
+			wantBody:    `{"error":"admins only during business hours"}`,
+			wantHeaders: map[string]string{"content-type": "application/json"},
+		},
+		{
+			name:       "admin path without jwt",
+			request:    request{path: "/admin/users", hour: 10},
+			wantCode:   codes.PermissionDenied,
+			wantStatus: 403,
+			wantBody:   `{"error":"admins only during business hours"}`,
+			wantHeaders: map[string]string{
+				"content-type": "application/json",
+			},
+		},
+		{
+			name: "source identity",
+			request: request{
+				path:       "/pay",
+				principal:  "spiffe://cluster.local/ns/payments/sa/checkout",
+				extensions: map[string]string{"app": "payments"},
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "other source identity",
+			request: request{
+				path:       "/pay",
+				principal:  "spiffe://cluster.local/ns/shop/sa/frontend",
+				extensions: map[string]string{"app": "payments"},
+			},
+			wantCode:   codes.Unauthenticated,
+			wantStatus: 401,
+			wantBody:   "unauthorized",
+		},
+		{
+			name:     "header",
+			request:  request{path: "/items", headers: map[string]string{"x-api-key": "secret"}},
+			wantCode: codes.OK,
+		},
+		{
+			name:       "header with other method",
+			request:    request{method: "POST", path: "/items", headers: map[string]string{"x-api-key": "secret"}},
+			wantCode:   codes.Unauthenticated,
+			wantStatus: 401,
+			wantBody:   "unauthorized",
+		},
+		{
+			name:       "no rule matched",
+			request:    request{path: "/items"},
+			wantCode:   codes.Unauthenticated,
+			wantStatus: 401,
+			wantBody:   "unauthorized",
+		},
+	}
+
+	engine, err := Parse([]byte(testRules))
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+
+			resp, err := engine.Check(checkRequest(t, tt.request))
+			r.NoError(err)
+			r.Equal(int32(tt.wantCode), resp.GetStatus().GetCode())
+			if tt.wantCode != codes.OK {
+				denied := resp.GetDeniedResponse()
+				r.Equal(tt.wantStatus, int32(denied.GetStatus().GetCode()))
+				r.Equal(tt.wantBody, denied.GetBody())
+				if tt.wantHeaders == nil {
+					r.Empty(denied.GetHeaders())
+				} else {
+					r.Equal(tt.wantHeaders, headers(denied.GetHeaders()))
+				}
+				return
+			}
+
+			ok := resp.GetOkResponse()
+			if tt.wantHeaders == nil {
+				r.Empty(ok.GetHeaders())
+			} else {
+				r.Equal(tt.wantHeaders, headers(ok.GetHeaders()))
+			}
+			r.Equal(tt.wantRemoved, ok.GetHeadersToRemove())
+			if tt.wantResponseHeaders == nil {
+				r.Empty(ok.GetResponseHeadersToAdd())
+			} else {
+				r.Equal(tt.wantResponseHeaders, headers(ok.GetResponseHeadersToAdd()))
+			}
+		})
+	}
+}
+
+func TestCheckDefaultAllow(t *testing.T) {
+	engine, err := Parse([]byte(`defaultDecision: Allow`))
+	require.NoError(t, err)
+
+	resp, err := engine.Check(checkRequest(t, request{path: "/"}))
+	require.NoError(t, err)
+	require.Equal(t, int32(codes.OK), resp.GetStatus().GetCode())
+}
+
+func TestCheckEvaluationError(t *testing.T) {
+	engine, err := Parse([]byte(`
+rules:
+- name: missing-claim
+  allow: jwt.sub == "alice"
+`))
+	require.NoError(t, err)
+
+	resp, err := engine.Check(checkRequest(t, request{path: "/"}))
+	require.ErrorContains(t, err, "rule missing-claim")
+	require.Equal(t, int32(codes.PermissionDenied), resp.GetStatus().GetCode())
+	require.Equal(t, int32(403), int32(resp.GetDeniedResponse().GetStatus().GetCode()))
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   string
+		wantErr string
+	}{
+		{
+			name:    "unknown field",
+			rules:   "rules:\n- name: a\n  allows: 'true'\n",
+			wantErr: "invalid rules configuration",
+		},
+		{
+			name:    "invalid expression",
+			rules:   "rules:\n- name: a\n  when: request.path ==\n",
+			wantErr: "rule a: when",
+		},
+		{
+			name:    "non boolean expression",
+			rules:   "rules:\n- name: a\n  allow: '\"a\"'\n",
+			wantErr: "rule a: allow: expression must return a bool",
+		},
+		{
+			name:    "non string header",
+			rules:   "rules:\n- name: a\n  requestHeaders:\n    x-a: '1'\n",
+			wantErr: "rule a: header x-a: expression must return a string",
+		},
+		{
+			name:    "invalid deny status",
+			rules:   "denyResponse:\n  status: 99\n",
+			wantErr: "invalid deny response status 99",
+		},
+		{
+			name:    "invalid default decision",
+			rules:   "defaultDecision: Maybe\n",
+			wantErr: "invalid default decision",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.rules))
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
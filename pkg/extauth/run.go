@@ -0,0 +1,118 @@
+package extauth
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"github.com/fsnotify/fsnotify"
+	"github.com/kelseyhightower/envconfig"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/extauth/rules"
+	"github.com/kgateway-dev/kgateway/v2/pkg/extauth/server"
+	"github.com/kgateway-dev/kgateway/v2/pkg/logging"
+)
+
+var logger = logging.New("extauth_server")
+
+// The ext auth server is a gRPC ext_authz server deciding requests with CEL rules, for policies
+// richer than RBAC that do not warrant running a policy engine. It is referenced by an ExtAuth
+// GatewayExtension, and reads its rules from a file, usually mounted from a ConfigMap, which is
+// reloaded when it changes.
+
+// Config is read from the EXTAUTH_SERVER_ADDRESS and EXTAUTH_RULES_FILE environment variables.
+type Config struct {
+	ServerAddress string `split_words:"true" default:"0.0.0.0:9000"`
+	RulesFile     string `split_words:"true" default:"/etc/extauth/rules.yaml"`
+}
+
+func RunMain() {
+	var c Config
+	if err := envconfig.Process("extauth", &c); err != nil {
+		log.Fatalf("failed to process env config: %v", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	if err := Run(ctx, c, logger); err != nil {
+		log.Fatalf("failed to run ext auth server: %v", err)
+	}
+}
+
+// Run serves the rules of the configured file until the context is done.
+func Run(ctx context.Context, c Config, logger *slog.Logger) error {
+	authServer := server.NewServer(logger)
+	engine, err := loadRules(c.RulesFile)
+	if err != nil {
+		return err
+	}
+	authServer.SetEngine(engine)
+	logger.Info("rules loaded", "file", c.RulesFile)
+
+	// ConfigMap volumes are updated by swapping a symlink in the directory of the file.
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+	if err := watcher.Add(filepath.Dir(c.RulesFile)); err != nil {
+		return fmt.Errorf("failed to watch rules file: %w", err)
+	}
+	go func() {
+		for {
+			select {
+			case <-watcher.Events:
+				engine, err := loadRules(c.RulesFile)
+				if err != nil {
+					logger.Error("failed to reload rules, keeping the previous rules", "error", err)
+					continue
+				}
+				authServer.SetEngine(engine)
+				logger.Info("rules reloaded", "file", c.RulesFile)
+			case err := <-watcher.Errors:
+				logger.Warn("received error from file watcher", "error", err)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	grpcServer := grpc.NewServer()
+	authv3.RegisterAuthorizationServer(grpcServer, authServer)
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+
+	lis, err := net.Listen("tcp", c.ServerAddress)
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		grpcServer.GracefulStop()
+	}()
+	logger.Info("ext auth server listening", "address", c.ServerAddress)
+	return grpcServer.Serve(lis)
+}
+
+func loadRules(file string) (*rules.Engine, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules file: %w", err)
+	}
+	// an empty file is usually being written, rather than meant to deny all requests
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, fmt.Errorf("rules file %s is empty", file)
+	}
+	return rules.Parse(data)
+}
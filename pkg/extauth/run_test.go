@@ -0,0 +1,83 @@
+package extauth
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const testServerAddress = "127.0.0.1:9236"
+
+func checkPath(path string) *authv3.CheckRequest {
+	return &authv3.CheckRequest{
+		Attributes: &authv3.AttributeContext{
+			Request: &authv3.AttributeContext_Request{
+				Http: &authv3.AttributeContext_HttpRequest{Path: path},
+			},
+		},
+	}
+}
+
+// writeRules replaces the rules file the way ConfigMap volumes are updated.
+func writeRules(t *testing.T, file, rules string) {
+	tmp := file + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, []byte(rules), 0o600))
+	require.NoError(t, os.Rename(tmp, file))
+}
+
+func TestRunReloadsRules(t *testing.T) {
+	r := require.New(t)
+	rulesFile := filepath.Join(t.TempDir(), "rules.yaml")
+	writeRules(t, rulesFile, "rules:\n- when: request.path == '/a'\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error)
+	go func() {
+		stopped <- Run(ctx, Config{ServerAddress: testServerAddress, RulesFile: rulesFile}, slog.New(slog.DiscardHandler))
+	}()
+
+	conn, err := grpc.NewClient(testServerAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	r.NoError(err)
+	defer conn.Close()
+	client := authv3.NewAuthorizationClient(conn)
+
+	assertCode := func(path string, want codes.Code) {
+		r.EventuallyWithT(func(c *assert.CollectT) {
+			resp, err := client.Check(ctx, checkPath(path))
+			require.NoError(c, err)
+			assert.Equal(c, int32(want), resp.GetStatus().GetCode())
+		}, 10*time.Second, 100*time.Millisecond)
+	}
+	assertCode("/a", codes.OK)
+	assertCode("/b", codes.PermissionDenied)
+
+	writeRules(t, rulesFile, "rules:\n- when: request.path == '/b'\n")
+	assertCode("/a", codes.PermissionDenied)
+	assertCode("/b", codes.OK)
+
+	// invalid rules keep the previous rules
+	writeRules(t, rulesFile, "rules:\n- when: request.path ==\n")
+	time.Sleep(200 * time.Millisecond)
+	assertCode("/b", codes.OK)
+
+	cancel()
+	r.NoError(<-stopped)
+}
+
+func TestRunInvalidRules(t *testing.T) {
+	rulesFile := filepath.Join(t.TempDir(), "rules.yaml")
+	writeRules(t, rulesFile, "rules: {}\n")
+
+	err := Run(context.Background(), Config{ServerAddress: testServerAddress, RulesFile: rulesFile}, slog.New(slog.DiscardHandler))
+	require.ErrorContains(t, err, "invalid rules configuration")
+}
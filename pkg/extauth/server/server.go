@@ -0,0 +1,48 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/extauth/rules"
+)
+
+// Server is an ext_authz server deciding requests with CEL rules. The rules can be swapped
+// while the server runs, when their configuration changes.
+type Server struct {
+	authv3.UnimplementedAuthorizationServer
+	engine atomic.Pointer[rules.Engine]
+	logger *slog.Logger
+}
+
+var _ authv3.AuthorizationServer = &Server{}
+
+func NewServer(logger *slog.Logger) *Server {
+	return &Server{
+		logger: logger,
+	}
+}
+
+// SetEngine sets the rules deciding the next requests.
+func (s *Server) SetEngine(engine *rules.Engine) {
+	s.engine.Store(engine)
+}
+
+func (s *Server) Check(_ context.Context, req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+	engine := s.engine.Load()
+	if engine == nil {
+		return nil, grpcstatus.Error(codes.Unavailable, "rules not loaded")
+	}
+	// Evaluation errors deny the request rather than failing the check, which the proxy could
+	// allow when the GatewayExtension fails open.
+	resp, err := engine.Check(req)
+	if err != nil {
+		s.logger.Warn("failed to evaluate rules", "error", err, "path", req.GetAttributes().GetRequest().GetHttp().GetPath())
+	}
+	return resp, nil
+}
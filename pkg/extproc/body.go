@@ -0,0 +1,80 @@
+package extproc
+
+import (
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+)
+
+// Body is a body message of a request or response. What it holds depends on the body mode of the
+// ProcessingMode:
+//   - BUFFERED: the whole body, buffered by the proxy, in a single message.
+//   - BUFFERED_PARTIAL: the body buffered by the proxy up to its buffer limit. More chunks follow
+//     unless EndOfStream is true.
+//   - STREAMED and FULL_DUPLEX_STREAMED: a chunk of the body, as received by the proxy.
+//
+// Replace and Clear apply to the data of this message only, so a streamed body is modified chunk
+// by chunk.
+type Body struct {
+	data        []byte
+	endOfStream bool
+
+	replaced    bool
+	replacement []byte
+}
+
+func newBody(b *extprocv3.HttpBody) *Body {
+	return &Body{
+		data:        b.GetBody(),
+		endOfStream: b.GetEndOfStream(),
+	}
+}
+
+// Bytes returns the data of the message, or its replacement.
+func (b *Body) Bytes() []byte {
+	if b.replaced {
+		return b.replacement
+	}
+	return b.data
+}
+
+// EndOfStream reports whether this is the last body message. It is false on the last message of
+// a body followed by trailers.
+func (b *Body) EndOfStream() bool {
+	return b.endOfStream
+}
+
+// Replace replaces the data of the message.
+func (b *Body) Replace(data []byte) {
+	b.replaced = true
+	b.replacement = data
+}
+
+// Clear removes the data of the message.
+func (b *Body) Clear() {
+	b.Replace(nil)
+}
+
+// mutation returns the body mutation to send back to the proxy. In full duplex mode the proxy
+// does not keep the body, so the data is always sent back.
+func (b *Body) mutation(fullDuplex bool) *extprocv3.BodyMutation {
+	if fullDuplex {
+		return &extprocv3.BodyMutation{
+			Mutation: &extprocv3.BodyMutation_StreamedResponse{
+				StreamedResponse: &extprocv3.StreamedBodyResponse{
+					Body:        b.Bytes(),
+					EndOfStream: b.endOfStream,
+				},
+			},
+		}
+	}
+	if !b.replaced {
+		return nil
+	}
+	if len(b.replacement) == 0 {
+		return &extprocv3.BodyMutation{
+			Mutation: &extprocv3.BodyMutation_ClearBody{ClearBody: true},
+		}
+	}
+	return &extprocv3.BodyMutation{
+		Mutation: &extprocv3.BodyMutation_Body{Body: b.replacement},
+	}
+}
@@ -0,0 +1,493 @@
+// Package extproctest simulates the proxy side of ext_proc streams, to test ext_proc servers
+// without running a proxy.
+package extproctest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+	"strings"
+	"testing"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extprocfilterv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+)
+
+// Options configure the simulated proxy, as the ExtProc GatewayExtension configures the proxy.
+type Options struct {
+	// ProcessingMode selects the messages sent to the processor. The defaults of the API apply to
+	// the unset modes: headers are sent, bodies and trailers are not.
+	ProcessingMode *kgateway.ProcessingMode
+	// MetadataOptions selects the namespaces of the Metadata sent to the processor.
+	MetadataOptions *kgateway.MetadataOptions
+	// Metadata is the dynamic metadata of the stream, such as the metadata of the other filters.
+	Metadata *corev3.Metadata
+}
+
+// Message is an HTTP request or response, as received by the proxy.
+type Message struct {
+	// Headers are keyed by lower case name, and include pseudo headers such as :method, :path
+	// and :status.
+	Headers map[string][]string
+	// Body holds the chunks of the body, as the proxy receives them.
+	Body [][]byte
+	// Trailers are keyed by lower case name.
+	Trailers map[string][]string
+}
+
+// BodyBytes returns the whole body.
+func (m Message) BodyBytes() []byte {
+	return bytes.Join(m.Body, nil)
+}
+
+// Result is the outcome of an HTTP stream processed by the processor.
+type Result struct {
+	// Request is the request forwarded upstream, with the mutations of the processor.
+	Request Message
+	// Response is the response sent to the client, with the mutations of the processor. It is
+	// empty when the processor responded directly.
+	Response Message
+	// ImmediateResponse is the response of the processor, when it responded directly to the client.
+	ImmediateResponse *extprocv3.ImmediateResponse
+}
+
+// Envoy simulates the ext_proc filter of the proxy, connected to a processor.
+type Envoy struct {
+	client extprocv3.ExternalProcessorClient
+	opts   Options
+}
+
+// NewEnvoy serves the processor over an in-memory connection, stopped when the test ends, and
+// returns a proxy connected to it.
+func NewEnvoy(t testing.TB, processor extprocv3.ExternalProcessorServer, opts Options) *Envoy {
+	t.Helper()
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	extprocv3.RegisterExternalProcessorServer(grpcServer, processor)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///extproc",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to connect to the processor: %v", err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		grpcServer.Stop()
+	})
+
+	return &Envoy{
+		client: extprocv3.NewExternalProcessorClient(conn),
+		opts:   opts,
+	}
+}
+
+// Process sends a request and its upstream response through the processor, over a single stream
+// as the proxy does, and returns the request and response with the mutations of the processor.
+// Bodies are sent according to the body modes: BUFFERED and BUFFERED_PARTIAL send the whole body
+// in one message, STREAMED sends each chunk and waits for its response, and FULL_DUPLEX_STREAMED
+// sends all the chunks before reading the responses.
+func (e *Envoy) Process(ctx context.Context, request, response Message) (*Result, error) {
+	mode := e.opts.ProcessingMode
+	if mode == nil {
+		mode = &kgateway.ProcessingMode{}
+	}
+	// as validated by the proxy
+	if mode.RequestBodyMode == "FULL_DUPLEX_STREAMED" && mode.RequestTrailerMode != "SEND" ||
+		mode.ResponseBodyMode == "FULL_DUPLEX_STREAMED" && mode.ResponseTrailerMode != "SEND" {
+		return nil, fmt.Errorf("the FULL_DUPLEX_STREAMED body mode requires the SEND trailer mode")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := e.client.Process(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s := &streamState{
+		stream:   stream,
+		metadata: forwardedMetadata(e.opts.Metadata, e.opts.MetadataOptions),
+		protocol: &extprocv3.ProtocolConfiguration{
+			RequestBodyMode:  bodyMode(mode.RequestBodyMode),
+			ResponseBodyMode: bodyMode(mode.ResponseBodyMode),
+		},
+	}
+
+	result := &Result{Request: clone(request)}
+	result.ImmediateResponse, err = s.process(&result.Request, direction{
+		request:     true,
+		headerMode:  mode.RequestHeaderMode,
+		bodyMode:    mode.RequestBodyMode,
+		trailerMode: mode.RequestTrailerMode,
+	})
+	if err != nil || result.ImmediateResponse != nil {
+		return result, err
+	}
+
+	result.Response = clone(response)
+	result.ImmediateResponse, err = s.process(&result.Response, direction{
+		headerMode:  mode.ResponseHeaderMode,
+		bodyMode:    mode.ResponseBodyMode,
+		trailerMode: mode.ResponseTrailerMode,
+	})
+	if result.ImmediateResponse != nil {
+		result.Response = Message{}
+	}
+	if err != nil || result.ImmediateResponse != nil {
+		return result, err
+	}
+	return result, stream.CloseSend()
+}
+
+type direction struct {
+	request     bool
+	headerMode  string
+	bodyMode    string
+	trailerMode string
+}
+
+type streamState struct {
+	stream   extprocv3.ExternalProcessor_ProcessClient
+	metadata *corev3.Metadata
+	protocol *extprocv3.ProtocolConfiguration
+}
+
+func (s *streamState) process(msg *Message, d direction) (*extprocv3.ImmediateResponse, error) {
+	hasBody := len(msg.BodyBytes()) > 0
+	hasTrailers := len(msg.Trailers) > 0
+	sendTrailers := hasTrailers && d.trailerMode == "SEND"
+
+	if d.headerMode != "SKIP" {
+		req := &extprocv3.HttpHeaders{
+			Headers:     headerMap(msg.Headers),
+			EndOfStream: !hasBody && !hasTrailers,
+		}
+		if d.request {
+			err := s.send(&extprocv3.ProcessingRequest{Request: &extprocv3.ProcessingRequest_RequestHeaders{RequestHeaders: req}})
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			err := s.send(&extprocv3.ProcessingRequest{Request: &extprocv3.ProcessingRequest_ResponseHeaders{ResponseHeaders: req}})
+			if err != nil {
+				return nil, err
+			}
+		}
+		resp, immediate, err := s.recv()
+		if err != nil || immediate != nil {
+			return immediate, err
+		}
+		common, err := headersResponse(resp, d.request)
+		if err != nil {
+			return nil, err
+		}
+		msg.Headers = applyHeaderMutation(msg.Headers, common.GetHeaderMutation())
+		// the processor may replace the body, which is then not sent to it
+		if common.GetStatus() == extprocv3.CommonResponse_CONTINUE_AND_REPLACE {
+			msg.Body = nil
+			if body := common.GetBodyMutation().GetBody(); len(body) > 0 {
+				msg.Body = [][]byte{body}
+			}
+			return nil, nil
+		}
+	}
+
+	if hasBody && d.bodyMode != "" && d.bodyMode != "NONE" {
+		var immediate *extprocv3.ImmediateResponse
+		var err error
+		switch d.bodyMode {
+		case "STREAMED":
+			immediate, err = s.processStreamedBody(msg, d.request, hasTrailers)
+		case "FULL_DUPLEX_STREAMED":
+			immediate, err = s.processFullDuplexBody(msg, d.request, sendTrailers)
+		default:
+			immediate, err = s.processBufferedBody(msg, d.request, hasTrailers)
+		}
+		if err != nil || immediate != nil {
+			return immediate, err
+		}
+		if d.bodyMode == "FULL_DUPLEX_STREAMED" {
+			// the trailers were processed along with the body
+			return nil, nil
+		}
+	}
+
+	if sendTrailers {
+		if err := s.sendTrailers(msg, d.request); err != nil {
+			return nil, err
+		}
+		resp, immediate, err := s.recv()
+		if err != nil || immediate != nil {
+			return immediate, err
+		}
+		return nil, s.applyTrailersResponse(msg, resp, d.request)
+	}
+	return nil, nil
+}
+
+func (s *streamState) processBufferedBody(msg *Message, request, hasTrailers bool) (*extprocv3.ImmediateResponse, error) {
+	body := msg.BodyBytes()
+	if err := s.sendBody(body, !hasTrailers, request); err != nil {
+		return nil, err
+	}
+	resp, immediate, err := s.recv()
+	if err != nil || immediate != nil {
+		return immediate, err
+	}
+	body, err = applyBodyResponse(body, resp, request)
+	if err != nil {
+		return nil, err
+	}
+	msg.Body = [][]byte{body}
+	return nil, nil
+}
+
+func (s *streamState) processStreamedBody(msg *Message, request, hasTrailers bool) (*extprocv3.ImmediateResponse, error) {
+	chunks := make([][]byte, 0, len(msg.Body))
+	for i, chunk := range msg.Body {
+		if err := s.sendBody(chunk, i == len(msg.Body)-1 && !hasTrailers, request); err != nil {
+			return nil, err
+		}
+		resp, immediate, err := s.recv()
+		if err != nil || immediate != nil {
+			return immediate, err
+		}
+		chunk, err = applyBodyResponse(chunk, resp, request)
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, chunk)
+	}
+	msg.Body = chunks
+	return nil, nil
+}
+
+// processFullDuplexBody sends the body and the trailers, and reads the body streamed back by the
+// processor, which replaces the original body.
+func (s *streamState) processFullDuplexBody(msg *Message, request, sendTrailers bool) (*extprocv3.ImmediateResponse, error) {
+	for i, chunk := range msg.Body {
+		if err := s.sendBody(chunk, i == len(msg.Body)-1 && !sendTrailers, request); err != nil {
+			return nil, err
+		}
+	}
+	if sendTrailers {
+		if err := s.sendTrailers(msg, request); err != nil {
+			return nil, err
+		}
+	}
+
+	var chunks [][]byte
+	for {
+		resp, immediate, err := s.recv()
+		if err != nil || immediate != nil {
+			return immediate, err
+		}
+		if _, ok := trailersResponse(resp, request); ok {
+			msg.Body = chunks
+			return nil, s.applyTrailersResponse(msg, resp, request)
+		}
+		common, err := bodyResponse(resp, request)
+		if err != nil {
+			return nil, err
+		}
+		streamed := common.GetBodyMutation().GetStreamedResponse()
+		if streamed == nil {
+			return nil, fmt.Errorf("expected a streamed body response in full duplex mode, got %v", resp)
+		}
+		chunks = append(chunks, streamed.GetBody())
+		if streamed.GetEndOfStream() {
+			msg.Body = chunks
+			return nil, nil
+		}
+	}
+}
+
+func (s *streamState) send(req *extprocv3.ProcessingRequest) error {
+	req.MetadataContext = s.metadata
+	req.ProtocolConfig = s.protocol
+	// the protocol configuration is only sent with the first message
+	s.protocol = nil
+	return s.stream.Send(req)
+}
+
+func (s *streamState) sendBody(body []byte, endOfStream, request bool) error {
+	b := &extprocv3.HttpBody{Body: body, EndOfStream: endOfStream}
+	if request {
+		return s.send(&extprocv3.ProcessingRequest{Request: &extprocv3.ProcessingRequest_RequestBody{RequestBody: b}})
+	}
+	return s.send(&extprocv3.ProcessingRequest{Request: &extprocv3.ProcessingRequest_ResponseBody{ResponseBody: b}})
+}
+
+func (s *streamState) sendTrailers(msg *Message, request bool) error {
+	t := &extprocv3.HttpTrailers{Trailers: headerMap(msg.Trailers)}
+	if request {
+		return s.send(&extprocv3.ProcessingRequest{Request: &extprocv3.ProcessingRequest_RequestTrailers{RequestTrailers: t}})
+	}
+	return s.send(&extprocv3.ProcessingRequest{Request: &extprocv3.ProcessingRequest_ResponseTrailers{ResponseTrailers: t}})
+}
+
+func (s *streamState) recv() (*extprocv3.ProcessingResponse, *extprocv3.ImmediateResponse, error) {
+	resp, err := s.stream.Recv()
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, resp.GetImmediateResponse(), nil
+}
+
+func (s *streamState) applyTrailersResponse(msg *Message, resp *extprocv3.ProcessingResponse, request bool) error {
+	trailers, ok := trailersResponse(resp, request)
+	if !ok {
+		return fmt.Errorf("expected a trailers response, got %v", resp)
+	}
+	msg.Trailers = applyHeaderMutation(msg.Trailers, trailers.GetHeaderMutation())
+	return nil
+}
+
+func headersResponse(resp *extprocv3.ProcessingResponse, request bool) (*extprocv3.CommonResponse, error) {
+	var headers *extprocv3.HeadersResponse
+	if request {
+		headers = resp.GetRequestHeaders()
+	} else {
+		headers = resp.GetResponseHeaders()
+	}
+	if headers == nil {
+		return nil, fmt.Errorf("expected a headers response, got %v", resp)
+	}
+	return headers.GetResponse(), nil
+}
+
+func bodyResponse(resp *extprocv3.ProcessingResponse, request bool) (*extprocv3.CommonResponse, error) {
+	var body *extprocv3.BodyResponse
+	if request {
+		body = resp.GetRequestBody()
+	} else {
+		body = resp.GetResponseBody()
+	}
+	if body == nil {
+		return nil, fmt.Errorf("expected a body response, got %v", resp)
+	}
+	return body.GetResponse(), nil
+}
+
+func trailersResponse(resp *extprocv3.ProcessingResponse, request bool) (*extprocv3.TrailersResponse, bool) {
+	if request {
+		return resp.GetRequestTrailers(), resp.GetRequestTrailers() != nil
+	}
+	return resp.GetResponseTrailers(), resp.GetResponseTrailers() != nil
+}
+
+func applyBodyResponse(body []byte, resp *extprocv3.ProcessingResponse, request bool) ([]byte, error) {
+	common, err := bodyResponse(resp, request)
+	if err != nil {
+		return nil, err
+	}
+	switch m := common.GetBodyMutation().GetMutation().(type) {
+	case *extprocv3.BodyMutation_Body:
+		return m.Body, nil
+	case *extprocv3.BodyMutation_ClearBody:
+		return nil, nil
+	default:
+		return body, nil
+	}
+}
+
+func applyHeaderMutation(headers map[string][]string, m *extprocv3.HeaderMutation) map[string][]string {
+	if m == nil {
+		return headers
+	}
+	out := maps.Clone(headers)
+	if out == nil {
+		out = map[string][]string{}
+	}
+	for _, name := range m.GetRemoveHeaders() {
+		delete(out, strings.ToLower(name))
+	}
+	for _, o := range m.GetSetHeaders() {
+		name := strings.ToLower(o.GetHeader().GetKey())
+		value := o.GetHeader().GetValue()
+		if len(o.GetHeader().GetRawValue()) > 0 {
+			value = string(o.GetHeader().GetRawValue())
+		}
+		action := o.GetAppendAction()
+		if o.GetAppend() != nil && !o.GetAppend().GetValue() {
+			action = corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD
+		}
+		_, exists := out[name]
+		switch action {
+		case corev3.HeaderValueOption_APPEND_IF_EXISTS_OR_ADD:
+			out[name] = append(slices.Clone(out[name]), value)
+		case corev3.HeaderValueOption_ADD_IF_ABSENT:
+			if !exists {
+				out[name] = []string{value}
+			}
+		case corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD:
+			out[name] = []string{value}
+		case corev3.HeaderValueOption_OVERWRITE_IF_EXISTS:
+			if exists {
+				out[name] = []string{value}
+			}
+		}
+	}
+	return out
+}
+
+func headerMap(headers map[string][]string) *corev3.HeaderMap {
+	m := &corev3.HeaderMap{}
+	for _, name := range slices.Sorted(maps.Keys(headers)) {
+		for _, value := range headers[name] {
+			m.Headers = append(m.Headers, &corev3.HeaderValue{Key: strings.ToLower(name), RawValue: []byte(value)})
+		}
+	}
+	return m
+}
+
+// forwardedMetadata returns the namespaces of the metadata forwarded to the processor.
+func forwardedMetadata(metadata *corev3.Metadata, opts *kgateway.MetadataOptions) *corev3.Metadata {
+	if opts == nil || opts.Forwarding == nil || metadata == nil {
+		return nil
+	}
+	forwarded := &corev3.Metadata{
+		FilterMetadata:      map[string]*structpb.Struct{},
+		TypedFilterMetadata: map[string]*anypb.Any{},
+	}
+	for _, ns := range opts.Forwarding.Untyped {
+		if v, ok := metadata.GetFilterMetadata()[ns]; ok {
+			forwarded.FilterMetadata[ns] = v
+		}
+	}
+	for _, ns := range opts.Forwarding.Typed {
+		if v, ok := metadata.GetTypedFilterMetadata()[ns]; ok {
+			forwarded.TypedFilterMetadata[ns] = v
+		}
+	}
+	return forwarded
+}
+
+func bodyMode(mode string) extprocfilterv3.ProcessingMode_BodySendMode {
+	return extprocfilterv3.ProcessingMode_BodySendMode(extprocfilterv3.ProcessingMode_BodySendMode_value[mode])
+}
+
+func clone(m Message) Message {
+	return Message{
+		Headers:  maps.Clone(m.Headers),
+		Body:     slices.Clone(m.Body),
+		Trailers: maps.Clone(m.Trailers),
+	}
+}
@@ -0,0 +1,141 @@
+package extproc
+
+import (
+	"slices"
+	"strings"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+)
+
+// Headers are the headers or trailers of a request or response. Names are lower case, as sent by
+// the proxy, including pseudo headers such as :method, :path and :status.
+//
+// Set, Add and Remove record mutations, which are sent back to the proxy once the handler
+// returns. Reads reflect the mutations made so far, and are safe on nil Headers.
+type Headers struct {
+	entries     []headerEntry
+	endOfStream bool
+	mutation    *extprocv3.HeaderMutation
+}
+
+type headerEntry struct {
+	name  string
+	value string
+}
+
+func newHeaders(m *corev3.HeaderMap, endOfStream bool) *Headers {
+	h := &Headers{endOfStream: endOfStream}
+	for _, hv := range m.GetHeaders() {
+		value := hv.GetValue()
+		// recent proxies only set the raw value
+		if len(hv.GetRawValue()) > 0 {
+			value = string(hv.GetRawValue())
+		}
+		h.entries = append(h.entries, headerEntry{name: strings.ToLower(hv.GetKey()), value: value})
+	}
+	return h
+}
+
+// Get returns the first value of a header, or an empty string if it is not set.
+func (h *Headers) Get(name string) string {
+	if h == nil {
+		return ""
+	}
+	name = strings.ToLower(name)
+	for _, e := range h.entries {
+		if e.name == name {
+			return e.value
+		}
+	}
+	return ""
+}
+
+// Values returns all the values of a header.
+func (h *Headers) Values(name string) []string {
+	if h == nil {
+		return nil
+	}
+	name = strings.ToLower(name)
+	var values []string
+	for _, e := range h.entries {
+		if e.name == name {
+			values = append(values, e.value)
+		}
+	}
+	return values
+}
+
+// Has reports whether a header is set.
+func (h *Headers) Has(name string) bool {
+	return h.Values(name) != nil
+}
+
+// Range calls f for each header value, in order, until f returns false.
+func (h *Headers) Range(f func(name, value string) bool) {
+	if h == nil {
+		return
+	}
+	for _, e := range h.entries {
+		if !f(e.name, e.value) {
+			return
+		}
+	}
+}
+
+// EndOfStream reports whether the request or response ends with these headers, without a body or
+// trailers.
+func (h *Headers) EndOfStream() bool {
+	return h != nil && h.endOfStream
+}
+
+// Set replaces the values of a header.
+func (h *Headers) Set(name, value string) {
+	name = strings.ToLower(name)
+	h.remove(name)
+	h.entries = append(h.entries, headerEntry{name: name, value: value})
+	h.setHeader(name, value, corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD)
+}
+
+// Add appends a value to a header.
+func (h *Headers) Add(name, value string) {
+	name = strings.ToLower(name)
+	h.entries = append(h.entries, headerEntry{name: name, value: value})
+	h.setHeader(name, value, corev3.HeaderValueOption_APPEND_IF_EXISTS_OR_ADD)
+}
+
+// Remove removes all the values of a header.
+func (h *Headers) Remove(name string) {
+	name = strings.ToLower(name)
+	h.remove(name)
+	m := h.headerMutation()
+	// a header set earlier by the handler is removed as well
+	m.SetHeaders = slices.DeleteFunc(m.SetHeaders, func(o *corev3.HeaderValueOption) bool {
+		return o.GetHeader().GetKey() == name
+	})
+	m.RemoveHeaders = append(m.RemoveHeaders, name)
+}
+
+func (h *Headers) remove(name string) {
+	h.entries = slices.DeleteFunc(h.entries, func(e headerEntry) bool { return e.name == name })
+}
+
+func (h *Headers) setHeader(name, value string, action corev3.HeaderValueOption_HeaderAppendAction) {
+	m := h.headerMutation()
+	if action == corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD {
+		m.SetHeaders = slices.DeleteFunc(m.SetHeaders, func(o *corev3.HeaderValueOption) bool {
+			return o.GetHeader().GetKey() == name
+		})
+	}
+	m.SetHeaders = append(m.SetHeaders, &corev3.HeaderValueOption{
+		Header:       &corev3.HeaderValue{Key: name, RawValue: []byte(value)},
+		AppendAction: action,
+	})
+}
+
+func (h *Headers) headerMutation() *extprocv3.HeaderMutation {
+	if h.mutation == nil {
+		h.mutation = &extprocv3.HeaderMutation{}
+	}
+	return h.mutation
+}
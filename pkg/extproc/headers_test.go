@@ -0,0 +1,61 @@
+package extproc
+
+import (
+	"testing"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestHeaders(t *testing.T) {
+	r := require.New(t)
+	h := newHeaders(&corev3.HeaderMap{Headers: []*corev3.HeaderValue{
+		{Key: ":path", RawValue: []byte("/")},
+		{Key: "X-Old", Value: "1"},
+		{Key: "accept", RawValue: []byte("text/html")},
+		{Key: "accept", RawValue: []byte("application/json")},
+	}}, false)
+
+	r.Equal("/", h.Get(":path"))
+	r.Equal("1", h.Get("x-old"))
+	r.Equal([]string{"text/html", "application/json"}, h.Values("Accept"))
+	r.False(h.Has("x-new"))
+
+	h.Add("x-new", "a")
+	h.Set("x-new", "b")
+	h.Add("x-new", "c")
+	h.Set("accept", "*/*")
+	h.Add("x-tmp", "1")
+	h.Remove("x-tmp")
+	h.Remove("x-old")
+
+	r.Equal([]string{"b", "c"}, h.Values("x-new"))
+	r.Equal([]string{"*/*"}, h.Values("accept"))
+	r.False(h.Has("x-old"))
+	r.False(h.Has("x-tmp"))
+
+	expected := &extprocv3.HeaderMutation{
+		SetHeaders: []*corev3.HeaderValueOption{
+			{
+				Header:       &corev3.HeaderValue{Key: "x-new", RawValue: []byte("b")},
+				AppendAction: corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+			},
+			{
+				Header:       &corev3.HeaderValue{Key: "x-new", RawValue: []byte("c")},
+				AppendAction: corev3.HeaderValueOption_APPEND_IF_EXISTS_OR_ADD,
+			},
+			{
+				Header:       &corev3.HeaderValue{Key: "accept", RawValue: []byte("*/*")},
+				AppendAction: corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+			},
+		},
+		RemoveHeaders: []string{"x-tmp", "x-old"},
+	}
+	r.True(proto.Equal(expected, h.mutation), "unexpected mutation: %v", h.mutation)
+
+	var missing *Headers
+	r.Empty(missing.Get(":path"))
+	r.False(missing.EndOfStream())
+}
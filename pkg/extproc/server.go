@@ -0,0 +1,193 @@
+// Package extproc is a framework for writing Envoy external processing (ext_proc) servers, the
+// services referenced by ExtProc GatewayExtensions.
+//
+// The Server handles the gRPC stream opened by the proxy for each HTTP request, and calls a
+// Handler with the headers, body and trailers of the request and response, as configured by the
+// ProcessingMode of the GatewayExtension or TrafficPolicy. Handlers mutate the messages in place,
+// or reply directly to the client with Stream.Respond, and the Server sends the mutations back to
+// the proxy. The extproctest package simulates the proxy side of the stream, to test handlers.
+package extproc
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// RequestHandler processes the messages of a request. Each method is only called for the
+// messages the ProcessingMode sends to the processor.
+type RequestHandler interface {
+	OnRequestHeaders(s *Stream, headers *Headers) error
+	OnRequestBody(s *Stream, body *Body) error
+	OnRequestTrailers(s *Stream, trailers *Headers) error
+}
+
+// ResponseHandler processes the messages of a response. Each method is only called for the
+// messages the ProcessingMode sends to the processor.
+type ResponseHandler interface {
+	OnResponseHeaders(s *Stream, headers *Headers) error
+	OnResponseBody(s *Stream, body *Body) error
+	OnResponseTrailers(s *Stream, trailers *Headers) error
+}
+
+// Handler processes an HTTP stream. A Handler is created for each stream, so it can keep state
+// between the messages of the request and the response.
+//
+// Returning an error aborts the stream, and the proxy applies the FailOpen setting of the
+// GatewayExtension. Errors created with the grpc status package keep their code.
+type Handler interface {
+	RequestHandler
+	ResponseHandler
+}
+
+// NopHandler continues every message unmodified. Embed it in handlers to only implement some of
+// the methods.
+type NopHandler struct{}
+
+var _ Handler = NopHandler{}
+
+func (NopHandler) OnRequestHeaders(*Stream, *Headers) error   { return nil }
+func (NopHandler) OnRequestBody(*Stream, *Body) error         { return nil }
+func (NopHandler) OnRequestTrailers(*Stream, *Headers) error  { return nil }
+func (NopHandler) OnResponseHeaders(*Stream, *Headers) error  { return nil }
+func (NopHandler) OnResponseBody(*Stream, *Body) error        { return nil }
+func (NopHandler) OnResponseTrailers(*Stream, *Headers) error { return nil }
+
+// Server is an ext_proc gRPC server calling a Handler for each HTTP stream.
+type Server struct {
+	extprocv3.UnimplementedExternalProcessorServer
+	newHandler func() Handler
+	logger     *slog.Logger
+}
+
+var _ extprocv3.ExternalProcessorServer = &Server{}
+
+// NewServer returns a server creating the handler of each HTTP stream with newHandler.
+func NewServer(newHandler func() Handler, logger *slog.Logger) *Server {
+	return &Server{
+		newHandler: newHandler,
+		logger:     logger,
+	}
+}
+
+func (s *Server) Process(srv extprocv3.ExternalProcessor_ProcessServer) error {
+	stream := &Stream{ctx: srv.Context()}
+	handler := s.newHandler()
+	for {
+		req, err := srv.Recv()
+		if errors.Is(err, io.EOF) || grpcstatus.Code(err) == codes.Canceled {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		resp, err := stream.process(handler, req)
+		if err != nil {
+			s.logger.Error("failed to process message", "error", err)
+			if _, ok := grpcstatus.FromError(err); ok {
+				return err
+			}
+			return grpcstatus.Error(codes.Internal, err.Error())
+		}
+		// the proxy does not wait for, nor accept, responses in observability mode
+		if req.GetObservabilityMode() {
+			continue
+		}
+		if err := srv.Send(resp); err != nil {
+			return err
+		}
+		if resp.GetImmediateResponse() != nil {
+			return nil
+		}
+	}
+}
+
+// process calls the handler for a message of the proxy, and returns the response to the message.
+func (s *Stream) process(handler Handler, req *extprocv3.ProcessingRequest) (*extprocv3.ProcessingResponse, error) {
+	if req.GetMetadataContext() != nil {
+		s.metadata = req.GetMetadataContext()
+	}
+	// the protocol configuration is only sent with the first message
+	if config := req.GetProtocolConfig(); config != nil {
+		s.requestBodyMode = config.GetRequestBodyMode()
+		s.responseBodyMode = config.GetResponseBodyMode()
+	}
+	s.immediateResponse = nil
+	s.clearRouteCache = false
+
+	resp := &extprocv3.ProcessingResponse{}
+	var err error
+	switch r := req.GetRequest().(type) {
+	case *extprocv3.ProcessingRequest_RequestHeaders:
+		headers := newHeaders(r.RequestHeaders.GetHeaders(), r.RequestHeaders.GetEndOfStream())
+		s.requestHeaders = headers
+		err = handler.OnRequestHeaders(s, headers)
+		resp.Response = &extprocv3.ProcessingResponse_RequestHeaders{
+			RequestHeaders: s.headersResponse(headers),
+		}
+	case *extprocv3.ProcessingRequest_RequestBody:
+		body := newBody(r.RequestBody)
+		err = handler.OnRequestBody(s, body)
+		resp.Response = &extprocv3.ProcessingResponse_RequestBody{
+			RequestBody: s.bodyResponse(body, s.fullDuplex(true)),
+		}
+	case *extprocv3.ProcessingRequest_RequestTrailers:
+		trailers := newHeaders(r.RequestTrailers.GetTrailers(), true)
+		err = handler.OnRequestTrailers(s, trailers)
+		resp.Response = &extprocv3.ProcessingResponse_RequestTrailers{
+			RequestTrailers: &extprocv3.TrailersResponse{HeaderMutation: trailers.mutation},
+		}
+	case *extprocv3.ProcessingRequest_ResponseHeaders:
+		headers := newHeaders(r.ResponseHeaders.GetHeaders(), r.ResponseHeaders.GetEndOfStream())
+		err = handler.OnResponseHeaders(s, headers)
+		resp.Response = &extprocv3.ProcessingResponse_ResponseHeaders{
+			ResponseHeaders: s.headersResponse(headers),
+		}
+	case *extprocv3.ProcessingRequest_ResponseBody:
+		body := newBody(r.ResponseBody)
+		err = handler.OnResponseBody(s, body)
+		resp.Response = &extprocv3.ProcessingResponse_ResponseBody{
+			ResponseBody: s.bodyResponse(body, s.fullDuplex(false)),
+		}
+	case *extprocv3.ProcessingRequest_ResponseTrailers:
+		trailers := newHeaders(r.ResponseTrailers.GetTrailers(), true)
+		err = handler.OnResponseTrailers(s, trailers)
+		resp.Response = &extprocv3.ProcessingResponse_ResponseTrailers{
+			ResponseTrailers: &extprocv3.TrailersResponse{HeaderMutation: trailers.mutation},
+		}
+	default:
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "unexpected processing request %T", r)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if s.immediateResponse != nil {
+		resp.Response = &extprocv3.ProcessingResponse_ImmediateResponse{
+			ImmediateResponse: s.immediateResponse,
+		}
+	}
+	return resp, nil
+}
+
+func (s *Stream) headersResponse(headers *Headers) *extprocv3.HeadersResponse {
+	return &extprocv3.HeadersResponse{
+		Response: &extprocv3.CommonResponse{
+			HeaderMutation:  headers.mutation,
+			ClearRouteCache: s.clearRouteCache,
+		},
+	}
+}
+
+func (s *Stream) bodyResponse(body *Body, fullDuplex bool) *extprocv3.BodyResponse {
+	return &extprocv3.BodyResponse{
+		Response: &extprocv3.CommonResponse{
+			BodyMutation: body.mutation(fullDuplex),
+		},
+	}
+}
@@ -0,0 +1,224 @@
+package extproc_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/extproc"
+	"github.com/kgateway-dev/kgateway/v2/pkg/extproc/extproctest"
+)
+
+// testHandler tags requests with the tenant of the JWT, denies requests without one, and upper
+// cases the bodies.
+type testHandler struct {
+	extproc.NopHandler
+	tenant string
+}
+
+func (h *testHandler) OnRequestHeaders(s *extproc.Stream, headers *extproc.Headers) error {
+	if headers.Get(":path") == "/error" {
+		return errors.New("boom")
+	}
+	h.tenant = s.Metadata("envoy.filters.http.jwt_authn").GetFields()["tenant"].GetStringValue()
+	if h.tenant == "" {
+		s.Respond(403, map[string]string{"content-type": "text/plain"}, []byte("no tenant"))
+		return nil
+	}
+	headers.Set("x-tenant", h.tenant)
+	headers.Remove("authorization")
+	s.ClearRouteCache()
+	return nil
+}
+
+func (h *testHandler) OnRequestBody(_ *extproc.Stream, body *extproc.Body) error {
+	body.Replace(bytes.ToUpper(body.Bytes()))
+	return nil
+}
+
+func (h *testHandler) OnRequestTrailers(_ *extproc.Stream, trailers *extproc.Headers) error {
+	trailers.Add("x-checksum", "abc")
+	return nil
+}
+
+func (h *testHandler) OnResponseHeaders(s *extproc.Stream, headers *extproc.Headers) error {
+	headers.Add("x-served-for", h.tenant)
+	headers.Set("x-path", s.RequestHeaders().Get(":path"))
+	return nil
+}
+
+func (h *testHandler) OnResponseBody(_ *extproc.Stream, body *extproc.Body) error {
+	if body.EndOfStream() && len(body.Bytes()) == 0 {
+		return nil
+	}
+	body.Replace(bytes.ToUpper(body.Bytes()))
+	return nil
+}
+
+func (h *testHandler) OnResponseTrailers(_ *extproc.Stream, trailers *extproc.Headers) error {
+	trailers.Remove("grpc-message")
+	return nil
+}
+
+func newTestEnvoy(t *testing.T, mode *kgateway.ProcessingMode) *extproctest.Envoy {
+	server := extproc.NewServer(func() extproc.Handler { return &testHandler{} }, slog.Default())
+	tenant, err := structpb.NewStruct(map[string]any{"tenant": "acme"})
+	require.NoError(t, err)
+	internal, err := structpb.NewStruct(map[string]any{"tenant": "internal"})
+	require.NoError(t, err)
+	return extproctest.NewEnvoy(t, server, extproctest.Options{
+		ProcessingMode: mode,
+		MetadataOptions: &kgateway.MetadataOptions{
+			Forwarding: &kgateway.MetadataNamespaces{Untyped: []string{"envoy.filters.http.jwt_authn"}},
+		},
+		Metadata: &corev3.Metadata{FilterMetadata: map[string]*structpb.Struct{
+			"envoy.filters.http.jwt_authn": tenant,
+			// not forwarded
+			"internal": internal,
+		}},
+	})
+}
+
+var (
+	testRequest = extproctest.Message{
+		Headers: map[string][]string{
+			":method":       {"POST"},
+			":path":         {"/orders"},
+			"authorization": {"Bearer token"},
+		},
+		Body:     [][]byte{[]byte("hello "), []byte("world")},
+		Trailers: map[string][]string{"x-trailer": {"1"}},
+	}
+	testResponse = extproctest.Message{
+		Headers:  map[string][]string{":status": {"200"}, "x-served-for": {"upstream"}},
+		Body:     [][]byte{[]byte("good"), []byte("bye")},
+		Trailers: map[string][]string{"grpc-status": {"0"}, "grpc-message": {"ok"}},
+	}
+)
+
+func TestProcessHeaders(t *testing.T) {
+	r := require.New(t)
+	envoy := newTestEnvoy(t, nil)
+
+	result, err := envoy.Process(context.Background(), testRequest, testResponse)
+	r.NoError(err)
+	r.Nil(result.ImmediateResponse)
+	r.Equal(map[string][]string{
+		":method":  {"POST"},
+		":path":    {"/orders"},
+		"x-tenant": {"acme"},
+	}, result.Request.Headers)
+	r.Equal(map[string][]string{
+		":status":      {"200"},
+		"x-served-for": {"upstream", "acme"},
+		"x-path":       {"/orders"},
+	}, result.Response.Headers)
+	// bodies and trailers are not sent by default
+	r.Equal("hello world", string(result.Request.BodyBytes()))
+	r.Equal(testRequest.Trailers, result.Request.Trailers)
+	r.Equal("goodbye", string(result.Response.BodyBytes()))
+}
+
+func TestProcessBodies(t *testing.T) {
+	tests := []struct {
+		name             string
+		mode             *kgateway.ProcessingMode
+		requestBody      [][]byte
+		requestTrailers  map[string][]string
+		responseBody     [][]byte
+		responseTrailers map[string][]string
+	}{
+		{
+			name: "buffered",
+			mode: &kgateway.ProcessingMode{
+				RequestBodyMode:  "BUFFERED",
+				ResponseBodyMode: "BUFFERED_PARTIAL",
+			},
+			requestBody:      [][]byte{[]byte("HELLO WORLD")},
+			requestTrailers:  testRequest.Trailers,
+			responseBody:     [][]byte{[]byte("GOODBYE")},
+			responseTrailers: testResponse.Trailers,
+		},
+		{
+			name: "streamed with trailers",
+			mode: &kgateway.ProcessingMode{
+				RequestBodyMode:     "STREAMED",
+				ResponseBodyMode:    "STREAMED",
+				RequestTrailerMode:  "SEND",
+				ResponseTrailerMode: "SEND",
+			},
+			requestBody:      [][]byte{[]byte("HELLO "), []byte("WORLD")},
+			requestTrailers:  map[string][]string{"x-trailer": {"1"}, "x-checksum": {"abc"}},
+			responseBody:     [][]byte{[]byte("GOOD"), []byte("BYE")},
+			responseTrailers: map[string][]string{"grpc-status": {"0"}},
+		},
+		{
+			name: "full duplex",
+			mode: &kgateway.ProcessingMode{
+				RequestHeaderMode:   "SKIP",
+				RequestBodyMode:     "FULL_DUPLEX_STREAMED",
+				RequestTrailerMode:  "SEND",
+				ResponseBodyMode:    "FULL_DUPLEX_STREAMED",
+				ResponseTrailerMode: "SEND",
+			},
+			requestBody:      [][]byte{[]byte("HELLO "), []byte("WORLD")},
+			requestTrailers:  map[string][]string{"x-trailer": {"1"}, "x-checksum": {"abc"}},
+			responseBody:     [][]byte{[]byte("GOOD"), []byte("BYE")},
+			responseTrailers: map[string][]string{"grpc-status": {"0"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			envoy := newTestEnvoy(t, tt.mode)
+
+			result, err := envoy.Process(context.Background(), testRequest, testResponse)
+			r.NoError(err)
+			r.Nil(result.ImmediateResponse)
+			r.Equal(tt.requestBody, result.Request.Body)
+			r.Equal(tt.requestTrailers, result.Request.Trailers)
+			r.Equal(tt.responseBody, result.Response.Body)
+			r.Equal(tt.responseTrailers, result.Response.Trailers)
+		})
+	}
+}
+
+func TestProcessFullDuplexRequiresTrailers(t *testing.T) {
+	envoy := newTestEnvoy(t, &kgateway.ProcessingMode{RequestBodyMode: "FULL_DUPLEX_STREAMED"})
+	_, err := envoy.Process(context.Background(), testRequest, testResponse)
+	require.ErrorContains(t, err, "requires the SEND trailer mode")
+}
+
+func TestProcessImmediateResponse(t *testing.T) {
+	r := require.New(t)
+	server := extproc.NewServer(func() extproc.Handler { return &testHandler{} }, slog.Default())
+	envoy := extproctest.NewEnvoy(t, server, extproctest.Options{
+		ProcessingMode: &kgateway.ProcessingMode{RequestBodyMode: "BUFFERED"},
+	})
+
+	result, err := envoy.Process(context.Background(), testRequest, testResponse)
+	r.NoError(err)
+	r.NotNil(result.ImmediateResponse)
+	r.EqualValues(403, result.ImmediateResponse.GetStatus().GetCode())
+	r.Equal("no tenant", string(result.ImmediateResponse.GetBody()))
+	r.Equal("content-type", result.ImmediateResponse.GetHeaders().GetSetHeaders()[0].GetHeader().GetKey())
+	r.Empty(result.Response.Headers)
+}
+
+func TestProcessHandlerError(t *testing.T) {
+	envoy := newTestEnvoy(t, nil)
+	request := testRequest
+	request.Headers = map[string][]string{":path": {"/error"}}
+
+	_, err := envoy.Process(context.Background(), request, testResponse)
+	require.Equal(t, codes.Internal, grpcstatus.Code(err))
+}
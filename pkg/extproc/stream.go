@@ -0,0 +1,93 @@
+package extproc
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extprocfilterv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Stream is the state of the processing of an HTTP stream, shared by the messages of the stream.
+type Stream struct {
+	ctx              context.Context
+	metadata         *corev3.Metadata
+	requestHeaders   *Headers
+	requestBodyMode  extprocfilterv3.ProcessingMode_BodySendMode
+	responseBodyMode extprocfilterv3.ProcessingMode_BodySendMode
+
+	// reset after each message
+	immediateResponse *extprocv3.ImmediateResponse
+	clearRouteCache   bool
+}
+
+// Context returns the context of the gRPC stream, done when the proxy closes it.
+func (s *Stream) Context() context.Context {
+	return s.ctx
+}
+
+// RequestHeaders returns the request headers, with the mutations of the handler, or nil if they
+// were not sent to the processor.
+func (s *Stream) RequestHeaders() *Headers {
+	return s.requestHeaders
+}
+
+// Metadata returns the untyped dynamic metadata of a namespace, or nil if the proxy did not send
+// it. Only the namespaces listed in the untyped forwarding namespaces of the MetadataOptions of the
+// GatewayExtension are sent.
+func (s *Stream) Metadata(namespace string) *structpb.Struct {
+	return s.metadata.GetFilterMetadata()[namespace]
+}
+
+// TypedMetadata returns the typed dynamic metadata of a namespace, or nil if the proxy did not
+// send it. Only the namespaces listed in the typed forwarding namespaces of the MetadataOptions of
+// the GatewayExtension are sent.
+func (s *Stream) TypedMetadata(namespace string) *anypb.Any {
+	return s.metadata.GetTypedFilterMetadata()[namespace]
+}
+
+// MetadataNamespaces returns the sorted namespaces of the untyped dynamic metadata sent by the
+// proxy.
+func (s *Stream) MetadataNamespaces() []string {
+	return slices.Sorted(maps.Keys(s.metadata.GetFilterMetadata()))
+}
+
+// Respond stops the processing of the stream, and has the proxy reply to the client directly with
+// a response instead of forwarding the request or the upstream response. It takes effect once the
+// handler returns, and any mutation made by the handler is dropped.
+func (s *Stream) Respond(status int, headers map[string]string, body []byte) {
+	resp := &extprocv3.ImmediateResponse{
+		Status: &typev3.HttpStatus{Code: typev3.StatusCode(status)}, //nolint:gosec // G115: HTTP status codes fit in int32
+		Body:   body,
+	}
+	if len(headers) > 0 {
+		resp.Headers = &extprocv3.HeaderMutation{}
+		for _, name := range slices.Sorted(maps.Keys(headers)) {
+			resp.Headers.SetHeaders = append(resp.Headers.SetHeaders, &corev3.HeaderValueOption{
+				Header:       &corev3.HeaderValue{Key: name, RawValue: []byte(headers[name])},
+				AppendAction: corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+			})
+		}
+	}
+	s.immediateResponse = resp
+}
+
+// ClearRouteCache has the proxy select the route of the request again after the request headers
+// are mutated, for example to route on a header set by the handler. It is only honored for the
+// request headers, and when the RouteCacheAction of the GatewayExtension is FromResponse.
+func (s *Stream) ClearRouteCache() {
+	s.clearRouteCache = true
+}
+
+func (s *Stream) fullDuplex(request bool) bool {
+	mode := s.responseBodyMode
+	if request {
+		mode = s.requestBodyMode
+	}
+	return mode == extprocfilterv3.ProcessingMode_FULL_DUPLEX_STREAMED
+}
@@ -0,0 +1,234 @@
+package apiconsumer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"slices"
+	"sync"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/authverifier"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/basicauth"
+)
+
+// The API consumer verifier resolves the principal a request is authenticated as to an API
+// consumer, and emits the consumer and its plan as dynamic metadata and request headers. The
+// consumers of a route are sent by the proxy in the ext_authz context extensions of the route,
+// and the principals authenticated by the other filters in the ext_authz metadata context.
+
+const (
+	// ConfigContextExtension is the ext_authz context extension carrying the JSON encoded Config of a route.
+	ConfigContextExtension = "kgateway.api_consumer.config"
+
+	// ConsumerMetadataKey is the metadata name of the consumer.
+	ConsumerMetadataKey = "consumer"
+	// PlanMetadataKey is the metadata name of the plan of the consumer.
+	PlanMetadataKey = "plan"
+
+	// maxCacheSize bounds the number of cached route configurations.
+	maxCacheSize = 1000
+
+	noConsumerMessage       = "No API consumer found."
+	routeNotAllowedMessage  = "API consumer not allowed on this route."
+	jwtPayloadKey           = "payload"
+	apiKeyClientMetadataKey = "client"
+)
+
+// Config is the API consumer verifier configuration of a route.
+type Config struct {
+	// Consumers are the consumers selected on the route, in resolution order.
+	Consumers []Consumer `json:"consumers"`
+	// RequireConsumer denies the requests not resolving to a consumer.
+	RequireConsumer bool `json:"requireConsumer,omitempty"`
+	// APIKeyNamespace is the dynamic metadata namespace of the API key verifier.
+	APIKeyNamespace string `json:"apiKeyNamespace,omitempty"`
+	// JWTNamespace is the dynamic metadata namespace of the JWT filter.
+	JWTNamespace string `json:"jwtNamespace,omitempty"`
+	// BasicAuth resolves the basic auth username, only set when basic auth applies to the route.
+	BasicAuth bool `json:"basicAuth,omitempty"`
+}
+
+// Consumer is an API consumer.
+type Consumer struct {
+	Name      string         `json:"name"`
+	Plan      string         `json:"plan,omitempty"`
+	APIKeys   []string       `json:"apiKeys,omitempty"`
+	JWT       []JWTPrincipal `json:"jwt,omitempty"`
+	BasicAuth []string       `json:"basicAuth,omitempty"`
+	MTLS      []string       `json:"mtls,omitempty"`
+	// Headers are set on the requests of the consumer.
+	Headers []Header `json:"headers,omitempty"`
+	// Forbidden denies the requests of the consumer, as the route is not allowed by its plan.
+	Forbidden bool `json:"forbidden,omitempty"`
+}
+
+// JWTPrincipal matches the claims of a validated JWT. Empty fields match any value.
+type JWTPrincipal struct {
+	Issuer   string `json:"issuer,omitempty"`
+	Subject  string `json:"subject,omitempty"`
+	ClientID string `json:"clientId,omitempty"`
+}
+
+// Header is a request header.
+type Header struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// principal is what a request is authenticated as.
+type principal struct {
+	apiKeyClient string
+	jwtClaims    map[string]*structpb.Value
+	username     string
+	certificate  string
+}
+
+type Server struct {
+	// configs caches the parsed route configurations, as they can hold many consumers.
+	mu      sync.Mutex
+	configs map[[sha256.Size]byte]*Config
+}
+
+var _ authverifier.Verifier = &Server{}
+
+func NewServer() *Server {
+	return &Server{
+		configs: make(map[[sha256.Size]byte]*Config),
+	}
+}
+
+func (s *Server) ContextExtension() string {
+	return ConfigContextExtension
+}
+
+func (s *Server) Check(_ context.Context, raw string, req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+	cfg, err := s.config(raw)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "invalid %s context extension: %v", ConfigContextExtension, err)
+	}
+
+	p := requestPrincipal(cfg, req.GetAttributes())
+	for i := range cfg.Consumers {
+		consumer := &cfg.Consumers[i]
+		if !consumer.matches(p) {
+			continue
+		}
+		if consumer.Forbidden {
+			return authverifier.Forbidden(routeNotAllowedMessage), nil
+		}
+		return allowed(consumer)
+	}
+	if cfg.RequireConsumer {
+		return authverifier.Forbidden(noConsumerMessage), nil
+	}
+	return authverifier.Allowed(), nil
+}
+
+func (s *Server) config(raw string) (*Config, error) {
+	cacheKey := sha256.Sum256([]byte(raw))
+	s.mu.Lock()
+	cfg, cached := s.configs[cacheKey]
+	s.mu.Unlock()
+	if cached {
+		return cfg, nil
+	}
+
+	cfg = &Config{}
+	if err := json.Unmarshal([]byte(raw), cfg); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if len(s.configs) >= maxCacheSize {
+		clear(s.configs)
+	}
+	s.configs[cacheKey] = cfg
+	s.mu.Unlock()
+	return cfg, nil
+}
+
+// requestPrincipal returns the principal a request is authenticated as by the filters
+// running before the verifier.
+func requestPrincipal(cfg *Config, attrs *authv3.AttributeContext) principal {
+	var p principal
+	metadata := attrs.GetMetadataContext().GetFilterMetadata()
+	if cfg.APIKeyNamespace != "" {
+		p.apiKeyClient = metadata[cfg.APIKeyNamespace].GetFields()[apiKeyClientMetadataKey].GetStringValue()
+	}
+	if cfg.JWTNamespace != "" {
+		p.jwtClaims = metadata[cfg.JWTNamespace].GetFields()[jwtPayloadKey].GetStructValue().GetFields()
+	}
+	if cfg.BasicAuth {
+		p.username = basicAuthUsername(attrs.GetRequest().GetHttp().GetHeaders()["authorization"])
+	}
+	p.certificate = attrs.GetSource().GetPrincipal()
+	return p
+}
+
+// basicAuthUsername returns the username of a basic Authorization header, parsed as the basic
+// auth verifier does so that authenticated users map to their consumer.
+func basicAuthUsername(authorization string) string {
+	username, _, _ := basicauth.ParseBasicAuth(authorization)
+	return username
+}
+
+func (c *Consumer) matches(p principal) bool {
+	if p.apiKeyClient != "" && slices.Contains(c.APIKeys, p.apiKeyClient) {
+		return true
+	}
+	if p.username != "" && slices.Contains(c.BasicAuth, p.username) {
+		return true
+	}
+	if p.certificate != "" && slices.Contains(c.MTLS, p.certificate) {
+		return true
+	}
+	if p.jwtClaims != nil {
+		for _, jwt := range c.JWT {
+			if jwt.matches(p.jwtClaims) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (j *JWTPrincipal) matches(claims map[string]*structpb.Value) bool {
+	clientID := claims["client_id"].GetStringValue()
+	if clientID == "" {
+		clientID = claims["azp"].GetStringValue()
+	}
+	return (j.Issuer == "" || j.Issuer == claims["iss"].GetStringValue()) &&
+		(j.Subject == "" || j.Subject == claims["sub"].GetStringValue()) &&
+		(j.ClientID == "" || j.ClientID == clientID)
+}
+
+// allowed builds the OK response of a request of a consumer.
+func allowed(consumer *Consumer) (*authv3.CheckResponse, error) {
+	resp := authverifier.Allowed()
+	okResp := resp.GetOkResponse()
+	for _, h := range consumer.Headers {
+		okResp.Headers = append(okResp.Headers, &envoycorev3.HeaderValueOption{
+			Header:       &envoycorev3.HeaderValue{Key: h.Name, Value: h.Value},
+			AppendAction: envoycorev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+		})
+	}
+
+	metadata := map[string]any{
+		ConsumerMetadataKey: consumer.Name,
+	}
+	if consumer.Plan != "" {
+		metadata[PlanMetadataKey] = consumer.Plan
+	}
+	dynamicMetadata, err := structpb.NewStruct(metadata)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "invalid metadata: %v", err)
+	}
+	resp.DynamicMetadata = dynamicMetadata
+	return resp, nil
+}
@@ -0,0 +1,205 @@
+package apiconsumer
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	apiKeyNamespace = "ext_auth/api_key_verifier"
+	jwtNamespace    = "envoy.filters.http.jwt_authn"
+)
+
+func testConfig(t *testing.T, requireConsumer, basicAuth bool) string {
+	cfg := Config{
+		Consumers: []Consumer{
+			{
+				Name:    "acme",
+				Plan:    "gold",
+				APIKeys: []string{"acme-key"},
+				JWT:     []JWTPrincipal{{Issuer: "https://issuer.example.com", ClientID: "acme-app"}},
+				Headers: []Header{{Name: "x-plan", Value: "gold"}},
+			},
+			{
+				Name:      "globex",
+				BasicAuth: []string{"globex"},
+				MTLS:      []string{"spiffe://example.com/globex"},
+			},
+			{
+				Name:      "initech",
+				APIKeys:   []string{"initech-key"},
+				Forbidden: true,
+			},
+		},
+		RequireConsumer: requireConsumer,
+		APIKeyNamespace: apiKeyNamespace,
+		JWTNamespace:    jwtNamespace,
+		BasicAuth:       basicAuth,
+	}
+	raw, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	return string(raw)
+}
+
+type request struct {
+	apiKeyClient  string
+	jwtPayload    map[string]any
+	authorization string
+	principal     string
+}
+
+func checkRequest(t *testing.T, r request) *authv3.CheckRequest {
+	metadata := map[string]*structpb.Struct{}
+	if r.apiKeyClient != "" {
+		s, err := structpb.NewStruct(map[string]any{"client": r.apiKeyClient})
+		require.NoError(t, err)
+		metadata[apiKeyNamespace] = s
+	}
+	if r.jwtPayload != nil {
+		s, err := structpb.NewStruct(map[string]any{"payload": r.jwtPayload})
+		require.NoError(t, err)
+		metadata[jwtNamespace] = s
+	}
+	headers := map[string]string{}
+	if r.authorization != "" {
+		headers["authorization"] = r.authorization
+	}
+	return &authv3.CheckRequest{
+		Attributes: &authv3.AttributeContext{
+			Source: &authv3.AttributeContext_Peer{Principal: r.principal},
+			Request: &authv3.AttributeContext_Request{
+				Http: &authv3.AttributeContext_HttpRequest{Headers: headers},
+			},
+			MetadataContext: &envoycorev3.Metadata{FilterMetadata: metadata},
+		},
+	}
+}
+
+func basic(username, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name            string
+		request         request
+		requireConsumer bool
+		basicAuth       bool
+		wantCode        codes.Code
+		wantMetadata    map[string]any
+		wantHeaders     map[string]string
+	}{
+		{
+			name:         "api key client",
+			request:      request{apiKeyClient: "acme-key"},
+			wantCode:     codes.OK,
+			wantMetadata: map[string]any{"consumer": "acme", "plan": "gold"},
+			wantHeaders:  map[string]string{"x-plan": "gold"},
+		},
+		{
+			name: "jwt client id",
+			request: request{jwtPayload: map[string]any{
+				"iss": "https://issuer.example.com",
+				"azp": "acme-app",
+			}},
+			wantCode:     codes.OK,
+			wantMetadata: map[string]any{"consumer": "acme", "plan": "gold"},
+			wantHeaders:  map[string]string{"x-plan": "gold"},
+		},
+		{
+			name: "jwt other issuer",
+			request: request{jwtPayload: map[string]any{
+				"iss":       "https://other.example.com",
+				"client_id": "acme-app",
+			}},
+			requireConsumer: true,
+			wantCode:        codes.PermissionDenied,
+		},
+		{
+			name:         "basic auth username",
+			request:      request{authorization: basic("globex", "password")},
+			basicAuth:    true,
+			wantCode:     codes.OK,
+			wantMetadata: map[string]any{"consumer": "globex"},
+		},
+		{
+			name:         "basic auth username with a lowercase scheme",
+			request:      request{authorization: "basic " + base64.StdEncoding.EncodeToString([]byte("globex:password"))},
+			basicAuth:    true,
+			wantCode:     codes.OK,
+			wantMetadata: map[string]any{"consumer": "globex"},
+		},
+		{
+			name:            "basic auth username without basic auth on the route",
+			request:         request{authorization: basic("globex", "password")},
+			requireConsumer: true,
+			wantCode:        codes.PermissionDenied,
+		},
+		{
+			name:         "client certificate",
+			request:      request{principal: "spiffe://example.com/globex"},
+			wantCode:     codes.OK,
+			wantMetadata: map[string]any{"consumer": "globex"},
+		},
+		{
+			name:     "route not allowed",
+			request:  request{apiKeyClient: "initech-key"},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:            "no consumer required",
+			request:         request{apiKeyClient: "unknown"},
+			requireConsumer: true,
+			wantCode:        codes.PermissionDenied,
+		},
+		{
+			name:     "no consumer",
+			request:  request{apiKeyClient: "unknown"},
+			wantCode: codes.OK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			s := NewServer()
+
+			resp, err := s.Check(context.Background(), testConfig(t, tt.requireConsumer, tt.basicAuth), checkRequest(t, tt.request))
+			r.NoError(err)
+			r.Equal(int32(tt.wantCode), resp.GetStatus().GetCode())
+			if tt.wantCode != codes.OK {
+				r.Equal(403, int(resp.GetDeniedResponse().GetStatus().GetCode()))
+				return
+			}
+
+			if tt.wantMetadata == nil {
+				r.Nil(resp.GetDynamicMetadata())
+			} else {
+				r.Equal(tt.wantMetadata, resp.GetDynamicMetadata().AsMap())
+			}
+			headers := map[string]string{}
+			for _, h := range resp.GetOkResponse().GetHeaders() {
+				headers[h.GetHeader().GetKey()] = h.GetHeader().GetValue()
+			}
+			if tt.wantHeaders == nil {
+				r.Empty(headers)
+			} else {
+				r.Equal(tt.wantHeaders, headers)
+			}
+		})
+	}
+}
+
+func TestCheckInvalidConfig(t *testing.T) {
+	_, err := NewServer().Check(context.Background(), "{", checkRequest(t, request{}))
+	require.Equal(t, codes.InvalidArgument, grpcstatus.Code(err))
+}
@@ -0,0 +1,253 @@
+package apikeyauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/authverifier"
+)
+
+// The API key verifier verifies API keys against their SHA-256 digests, and emits the metadata of
+// the matched key as dynamic metadata and request headers. The keys of a route are sent by the
+// proxy in the ext_authz context extensions of the route.
+
+const (
+	// ConfigContextExtension is the ext_authz context extension carrying the JSON encoded Config of a route.
+	ConfigContextExtension = "kgateway.api_key_auth.config"
+
+	// ClientMetadataKey is the metadata name of the client identifier.
+	ClientMetadataKey = "client"
+
+	// maxCacheSize bounds the number of cached route configurations.
+	maxCacheSize = 1000
+
+	// denial message, matching the one of the Envoy api_key_auth filter
+	authenticationFailedMessage = "Client authentication failed."
+
+	// bearerPrefix is removed from the header values, as the Envoy api_key_auth filter does
+	bearerPrefix = "Bearer "
+)
+
+// Config is the API key verifier configuration of a route.
+type Config struct {
+	// Keys are the API keys accepted on the route.
+	Keys []Key `json:"keys"`
+	// Sources are where the API key is read from, in order.
+	Sources []Source `json:"sources"`
+	// HideCredentials removes the API key from the request.
+	HideCredentials bool `json:"hideCredentials,omitempty"`
+	// Headers maps request headers to the name of the metadata their value is set to.
+	// Headers of metadata the matched key does not have are removed.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// Key is an API key.
+type Key struct {
+	// Digest is the hex encoded SHA-256 digest of the key.
+	Digest string `json:"digest"`
+	// Metadata is the metadata of the key, including its client identifier.
+	Metadata map[string]string `json:"metadata"`
+}
+
+// Source is where an API key is read from. Within a source, a header takes precedence over
+// a query parameter, which takes precedence over a cookie.
+type Source struct {
+	Header string `json:"header,omitempty"`
+	Query  string `json:"query,omitempty"`
+	Cookie string `json:"cookie,omitempty"`
+}
+
+// Digest returns the hex encoded SHA-256 digest of an API key.
+func Digest(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+type Server struct {
+	// configs caches the parsed route configurations, as they can hold many keys.
+	mu      sync.Mutex
+	configs map[[sha256.Size]byte]*config
+}
+
+var _ authverifier.Verifier = &Server{}
+
+// config is a parsed Config, with its keys indexed by digest.
+type config struct {
+	Config
+	keys map[string]*Key
+}
+
+func NewServer() *Server {
+	return &Server{
+		configs: make(map[[sha256.Size]byte]*config),
+	}
+}
+
+func (s *Server) ContextExtension() string {
+	return ConfigContextExtension
+}
+
+func (s *Server) Check(_ context.Context, raw string, req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+	cfg, err := s.config(raw)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, "invalid %s context extension: %v", ConfigContextExtension, err)
+	}
+
+	httpReq := req.GetAttributes().GetRequest().GetHttp()
+	apiKey, found, ok := extractKey(cfg.Sources, httpReq)
+	if !ok {
+		return authverifier.Denied(authenticationFailedMessage), nil
+	}
+	key, ok := cfg.keys[Digest(apiKey)]
+	if !ok {
+		return authverifier.Denied(authenticationFailedMessage), nil
+	}
+	return allowed(cfg, key, found, httpReq)
+}
+
+func (s *Server) config(raw string) (*config, error) {
+	cacheKey := sha256.Sum256([]byte(raw))
+	s.mu.Lock()
+	cfg, cached := s.configs[cacheKey]
+	s.mu.Unlock()
+	if cached {
+		return cfg, nil
+	}
+
+	cfg = &config{}
+	if err := json.Unmarshal([]byte(raw), &cfg.Config); err != nil {
+		return nil, err
+	}
+	cfg.keys = make(map[string]*Key, len(cfg.Keys))
+	for i := range cfg.Keys {
+		cfg.keys[strings.ToLower(cfg.Keys[i].Digest)] = &cfg.Keys[i]
+	}
+
+	s.mu.Lock()
+	if len(s.configs) >= maxCacheSize {
+		clear(s.configs)
+	}
+	s.configs[cacheKey] = cfg
+	s.mu.Unlock()
+	return cfg, nil
+}
+
+// trimBearer removes the case-insensitive Bearer prefix from a header value.
+func trimBearer(v string) string {
+	if len(v) >= len(bearerPrefix) && strings.EqualFold(v[:len(bearerPrefix)], bearerPrefix) {
+		return v[len(bearerPrefix):]
+	}
+	return v
+}
+
+// extractKey returns the API key of a request, along with the source it was found in,
+// holding only the matched header, query parameter or cookie.
+func extractKey(sources []Source, req *authv3.AttributeContext_HttpRequest) (string, Source, bool) {
+	var query url.Values
+	if _, rawQuery, ok := strings.Cut(req.GetPath(), "?"); ok {
+		query, _ = url.ParseQuery(rawQuery)
+	}
+	var cookies []*http.Cookie
+	if line := req.GetHeaders()["cookie"]; line != "" {
+		cookies, _ = http.ParseCookie(line)
+	}
+
+	for _, source := range sources {
+		if source.Header != "" {
+			if v := trimBearer(req.GetHeaders()[strings.ToLower(source.Header)]); v != "" {
+				return v, Source{Header: source.Header}, true
+			}
+		}
+		if source.Query != "" {
+			if v := query.Get(source.Query); v != "" {
+				return v, Source{Query: source.Query}, true
+			}
+		}
+		if source.Cookie != "" {
+			for _, c := range cookies {
+				if c.Name == source.Cookie && c.Value != "" {
+					return c.Value, Source{Cookie: source.Cookie}, true
+				}
+			}
+		}
+	}
+	return "", Source{}, false
+}
+
+// allowed builds the OK response of a request authenticated by key, found in the given source.
+func allowed(cfg *config, key *Key, found Source, req *authv3.AttributeContext_HttpRequest) (*authv3.CheckResponse, error) {
+	resp := authverifier.Allowed()
+	okResp := resp.GetOkResponse()
+
+	headers := make([]string, 0, len(cfg.Headers))
+	for header := range cfg.Headers {
+		headers = append(headers, header)
+	}
+	slices.Sort(headers)
+	for _, header := range headers {
+		value, set := key.Metadata[cfg.Headers[header]]
+		if !set {
+			okResp.HeadersToRemove = append(okResp.HeadersToRemove, header)
+			continue
+		}
+		okResp.Headers = append(okResp.Headers, &envoycorev3.HeaderValueOption{
+			Header:       &envoycorev3.HeaderValue{Key: header, Value: value},
+			AppendAction: envoycorev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+		})
+	}
+
+	if cfg.HideCredentials {
+		switch {
+		case found.Header != "":
+			okResp.HeadersToRemove = append(okResp.HeadersToRemove, found.Header)
+		case found.Query != "":
+			okResp.QueryParametersToRemove = []string{found.Query}
+		case found.Cookie != "":
+			if cookies := removeCookie(req.GetHeaders()["cookie"], found.Cookie); cookies != "" {
+				okResp.Headers = append(okResp.Headers, &envoycorev3.HeaderValueOption{
+					Header:       &envoycorev3.HeaderValue{Key: "cookie", Value: cookies},
+					AppendAction: envoycorev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+				})
+			} else {
+				okResp.HeadersToRemove = append(okResp.HeadersToRemove, "cookie")
+			}
+		}
+	}
+
+	metadata := make(map[string]any, len(key.Metadata))
+	for k, v := range key.Metadata {
+		metadata[k] = v
+	}
+	dynamicMetadata, err := structpb.NewStruct(metadata)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.Internal, "invalid metadata: %v", err)
+	}
+	resp.DynamicMetadata = dynamicMetadata
+	return resp, nil
+}
+
+// removeCookie returns a Cookie header without the named cookie.
+func removeCookie(line, name string) string {
+	var kept []string
+	for part := range strings.SplitSeq(line, ";") {
+		part = strings.TrimSpace(part)
+		if n, _, _ := strings.Cut(part, "="); part == "" || n == name {
+			continue
+		}
+		kept = append(kept, part)
+	}
+	return strings.Join(kept, "; ")
+}
@@ -0,0 +1,176 @@
+package apikeyauth
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+func testConfig(t *testing.T, hideCredentials bool) string {
+	cfg := Config{
+		Keys: []Key{
+			{
+				Digest:   Digest("k-123"),
+				Metadata: map[string]string{ClientMetadataKey: "client1", "plan": "gold"},
+			},
+			{
+				Digest:   Digest("k-456"),
+				Metadata: map[string]string{ClientMetadataKey: "client2"},
+			},
+		},
+		Sources: []Source{
+			{Header: "X-API-Key"},
+			{Query: "api_key", Cookie: "token"},
+		},
+		HideCredentials: hideCredentials,
+		Headers: map[string]string{
+			"x-client-id": ClientMetadataKey,
+			"x-plan":      "plan",
+		},
+	}
+	raw, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	return string(raw)
+}
+
+func checkRequest(path string, headers map[string]string) *authv3.CheckRequest {
+	return &authv3.CheckRequest{
+		Attributes: &authv3.AttributeContext{
+			Request: &authv3.AttributeContext_Request{
+				Http: &authv3.AttributeContext_HttpRequest{Path: path, Headers: headers},
+			},
+		},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		headers  map[string]string
+		wantCode codes.Code
+		// wantHeaders are the headers set on the request, wantRemoved the ones removed
+		wantHeaders  map[string]string
+		wantRemoved  []string
+		wantMetadata map[string]any
+	}{
+		{
+			name:         "header key with metadata",
+			path:         "/",
+			headers:      map[string]string{"x-api-key": "k-123", "x-plan": "spoofed"},
+			wantCode:     codes.OK,
+			wantHeaders:  map[string]string{"x-client-id": "client1", "x-plan": "gold"},
+			wantMetadata: map[string]any{"client": "client1", "plan": "gold"},
+		},
+		{
+			name:         "header key with bearer prefix",
+			path:         "/",
+			headers:      map[string]string{"x-api-key": "bearer k-123"},
+			wantCode:     codes.OK,
+			wantHeaders:  map[string]string{"x-client-id": "client1", "x-plan": "gold"},
+			wantMetadata: map[string]any{"client": "client1", "plan": "gold"},
+		},
+		{
+			name:     "header key with bearer prefix only",
+			path:     "/",
+			headers:  map[string]string{"x-api-key": "Bearer "},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:         "query key without plan removes the plan header",
+			path:         "/?api_key=k-456",
+			headers:      map[string]string{"x-plan": "spoofed"},
+			wantCode:     codes.OK,
+			wantHeaders:  map[string]string{"x-client-id": "client2"},
+			wantRemoved:  []string{"x-plan"},
+			wantMetadata: map[string]any{"client": "client2"},
+		},
+		{
+			name:         "cookie key",
+			path:         "/",
+			headers:      map[string]string{"cookie": "a=b; token=k-123"},
+			wantCode:     codes.OK,
+			wantHeaders:  map[string]string{"x-client-id": "client1", "x-plan": "gold"},
+			wantMetadata: map[string]any{"client": "client1", "plan": "gold"},
+		},
+		{
+			name:     "invalid key",
+			path:     "/",
+			headers:  map[string]string{"x-api-key": "k-789"},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "missing key",
+			path:     "/?other=k-123",
+			wantCode: codes.Unauthenticated,
+		},
+	}
+	s := NewServer()
+	raw := testConfig(t, false)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+
+			resp, err := s.Check(context.Background(), raw, checkRequest(tt.path, tt.headers))
+			r.NoError(err)
+			r.Equal(int32(tt.wantCode), resp.GetStatus().GetCode())
+			if tt.wantCode != codes.OK {
+				r.Equal(authenticationFailedMessage, resp.GetDeniedResponse().GetBody())
+				return
+			}
+
+			headers := map[string]string{}
+			for _, h := range resp.GetOkResponse().GetHeaders() {
+				headers[h.GetHeader().GetKey()] = h.GetHeader().GetValue()
+			}
+			r.Equal(tt.wantHeaders, headers)
+			r.Equal(tt.wantRemoved, resp.GetOkResponse().GetHeadersToRemove())
+			r.Equal(tt.wantMetadata, resp.GetDynamicMetadata().AsMap())
+		})
+	}
+}
+
+func TestCheckHideCredentials(t *testing.T) {
+	s := NewServer()
+	raw := testConfig(t, true)
+
+	t.Run("header", func(t *testing.T) {
+		resp, err := s.Check(context.Background(), raw, checkRequest("/", map[string]string{"x-api-key": "k-456"}))
+		require.NoError(t, err)
+		require.Equal(t, []string{"x-plan", "X-API-Key"}, resp.GetOkResponse().GetHeadersToRemove())
+	})
+
+	t.Run("query", func(t *testing.T) {
+		resp, err := s.Check(context.Background(), raw, checkRequest("/?api_key=k-123", nil))
+		require.NoError(t, err)
+		require.Equal(t, []string{"api_key"}, resp.GetOkResponse().GetQueryParametersToRemove())
+	})
+
+	t.Run("cookie", func(t *testing.T) {
+		resp, err := s.Check(context.Background(), raw, checkRequest("/", map[string]string{"cookie": "a=b; token=k-123; c=d"}))
+		require.NoError(t, err)
+		var cookie string
+		for _, h := range resp.GetOkResponse().GetHeaders() {
+			if h.GetHeader().GetKey() == "cookie" {
+				cookie = h.GetHeader().GetValue()
+			}
+		}
+		require.Equal(t, "a=b; c=d", cookie)
+	})
+
+	t.Run("only cookie", func(t *testing.T) {
+		resp, err := s.Check(context.Background(), raw, checkRequest("/", map[string]string{"cookie": "token=k-123"}))
+		require.NoError(t, err)
+		require.Contains(t, resp.GetOkResponse().GetHeadersToRemove(), "cookie")
+	})
+}
+
+func TestCheckInvalidConfig(t *testing.T) {
+	_, err := NewServer().Check(context.Background(), "{", checkRequest("/", nil))
+	require.Equal(t, codes.InvalidArgument, grpcstatus.Code(err))
+}
@@ -0,0 +1,90 @@
+package authverifier
+
+import (
+	"context"
+
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	envoytypev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// The auth verifier is an ext_authz server, run by cmd/authverifier, verifying credentials in ways
+// the Envoy filters do not support. It is stateless: the configuration of a route is sent by the
+// proxy in the ext_authz context extensions of the route, and dispatched to the verifier owning
+// the context extension.
+
+// Verifier verifies the requests of the routes carrying its context extension.
+type Verifier interface {
+	// ContextExtension is the ext_authz context extension carrying the verifier configuration of a route.
+	ContextExtension() string
+	// Check verifies a request with the configuration of its route.
+	Check(ctx context.Context, config string, req *authv3.CheckRequest) (*authv3.CheckResponse, error)
+}
+
+type Server struct {
+	authv3.UnimplementedAuthorizationServer
+
+	verifiers []Verifier
+}
+
+var _ authv3.AuthorizationServer = &Server{}
+
+func NewServer(verifiers ...Verifier) *Server {
+	return &Server{
+		verifiers: verifiers,
+	}
+}
+
+// Register registers the verifiers on a gRPC server.
+func Register(s *grpc.Server, verifiers ...Verifier) {
+	authv3.RegisterAuthorizationServer(s, NewServer(verifiers...))
+}
+
+func (s *Server) Check(ctx context.Context, req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+	extensions := req.GetAttributes().GetContextExtensions()
+	for _, v := range s.verifiers {
+		if config, ok := extensions[v.ContextExtension()]; ok {
+			return v.Check(ctx, config, req)
+		}
+	}
+	return nil, grpcstatus.Error(codes.InvalidArgument, "missing verifier context extension")
+}
+
+// Allowed returns an OK response.
+func Allowed() *authv3.CheckResponse {
+	return &authv3.CheckResponse{
+		Status: &status.Status{Code: int32(codes.OK)},
+		HttpResponse: &authv3.CheckResponse_OkResponse{
+			OkResponse: &authv3.OkHttpResponse{},
+		},
+	}
+}
+
+// Denied returns a 401 response with the given body.
+func Denied(message string) *authv3.CheckResponse {
+	return &authv3.CheckResponse{
+		Status: &status.Status{Code: int32(codes.Unauthenticated)},
+		HttpResponse: &authv3.CheckResponse_DeniedResponse{
+			DeniedResponse: &authv3.DeniedHttpResponse{
+				Status: &envoytypev3.HttpStatus{Code: envoytypev3.StatusCode_Unauthorized},
+				Body:   message,
+			},
+		},
+	}
+}
+
+// Forbidden returns a 403 response with the given body.
+func Forbidden(message string) *authv3.CheckResponse {
+	return &authv3.CheckResponse{
+		Status: &status.Status{Code: int32(codes.PermissionDenied)},
+		HttpResponse: &authv3.CheckResponse_DeniedResponse{
+			DeniedResponse: &authv3.DeniedHttpResponse{
+				Status: &envoytypev3.HttpStatus{Code: envoytypev3.StatusCode_Forbidden},
+				Body:   message,
+			},
+		},
+	}
+}
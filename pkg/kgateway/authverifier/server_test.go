@@ -0,0 +1,52 @@
+package authverifier
+
+import (
+	"context"
+	"testing"
+
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+type fakeVerifier struct {
+	extension string
+	config    string
+}
+
+func (f *fakeVerifier) ContextExtension() string {
+	return f.extension
+}
+
+func (f *fakeVerifier) Check(_ context.Context, config string, _ *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+	f.config = config
+	return Allowed(), nil
+}
+
+func checkRequest(extensions map[string]string) *authv3.CheckRequest {
+	return &authv3.CheckRequest{
+		Attributes: &authv3.AttributeContext{
+			ContextExtensions: extensions,
+		},
+	}
+}
+
+func TestCheckDispatchesToVerifier(t *testing.T) {
+	r := require.New(t)
+	a := &fakeVerifier{extension: "a"}
+	b := &fakeVerifier{extension: "b"}
+	s := NewServer(a, b)
+
+	resp, err := s.Check(context.Background(), checkRequest(map[string]string{"b": "config"}))
+	r.NoError(err)
+	r.Equal(int32(codes.OK), resp.GetStatus().GetCode())
+	r.Empty(a.config)
+	r.Equal("config", b.config)
+}
+
+func TestCheckMissingContextExtension(t *testing.T) {
+	s := NewServer(&fakeVerifier{extension: "a"})
+	_, err := s.Check(context.Background(), checkRequest(map[string]string{"other": "config"}))
+	require.Equal(t, codes.InvalidArgument, grpcstatus.Code(err))
+}
@@ -0,0 +1,237 @@
+package backendhealth
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+	"istio.io/istio/pkg/kube/krt"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/logging"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+// Collector periodically scrapes the stats of the gateway proxies and aggregates
+// the endpoint health of every cluster they report. The result is published to
+// CommonCollections so that it can be written to Backend and policy status.
+
+var logger = logging.New("backend_health")
+
+const RunnableName = "backend-health"
+
+// statsPortName is the name of the container port on which gateway proxies expose their
+// prometheus stats. The deployer only adds it when the stats of the GatewayParameters are enabled.
+const statsPortName = "http-monitoring"
+
+const (
+	metricMembershipTotal   = "envoy_cluster_membership_total"
+	metricMembershipHealthy = "envoy_cluster_membership_healthy"
+	metricEjectionsActive   = "envoy_cluster_outlier_detection_ejections_active"
+	labelClusterName        = "envoy_cluster_name"
+)
+
+type Collector struct {
+	pods     krt.Collection[krtcollections.WrappedPod]
+	sink     func([]ir.BackendHealth)
+	interval time.Duration
+	client   *http.Client
+}
+
+func NewCollector(commonCols *collections.CommonCollections, interval time.Duration) *Collector {
+	return &Collector{
+		pods:     commonCols.WrappedPods,
+		sink:     commonCols.SetBackendHealth,
+		interval: interval,
+		client:   &http.Client{Timeout: min(interval, 5*time.Second)},
+	}
+}
+
+func (c *Collector) Start(ctx context.Context) error {
+	logger.Info("starting backend health collector", "interval", c.interval)
+	if !c.pods.WaitUntilSynced(ctx.Done()) {
+		return nil
+	}
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		c.collect(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection returns true since only the leader writes status.
+func (c *Collector) NeedLeaderElection() bool {
+	return true
+}
+
+func (c *Collector) RunnableName() string {
+	return RunnableName
+}
+
+// collect scrapes all the ready gateway pods and publishes the aggregated health.
+func (c *Collector) collect(ctx context.Context) {
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		perPod []map[string]clusterHealth
+	)
+	for _, pod := range c.pods.List() {
+		if !isGatewayPod(pod) {
+			continue
+		}
+		port, ok := statsPort(pod)
+		if !ok {
+			logger.Debug("skipping gateway pod without stats port", "pod", pod.ResourceName(), "port", statsPortName)
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			health, err := c.scrape(ctx, pod.PodIPs[0].IP, port)
+			if err != nil {
+				logger.Debug("failed to collect backend health", "pod", pod.ResourceName(), "error", err)
+				return
+			}
+			mu.Lock()
+			perPod = append(perPod, health)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+	c.sink(aggregate(perPod))
+}
+
+func (c *Collector) scrape(ctx context.Context, podIP string, port int32) (map[string]clusterHealth, error) {
+	url := fmt.Sprintf("http://%s/metrics", net.JoinHostPort(podIP, strconv.Itoa(int(port))))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, url)
+	}
+	return parseClusterHealth(resp.Body)
+}
+
+func isGatewayPod(pod krtcollections.WrappedPod) bool {
+	_, ok := pod.Labels[wellknown.GatewayNameLabel]
+	return ok && pod.Ready && !pod.Terminal && pod.DeletionTimestamp == nil && len(pod.PodIPs) > 0
+}
+
+// statsPort returns the stats port of a gateway pod, as configured by its GatewayParameters.
+func statsPort(pod krtcollections.WrappedPod) (int32, bool) {
+	for _, ports := range pod.ContainerPorts {
+		for _, p := range ports {
+			if p.Name == statsPortName {
+				return p.ContainerPort, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// clusterHealth is the endpoint health of a cluster as seen by a single proxy.
+type clusterHealth struct {
+	total   uint64
+	healthy uint64
+	ejected uint64
+}
+
+// parseClusterHealth extracts the per-cluster membership and outlier ejection gauges
+// from the prometheus text exposition of an Envoy proxy.
+func parseClusterHealth(r io.Reader) (map[string]clusterHealth, error) {
+	parser := expfmt.NewTextParser(model.LegacyValidation)
+	families, err := parser.TextToMetricFamilies(r)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]clusterHealth)
+	set := func(name string, apply func(h *clusterHealth, v uint64)) {
+		family, ok := families[name]
+		if !ok {
+			return
+		}
+		for _, m := range family.GetMetric() {
+			cluster := labelValue(m, labelClusterName)
+			if cluster == "" || m.GetGauge() == nil {
+				continue
+			}
+			h := res[cluster]
+			apply(&h, uint64(max(m.GetGauge().GetValue(), 0)))
+			res[cluster] = h
+		}
+	}
+	set(metricMembershipTotal, func(h *clusterHealth, v uint64) { h.total = v })
+	set(metricMembershipHealthy, func(h *clusterHealth, v uint64) { h.healthy = v })
+	set(metricEjectionsActive, func(h *clusterHealth, v uint64) { h.ejected = v })
+	return res, nil
+}
+
+func labelValue(m *dto.Metric, name string) string {
+	for _, l := range m.GetLabel() {
+		if l.GetName() == name {
+			return l.GetValue()
+		}
+	}
+	return ""
+}
+
+// aggregate merges the health reported by each proxy. Every proxy health checks
+// and ejects independently, so the worst view is kept: the largest endpoint and
+// ejection counts, and the smallest healthy count.
+func aggregate(perPod []map[string]clusterHealth) []ir.BackendHealth {
+	merged := make(map[string]*ir.BackendHealth)
+	for _, pod := range perPod {
+		for cluster, h := range pod {
+			cur, ok := merged[cluster]
+			if !ok {
+				merged[cluster] = &ir.BackendHealth{
+					ClusterName: cluster,
+					Total:       h.total,
+					Healthy:     h.healthy,
+					Ejected:     h.ejected,
+				}
+				continue
+			}
+			cur.Total = max(cur.Total, h.total)
+			cur.Healthy = min(cur.Healthy, h.healthy)
+			cur.Ejected = max(cur.Ejected, h.ejected)
+		}
+	}
+
+	res := make([]ir.BackendHealth, 0, len(merged))
+	for _, h := range merged {
+		res = append(res, *h)
+	}
+	slices.SortFunc(res, func(a, b ir.BackendHealth) int {
+		return strings.Compare(a.ClusterName, b.ClusterName)
+	})
+	return res
+}
@@ -0,0 +1,135 @@
+package backendhealth
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"istio.io/istio/pkg/kube/krt"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+const proxyStats = `# TYPE envoy_cluster_membership_total gauge
+envoy_cluster_membership_total{envoy_cluster_name="kube_default_reviews_8080"} 5
+envoy_cluster_membership_total{envoy_cluster_name="backend_default_static_0"} 2
+# TYPE envoy_cluster_membership_healthy gauge
+envoy_cluster_membership_healthy{envoy_cluster_name="kube_default_reviews_8080"} 3
+envoy_cluster_membership_healthy{envoy_cluster_name="backend_default_static_0"} 2
+# TYPE envoy_cluster_outlier_detection_ejections_active gauge
+envoy_cluster_outlier_detection_ejections_active{envoy_cluster_name="kube_default_reviews_8080"} 2
+# TYPE envoy_cluster_upstream_rq_total counter
+envoy_cluster_upstream_rq_total{envoy_cluster_name="kube_default_reviews_8080"} 42
+`
+
+func TestParseClusterHealth(t *testing.T) {
+	r := require.New(t)
+
+	health, err := parseClusterHealth(strings.NewReader(proxyStats))
+	r.NoError(err)
+	r.Equal(map[string]clusterHealth{
+		"kube_default_reviews_8080": {total: 5, healthy: 3, ejected: 2},
+		"backend_default_static_0":  {total: 2, healthy: 2},
+	}, health)
+}
+
+func TestParseClusterHealthInvalid(t *testing.T) {
+	_, err := parseClusterHealth(strings.NewReader("not prometheus {"))
+	require.Error(t, err)
+}
+
+func TestAggregate(t *testing.T) {
+	r := require.New(t)
+
+	got := aggregate([]map[string]clusterHealth{
+		{
+			"b": {total: 5, healthy: 5},
+			"a": {total: 3, healthy: 3},
+		},
+		{
+			"b": {total: 5, healthy: 3, ejected: 2},
+		},
+	})
+	r.Equal([]ir.BackendHealth{
+		{ClusterName: "a", Total: 3, Healthy: 3},
+		{ClusterName: "b", Total: 5, Healthy: 3, Ejected: 2},
+	}, got)
+}
+
+func TestCollect(t *testing.T) {
+	r := require.New(t)
+
+	var scrapes atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/metrics" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		scrapes.Add(1)
+		_, _ = w.Write([]byte(proxyStats))
+	}))
+	defer srv.Close()
+	host, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	r.NoError(err)
+	portNum, err := strconv.Atoi(port)
+	r.NoError(err)
+
+	gatewayPod := func(name string, ready bool) krtcollections.WrappedPod {
+		return krtcollections.WrappedPod{
+			Named:  krt.Named{Name: name, Namespace: "default"},
+			Labels: map[string]string{wellknown.GatewayNameLabel: "gw"},
+			Ready:  ready,
+			PodIPs: []corev1.PodIP{{IP: host}},
+			ContainerPorts: map[string][]corev1.ContainerPort{
+				"kgateway-proxy": {
+					{Name: "http", ContainerPort: 8080},
+					{Name: statsPortName, ContainerPort: int32(portNum)},
+				},
+			},
+		}
+	}
+	noStats := gatewayPod("gw-4", true)
+	noStats.ContainerPorts = map[string][]corev1.ContainerPort{
+		"kgateway-proxy": {{Name: "http", ContainerPort: 8080}},
+	}
+	pods := krt.NewStaticCollection(nil, []krtcollections.WrappedPod{
+		gatewayPod("gw-1", true),
+		gatewayPod("gw-2", true),
+		// not ready pods are skipped
+		gatewayPod("gw-3", false),
+		// pods without stats are skipped
+		noStats,
+		// non-gateway pods are skipped
+		{
+			Named:  krt.Named{Name: "app", Namespace: "default"},
+			Ready:  true,
+			PodIPs: []corev1.PodIP{{IP: host}},
+		},
+	})
+
+	var got []ir.BackendHealth
+	c := &Collector{
+		pods:     pods,
+		sink:     func(h []ir.BackendHealth) { got = h },
+		interval: time.Second,
+		client:   srv.Client(),
+	}
+	c.collect(context.Background())
+
+	r.Equal(int32(2), scrapes.Load())
+
+	r.Equal([]ir.BackendHealth{
+		{ClusterName: "backend_default_static_0", Total: 2, Healthy: 2},
+		{ClusterName: "kube_default_reviews_8080", Total: 5, Healthy: 3, Ejected: 2},
+	}, got)
+}
@@ -0,0 +1,339 @@
+package basicauth
+
+import (
+	"crypto/md5"  //nolint:gosec // APR1 is defined on MD5
+	"crypto/sha1" //nolint:gosec // {SHA} htpasswd hashes are defined on SHA-1
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Format is an htpasswd password hash format.
+type Format string
+
+const (
+	FormatSHA         Format = "SHA-1"
+	FormatBcrypt      Format = "bcrypt"
+	FormatAPR1        Format = "APR1"
+	FormatSHA256Crypt Format = "SHA-256-crypt"
+	FormatSHA512Crypt Format = "SHA-512-crypt"
+)
+
+const (
+	shaPrefix     = "{SHA}"
+	apr1Prefix    = "$apr1$"
+	sha256Prefix  = "$5$"
+	sha512Prefix  = "$6$"
+	roundsPrefix  = "rounds="
+	cryptAlphabet = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// SHA-crypt parameters, see https://www.akkadia.org/drepper/SHA-crypt.txt
+	shaCryptDefaultRounds = 5000
+	shaCryptMinRounds     = 1000
+	// shaCryptMaxRounds bounds the work of a check below the 999999999 rounds of the spec, as failed
+	// checks are not cached.
+	shaCryptMaxRounds  = 100000
+	shaCryptMaxSaltLen = 16
+	// apr1MaxSaltLen is the maximum salt length of MD5-crypt.
+	apr1MaxSaltLen = 8
+)
+
+var (
+	errUnsupportedHash = errors.New("unsupported password hash format")
+	errMalformedHash   = errors.New("malformed password hash")
+)
+
+// DetectFormat returns the format of an htpasswd password hash.
+func DetectFormat(h string) (Format, error) {
+	switch {
+	case strings.HasPrefix(h, shaPrefix):
+		// 28 is the length of a base64 encoded SHA-1 digest
+		if len(h) != len(shaPrefix)+28 {
+			return "", errMalformedHash
+		}
+		return FormatSHA, nil
+	case strings.HasPrefix(h, "$2a$"), strings.HasPrefix(h, "$2b$"), strings.HasPrefix(h, "$2y$"):
+		if _, err := bcrypt.Cost([]byte(h)); err != nil {
+			return "", errMalformedHash
+		}
+		return FormatBcrypt, nil
+	case strings.HasPrefix(h, apr1Prefix):
+		if _, _, ok := splitCrypt(h, apr1Prefix, 22); !ok {
+			return "", errMalformedHash
+		}
+		return FormatAPR1, nil
+	case strings.HasPrefix(h, sha256Prefix):
+		params, _, ok := splitCrypt(h, sha256Prefix, 43)
+		if !ok {
+			return "", errMalformedHash
+		}
+		if _, _, _, err := shaCryptParams(params); err != nil {
+			return "", err
+		}
+		return FormatSHA256Crypt, nil
+	case strings.HasPrefix(h, sha512Prefix):
+		params, _, ok := splitCrypt(h, sha512Prefix, 86)
+		if !ok {
+			return "", errMalformedHash
+		}
+		if _, _, _, err := shaCryptParams(params); err != nil {
+			return "", err
+		}
+		return FormatSHA512Crypt, nil
+	default:
+		return "", errUnsupportedHash
+	}
+}
+
+// Verify returns true if password matches the htpasswd password hash h.
+func Verify(h, password string) (bool, error) {
+	format, err := DetectFormat(h)
+	if err != nil {
+		return false, err
+	}
+
+	var computed string
+	switch format {
+	case FormatSHA:
+		sum := sha1.Sum([]byte(password)) //nolint:gosec // see import
+		computed = shaPrefix + base64.StdEncoding.EncodeToString(sum[:])
+	case FormatBcrypt:
+		err := bcrypt.CompareHashAndPassword([]byte(h), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		return err == nil, err
+	case FormatAPR1:
+		salt, _, _ := splitCrypt(h, apr1Prefix, 22)
+		computed = apr1(password, salt)
+	case FormatSHA256Crypt:
+		params, _, _ := splitCrypt(h, sha256Prefix, 43)
+		computed, err = shaCrypt(sha256.New, sha256Prefix, sha256Permutation, password, params)
+	case FormatSHA512Crypt:
+		params, _, _ := splitCrypt(h, sha512Prefix, 86)
+		computed, err = shaCrypt(sha512.New, sha512Prefix, sha512Permutation, password, params)
+	}
+	if err != nil {
+		return false, err
+	}
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(h)) == 1, nil
+}
+
+// splitCrypt splits a "<prefix><params>$<checksum>" crypt hash and checks the checksum length.
+func splitCrypt(h, prefix string, checksumLen int) (params, checksum string, ok bool) {
+	rest := strings.TrimPrefix(h, prefix)
+	i := strings.LastIndexByte(rest, '$')
+	if i < 0 {
+		return "", "", false
+	}
+	params, checksum = rest[:i], rest[i+1:]
+	return params, checksum, len(checksum) == checksumLen
+}
+
+// apr1 computes the Apache MD5-crypt hash of password.
+func apr1(password, salt string) string {
+	if len(salt) > apr1MaxSaltLen {
+		salt = salt[:apr1MaxSaltLen]
+	}
+	pw := []byte(password)
+
+	alt := md5.New() //nolint:gosec // see import
+	alt.Write(pw)
+	alt.Write([]byte(salt))
+	alt.Write(pw)
+	altSum := alt.Sum(nil)
+
+	ctx := md5.New() //nolint:gosec // see import
+	ctx.Write(pw)
+	ctx.Write([]byte(apr1Prefix))
+	ctx.Write([]byte(salt))
+	for i := len(pw); i > 0; i -= md5.Size {
+		ctx.Write(altSum[:min(i, md5.Size)])
+	}
+	for i := len(pw); i > 0; i >>= 1 {
+		if i&1 != 0 {
+			ctx.Write([]byte{0})
+		} else {
+			ctx.Write(pw[:1])
+		}
+	}
+	sum := ctx.Sum(nil)
+
+	for i := range 1000 {
+		round := md5.New() //nolint:gosec // see import
+		if i&1 != 0 {
+			round.Write(pw)
+		} else {
+			round.Write(sum)
+		}
+		if i%3 != 0 {
+			round.Write([]byte(salt))
+		}
+		if i%7 != 0 {
+			round.Write(pw)
+		}
+		if i&1 != 0 {
+			round.Write(sum)
+		} else {
+			round.Write(pw)
+		}
+		sum = round.Sum(nil)
+	}
+
+	return apr1Prefix + salt + "$" + encodeCrypt(sum, apr1Permutation)
+}
+
+// shaCryptParams parses the "[rounds=N$]salt" params of a SHA-crypt hash. Rounds below the minimum
+// are raised to it, as in the spec, while rounds above shaCryptMaxRounds are rejected.
+func shaCryptParams(params string) (rounds int, salt string, explicitRounds bool, err error) {
+	rounds, salt = shaCryptDefaultRounds, params
+	if strings.HasPrefix(params, roundsPrefix) {
+		r, s, ok := strings.Cut(strings.TrimPrefix(params, roundsPrefix), "$")
+		if !ok {
+			return 0, "", false, errMalformedHash
+		}
+		n, err := strconv.Atoi(r)
+		if err != nil {
+			return 0, "", false, fmt.Errorf("%w: invalid rounds", errMalformedHash)
+		}
+		if n > shaCryptMaxRounds {
+			return 0, "", false, fmt.Errorf("%w: more than %d rounds", errUnsupportedHash, shaCryptMaxRounds)
+		}
+		rounds, explicitRounds, salt = max(n, shaCryptMinRounds), true, s
+	}
+	if len(salt) > shaCryptMaxSaltLen {
+		salt = salt[:shaCryptMaxSaltLen]
+	}
+	return rounds, salt, explicitRounds, nil
+}
+
+// shaCrypt computes the SHA-crypt hash of password with the given "[rounds=N$]salt" params.
+func shaCrypt(newHash func() hash.Hash, prefix string, permutation []int, password, params string) (string, error) {
+	rounds, salt, explicitRounds, err := shaCryptParams(params)
+	if err != nil {
+		return "", err
+	}
+	pw, saltBytes := []byte(password), []byte(salt)
+
+	b := newHash()
+	b.Write(pw)
+	b.Write(saltBytes)
+	b.Write(pw)
+	bSum := b.Sum(nil)
+	size := len(bSum)
+
+	a := newHash()
+	a.Write(pw)
+	a.Write(saltBytes)
+	for i := len(pw); i > 0; i -= size {
+		a.Write(bSum[:min(i, size)])
+	}
+	for i := len(pw); i > 0; i >>= 1 {
+		if i&1 != 0 {
+			a.Write(bSum)
+		} else {
+			a.Write(pw)
+		}
+	}
+	aSum := a.Sum(nil)
+
+	dp := newHash()
+	for range len(pw) {
+		dp.Write(pw)
+	}
+	p := repeatTo(dp.Sum(nil), len(pw))
+
+	ds := newHash()
+	for range 16 + int(aSum[0]) {
+		ds.Write(saltBytes)
+	}
+	s := repeatTo(ds.Sum(nil), len(saltBytes))
+
+	sum := aSum
+	for i := range rounds {
+		c := newHash()
+		if i&1 != 0 {
+			c.Write(p)
+		} else {
+			c.Write(sum)
+		}
+		if i%3 != 0 {
+			c.Write(s)
+		}
+		if i%7 != 0 {
+			c.Write(p)
+		}
+		if i&1 != 0 {
+			c.Write(sum)
+		} else {
+			c.Write(p)
+		}
+		sum = c.Sum(nil)
+	}
+
+	var out strings.Builder
+	out.WriteString(prefix)
+	if explicitRounds {
+		out.WriteString(roundsPrefix + strconv.Itoa(rounds) + "$")
+	}
+	out.WriteString(salt)
+	out.WriteString("$")
+	out.WriteString(encodeCrypt(sum, permutation))
+	return out.String(), nil
+}
+
+// repeatTo returns the digest repeated to n bytes.
+func repeatTo(digest []byte, n int) []byte {
+	out := make([]byte, 0, n)
+	for len(out) < n {
+		out = append(out, digest[:min(n-len(out), len(digest))]...)
+	}
+	return out
+}
+
+// Byte orders in which crypt(3) encodes the final digests, in groups of three bytes.
+var (
+	apr1Permutation = []int{
+		0, 6, 12, 1, 7, 13, 2, 8, 14, 3, 9, 15, 4, 10, 5, 11,
+	}
+	sha256Permutation = []int{
+		0, 10, 20, 21, 1, 11, 12, 22, 2, 3, 13, 23, 24, 4, 14, 15, 25, 5, 6, 16, 26, 27, 7, 17, 18, 28, 8, 9, 19, 29, 31, 30,
+	}
+	sha512Permutation = []int{
+		0, 21, 42, 22, 43, 1, 44, 2, 23, 3, 24, 45, 25, 46, 4, 47, 5, 26, 6, 27, 48, 28, 49, 7, 50, 8, 29, 9, 30, 51, 31, 52,
+		10, 53, 11, 32, 12, 33, 54, 34, 55, 13, 56, 14, 35, 15, 36, 57, 37, 58, 16, 59, 17, 38, 18, 39, 60, 40, 61, 19, 62, 20, 41, 63,
+	}
+)
+
+// encodeCrypt encodes the permuted digest with the crypt(3) base64 variant, which emits the
+// least significant bits of every 24-bit group first.
+func encodeCrypt(sum []byte, permutation []int) string {
+	var out strings.Builder
+	for i := 0; i < len(permutation); i += 3 {
+		var v uint32
+		n := 0
+		for j := range 3 {
+			if i+j < len(permutation) {
+				v |= uint32(sum[permutation[i+j]]) << (16 - 8*j)
+				n++
+			}
+		}
+		// little-endian 6-bit groups; a trailing group of n bytes yields n+1 characters
+		if n < 3 {
+			v >>= 8 * (3 - n)
+		}
+		for range n + 1 {
+			out.WriteByte(cryptAlphabet[v&0x3f])
+			v >>= 6
+		}
+	}
+	return out.String()
+}
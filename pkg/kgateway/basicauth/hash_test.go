@@ -0,0 +1,100 @@
+package basicauth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name     string
+		hash     string
+		password string
+		format   Format
+	}{
+		{
+			name:     "sha",
+			hash:     "{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=",
+			password: "password",
+			format:   FormatSHA,
+		},
+		{
+			name:     "bcrypt",
+			hash:     "$2a$04$RVjSAGowR8im7dUIj29tD.rv5HR/wyNZF9hPBLN0Y2s6G/jC5HTa.",
+			password: "password",
+			format:   FormatBcrypt,
+		},
+		{
+			name:     "apr1",
+			hash:     "$apr1$3zSE0Abt$EmsVLql8SA/6b44SAo4I91",
+			password: "password",
+			format:   FormatAPR1,
+		},
+		{
+			name:     "apr1 short salt",
+			hash:     "$apr1$ab$7pFWWVkRbydGNLvVKrmSw0",
+			password: "test",
+			format:   FormatAPR1,
+		},
+		{
+			name:     "sha256-crypt",
+			hash:     "$5$saltstring$5B8vYYiY.CVt1RlTTf8KbXBH3hsxY/GNooZaBBGWEc5",
+			password: "Hello world!",
+			format:   FormatSHA256Crypt,
+		},
+		{
+			name:     "sha256-crypt with rounds and truncated salt",
+			hash:     "$5$rounds=10000$saltstringsaltst$3xv.VbSHBb41AL9AvLeujZkZRBAwqFMz2.opqey6IcA",
+			password: "Hello world!",
+			format:   FormatSHA256Crypt,
+		},
+		{
+			name:     "sha512-crypt",
+			hash:     "$6$saltstring$svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl/O817G3uBnIFNjnQJuesI68u4OTLiBFdcbYEdFCoEOfaS35inz1",
+			password: "Hello world!",
+			format:   FormatSHA512Crypt,
+		},
+		{
+			name:     "sha512-crypt with rounds",
+			hash:     "$6$rounds=1000$short$yKjiOaKKa9hmO09NoaGIIFVrNG7WY8CArzJJlZJ5viSZrI9TzqYa2t7AA6THmblNo18mGtDJZYi2nxBwwsjsN.",
+			password: "we have a short salt string but not a short password",
+			format:   FormatSHA512Crypt,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+
+			format, err := DetectFormat(tt.hash)
+			r.NoError(err)
+			r.Equal(tt.format, format)
+
+			ok, err := Verify(tt.hash, tt.password)
+			r.NoError(err)
+			r.True(ok)
+
+			ok, err = Verify(tt.hash, tt.password+"x")
+			r.NoError(err)
+			r.False(ok)
+		})
+	}
+}
+
+func TestDetectFormatInvalid(t *testing.T) {
+	for _, h := range []string{
+		"plaintext",
+		"$1$abc$Kb85XxsXB.VXinPhbS4431",
+		"{SHA}tooshort",
+		"$2y$05$tooshort",
+		"$apr1$salt$tooshort",
+		"$5$nochecksum",
+		"$6$salt$tooshort",
+		"$5$rounds=100001$saltstringsaltst$3xv.VbSHBb41AL9AvLeujZkZRBAwqFMz2.opqey6IcA",
+		"$6$rounds=999999999$short$yKjiOaKKa9hmO09NoaGIIFVrNG7WY8CArzJJlZJ5viSZrI9TzqYa2t7AA6THmblNo18mGtDJZYi2nxBwwsjsN.",
+	} {
+		_, err := DetectFormat(h)
+		assert.Error(t, err, h)
+	}
+}
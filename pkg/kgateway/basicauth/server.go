@@ -0,0 +1,121 @@
+package basicauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"sync"
+
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/authverifier"
+	"github.com/kgateway-dev/kgateway/v2/pkg/logging"
+)
+
+// The basic auth verifier verifies basic auth credentials against password hashes the Envoy
+// basic_auth filter does not support. The htpasswd users of a route are sent by the proxy in the
+// ext_authz context extensions of the route.
+
+var logger = logging.New("basic_auth_verifier")
+
+const (
+	// HtpasswdContextExtension is the ext_authz context extension carrying the htpasswd users of a route.
+	HtpasswdContextExtension = "kgateway.basic_auth.htpasswd"
+
+	// maxCacheSize bounds the number of cached verified credentials.
+	maxCacheSize = 10000
+	// maxCredentialsLength bounds the length of the decoded credentials, as the cost of
+	// the hashes grows with the length of the password.
+	maxCredentialsLength = 256
+
+	// denial messages, matching the ones of the Envoy basic_auth filter
+	missingCredentialsMessage = "User authentication failed. Missing username and password."
+	invalidCredentialsMessage = "User authentication failed. Invalid username/password combination."
+)
+
+type Server struct {
+	// verified caches the credentials that matched a hash, as verifying bcrypt and
+	// SHA-crypt hashes on every request is expensive.
+	mu       sync.Mutex
+	verified map[[sha256.Size]byte]struct{}
+}
+
+var _ authverifier.Verifier = &Server{}
+
+func NewServer() *Server {
+	return &Server{
+		verified: make(map[[sha256.Size]byte]struct{}),
+	}
+}
+
+func (s *Server) ContextExtension() string {
+	return HtpasswdContextExtension
+}
+
+func (s *Server) Check(_ context.Context, htpasswd string, req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+	username, password, ok := ParseBasicAuth(req.GetAttributes().GetRequest().GetHttp().GetHeaders()["authorization"])
+	if !ok {
+		return authverifier.Denied(missingCredentialsMessage), nil
+	}
+
+	hash, ok := lookupUser(htpasswd, username)
+	if !ok || !s.verify(hash, password) {
+		return authverifier.Denied(invalidCredentialsMessage), nil
+	}
+	return authverifier.Allowed(), nil
+}
+
+func (s *Server) verify(hash, password string) bool {
+	key := sha256.Sum256([]byte(hash + "\x00" + password))
+	s.mu.Lock()
+	_, cached := s.verified[key]
+	s.mu.Unlock()
+	if cached {
+		return true
+	}
+
+	ok, err := Verify(hash, password)
+	if err != nil {
+		logger.Warn("failed to verify basic auth password", "error", err)
+		return false
+	}
+	if ok {
+		s.mu.Lock()
+		if len(s.verified) >= maxCacheSize {
+			clear(s.verified)
+		}
+		s.verified[key] = struct{}{}
+		s.mu.Unlock()
+	}
+	return ok
+}
+
+// ParseBasicAuth parses the username and password of a basic Authorization header, matching the
+// scheme case-insensitively. Credentials longer than maxCredentialsLength are rejected.
+func ParseBasicAuth(header string) (username, password string, ok bool) {
+	const prefix = "basic "
+	if len(header) < len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return "", "", false
+	}
+	encoded := strings.TrimSpace(header[len(prefix):])
+	if len(encoded) > base64.StdEncoding.EncodedLen(maxCredentialsLength) {
+		return "", "", false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(decoded) > maxCredentialsLength {
+		return "", "", false
+	}
+	return strings.Cut(string(decoded), ":")
+}
+
+// lookupUser returns the password hash of a user in htpasswd data.
+func lookupUser(htpasswd, username string) (string, bool) {
+	for line := range strings.SplitSeq(htpasswd, "\n") {
+		user, hash, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if ok && user == username {
+			return hash, true
+		}
+	}
+	return "", false
+}
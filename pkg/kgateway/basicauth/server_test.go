@@ -0,0 +1,101 @@
+package basicauth
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	envoytypev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+const testHtpasswd = `# comment
+alice:$apr1$3zSE0Abt$EmsVLql8SA/6b44SAo4I91
+bob:$2a$04$RVjSAGowR8im7dUIj29tD.rv5HR/wyNZF9hPBLN0Y2s6G/jC5HTa.`
+
+func checkRequest(authorization string) *authv3.CheckRequest {
+	headers := map[string]string{}
+	if authorization != "" {
+		headers["authorization"] = authorization
+	}
+	return &authv3.CheckRequest{
+		Attributes: &authv3.AttributeContext{
+			Request: &authv3.AttributeContext_Request{
+				Http: &authv3.AttributeContext_HttpRequest{Headers: headers},
+			},
+		},
+	}
+}
+
+func basic(username, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name          string
+		authorization string
+		wantCode      codes.Code
+		wantBody      string
+	}{
+		{
+			name:          "apr1 user",
+			authorization: basic("alice", "password"),
+			wantCode:      codes.OK,
+		},
+		{
+			name:          "bcrypt user with lowercase scheme",
+			authorization: "basic " + base64.StdEncoding.EncodeToString([]byte("bob:password")),
+			wantCode:      codes.OK,
+		},
+		{
+			name:          "wrong password",
+			authorization: basic("bob", "wrong"),
+			wantCode:      codes.Unauthenticated,
+			wantBody:      invalidCredentialsMessage,
+		},
+		{
+			name:          "unknown user",
+			authorization: basic("carol", "password"),
+			wantCode:      codes.Unauthenticated,
+			wantBody:      invalidCredentialsMessage,
+		},
+		{
+			name:     "missing credentials",
+			wantCode: codes.Unauthenticated,
+			wantBody: missingCredentialsMessage,
+		},
+		{
+			name:          "credentials too long",
+			authorization: basic("bob", strings.Repeat("a", maxCredentialsLength)),
+			wantCode:      codes.Unauthenticated,
+			wantBody:      missingCredentialsMessage,
+		},
+		{
+			name:          "not basic credentials",
+			authorization: "Bearer token",
+			wantCode:      codes.Unauthenticated,
+			wantBody:      missingCredentialsMessage,
+		},
+	}
+	s := NewServer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+
+			// check twice to exercise the cache
+			for range 2 {
+				resp, err := s.Check(context.Background(), testHtpasswd, checkRequest(tt.authorization))
+				r.NoError(err)
+				r.Equal(int32(tt.wantCode), resp.GetStatus().GetCode())
+				if tt.wantCode != codes.OK {
+					r.Equal(envoytypev3.StatusCode_Unauthorized, resp.GetDeniedResponse().GetStatus().GetCode())
+					r.Equal(tt.wantBody, resp.GetDeniedResponse().GetBody())
+				}
+			}
+		})
+	}
+}
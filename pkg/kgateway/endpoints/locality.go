@@ -0,0 +1,164 @@
+package endpoints
+
+import (
+	envoyendpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+const (
+	defaultLocalZonePercentage   = 100
+	defaultLocalZoneMinEndpoints = 6
+)
+
+// LocalityLbInfo holds the settings used to compute the locality weights
+// of a ClusterLoadAssignment. At most one of LocalZone and Weights is set.
+type LocalityLbInfo struct {
+	LocalZone *LocalZoneInfo
+	Weights   []LocalityWeight
+}
+
+// LocalZoneInfo weights the localities in the zone of the proxy over the others.
+type LocalZoneInfo struct {
+	// LocalPercentage is the percentage of requests kept in the zone of the proxy.
+	// Defaults to 100 when nil.
+	LocalPercentage *uint32
+	// MinEndpoints is the minimum number of endpoints required to prefer the local zone.
+	// Defaults to 6 when nil.
+	MinEndpoints *uint32
+	// FailWithoutLocalEndpoints drops all endpoints when the zone of the proxy has none,
+	// regardless of their health which is only known to the proxy.
+	FailWithoutLocalEndpoints bool
+}
+
+// LocalityWeight is an explicit weight for the localities it matches.
+// An empty Zone or SubZone matches any value.
+type LocalityWeight struct {
+	Region  string
+	Zone    string
+	SubZone string
+	Weight  uint32
+}
+
+func (w LocalityWeight) matches(l ir.PodLocality) bool {
+	if w.Region != l.Region {
+		return false
+	}
+	if w.Zone != "" && w.Zone != l.Zone {
+		return false
+	}
+	if w.SubZone != "" && w.SubZone != l.Subzone {
+		return false
+	}
+	return true
+}
+
+func applyLocalityLbInfo(
+	proxyLocality ir.PodLocality,
+	cla *envoyendpointv3.ClusterLoadAssignment,
+	info *LocalityLbInfo,
+) {
+	if info == nil {
+		return
+	}
+	if info.LocalZone != nil {
+		applyLocalZonePreference(proxyLocality, cla, info.LocalZone)
+		return
+	}
+	applyExplicitLocalityWeights(cla, info.Weights)
+}
+
+func applyExplicitLocalityWeights(cla *envoyendpointv3.ClusterLoadAssignment, weights []LocalityWeight) {
+	for _, localityEps := range cla.GetEndpoints() {
+		l := toPodLocality(localityEps)
+		for _, w := range weights {
+			if w.matches(l) {
+				localityEps.LoadBalancingWeight = wrapperspb.UInt32(w.Weight)
+				break
+			}
+		}
+	}
+}
+
+// applyLocalZonePreference computes the locality weights so that LocalPercentage percent of
+// the requests stay in the zone of the proxy, and the rest is spread across all
+// localities in proportion to their number of endpoints. Localities in other zones
+// always keep a non-zero weight so that they can take over when the local zone is unhealthy.
+// Unlike Envoy zone aware routing, the weights are static: they only depend on the endpoints
+// of each zone, not on the traffic of the proxies.
+func applyLocalZonePreference(
+	proxyLocality ir.PodLocality,
+	cla *envoyendpointv3.ClusterLoadAssignment,
+	cfg *LocalZoneInfo,
+) {
+	if proxyLocality.Zone == "" {
+		return
+	}
+
+	localPercentage := uint64(defaultLocalZonePercentage)
+	if cfg.LocalPercentage != nil {
+		localPercentage = uint64(min(*cfg.LocalPercentage, 100))
+	}
+	minEndpoints := uint64(defaultLocalZoneMinEndpoints)
+	if cfg.MinEndpoints != nil {
+		minEndpoints = uint64(*cfg.MinEndpoints)
+	}
+
+	isLocal := func(localityEps *envoyendpointv3.LocalityLbEndpoints) bool {
+		l := localityEps.GetLocality()
+		return l.GetRegion() == proxyLocality.Region && l.GetZone() == proxyLocality.Zone
+	}
+
+	var total, local uint64
+	for _, localityEps := range cla.GetEndpoints() {
+		n := uint64(len(localityEps.GetLbEndpoints()))
+		total += n
+		if isLocal(localityEps) {
+			local += n
+		}
+	}
+	if total < minEndpoints {
+		return
+	}
+	if local == 0 {
+		if cfg.FailWithoutLocalEndpoints {
+			cla.Endpoints = nil
+		}
+		return
+	}
+
+	// weights are computed per priority, as envoy only balances across localities of the same priority
+	byPriority := map[uint32][]*envoyendpointv3.LocalityLbEndpoints{}
+	for _, localityEps := range cla.GetEndpoints() {
+		byPriority[localityEps.GetPriority()] = append(byPriority[localityEps.GetPriority()], localityEps)
+	}
+	for _, group := range byPriority {
+		var groupTotal, groupLocal uint64
+		for _, localityEps := range group {
+			n := uint64(len(localityEps.GetLbEndpoints()))
+			groupTotal += n
+			if isLocal(localityEps) {
+				groupLocal += n
+			}
+		}
+		for _, localityEps := range group {
+			n := uint64(len(localityEps.GetLbEndpoints()))
+			// weights are scaled by 100 to express LocalPercentage as an integer percentage
+			weight := n * (100 - localPercentage)
+			if groupLocal > 0 && isLocal(localityEps) {
+				weight += localPercentage * groupTotal * n / groupLocal
+			}
+			localityEps.LoadBalancingWeight = wrapperspb.UInt32(uint32(max(weight, 1))) //nolint:gosec // G115: bounded by the number of endpoints times 100
+		}
+	}
+}
+
+func toPodLocality(localityEps *envoyendpointv3.LocalityLbEndpoints) ir.PodLocality {
+	l := localityEps.GetLocality()
+	return ir.PodLocality{
+		Region:  l.GetRegion(),
+		Zone:    l.GetZone(),
+		Subzone: l.GetSubZone(),
+	}
+}
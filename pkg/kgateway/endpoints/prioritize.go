@@ -22,6 +22,7 @@ import (
 type EndpointsInputs struct {
 	EndpointsForBackend ir.EndpointsForBackend
 	PriorityInfo        *PriorityInfo
+	LocalityLbInfo      *LocalityLbInfo
 }
 
 // PrioritizeEndpoints converts EndpointsInputs into a ClusterLoadAssignment.
@@ -31,8 +32,9 @@ func PrioritizeEndpoints(
 	inputs EndpointsInputs,
 ) *envoyendpointv3.ClusterLoadAssignment {
 	lbInfo := LoadBalancingInfo{
-		PodLabels:   ucc.Labels,
-		PodLocality: ucc.Locality,
+		PodLabels:      ucc.Labels,
+		PodLocality:    ucc.Locality,
+		LocalityLbInfo: inputs.LocalityLbInfo,
 	}
 
 	if inputs.PriorityInfo == nil {
@@ -54,6 +56,9 @@ type LoadBalancingInfo struct {
 
 	// dest rule info:
 	PriorityInfo *PriorityInfo
+
+	// locality weight info:
+	LocalityLbInfo *LocalityLbInfo
 }
 
 type PriorityInfo struct {
@@ -146,6 +151,7 @@ func prioritizeWithLbInfo(logger *slog.Logger, ep ir.EndpointsForBackend, lbInfo
 		}
 		applyLocalityFailover(&proxyLocality, cla, lbInfo.PriorityInfo.Failover)
 	}
+	applyLocalityLbInfo(lbInfo.PodLocality, cla, lbInfo.LocalityLbInfo)
 	if logger != nil {
 		logger.Debug("created cla", "cluster", cla.GetClusterName(), "total_endpoints", totalEndpoints)
 	}
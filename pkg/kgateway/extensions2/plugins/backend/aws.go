@@ -3,7 +3,9 @@ package backend
 import (
 	"errors"
 	"fmt"
+	"net"
 	"net/url"
+	"slices"
 	"strconv"
 	"unicode/utf8"
 
@@ -14,10 +16,13 @@ import (
 	envoy_request_signing_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/aws_request_signing/v3"
 	envoy_upstream_codec "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/upstream_codec/v3"
 	envoy_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	envoy_upstreams_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
+	envoywellknown "github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"k8s.io/utils/ptr"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/pluginutils"
@@ -40,11 +45,15 @@ const (
 	upstreamCodecFilterName = "envoy.filters.http.upstream_codec"
 )
 
+// awsServicesWithoutRegionalEndpoint are the AWS services whose endpoints are specific to an API,
+// domain or collection, so that `<service>.<region>.amazonaws.com` is not a valid endpoint.
+var awsServicesWithoutRegionalEndpoint = []string{"execute-api", "es", "aoss"}
+
 // AwsIr is the internal representation of an AWS backend.
 type AwsIr struct {
-	lambdaFilters         *lambdaFilters
-	lambdaEndpoint        *lambdaEndpointConfig
-	lambdaTransportSocket *envoycorev3.TransportSocket
+	filters         *awsFilters
+	endpoint        *awsEndpointConfig
+	transportSocket *envoycorev3.TransportSocket
 }
 
 // Equals checks if two AwsIr objects are equal.
@@ -60,79 +69,177 @@ func (u *AwsIr) Equals(other any) bool {
 		if otherAws == nil {
 			return false
 		}
-		if !u.lambdaEndpoint.Equals(otherAws.lambdaEndpoint) {
+		if !u.endpoint.Equals(otherAws.endpoint) {
 			return false
 		}
-		if !u.lambdaFilters.Equals(otherAws.lambdaFilters) {
+		if !u.filters.Equals(otherAws.filters) {
 			return false
 		}
-		if !proto.Equal(u.lambdaTransportSocket, otherAws.lambdaTransportSocket) {
+		if !proto.Equal(u.transportSocket, otherAws.transportSocket) {
 			return false
 		}
 	}
 	return true
 }
 
+// buildAwsIr builds the AwsIr for the given AWS backend. Errors are collected rather than returned
+// early so that the backend reports all of them at once.
+func buildAwsIr(in *kgateway.AwsBackend, secret *ir.Secret) (*AwsIr, []error) {
+	var errs []error
+	region := in.Region
+
+	var endpointConfig *awsEndpointConfig
+	var filters *awsFilters
+	var err error
+	switch {
+	case in.Service != nil:
+		endpointConfig, err = configureServiceEndpoint(in.Service, region)
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		filters, err = buildSigningFilters(
+			in.Service.Name, endpointConfig, ptr.Deref(in.Service.UnsignedPayload, false), region, secret)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	case in.Lambda.FunctionName == "":
+		// defensive check; CEL validation requires exactly one of lambda or service
+		return nil, []error{errors.New("aws backend must specify either lambda or service")}
+	case in.Lambda.FunctionURL != nil:
+		// function URLs are plain HTTP endpoints, so the requests are only signed. As Envoy does not
+		// buffer proxied responses, this also streams the responses of RESPONSE_STREAM function URLs.
+		if in.Lambda.PayloadTransformMode == kgateway.AWSLambdaPayloadTransformNone {
+			errs = append(errs, errors.New("payloadTransformMode None is not supported with functionURL, as the function URL transforms the payload"))
+		}
+		endpointConfig, err = parseEndpointURL(*in.Lambda.FunctionURL)
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		filters, err = buildSigningFilters(lambdaServiceName, endpointConfig, false, region, secret)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	default:
+		endpointConfig, err = configureLambdaEndpoint(in)
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		lambdaArn, err := buildLambdaARN(in, region)
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		filters, err = buildLambdaFilters(
+			lambdaArn, region, secret, getLambdaInvocationMode(in), in.Lambda.PayloadTransformMode)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	var transportSocket *envoycorev3.TransportSocket
+	if endpointConfig != nil && endpointConfig.useTLS {
+		// TODO(yuval-k): Add verification context
+		typedConfig, err := utils.MessageToAny(&envoytlsv3.UpstreamTlsContext{
+			Sni: endpointConfig.hostname,
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+		transportSocket = &envoycorev3.TransportSocket{
+			Name: envoywellknown.TransportSocketTls,
+			ConfigType: &envoycorev3.TransportSocket_TypedConfig{
+				TypedConfig: typedConfig,
+			},
+		}
+	}
+
+	return &AwsIr{
+		endpoint:        endpointConfig,
+		transportSocket: transportSocket,
+		filters:         filters,
+	}, errs
+}
+
 // processAws processes an AWS backend and returns an envoy cluster.
 func processAws(ir *AwsIr, out *envoyclusterv3.Cluster) error {
 	// defensive check; this should never happen with union types
 	if ir == nil {
 		return fmt.Errorf("aws ir is nil")
 	}
+	if ir.filters == nil || ir.endpoint == nil {
+		return fmt.Errorf("aws ir is incomplete")
+	}
 
 	out.ClusterDiscoveryType = &envoyclusterv3.Cluster_Type{
 		Type: envoyclusterv3.Cluster_LOGICAL_DNS,
 	}
-	if ir.lambdaTransportSocket != nil {
-		out.TransportSocket = ir.lambdaTransportSocket
+	if ir.transportSocket != nil {
+		out.TransportSocket = ir.transportSocket
 	}
 
 	if err := translatorutils.MutateHttpOptions(out, func(opts *envoy_upstreams_v3.HttpProtocolOptions) {
-		opts.UpstreamProtocolOptions = &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig_{
-			ExplicitHttpConfig: &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig{
-				ProtocolConfig: &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig_Http2ProtocolOptions{
-					Http2ProtocolOptions: &envoycorev3.Http2ProtocolOptions{},
+		// The Lambda invoke API is called over HTTP/2, while HTTP/1.1 is used for other services and
+		// function URLs as it is the only protocol supported by all AWS service endpoints.
+		if ir.filters.lambdaConfigAny != nil {
+			opts.UpstreamProtocolOptions = &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig_{
+				ExplicitHttpConfig: &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig{
+					ProtocolConfig: &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig_Http2ProtocolOptions{
+						Http2ProtocolOptions: &envoycorev3.Http2ProtocolOptions{},
+					},
 				},
-			},
+			}
+		} else {
+			opts.UpstreamProtocolOptions = &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig_{
+				ExplicitHttpConfig: &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig{
+					ProtocolConfig: &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig_HttpProtocolOptions{
+						HttpProtocolOptions: &envoycorev3.Http1ProtocolOptions{},
+					},
+				},
+			}
 		}
 		opts.CommonHttpProtocolOptions = &envoycorev3.HttpProtocolOptions{
 			IdleTimeout: &durationpb.Duration{
 				Seconds: 30,
 			},
 		}
-		opts.HttpFilters = append(opts.GetHttpFilters(), &envoy_hcm.HttpFilter{
-			Name: lambdaFilterName,
-			ConfigType: &envoy_hcm.HttpFilter_TypedConfig{
-				TypedConfig: ir.lambdaFilters.lambdaConfigAny,
-			},
-		})
+		if ir.filters.lambdaConfigAny != nil {
+			opts.HttpFilters = append(opts.GetHttpFilters(), &envoy_hcm.HttpFilter{
+				Name: lambdaFilterName,
+				ConfigType: &envoy_hcm.HttpFilter_TypedConfig{
+					TypedConfig: ir.filters.lambdaConfigAny,
+				},
+			})
+		}
 		opts.HttpFilters = append(opts.GetHttpFilters(), &envoy_hcm.HttpFilter{
 			Name: awsRequestSigningFilterName,
 			ConfigType: &envoy_hcm.HttpFilter_TypedConfig{
-				TypedConfig: ir.lambdaFilters.awsRequestSigningAny,
+				TypedConfig: ir.filters.awsRequestSigningAny,
 			},
 		})
 		opts.HttpFilters = append(opts.GetHttpFilters(), &envoy_hcm.HttpFilter{
 			Name: upstreamCodecFilterName,
 			ConfigType: &envoy_hcm.HttpFilter_TypedConfig{
-				TypedConfig: ir.lambdaFilters.codecConfigAny,
+				TypedConfig: ir.filters.codecConfigAny,
 			},
 		})
 	}); err != nil {
 		return fmt.Errorf("failed to mutate http options: %v", err)
 	}
 
-	pluginutils.EnvoySingleEndpointLoadAssignment(out, ir.lambdaEndpoint.hostname, ir.lambdaEndpoint.port)
+	pluginutils.EnvoySingleEndpointLoadAssignment(out, ir.endpoint.hostname, ir.endpoint.port)
 	return nil
 }
 
-// configureAWSAuth configures AWS authentication for the given backend.
-func configureAWSAuth(secret *ir.Secret, region string) (*envoy_request_signing_v3.AwsRequestSigning, error) {
+// configureAWSAuth configures AWS authentication for the given backend and service.
+func configureAWSAuth(secret *ir.Secret, serviceName, region string) (*envoy_request_signing_v3.AwsRequestSigning, error) {
 	// when no auth is specified, use the default aws auth provider documented by the lambda filter:
 	// https://www.envoyproxy.io/docs/envoy/latest/configuration/http/http_filters/aws_lambda_filter#credentials.
 	if secret == nil || secret.Data == nil {
 		return &envoy_request_signing_v3.AwsRequestSigning{
-			ServiceName: lambdaServiceName,
+			ServiceName: serviceName,
 			Region:      region,
 		}, nil
 	}
@@ -143,7 +250,7 @@ func configureAWSAuth(secret *ir.Secret, region string) (*envoy_request_signing_
 	}
 
 	return &envoy_request_signing_v3.AwsRequestSigning{
-		ServiceName: lambdaServiceName,
+		ServiceName: serviceName,
 		Region:      region,
 		CredentialProvider: &envoy_aws_common_v3.AwsCredentialProvider{
 			InlineCredential: &envoy_aws_common_v3.InlineCredentialProvider{
@@ -155,16 +262,17 @@ func configureAWSAuth(secret *ir.Secret, region string) (*envoy_request_signing_
 	}, nil
 }
 
-// lambdaFilters is a helper struct to store the lambda filters for the given backend.
-type lambdaFilters struct {
+// awsFilters is a helper struct to store the upstream HTTP filters for the given backend.
+// lambdaConfigAny is only set for Lambda backends.
+type awsFilters struct {
 	lambdaConfigAny      *anypb.Any
 	awsRequestSigningAny *anypb.Any
 	codecConfigAny       *anypb.Any
 }
 
-// Equals checks if two lambdaFilters objects are equal.
-func (u *lambdaFilters) Equals(other *lambdaFilters) bool {
-	return cmputils.CompareWithNils(u, other, func(a, b *lambdaFilters) bool {
+// Equals checks if two awsFilters objects are equal.
+func (u *awsFilters) Equals(other *awsFilters) bool {
+	return cmputils.CompareWithNils(u, other, func(a, b *awsFilters) bool {
 		return proto.Equal(a.lambdaConfigAny, b.lambdaConfigAny) &&
 			proto.Equal(a.awsRequestSigningAny, b.awsRequestSigningAny) &&
 			proto.Equal(a.codecConfigAny, b.codecConfigAny)
@@ -178,7 +286,8 @@ func buildLambdaFilters(
 	secret *ir.Secret,
 	invokeMode envoy_lambda_v3.Config_InvocationMode,
 	payloadTransformMode kgateway.AWSLambdaPayloadTransformMode,
-) (*lambdaFilters, error) {
+) (*awsFilters, error) {
+	// the payload is transformed by Envoy unless the mode is explicitly None
 	var payloadPassthrough bool
 	switch payloadTransformMode {
 	case kgateway.AWSLambdaPayloadTransformNone:
@@ -196,7 +305,7 @@ func buildLambdaFilters(
 		return nil, fmt.Errorf("failed to create lambda config: %v", err)
 	}
 
-	awsRequestSigning, err := configureAWSAuth(secret, region)
+	awsRequestSigning, err := configureAWSAuth(secret, lambdaServiceName, region)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create aws request signing config: %v", err)
 	}
@@ -210,13 +319,46 @@ func buildLambdaFilters(
 		return nil, fmt.Errorf("failed to create upstream codec config: %v", err)
 	}
 
-	return &lambdaFilters{
+	return &awsFilters{
 		lambdaConfigAny:      lambdaConfigAny,
 		awsRequestSigningAny: awsRequestSigningAny,
 		codecConfigAny:       codecConfigAny,
 	}, nil
 }
 
+// buildSigningFilters configures cluster's upstream HTTP filters to sign the requests for the given
+// AWS service. The host header is rewritten to the endpoint host, as it is part of the signature.
+func buildSigningFilters(
+	serviceName string,
+	endpoint *awsEndpointConfig,
+	unsignedPayload bool,
+	region string,
+	secret *ir.Secret,
+) (*awsFilters, error) {
+	awsRequestSigning, err := configureAWSAuth(secret, serviceName, region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create aws request signing config: %v", err)
+	}
+	if endpoint != nil {
+		awsRequestSigning.HostRewrite = endpoint.host()
+	}
+	awsRequestSigning.UseUnsignedPayload = unsignedPayload
+	awsRequestSigningAny, err := utils.MessageToAny(awsRequestSigning)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create aws request signing config: %v", err)
+	}
+
+	codecConfigAny, err := utils.MessageToAny(&envoy_upstream_codec.UpstreamCodec{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upstream codec config: %v", err)
+	}
+
+	return &awsFilters{
+		awsRequestSigningAny: awsRequestSigningAny,
+		codecConfigAny:       codecConfigAny,
+	}, nil
+}
+
 // getLambdaHostname returns the hostname for the lambda function. When using a custom endpoint
 // has been specified, it will be returned. Otherwise, the default lambda hostname is returned.
 func getLambdaHostname(in *kgateway.AwsBackend) string {
@@ -249,39 +391,77 @@ func buildLambdaARN(in *kgateway.AwsBackend, region string) (string, error) {
 	return parsedARN.String(), nil
 }
 
-// lambdaEndpointConfig is a helper struct to store the endpoint configuration for the Lambda backend.
-type lambdaEndpointConfig struct {
+// awsEndpointConfig is a helper struct to store the endpoint configuration for the AWS backend.
+type awsEndpointConfig struct {
 	hostname string
 	port     uint32
 	useTLS   bool
 }
 
-// Equals checks if two lambdaEndpointConfig objects are equal.
-func (u *lambdaEndpointConfig) Equals(other *lambdaEndpointConfig) bool {
-	return u.hostname == other.hostname && u.port == other.port && u.useTLS == other.useTLS
+// Equals checks if two awsEndpointConfig objects are equal.
+func (u *awsEndpointConfig) Equals(other *awsEndpointConfig) bool {
+	return cmputils.CompareWithNils(u, other, func(a, b *awsEndpointConfig) bool {
+		return a.hostname == b.hostname && a.port == b.port && a.useTLS == b.useTLS
+	})
 }
 
-// configureLambdaEndpoint parses the endpoint URL and returns the endpoint configuration.
-func configureLambdaEndpoint(in *kgateway.AwsBackend) (*lambdaEndpointConfig, error) {
-	config := &lambdaEndpointConfig{
-		hostname: getLambdaHostname(in),
-		port:     443,
-		useTLS:   true,
+// host returns the value of the host header for the endpoint, which only includes the port
+// when it is not the default port of the scheme.
+func (u *awsEndpointConfig) host() string {
+	if (u.useTLS && u.port == 443) || (!u.useTLS && u.port == 80) {
+		return u.hostname
 	}
+	return net.JoinHostPort(u.hostname, strconv.FormatUint(uint64(u.port), 10))
+}
 
+// configureLambdaEndpoint parses the endpoint URL and returns the endpoint configuration.
+func configureLambdaEndpoint(in *kgateway.AwsBackend) (*awsEndpointConfig, error) {
 	if in.Lambda.EndpointURL == nil {
 		// no custom endpoint specified, use the default lambda hostname.
-		return config, nil
+		return &awsEndpointConfig{
+			hostname: getLambdaHostname(in),
+			port:     443,
+			useTLS:   true,
+		}, nil
 	}
+	return parseEndpointURL(*in.Lambda.EndpointURL)
+}
 
-	inUrl := *in.Lambda.EndpointURL
+// configureServiceEndpoint parses the endpoint URL of the AWS service and returns the endpoint configuration.
+// When no endpoint URL is specified, the regional endpoint of the service is used.
+func configureServiceEndpoint(in *kgateway.AwsService, region string) (*awsEndpointConfig, error) {
+	if in.EndpointURL == nil {
+		if slices.Contains(awsServicesWithoutRegionalEndpoint, in.Name) {
+			return nil, fmt.Errorf("endpointURL must be specified for the %s service", in.Name)
+		}
+		return &awsEndpointConfig{
+			hostname: fmt.Sprintf("%s.%s.amazonaws.com", in.Name, region),
+			port:     443,
+			useTLS:   true,
+		}, nil
+	}
+	return parseEndpointURL(*in.EndpointURL)
+}
+
+// parseEndpointURL parses the given endpoint URL. When the URL has no port, the default port of
+// its scheme is used.
+func parseEndpointURL(inUrl string) (*awsEndpointConfig, error) {
 	parsedURL, err := url.Parse(inUrl)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse endpoint URL: %v", err)
 	}
-	config.useTLS = parsedURL.Scheme == "https"
-	config.hostname = parsedURL.Hostname()
+	config := &awsEndpointConfig{
+		hostname: parsedURL.Hostname(),
+		useTLS:   parsedURL.Scheme == "https",
+	}
 
+	if parsedURL.Port() == "" {
+		config.port = 80
+		if config.useTLS {
+			config.port = 443
+		}
+		return config, nil
+	}
 	port, err := strconv.ParseUint(parsedURL.Port(), 10, 32)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse port: %v", err)
@@ -1,14 +1,27 @@
 package backend
 
 import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
 	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoyrbacv3 "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	envoy_dfp_cluster "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/dynamic_forward_proxy/v3"
 	envoydfp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/dynamic_forward_proxy/v3"
+	envoylua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	envoyrbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
 	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	envoymatcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 	"k8s.io/utils/ptr"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
@@ -17,16 +30,65 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/pkg/utils/cmputils"
 )
 
+const (
+	// dfpAllowlistRbacFilterName is the name of the RBAC filter that evaluates the allowed hosts of
+	// dynamic forward proxy backends. It runs in shadow mode, so it only records its decision.
+	dfpAllowlistRbacFilterName = "envoy.filters.http.rbac/dfp_allowlist"
+	// dfpAllowlistLuaFilterName is the name of the Lua filter that sends the deny response of dynamic
+	// forward proxy backends when the RBAC filter denied the destination host.
+	dfpAllowlistLuaFilterName = "envoy.filters.http.lua/dfp_allowlist"
+	// dfpAllowlistStatPrefix prefixes the RBAC stats and the dynamic metadata keys of the allowlist decision.
+	dfpAllowlistStatPrefix = "dfp_allowlist_"
+	// dfpDenyScriptName is the name of the Lua script that sends the deny response.
+	dfpDenyScriptName = "dfp_deny"
+
+	defaultDfpDenyStatusCode = 403
+	defaultDfpDenyBody       = "destination host is not allowed"
+)
+
+// dfpDenyScript sends the deny response, which is passed in the filter context of the route,
+// when the shadow RBAC filter denied the destination host.
+const dfpDenyScript = `function envoy_on_request(request_handle)
+  local metadata = request_handle:streamInfo():dynamicMetadata():get("envoy.filters.http.rbac")
+  if metadata == nil or metadata["` + dfpAllowlistStatPrefix + `shadow_engine_result"] ~= "denied" then
+    return
+  end
+  local context = request_handle:filterContext()
+  request_handle:respond(
+    {[":status"] = context["status"], ["content-type"] = "text/plain"},
+    context["body"])
+end
+`
+
 var dfpFilterConfig = &envoydfp.FilterConfig{
 	ImplementationSpecifier: &envoydfp.FilterConfig_SubClusterConfig{
 		SubClusterConfig: &envoydfp.SubClusterConfig{},
 	},
 }
 
+var dfpAllowlistRbacFilterConfig = &envoyrbac.RBAC{
+	ShadowRulesStatPrefix: dfpAllowlistStatPrefix,
+}
+
+var dfpAllowlistLuaFilterConfig = &envoylua.Lua{
+	SourceCodes: map[string]*envoycorev3.DataSource{
+		dfpDenyScriptName: {
+			Specifier: &envoycorev3.DataSource_InlineString{
+				InlineString: dfpDenyScript,
+			},
+		},
+	},
+}
+
 // DfpIr is the internal representation of a dynamic forward proxy backend.
 type DfpIr struct {
 	clusterTypeConfig *anypb.Any
 	transportSocket   *envoycorev3.TransportSocket
+	dnsRefreshRate    *durationpb.Duration
+	respectDnsTtl     bool
+	// allowlistRbac and denyLua are set on the routes to the backend when AllowedHosts is specified.
+	allowlistRbac *envoyrbac.RBACPerRoute
+	denyLua       *envoylua.LuaPerRoute
 }
 
 // Equals checks if two DfpIr objects are equal.
@@ -37,18 +99,46 @@ func (u *DfpIr) Equals(other any) bool {
 	}
 	return cmputils.CompareWithNils(u, otherDfp, func(a, b *DfpIr) bool {
 		return proto.Equal(a.clusterTypeConfig, b.clusterTypeConfig) &&
-			proto.Equal(a.transportSocket, b.transportSocket)
+			proto.Equal(a.transportSocket, b.transportSocket) &&
+			proto.Equal(a.dnsRefreshRate, b.dnsRefreshRate) &&
+			a.respectDnsTtl == b.respectDnsTtl &&
+			proto.Equal(a.allowlistRbac, b.allowlistRbac) &&
+			proto.Equal(a.denyLua, b.denyLua)
 	})
 }
 
 func buildDfpIr(in *kgateway.DynamicForwardProxyBackend) (*DfpIr, error) {
 	ir := &DfpIr{}
 
+	subClustersConfig := &envoy_dfp_cluster.SubClustersConfig{
+		LbPolicy: envoyclusterv3.Cluster_LEAST_REQUEST,
+	}
+	// the sub clusters of the destination hosts are STRICT_DNS clusters copied from the DFP cluster,
+	// so the DNS settings of the DFP cluster apply to them.
+	if cache := in.DNSCache; cache != nil {
+		if cache.MaxHosts != nil {
+			subClustersConfig.MaxSubClusters = wrapperspb.UInt32(uint32(*cache.MaxHosts)) // nolint:gosec // G115: kubebuilder validation ensures safe for uint32
+		}
+		if cache.HostTTL != nil {
+			subClustersConfig.SubClusterTtl = durationpb.New(cache.HostTTL.Duration)
+		}
+		for _, h := range cache.PreresolvedHosts {
+			subClustersConfig.PreresolveClusters = append(subClustersConfig.PreresolveClusters, &envoycorev3.SocketAddress{
+				Address: h.Host,
+				PortSpecifier: &envoycorev3.SocketAddress_PortValue{
+					PortValue: uint32(h.Port), // nolint:gosec // G115: Gateway API PortNumber is 1-65535
+				},
+			})
+		}
+		if cache.RefreshRate != nil {
+			ir.dnsRefreshRate = durationpb.New(cache.RefreshRate.Duration)
+		}
+		ir.respectDnsTtl = ptr.Deref(cache.RespectDNSTTL, false)
+	}
+
 	c := &envoy_dfp_cluster.ClusterConfig{
 		ClusterImplementationSpecifier: &envoy_dfp_cluster.ClusterConfig_SubClustersConfig{
-			SubClustersConfig: &envoy_dfp_cluster.SubClustersConfig{
-				LbPolicy: envoyclusterv3.Cluster_LEAST_REQUEST,
-			},
+			SubClustersConfig: subClustersConfig,
 		},
 	}
 	anyCluster, err := utils.MessageToAny(c)
@@ -86,9 +176,102 @@ func buildDfpIr(in *kgateway.DynamicForwardProxyBackend) (*DfpIr, error) {
 		}
 	}
 
+	if len(in.AllowedHosts) > 0 {
+		allowlistRbac, err := buildDfpAllowlist(in.AllowedHosts)
+		if err != nil {
+			return nil, err
+		}
+		ir.allowlistRbac = allowlistRbac
+		ir.denyLua = buildDfpDenyResponse(in.DenyResponse)
+	}
+
 	return ir, nil
 }
 
+// buildDfpAllowlist builds the shadow RBAC rules that allow the hosts matching any of the allowed hosts.
+// All the matches are translated to regular expressions, so that the port of the host can be ignored.
+func buildDfpAllowlist(allowedHosts []kgateway.DynamicForwardProxyHostMatch) (*envoyrbac.RBACPerRoute, error) {
+	permissions := make([]*envoyrbacv3.Permission, 0, len(allowedHosts))
+	for i, h := range allowedHosts {
+		var hostRegex string
+		switch h.Type {
+		case kgateway.DynamicForwardProxyHostMatchSuffix:
+			// the suffix starts at a label boundary, so example.com does not match evilexample.com
+			if strings.HasPrefix(h.Value, ".") {
+				hostRegex = ".*" + regexp.QuoteMeta(h.Value)
+			} else {
+				hostRegex = "(?:.*\\.)?" + regexp.QuoteMeta(h.Value)
+			}
+		case kgateway.DynamicForwardProxyHostMatchRegularExpression:
+			if _, err := regexp.Compile(h.Value); err != nil {
+				return nil, fmt.Errorf("invalid allowedHosts[%d] regular expression: %w", i, err)
+			}
+			hostRegex = "(?:" + h.Value + ")"
+		default:
+			hostRegex = regexp.QuoteMeta(h.Value)
+		}
+		permissions = append(permissions, &envoyrbacv3.Permission{
+			Rule: &envoyrbacv3.Permission_Header{
+				Header: &envoyroutev3.HeaderMatcher{
+					Name: ":authority",
+					HeaderMatchSpecifier: &envoyroutev3.HeaderMatcher_StringMatch{
+						StringMatch: &envoymatcherv3.StringMatcher{
+							MatchPattern: &envoymatcherv3.StringMatcher_SafeRegex{
+								SafeRegex: &envoymatcherv3.RegexMatcher{
+									// hosts are case-insensitive and may include a port
+									Regex: "(?i)" + hostRegex + "(:[0-9]+)?",
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	return &envoyrbac.RBACPerRoute{
+		Rbac: &envoyrbac.RBAC{
+			ShadowRules: &envoyrbacv3.RBAC{
+				Action: envoyrbacv3.RBAC_ALLOW,
+				Policies: map[string]*envoyrbacv3.Policy{
+					"allowed-hosts": {
+						Permissions: []*envoyrbacv3.Permission{{
+							Rule: &envoyrbacv3.Permission_OrRules{
+								OrRules: &envoyrbacv3.Permission_Set{Rules: permissions},
+							},
+						}},
+						Principals: []*envoyrbacv3.Principal{{
+							Identifier: &envoyrbacv3.Principal_Any{Any: true},
+						}},
+					},
+				},
+			},
+			ShadowRulesStatPrefix: dfpAllowlistStatPrefix,
+		},
+	}, nil
+}
+
+// buildDfpDenyResponse passes the deny response to the deny script through the filter context.
+func buildDfpDenyResponse(in *kgateway.DynamicForwardProxyDenyResponse) *envoylua.LuaPerRoute {
+	statusCode := int32(defaultDfpDenyStatusCode)
+	body := defaultDfpDenyBody
+	if in != nil {
+		statusCode = ptr.Deref(in.StatusCode, statusCode)
+		body = ptr.Deref(in.Body, body)
+	}
+	return &envoylua.LuaPerRoute{
+		Override: &envoylua.LuaPerRoute_Name{
+			Name: dfpDenyScriptName,
+		},
+		FilterContext: &structpb.Struct{
+			Fields: map[string]*structpb.Value{
+				"status": structpb.NewStringValue(strconv.Itoa(int(statusCode))),
+				"body":   structpb.NewStringValue(body),
+			},
+		},
+	}
+}
+
 // processDynamicForwardProxy applies the DFP IR to the envoy cluster.
 func processDynamicForwardProxy(ir *DfpIr, out *envoyclusterv3.Cluster) {
 	out.LbPolicy = envoyclusterv3.Cluster_CLUSTER_PROVIDED
@@ -102,4 +285,10 @@ func processDynamicForwardProxy(ir *DfpIr, out *envoyclusterv3.Cluster) {
 	if ir.transportSocket != nil {
 		out.TransportSocket = ir.transportSocket
 	}
+	if ir.dnsRefreshRate != nil {
+		out.DnsRefreshRate = ir.dnsRefreshRate
+	}
+	if ir.respectDnsTtl {
+		out.RespectDnsTtl = true
+	}
 }
@@ -0,0 +1,153 @@
+package backend
+
+import (
+	"regexp"
+	"testing"
+
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+// allowedByDfpAllowlist evaluates the shadow RBAC rules of the allowlist as Envoy does, with
+// regular expressions matching the whole :authority header.
+func allowedByDfpAllowlist(t *testing.T, rbac *envoyroutev3.HeaderMatcher, authority string) bool {
+	t.Helper()
+	require.Equal(t, ":authority", rbac.GetName())
+	re, err := regexp.Compile("^(?:" + rbac.GetStringMatch().GetSafeRegex().GetRegex() + ")$")
+	require.NoError(t, err)
+	return re.MatchString(authority)
+}
+
+func TestBuildDfpAllowlist(t *testing.T) {
+	allowlist, err := buildDfpAllowlist([]kgateway.DynamicForwardProxyHostMatch{
+		{Value: "api.github.com"},
+		{Type: kgateway.DynamicForwardProxyHostMatchSuffix, Value: ".amazonaws.com"},
+		{Type: kgateway.DynamicForwardProxyHostMatchSuffix, Value: "example.org"},
+		{Type: kgateway.DynamicForwardProxyHostMatchRegularExpression, Value: `[a-z]+\.internal|other\.example\.com`},
+	})
+	require.NoError(t, err)
+
+	rules := allowlist.GetRbac().GetShadowRules()
+	require.NotNil(t, rules)
+	assert.Equal(t, dfpAllowlistStatPrefix, allowlist.GetRbac().GetShadowRulesStatPrefix())
+	require.Len(t, rules.GetPolicies(), 1)
+	permissions := rules.GetPolicies()["allowed-hosts"].GetPermissions()
+	require.Len(t, permissions, 1)
+	orRules := permissions[0].GetOrRules().GetRules()
+	require.Len(t, orRules, 4)
+
+	allowed := func(authority string) bool {
+		for _, r := range orRules {
+			if allowedByDfpAllowlist(t, r.GetHeader(), authority) {
+				return true
+			}
+		}
+		return false
+	}
+
+	tests := []struct {
+		authority string
+		allowed   bool
+	}{
+		{authority: "api.github.com", allowed: true},
+		{authority: "API.GitHub.com:443", allowed: true},
+		{authority: "api.github.com.evil.com", allowed: false},
+		{authority: "apixgithub.com", allowed: false},
+		{authority: "s3.us-east-1.amazonaws.com:443", allowed: true},
+		{authority: "amazonaws.com", allowed: false},
+		{authority: "example.org", allowed: true},
+		{authority: "api.example.org:443", allowed: true},
+		// suffixes start at a label boundary
+		{authority: "evilexample.org", allowed: false},
+		{authority: "evil.amazonaws.com.evil", allowed: false},
+		{authority: "db.internal", allowed: true},
+		{authority: "other.example.com:8080", allowed: true},
+		// the alternatives of the regular expression are grouped
+		{authority: "db.internal.evil.com", allowed: false},
+		{authority: "evil.com/other.example.com", allowed: false},
+		{authority: "api.github.com:", allowed: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.authority, func(t *testing.T) {
+			assert.Equal(t, tt.allowed, allowed(tt.authority))
+		})
+	}
+}
+
+func TestBuildDfpAllowlistInvalidRegularExpression(t *testing.T) {
+	_, err := buildDfpAllowlist([]kgateway.DynamicForwardProxyHostMatch{
+		{Value: "api.github.com"},
+		{Type: kgateway.DynamicForwardProxyHostMatchRegularExpression, Value: "[a-z"},
+	})
+	require.ErrorContains(t, err, "invalid allowedHosts[1] regular expression")
+}
+
+func TestBuildDfpDenyResponse(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		out := buildDfpDenyResponse(nil)
+		assert.Equal(t, dfpDenyScriptName, out.GetName())
+		assert.Equal(t, "403", out.GetFilterContext().GetFields()["status"].GetStringValue())
+		assert.Equal(t, defaultDfpDenyBody, out.GetFilterContext().GetFields()["body"].GetStringValue())
+	})
+
+	t.Run("custom response", func(t *testing.T) {
+		out := buildDfpDenyResponse(&kgateway.DynamicForwardProxyDenyResponse{
+			StatusCode: ptr.To(int32(451)),
+			Body:       ptr.To("egress to this destination is not permitted"),
+		})
+		assert.Equal(t, "451", out.GetFilterContext().GetFields()["status"].GetStringValue())
+		assert.Equal(t, "egress to this destination is not permitted", out.GetFilterContext().GetFields()["body"].GetStringValue())
+	})
+}
+
+func TestApplyForBackendDfp(t *testing.T) {
+	backend := func(in *kgateway.DynamicForwardProxyBackend) *ir.BackendObjectIR {
+		dfpIr, err := buildDfpIr(in)
+		require.NoError(t, err)
+		be := ir.NewBackendObjectIR(ir.ObjectSource{Kind: "Backend", Namespace: "default", Name: "egress"}, 0, "")
+		be.Obj = &kgateway.Backend{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "egress"},
+			Spec:       kgateway.BackendSpec{Type: kgateway.BackendTypeDynamicForwardProxy},
+		}
+		be.ObjIr = &backendIr{dfpIr: dfpIr}
+		return &be
+	}
+
+	t.Run("allowed hosts", func(t *testing.T) {
+		p := &backendPlugin{}
+		pCtx := &ir.RouteBackendContext{
+			FilterChainName:   "listener~80",
+			Backend:           backend(&kgateway.DynamicForwardProxyBackend{AllowedHosts: []kgateway.DynamicForwardProxyHostMatch{{Value: "api.github.com"}}}),
+			TypedFilterConfig: ir.TypedFilterConfigMap{},
+		}
+		require.NoError(t, p.ApplyForBackend(pCtx, ir.HttpBackend{}, &envoyroutev3.Route{}))
+
+		assert.True(t, p.needsDfpFilter["listener~80"])
+		assert.True(t, p.needsDfpAllowlist["listener~80"])
+		assert.Contains(t, pCtx.TypedFilterConfig, dfpAllowlistRbacFilterName)
+		assert.Contains(t, pCtx.TypedFilterConfig, dfpAllowlistLuaFilterName)
+		// the allowlist checks the host of the request, which the route cannot rewrite
+		require.ErrorContains(t, pCtx.HostRewriteError, "the host cannot be rewritten on routes to the Backend default/egress")
+	})
+
+	t.Run("any host", func(t *testing.T) {
+		p := &backendPlugin{}
+		pCtx := &ir.RouteBackendContext{
+			FilterChainName:   "listener~80",
+			Backend:           backend(&kgateway.DynamicForwardProxyBackend{}),
+			TypedFilterConfig: ir.TypedFilterConfigMap{},
+		}
+		require.NoError(t, p.ApplyForBackend(pCtx, ir.HttpBackend{}, &envoyroutev3.Route{}))
+
+		assert.True(t, p.needsDfpFilter["listener~80"])
+		assert.False(t, p.needsDfpAllowlist["listener~80"])
+		assert.Empty(t, pCtx.TypedFilterConfig)
+		assert.NoError(t, pCtx.HostRewriteError)
+	})
+}
@@ -3,19 +3,19 @@ package backend
 import (
 	"context"
 	"errors"
+	"fmt"
+	"maps"
+	"slices"
 
 	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
-	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
-	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
-	envoywellknown "github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"istio.io/istio/pkg/kube/kclient"
 	"istio.io/istio/pkg/kube/krt"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/ptr"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
-	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
 	"github.com/kgateway-dev/kgateway/v2/pkg/logging"
@@ -37,6 +37,7 @@ type backendIr struct {
 	awsIr    *AwsIr
 	staticIr *StaticIr
 	dfpIr    *DfpIr
+	redisIr  *RedisIr
 	errors   []error
 }
 
@@ -57,6 +58,10 @@ func (u *backendIr) Equals(other any) bool {
 	if !u.dfpIr.Equals(otherBackend.dfpIr) {
 		return false
 	}
+	// Redis
+	if !u.redisIr.Equals(otherBackend.redisIr) {
+		return false
+	}
 	return true
 }
 
@@ -98,6 +103,7 @@ func NewPlugin(commoncol *collections.CommonCollections) sdk.Plugin {
 	endpoints := krt.NewCollection(col, func(krtctx krt.HandlerContext, i *kgateway.Backend) *ir.EndpointsForBackend {
 		return processEndpoints(i)
 	})
+	statusCol := buildStatusCollection(bcol, commoncol.BackendHealth, commoncol.KrtOpts)
 	return sdk.Plugin{
 		ContributesBackends: map[schema.GroupKind]sdk.BackendPlugin{
 			gk: {
@@ -115,7 +121,7 @@ func NewPlugin(commoncol *collections.CommonCollections) sdk.Plugin {
 			},
 		},
 		ContributesLeaderAction: map[schema.GroupKind]func(){
-			wellknown.BackendGVK.GroupKind(): buildRegisterCallback(cli, bcol),
+			wellknown.BackendGVK.GroupKind(): buildRegisterCallback(cli, statusCol),
 		},
 	}
 }
@@ -140,37 +146,24 @@ func buildTranslateFunc(
 				beIr.errors = append(beIr.errors, err)
 			}
 			beIr.dfpIr = dfpIr
-		case kgateway.BackendTypeAWS:
-			region := i.Spec.Aws.Region
-			invokeMode := getLambdaInvocationMode(i.Spec.Aws)
-
-			lambdaArn, err := buildLambdaARN(i.Spec.Aws, region)
-			if err != nil {
-				beIr.errors = append(beIr.errors, err)
-			}
-
-			endpointConfig, err := configureLambdaEndpoint(i.Spec.Aws)
-			if err != nil {
-				beIr.errors = append(beIr.errors, err)
-			}
-
-			var lambdaTransportSocket *envoycorev3.TransportSocket
-			if endpointConfig.useTLS {
-				// TODO(yuval-k): Add verification context
-				typedConfig, err := utils.MessageToAny(&envoytlsv3.UpstreamTlsContext{
-					Sni: endpointConfig.hostname,
-				})
+		case kgateway.BackendTypeRedis:
+			var secret *ir.Secret
+			if i.Spec.Redis.AuthSecretRef != nil {
+				var err error
+				secret, err = secrets.GetSecretWithoutRefGrant(krtctx, i.Spec.Redis.AuthSecretRef.Name, i.GetNamespace())
 				if err != nil {
 					beIr.errors = append(beIr.errors, err)
 				}
-				lambdaTransportSocket = &envoycorev3.TransportSocket{
-					Name: envoywellknown.TransportSocketTls,
-					ConfigType: &envoycorev3.TransportSocket_TypedConfig{
-						TypedConfig: typedConfig,
-					},
+			}
+			// without the secret, the proxy would connect to the Redis servers unauthenticated
+			if secret != nil || i.Spec.Redis.AuthSecretRef == nil {
+				redisIr, err := buildRedisIr(i.Spec.Redis, secret)
+				if err != nil {
+					beIr.errors = append(beIr.errors, err)
 				}
+				beIr.redisIr = redisIr
 			}
-
+		case kgateway.BackendTypeAWS:
 			var secret *ir.Secret
 			if i.Spec.Aws.Auth != nil && i.Spec.Aws.Auth.Type == kgateway.AwsAuthTypeSecret {
 				var err error
@@ -180,17 +173,9 @@ func buildTranslateFunc(
 				}
 			}
 
-			lambdaFilters, err := buildLambdaFilters(
-				lambdaArn, region, secret, invokeMode, i.Spec.Aws.Lambda.PayloadTransformMode)
-			if err != nil {
-				beIr.errors = append(beIr.errors, err)
-			}
-
-			beIr.awsIr = &AwsIr{
-				lambdaEndpoint:        endpointConfig,
-				lambdaTransportSocket: lambdaTransportSocket,
-				lambdaFilters:         lambdaFilters,
-			}
+			awsIr, errs := buildAwsIr(i.Spec.Aws, secret)
+			beIr.errors = append(beIr.errors, errs...)
+			beIr.awsIr = awsIr
 		}
 		return &beIr
 	}
@@ -221,6 +206,8 @@ func processBackendForEnvoy(ctx context.Context, in ir.BackendObjectIR, out *env
 		}
 	case kgateway.BackendTypeDynamicForwardProxy:
 		processDynamicForwardProxy(beIr.dfpIr, out)
+	case kgateway.BackendTypeRedis:
+		processRedis(beIr.redisIr, out)
 	}
 	return nil
 }
@@ -261,6 +248,10 @@ func processEndpoints(be *kgateway.Backend) *ir.EndpointsForBackend {
 type backendPlugin struct {
 	ir.UnimplementedProxyTranslationPass
 	needsDfpFilter map[string]bool
+	// needsDfpAllowlist records the filter chains with routes to DFP backends that restrict their hosts.
+	needsDfpAllowlist map[string]bool
+	// redisClusters are the clusters of the prefix routes and mirrors of the Redis backends, by name.
+	redisClusters map[string]*envoyclusterv3.Cluster
 }
 
 var _ ir.ProxyTranslationPass = &backendPlugin{}
@@ -281,6 +272,18 @@ func (p *backendPlugin) ApplyForBackend(pCtx *ir.RouteBackendContext, in ir.Http
 			p.needsDfpFilter = make(map[string]bool)
 		}
 		p.needsDfpFilter[pCtx.FilterChainName] = true
+
+		if beIr, ok := pCtx.Backend.ObjIr.(*backendIr); ok && beIr.dfpIr != nil && beIr.dfpIr.allowlistRbac != nil {
+			if p.needsDfpAllowlist == nil {
+				p.needsDfpAllowlist = make(map[string]bool)
+			}
+			p.needsDfpAllowlist[pCtx.FilterChainName] = true
+			pCtx.TypedFilterConfig.AddTypedConfig(dfpAllowlistRbacFilterName, beIr.dfpIr.allowlistRbac)
+			pCtx.TypedFilterConfig.AddTypedConfig(dfpAllowlistLuaFilterName, beIr.dfpIr.denyLua)
+			// the allowlist checks the host of the request, so the host the proxy resolves must be the same
+			pCtx.HostRewriteError = fmt.Errorf("the host cannot be rewritten on routes to the Backend %s/%s, which restricts the allowed hosts",
+				backend.Namespace, backend.Name)
+		}
 	default:
 		return nil
 	}
@@ -288,6 +291,34 @@ func (p *backendPlugin) ApplyForBackend(pCtx *ir.RouteBackendContext, in ir.Http
 	return nil
 }
 
+// ApplyForTcpBackend replaces the TcpProxy filter with the redis_proxy filter for Redis backends.
+// Invalid Redis backends return an error, so that the filter chain does not proxy the raw TCP
+// stream to the Redis servers.
+func (p *backendPlugin) ApplyForTcpBackend(pCtx *ir.TcpBackendContext) (*envoylistenerv3.Filter, error) {
+	beIr, ok := pCtx.Backend.ObjIr.(*backendIr)
+	if !ok {
+		return nil, nil
+	}
+	if beIr.redisIr == nil {
+		if be, ok := pCtx.Backend.Obj.(*kgateway.Backend); ok && be.Spec.Type == kgateway.BackendTypeRedis {
+			return nil, fmt.Errorf("invalid Redis backend %s/%s: %w", be.GetNamespace(), be.GetName(), errors.Join(beIr.errors...))
+		}
+		return nil, nil
+	}
+
+	filter, clusters, err := redisProxyFilter(beIr.redisIr, pCtx.Backend.ClusterName(), pCtx.FilterChainName)
+	if err != nil {
+		return nil, err
+	}
+	if p.redisClusters == nil {
+		p.redisClusters = make(map[string]*envoyclusterv3.Cluster)
+	}
+	for _, c := range clusters {
+		p.redisClusters[c.GetName()] = c
+	}
+	return filter, nil
+}
+
 // called 1 time per listener
 // if a plugin emits new filters, they must be with a plugin unique name.
 // any filter returned from route config must be disabled, so it doesnt impact other routes.
@@ -300,10 +331,25 @@ func (p *backendPlugin) HttpFilters(_ ir.HttpFiltersContext, fc ir.FilterChainCo
 		f := filters.MustNewStagedFilter("envoy.filters.http.dynamic_forward_proxy", dfpFilterConfig, pluginStage)
 		result = append(result, f)
 	}
+	if p.needsDfpAllowlist[fc.FilterChainName] {
+		// both filters are only enabled on the routes to DFP backends with allowed hosts, and must run
+		// before the DFP filter resolves the destination host.
+		rbacFilter := filters.MustNewStagedFilter(dfpAllowlistRbacFilterName, dfpAllowlistRbacFilterConfig,
+			filters.RelativeToStage(filters.OutAuthStage, -2))
+		rbacFilter.Filter.Disabled = true
+		luaFilter := filters.MustNewStagedFilter(dfpAllowlistLuaFilterName, dfpAllowlistLuaFilterConfig,
+			filters.BeforeStage(filters.OutAuthStage))
+		luaFilter.Filter.Disabled = true
+		result = append(result, rbacFilter, luaFilter)
+	}
 	return result, errors.Join(errs...)
 }
 
 // called 1 time (per envoy proxy). replaces GeneratedResources
 func (p *backendPlugin) ResourcesToAdd() ir.Resources {
-	return ir.Resources{}
+	var res ir.Resources
+	for _, name := range slices.Sorted(maps.Keys(p.redisClusters)) {
+		res.Clusters = append(res.Clusters, p.redisClusters[name])
+	}
+	return res
 }
@@ -0,0 +1,288 @@
+package backend
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoyredisclusterv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/redis/v3"
+	envoyredisv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/redis_proxy/v3"
+	envoytypev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"k8s.io/utils/ptr"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/cmputils"
+)
+
+const (
+	redisClusterTypeName  = "envoy.clusters.redis"
+	defaultRedisOpTimeout = 5 * time.Second
+	// defaultRedisConnectTimeout is the connect timeout of the clusters of the prefix routes and mirrors,
+	// which matches the default of the backend clusters.
+	defaultRedisConnectTimeout = 5 * time.Second
+
+	redisSecretPasswordKey = "password"
+	redisSecretUsernameKey = "username"
+)
+
+var redisReadPolicies = map[kgateway.RedisReadPolicy]envoyredisv3.RedisProxy_ConnPoolSettings_ReadPolicy{
+	kgateway.RedisReadPolicyPrimary:       envoyredisv3.RedisProxy_ConnPoolSettings_MASTER,
+	kgateway.RedisReadPolicyPreferPrimary: envoyredisv3.RedisProxy_ConnPoolSettings_PREFER_MASTER,
+	kgateway.RedisReadPolicyReplica:       envoyredisv3.RedisProxy_ConnPoolSettings_REPLICA,
+	kgateway.RedisReadPolicyPreferReplica: envoyredisv3.RedisProxy_ConnPoolSettings_PREFER_REPLICA,
+	kgateway.RedisReadPolicyAny:           envoyredisv3.RedisProxy_ConnPoolSettings_ANY,
+}
+
+// RedisIr is the internal representation of a Redis backend.
+type RedisIr struct {
+	// upstream holds the discovery settings of the backend cluster, which serves the catch-all route.
+	upstream *envoyclusterv3.Cluster
+	// proxy is the redis_proxy filter config. The cluster names of its prefix routes and mirrors are
+	// suffixes of the backend cluster name, which is only known when translating the filter chain.
+	proxy *envoyredisv3.RedisProxy
+	// routeClusters are the clusters of the prefix routes and mirrors, named with the same suffixes.
+	routeClusters []*envoyclusterv3.Cluster
+}
+
+// Equals checks if two RedisIr objects are equal.
+func (u *RedisIr) Equals(other any) bool {
+	otherRedis, ok := other.(*RedisIr)
+	if !ok {
+		return false
+	}
+	return cmputils.CompareWithNils(u, otherRedis, func(a, b *RedisIr) bool {
+		return proto.Equal(a.upstream, b.upstream) &&
+			proto.Equal(a.proxy, b.proxy) &&
+			slices.EqualFunc(a.routeClusters, b.routeClusters, func(x, y *envoyclusterv3.Cluster) bool {
+				return proto.Equal(x, y)
+			})
+	})
+}
+
+func buildRedisIr(in *kgateway.RedisBackend, secret *ir.Secret) (*RedisIr, error) {
+	var protocolOptions *anypb.Any
+	if secret != nil {
+		var err error
+		protocolOptions, err = buildRedisProtocolOptions(secret)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	upstream, err := buildRedisUpstreamCluster(in.RedisUpstream, protocolOptions)
+	if err != nil {
+		return nil, err
+	}
+	out := &RedisIr{
+		upstream: upstream,
+	}
+
+	opTimeout := defaultRedisOpTimeout
+	if in.OpTimeout != nil {
+		opTimeout = in.OpTimeout.Duration
+	}
+	settings := &envoyredisv3.RedisProxy_ConnPoolSettings{
+		OpTimeout:          durationpb.New(opTimeout),
+		EnableCommandStats: ptr.Deref(in.EnableCommandStats, false),
+		// follow the MOVED and ASK redirections of Redis Cluster nodes
+		EnableRedirection: in.Cluster != nil,
+	}
+	if in.ReadPolicy != nil {
+		settings.ReadPolicy = redisReadPolicies[*in.ReadPolicy]
+	}
+
+	prefixRoutes := &envoyredisv3.RedisProxy_PrefixRoutes{
+		CaseInsensitive: ptr.Deref(in.CaseInsensitivePrefixes, false),
+		// an empty cluster name is resolved to the backend cluster
+		CatchAllRoute: &envoyredisv3.RedisProxy_PrefixRoutes_Route{},
+	}
+	for i, route := range in.PrefixRoutes {
+		routeCluster, err := buildRedisUpstreamCluster(route.RedisUpstream, protocolOptions)
+		if err != nil {
+			return nil, fmt.Errorf("invalid prefix route %q: %w", route.Prefix, err)
+		}
+		routeCluster.Name = fmt.Sprintf("redis_route_%d", i)
+		out.routeClusters = append(out.routeClusters, routeCluster)
+		settings.EnableRedirection = settings.GetEnableRedirection() || route.Cluster != nil
+
+		outRoute := &envoyredisv3.RedisProxy_PrefixRoutes_Route{
+			Prefix:       route.Prefix,
+			RemovePrefix: ptr.Deref(route.RemovePrefix, false),
+			Cluster:      routeCluster.GetName(),
+		}
+		for j, mirror := range route.Mirrors {
+			mirrorCluster, err := buildRedisUpstreamCluster(mirror.RedisUpstream, protocolOptions)
+			if err != nil {
+				return nil, fmt.Errorf("invalid mirror of prefix route %q: %w", route.Prefix, err)
+			}
+			mirrorCluster.Name = fmt.Sprintf("%s_mirror_%d", routeCluster.GetName(), j)
+			out.routeClusters = append(out.routeClusters, mirrorCluster)
+			settings.EnableRedirection = settings.GetEnableRedirection() || mirror.Cluster != nil
+
+			mirrorPolicy := &envoyredisv3.RedisProxy_PrefixRoutes_Route_RequestMirrorPolicy{
+				Cluster:             mirrorCluster.GetName(),
+				ExcludeReadCommands: ptr.Deref(mirror.ExcludeReadCommands, false),
+			}
+			if mirror.Percentage != nil {
+				mirrorPolicy.RuntimeFraction = &envoycorev3.RuntimeFractionalPercent{
+					DefaultValue: &envoytypev3.FractionalPercent{
+						Numerator:   uint32(*mirror.Percentage), // nolint:gosec // G115: kubebuilder validation ensures 0 <= value <= 100
+						Denominator: envoytypev3.FractionalPercent_HUNDRED,
+					},
+				}
+			}
+			outRoute.RequestMirrorPolicy = append(outRoute.GetRequestMirrorPolicy(), mirrorPolicy)
+		}
+		prefixRoutes.Routes = append(prefixRoutes.GetRoutes(), outRoute)
+	}
+
+	out.proxy = &envoyredisv3.RedisProxy{
+		Settings:     settings,
+		PrefixRoutes: prefixRoutes,
+	}
+	return out, nil
+}
+
+// buildRedisProtocolOptions builds the protocol options that authenticate the proxy to the Redis servers.
+func buildRedisProtocolOptions(secret *ir.Secret) (*anypb.Any, error) {
+	password, ok := secret.Data[redisSecretPasswordKey]
+	if !ok || len(password) == 0 {
+		return nil, fmt.Errorf("secret %s/%s is missing the %q key", secret.Namespace, secret.Name, redisSecretPasswordKey)
+	}
+	opts := &envoyredisv3.RedisProtocolOptions{
+		AuthPassword: &envoycorev3.DataSource{
+			Specifier: &envoycorev3.DataSource_InlineBytes{InlineBytes: password},
+		},
+	}
+	if username, ok := secret.Data[redisSecretUsernameKey]; ok && len(username) > 0 {
+		opts.AuthUsername = &envoycorev3.DataSource{
+			Specifier: &envoycorev3.DataSource_InlineBytes{InlineBytes: username},
+		}
+	}
+	return utils.MessageToAny(opts)
+}
+
+// buildRedisUpstreamCluster builds an unnamed cluster for the hosts of the upstream.
+func buildRedisUpstreamCluster(in kgateway.RedisUpstream, protocolOptions *anypb.Any) (*envoyclusterv3.Cluster, error) {
+	staticIr, err := buildStaticIr(&kgateway.StaticBackend{Hosts: in.Hosts})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &envoyclusterv3.Cluster{
+		LoadAssignment: staticIr.loadAssignment,
+	}
+	if in.Cluster == nil {
+		out.ClusterDiscoveryType = &envoyclusterv3.Cluster_Type{
+			Type: staticIr.clusterType,
+		}
+	} else {
+		// the hosts are the seeds used to discover the topology of the Redis Cluster
+		config := &envoyredisclusterv3.RedisClusterConfig{}
+		if in.Cluster.RefreshRate != nil {
+			config.ClusterRefreshRate = durationpb.New(in.Cluster.RefreshRate.Duration)
+		}
+		if in.Cluster.RefreshTimeout != nil {
+			config.ClusterRefreshTimeout = durationpb.New(in.Cluster.RefreshTimeout.Duration)
+		}
+		typedConfig, err := utils.MessageToAny(config)
+		if err != nil {
+			return nil, err
+		}
+		out.ClusterDiscoveryType = &envoyclusterv3.Cluster_ClusterType{
+			ClusterType: &envoyclusterv3.Cluster_CustomClusterType{
+				Name:        redisClusterTypeName,
+				TypedConfig: typedConfig,
+			},
+		}
+		out.LbPolicy = envoyclusterv3.Cluster_CLUSTER_PROVIDED
+	}
+	if protocolOptions != nil {
+		out.TypedExtensionProtocolOptions = map[string]*anypb.Any{
+			wellknown.RedisProxy: protocolOptions,
+		}
+	}
+	return out, nil
+}
+
+// processRedis applies the Redis IR to the envoy cluster of the backend.
+func processRedis(ir *RedisIr, out *envoyclusterv3.Cluster) {
+	if ir == nil {
+		return
+	}
+	applyRedisUpstream(ir.upstream, out)
+}
+
+// applyRedisUpstream applies the discovery settings of a Redis upstream to the envoy cluster.
+func applyRedisUpstream(upstream *envoyclusterv3.Cluster, out *envoyclusterv3.Cluster) {
+	// clone needed to avoid modifying the IR when setting the cluster name.
+	upstream = proto.Clone(upstream).(*envoyclusterv3.Cluster)
+	out.ClusterDiscoveryType = upstream.GetClusterDiscoveryType()
+	out.LbPolicy = upstream.GetLbPolicy()
+	if upstream.GetLoadAssignment() != nil {
+		out.LoadAssignment = upstream.GetLoadAssignment()
+		out.LoadAssignment.ClusterName = out.GetName()
+	}
+	for name, opts := range upstream.GetTypedExtensionProtocolOptions() {
+		if out.GetTypedExtensionProtocolOptions() == nil {
+			out.TypedExtensionProtocolOptions = map[string]*anypb.Any{}
+		}
+		out.TypedExtensionProtocolOptions[name] = opts
+	}
+}
+
+// redisProxyFilter returns the redis_proxy network filter for the backend cluster,
+// and the clusters of its prefix routes and mirrors.
+func redisProxyFilter(
+	ir *RedisIr,
+	clusterName string,
+	statPrefix string,
+) (*envoylistenerv3.Filter, []*envoyclusterv3.Cluster, error) {
+	resolve := func(suffix string) string {
+		if suffix == "" {
+			return clusterName
+		}
+		return clusterName + "_" + suffix
+	}
+
+	proxy := proto.Clone(ir.proxy).(*envoyredisv3.RedisProxy)
+	proxy.StatPrefix = statPrefix
+	routes := append(proxy.GetPrefixRoutes().GetRoutes(), proxy.GetPrefixRoutes().GetCatchAllRoute())
+	for _, route := range routes {
+		route.Cluster = resolve(route.GetCluster())
+		for _, mirror := range route.GetRequestMirrorPolicy() {
+			mirror.Cluster = resolve(mirror.GetCluster())
+		}
+	}
+
+	clusters := make([]*envoyclusterv3.Cluster, 0, len(ir.routeClusters))
+	for _, routeCluster := range ir.routeClusters {
+		out := &envoyclusterv3.Cluster{
+			Name:           resolve(routeCluster.GetName()),
+			ConnectTimeout: durationpb.New(defaultRedisConnectTimeout),
+		}
+		applyRedisUpstream(routeCluster, out)
+		clusters = append(clusters, out)
+	}
+
+	typedConfig, err := utils.MessageToAny(proxy)
+	if err != nil {
+		return nil, nil, err
+	}
+	filter := &envoylistenerv3.Filter{
+		Name: wellknown.RedisProxy,
+		ConfigType: &envoylistenerv3.Filter_TypedConfig{
+			TypedConfig: typedConfig,
+		},
+	}
+	return filter, clusters, nil
+}
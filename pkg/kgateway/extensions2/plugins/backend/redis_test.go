@@ -0,0 +1,238 @@
+package backend
+
+import (
+	"testing"
+	"time"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoyredisclusterv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/redis/v3"
+	envoyredisv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/redis_proxy/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func redisUpstream(host string) kgateway.RedisUpstream {
+	return kgateway.RedisUpstream{Hosts: []kgateway.Host{{Host: host, Port: 6379}}}
+}
+
+func TestBuildRedisIr(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		out, err := buildRedisIr(&kgateway.RedisBackend{RedisUpstream: redisUpstream("10.0.0.1")}, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, envoyclusterv3.Cluster_STATIC, out.upstream.GetType())
+		assert.Empty(t, out.upstream.GetTypedExtensionProtocolOptions())
+		assert.Empty(t, out.routeClusters)
+
+		settings := out.proxy.GetSettings()
+		assert.Equal(t, defaultRedisOpTimeout, settings.GetOpTimeout().AsDuration())
+		assert.False(t, settings.GetEnableRedirection())
+		assert.Equal(t, envoyredisv3.RedisProxy_ConnPoolSettings_MASTER, settings.GetReadPolicy())
+		assert.Empty(t, out.proxy.GetPrefixRoutes().GetRoutes())
+		assert.Empty(t, out.proxy.GetPrefixRoutes().GetCatchAllRoute().GetCluster())
+	})
+
+	t.Run("redis cluster", func(t *testing.T) {
+		in := &kgateway.RedisBackend{
+			RedisUpstream: kgateway.RedisUpstream{
+				Hosts:   []kgateway.Host{{Host: "redis.example.com", Port: 6379}},
+				Cluster: &kgateway.RedisCluster{RefreshRate: &metav1.Duration{Duration: 10 * time.Second}},
+			},
+			ReadPolicy: ptr.To(kgateway.RedisReadPolicyPreferReplica),
+		}
+		out, err := buildRedisIr(in, nil)
+		require.NoError(t, err)
+
+		clusterType := out.upstream.GetClusterType()
+		require.NotNil(t, clusterType)
+		assert.Equal(t, redisClusterTypeName, clusterType.GetName())
+		config := &envoyredisclusterv3.RedisClusterConfig{}
+		require.NoError(t, clusterType.GetTypedConfig().UnmarshalTo(config))
+		assert.Equal(t, 10*time.Second, config.GetClusterRefreshRate().AsDuration())
+		assert.Equal(t, envoyclusterv3.Cluster_CLUSTER_PROVIDED, out.upstream.GetLbPolicy())
+
+		settings := out.proxy.GetSettings()
+		assert.True(t, settings.GetEnableRedirection())
+		assert.Equal(t, envoyredisv3.RedisProxy_ConnPoolSettings_PREFER_REPLICA, settings.GetReadPolicy())
+	})
+
+	t.Run("prefix routes and mirrors", func(t *testing.T) {
+		in := &kgateway.RedisBackend{
+			RedisUpstream: redisUpstream("10.0.0.1"),
+			PrefixRoutes: []kgateway.RedisPrefixRoute{{
+				Prefix:        "user:",
+				RemovePrefix:  ptr.To(true),
+				RedisUpstream: redisUpstream("10.0.0.2"),
+				Mirrors: []kgateway.RedisRequestMirror{{
+					RedisUpstream: kgateway.RedisUpstream{
+						Hosts:   []kgateway.Host{{Host: "10.0.0.3", Port: 6379}},
+						Cluster: &kgateway.RedisCluster{},
+					},
+					ExcludeReadCommands: ptr.To(true),
+					Percentage:          ptr.To(int32(25)),
+				}},
+			}},
+		}
+		out, err := buildRedisIr(in, nil)
+		require.NoError(t, err)
+
+		require.Len(t, out.routeClusters, 2)
+		assert.Equal(t, "redis_route_0", out.routeClusters[0].GetName())
+		assert.Equal(t, "redis_route_0_mirror_0", out.routeClusters[1].GetName())
+		// a mirror to a Redis Cluster follows the redirections
+		assert.True(t, out.proxy.GetSettings().GetEnableRedirection())
+
+		routes := out.proxy.GetPrefixRoutes().GetRoutes()
+		require.Len(t, routes, 1)
+		assert.Equal(t, "user:", routes[0].GetPrefix())
+		assert.True(t, routes[0].GetRemovePrefix())
+		assert.Equal(t, "redis_route_0", routes[0].GetCluster())
+		require.Len(t, routes[0].GetRequestMirrorPolicy(), 1)
+		mirror := routes[0].GetRequestMirrorPolicy()[0]
+		assert.Equal(t, "redis_route_0_mirror_0", mirror.GetCluster())
+		assert.True(t, mirror.GetExcludeReadCommands())
+		assert.Equal(t, uint32(25), mirror.GetRuntimeFraction().GetDefaultValue().GetNumerator())
+	})
+
+	t.Run("invalid prefix route", func(t *testing.T) {
+		in := &kgateway.RedisBackend{
+			RedisUpstream: redisUpstream("10.0.0.1"),
+			PrefixRoutes: []kgateway.RedisPrefixRoute{{
+				Prefix:        "user:",
+				RedisUpstream: kgateway.RedisUpstream{Hosts: []kgateway.Host{{Host: "10.0.0.2"}}},
+			}},
+		}
+		_, err := buildRedisIr(in, nil)
+		require.ErrorContains(t, err, `invalid prefix route "user:"`)
+	})
+}
+
+func TestBuildRedisIrAuth(t *testing.T) {
+	secret := func(data map[string][]byte) *ir.Secret {
+		return &ir.Secret{
+			ObjectSource: ir.ObjectSource{Namespace: "default", Name: "redis-auth"},
+			Data:         data,
+		}
+	}
+	in := &kgateway.RedisBackend{
+		RedisUpstream: redisUpstream("10.0.0.1"),
+		PrefixRoutes: []kgateway.RedisPrefixRoute{{
+			Prefix:        "user:",
+			RedisUpstream: redisUpstream("10.0.0.2"),
+		}},
+	}
+
+	t.Run("password and username", func(t *testing.T) {
+		out, err := buildRedisIr(in, secret(map[string][]byte{
+			redisSecretPasswordKey: []byte("secret"),
+			redisSecretUsernameKey: []byte("proxy"),
+		}))
+		require.NoError(t, err)
+
+		// all the clusters authenticate to their Redis servers
+		for _, c := range append([]*envoyclusterv3.Cluster{out.upstream}, out.routeClusters...) {
+			opts := &envoyredisv3.RedisProtocolOptions{}
+			require.NoError(t, c.GetTypedExtensionProtocolOptions()[wellknown.RedisProxy].UnmarshalTo(opts))
+			assert.Equal(t, []byte("secret"), opts.GetAuthPassword().GetInlineBytes())
+			assert.Equal(t, []byte("proxy"), opts.GetAuthUsername().GetInlineBytes())
+		}
+	})
+
+	t.Run("missing password", func(t *testing.T) {
+		_, err := buildRedisIr(in, secret(map[string][]byte{redisSecretUsernameKey: []byte("proxy")}))
+		require.ErrorContains(t, err, `secret default/redis-auth is missing the "password" key`)
+	})
+}
+
+func TestRedisProxyFilter(t *testing.T) {
+	redisIr, err := buildRedisIr(&kgateway.RedisBackend{
+		RedisUpstream: redisUpstream("10.0.0.1"),
+		PrefixRoutes: []kgateway.RedisPrefixRoute{{
+			Prefix:        "user:",
+			RedisUpstream: redisUpstream("10.0.0.2"),
+			Mirrors:       []kgateway.RedisRequestMirror{{RedisUpstream: redisUpstream("10.0.0.3")}},
+		}},
+	}, nil)
+	require.NoError(t, err)
+
+	filter, clusters, err := redisProxyFilter(redisIr, "backend_default_redis_6379", "listener~6379")
+	require.NoError(t, err)
+
+	assert.Equal(t, wellknown.RedisProxy, filter.GetName())
+	proxy := &envoyredisv3.RedisProxy{}
+	require.NoError(t, filter.GetTypedConfig().UnmarshalTo(proxy))
+	assert.Equal(t, "listener~6379", proxy.GetStatPrefix())
+	prefixRoutes := proxy.GetPrefixRoutes()
+	assert.Equal(t, "backend_default_redis_6379", prefixRoutes.GetCatchAllRoute().GetCluster())
+	require.Len(t, prefixRoutes.GetRoutes(), 1)
+	assert.Equal(t, "backend_default_redis_6379_redis_route_0", prefixRoutes.GetRoutes()[0].GetCluster())
+	assert.Equal(t, "backend_default_redis_6379_redis_route_0_mirror_0", prefixRoutes.GetRoutes()[0].GetRequestMirrorPolicy()[0].GetCluster())
+
+	require.Len(t, clusters, 2)
+	for i, name := range []string{
+		"backend_default_redis_6379_redis_route_0",
+		"backend_default_redis_6379_redis_route_0_mirror_0",
+	} {
+		assert.Equal(t, name, clusters[i].GetName())
+		assert.Equal(t, name, clusters[i].GetLoadAssignment().GetClusterName())
+		assert.Equal(t, defaultRedisConnectTimeout, clusters[i].GetConnectTimeout().AsDuration())
+	}
+
+	// the IR is not modified
+	assert.Empty(t, redisIr.proxy.GetStatPrefix())
+	assert.Equal(t, "redis_route_0", redisIr.proxy.GetPrefixRoutes().GetRoutes()[0].GetCluster())
+	assert.Empty(t, redisIr.routeClusters[0].GetLoadAssignment().GetClusterName())
+}
+
+func TestApplyForTcpBackend(t *testing.T) {
+	backend := func(backendType kgateway.BackendType, beIr *backendIr) *ir.BackendObjectIR {
+		be := ir.NewBackendObjectIR(ir.ObjectSource{Kind: "Backend", Namespace: "default", Name: "redis"}, 6379, "")
+		be.Obj = &kgateway.Backend{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "redis"},
+			Spec:       kgateway.BackendSpec{Type: backendType},
+		}
+		be.ObjIr = beIr
+		return &be
+	}
+
+	t.Run("redis backend", func(t *testing.T) {
+		redisIr, err := buildRedisIr(&kgateway.RedisBackend{RedisUpstream: redisUpstream("10.0.0.1")}, nil)
+		require.NoError(t, err)
+
+		p := &backendPlugin{}
+		filter, err := p.ApplyForTcpBackend(&ir.TcpBackendContext{
+			FilterChainName: "listener~6379",
+			Backend:         backend(kgateway.BackendTypeRedis, &backendIr{redisIr: redisIr}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, wellknown.RedisProxy, filter.GetName())
+	})
+
+	t.Run("invalid redis backend", func(t *testing.T) {
+		p := &backendPlugin{}
+		filter, err := p.ApplyForTcpBackend(&ir.TcpBackendContext{
+			FilterChainName: "listener~6379",
+			Backend: backend(kgateway.BackendTypeRedis, &backendIr{
+				errors: []error{assert.AnError},
+			}),
+		})
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, filter)
+	})
+
+	t.Run("other backends keep the TcpProxy filter", func(t *testing.T) {
+		p := &backendPlugin{}
+		filter, err := p.ApplyForTcpBackend(&ir.TcpBackendContext{
+			FilterChainName: "listener~6379",
+			Backend:         backend(kgateway.BackendTypeStatic, &backendIr{}),
+		})
+		require.NoError(t, err)
+		assert.Nil(t, filter)
+	})
+}
@@ -12,24 +12,61 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
-	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/pluginutils"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
 )
 
+// backendStatus joins a Backend with the endpoint health reported for its cluster.
+type backendStatus struct {
+	backend ir.BackendObjectIR
+	health  *ir.BackendHealth
+}
+
+func (s backendStatus) ResourceName() string {
+	return s.backend.ResourceName()
+}
+
+func (s backendStatus) Equals(in backendStatus) bool {
+	if !s.backend.Equals(in.backend) {
+		return false
+	}
+	if s.health == nil || in.health == nil {
+		return s.health == in.health
+	}
+	return s.health.Equals(*in.health)
+}
+
+// buildStatusCollection joins the backends with the endpoint health of their clusters.
+// healthCol may be nil, in which case no health is reported.
+func buildStatusCollection(
+	bcol krt.Collection[ir.BackendObjectIR],
+	healthCol krt.Collection[ir.BackendHealth],
+	krtOpts krtutil.KrtOptions,
+) krt.Collection[backendStatus] {
+	return krt.NewCollection(bcol, func(kctx krt.HandlerContext, in ir.BackendObjectIR) *backendStatus {
+		res := &backendStatus{backend: in}
+		if healthCol != nil {
+			res.health = krt.FetchOne(kctx, healthCol, krt.FilterKey(in.ClusterName()))
+		}
+		return res
+	}, krtOpts.ToOptions("BackendStatus")...)
+}
+
 func buildRegisterCallback(
 	cl kclient.Client[*kgateway.Backend],
-	bcol krt.Collection[ir.BackendObjectIR],
+	scol krt.Collection[backendStatus],
 ) func() {
 	return func() {
-		bcol.Register(func(o krt.Event[ir.BackendObjectIR]) {
+		scol.Register(func(o krt.Event[backendStatus]) {
 			if o.Event == controllers.EventDelete {
 				return
 			}
-			in := o.Latest()
+			latest := o.Latest()
+			in, health := latest.backend, latest.health
 			ir, ok := in.ObjIr.(*backendIr)
 			if !ok {
 				return
@@ -48,22 +85,19 @@ func buildRegisterCallback(
 						return pluginsdk.ErrNotFound
 					}
 
-					newCondition := pluginutils.BuildCondition("Backend", ir.errors)
-
-					found := meta.FindStatusCondition(cur.Status.Conditions, string(gwv1.PolicyConditionAccepted))
-					if found != nil {
-						typeEq := found.Type == newCondition.Type
-						statusEq := found.Status == newCondition.Status
-						reasonEq := found.Reason == newCondition.Reason
-						messageEq := found.Message == newCondition.Message
-						if typeEq && statusEq && reasonEq && messageEq {
-							// condition is already up-to-date, nothing to do
-							return nil
-						}
+					newConditions := []metav1.Condition{pluginutils.BuildCondition("Backend", ir.errors)}
+					if health != nil {
+						newConditions = append(newConditions, pluginutils.BuildEndpointsHealthyCondition(*health))
+					}
+					if conditionsUpToDate(cur.Status.Conditions, newConditions) {
+						// conditions are already up-to-date, nothing to do
+						return nil
 					}
 
-					conditions := make([]metav1.Condition, 0, 1)
-					meta.SetStatusCondition(&conditions, newCondition)
+					conditions := make([]metav1.Condition, 0, len(newConditions))
+					for _, c := range newConditions {
+						meta.SetStatusCondition(&conditions, c)
+					}
 					if _, err := cl.UpdateStatus(&kgateway.Backend{
 						ObjectMeta: pluginsdk.CloneObjectMetaForStatus(cur.ObjectMeta),
 						Status: kgateway.BackendStatus{
@@ -92,3 +126,21 @@ func buildRegisterCallback(
 		})
 	}
 }
+
+// conditionsUpToDate returns true if cur holds exactly the desired conditions,
+// ignoring transition times.
+func conditionsUpToDate(cur, desired []metav1.Condition) bool {
+	if len(cur) != len(desired) {
+		return false
+	}
+	for _, d := range desired {
+		found := meta.FindStatusCondition(cur, d.Type)
+		if found == nil ||
+			found.Status != d.Status ||
+			found.Reason != d.Reason ||
+			found.Message != d.Message {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package backendconfigpolicy
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+
+	"istio.io/istio/pkg/kube/krt"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+// newProcessEndpoints returns an endpoints plugin that sets the locality weight settings
+// of the BackendConfigPolicy attached to the backend on `out`.
+// The settings depend on the locality of each proxy, so they cannot be applied to the cluster.
+func newProcessEndpoints(commoncol *collections.CommonCollections) sdk.EndpointPlugin {
+	gk := wellknown.BackendConfigPolicyGVK.GroupKind()
+	return func(
+		kctx krt.HandlerContext,
+		_ context.Context,
+		_ ir.UniqlyConnectedClient,
+		out *sdk.EndpointsInputs,
+	) uint64 {
+		// the backend index is initialized after the plugins are created
+		if commoncol.BackendIndex == nil {
+			return 0
+		}
+
+		backend := out.EndpointsForBackend.Backend
+		polAtts := commoncol.BackendIndex.PolicyIndex().LookupTargetingPolicies(kctx, backend, "", out.EndpointsForBackend.BackendLabels)
+
+		// policies are applied to the cluster in order, so the last one with locality settings wins
+		var selected *ir.PolicyAtt
+		for i, polAtt := range polAtts {
+			if polAtt.GroupKind != gk || len(polAtt.Errors) > 0 {
+				continue
+			}
+			pol, ok := polAtt.PolicyIr.(*BackendConfigPolicyIR)
+			if !ok || pol.loadBalancerConfig == nil || pol.loadBalancerConfig.localityLbInfo == nil {
+				continue
+			}
+			selected = &polAtts[i]
+		}
+		if selected == nil {
+			return 0
+		}
+
+		out.LocalityLbInfo = selected.PolicyIr.(*BackendConfigPolicyIR).loadBalancerConfig.localityLbInfo
+		hasher := fnv.New64()
+		if ref := selected.PolicyRef; ref != nil {
+			hasher.Write([]byte(ref.Namespace))
+			hasher.Write([]byte(ref.Name))
+		}
+		hasher.Write(fmt.Appendf(nil, "%v", selected.Generation))
+		return hasher.Sum64()
+	}
+}
@@ -2,6 +2,7 @@ package backendconfigpolicy
 
 import (
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
@@ -20,6 +21,24 @@ func translateHealthCheck(hc *kgateway.HealthCheck) *envoycorev3.HealthCheck {
 	healthCheck.UnhealthyThreshold = &wrapperspb.UInt32Value{Value: uint32(hc.UnhealthyThreshold)} // nolint:gosec // G115: kubebuilder validation ensures 0 <= value <= 4294967295, safe for uint32
 	healthCheck.HealthyThreshold = &wrapperspb.UInt32Value{Value: uint32(hc.HealthyThreshold)}     // nolint:gosec // G115: kubebuilder validation ensures 0 <= value <= 4294967295, safe for uint32
 
+	if hc.NoTrafficInterval != nil {
+		healthCheck.NoTrafficInterval = durationpb.New(hc.NoTrafficInterval.Duration)
+	}
+	if hc.UnhealthyInterval != nil {
+		healthCheck.UnhealthyInterval = durationpb.New(hc.UnhealthyInterval.Duration)
+	}
+	if hc.UnhealthyEdgeInterval != nil {
+		healthCheck.UnhealthyEdgeInterval = durationpb.New(hc.UnhealthyEdgeInterval.Duration)
+	}
+	if hc.HealthyEdgeInterval != nil {
+		healthCheck.HealthyEdgeInterval = durationpb.New(hc.HealthyEdgeInterval.Duration)
+	}
+	if hc.TLSOptions != nil {
+		healthCheck.TlsOptions = &envoycorev3.HealthCheck_TlsOptions{
+			AlpnProtocols: hc.TLSOptions.ALPNProtocols,
+		}
+	}
+
 	if hc.Http != nil {
 		httpHealthCheck := &envoycorev3.HealthCheck_HttpHealthCheck{
 			Path: hc.Http.Path,
@@ -30,6 +49,20 @@ func translateHealthCheck(hc *kgateway.HealthCheck) *envoycorev3.HealthCheck {
 		if hc.Http.Method != nil {
 			httpHealthCheck.Method = envoycorev3.RequestMethod(envoycorev3.RequestMethod_value[*hc.Http.Method])
 		}
+		for _, status := range hc.Http.ExpectedStatuses {
+			httpHealthCheck.ExpectedStatuses = append(httpHealthCheck.ExpectedStatuses, &typev3.Int64Range{
+				Start: int64(status.Start),
+				End:   int64(status.End),
+			})
+		}
+		for _, header := range hc.Http.RequestHeadersToAdd {
+			httpHealthCheck.RequestHeadersToAdd = append(httpHealthCheck.RequestHeadersToAdd, &envoycorev3.HeaderValueOption{
+				Header: &envoycorev3.HeaderValue{
+					Key:   string(header.Name),
+					Value: header.Value,
+				},
+			})
+		}
 		healthCheck.HealthChecker = &envoycorev3.HealthCheck_HttpHealthCheck_{
 			HttpHealthCheck: httpHealthCheck,
 		}
@@ -43,7 +76,26 @@ func translateHealthCheck(hc *kgateway.HealthCheck) *envoycorev3.HealthCheck {
 		if hc.Grpc.Authority != nil {
 			healthCheck.GetGrpcHealthCheck().Authority = *hc.Grpc.Authority
 		}
+	} else if hc.Tcp != nil {
+		tcpHealthCheck := &envoycorev3.HealthCheck_TcpHealthCheck{}
+		if hc.Tcp.Send != nil {
+			tcpHealthCheck.Send = hexPayload(*hc.Tcp.Send)
+		}
+		for _, receive := range hc.Tcp.Receive {
+			tcpHealthCheck.Receive = append(tcpHealthCheck.Receive, hexPayload(receive))
+		}
+		healthCheck.HealthChecker = &envoycorev3.HealthCheck_TcpHealthCheck_{
+			TcpHealthCheck: tcpHealthCheck,
+		}
 	}
 
 	return healthCheck
 }
+
+func hexPayload(hex string) *envoycorev3.HealthCheck_Payload {
+	return &envoycorev3.HealthCheck_Payload{
+		Payload: &envoycorev3.HealthCheck_Payload_Text{
+			Text: hex,
+		},
+	}
+}
@@ -5,11 +5,13 @@ import (
 	"time"
 
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 )
@@ -110,6 +112,10 @@ func TestTranslateHealthCheck(t *testing.T) {
 				Http: &kgateway.HealthCheckHttp{
 					Host: ptr.To("example.com"),
 					Path: "/health",
+					ExpectedStatuses: []kgateway.HealthCheckStatusRange{
+						{Start: 200, End: 205},
+						{Start: 300, End: 400},
+					},
 				},
 			},
 			expected: &envoycorev3.HealthCheck{
@@ -121,10 +127,108 @@ func TestTranslateHealthCheck(t *testing.T) {
 					HttpHealthCheck: &envoycorev3.HealthCheck_HttpHealthCheck{
 						Host: "example.com",
 						Path: "/health",
+						ExpectedStatuses: []*typev3.Int64Range{
+							{Start: 200, End: 205},
+							{Start: 300, End: 400},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "HTTP health check with request headers, intervals and TLS options",
+			config: &kgateway.HealthCheck{
+				Timeout:               metav1.Duration{Duration: 5 * time.Second},
+				Interval:              metav1.Duration{Duration: 10 * time.Second},
+				UnhealthyThreshold:    2,
+				HealthyThreshold:      3,
+				NoTrafficInterval:     &metav1.Duration{Duration: 30 * time.Second},
+				UnhealthyInterval:     &metav1.Duration{Duration: 20 * time.Second},
+				UnhealthyEdgeInterval: &metav1.Duration{Duration: time.Second},
+				HealthyEdgeInterval:   &metav1.Duration{Duration: 2 * time.Second},
+				TLSOptions: &kgateway.HealthCheckTLSOptions{
+					ALPNProtocols: []string{"http/1.1"},
+				},
+				Http: &kgateway.HealthCheckHttp{
+					Path: "/health",
+					RequestHeadersToAdd: []gwv1.HTTPHeader{
+						{Name: "x-health-check", Value: "true"},
+					},
+				},
+			},
+			expected: &envoycorev3.HealthCheck{
+				Timeout:               durationpb.New(5 * time.Second),
+				Interval:              durationpb.New(10 * time.Second),
+				UnhealthyThreshold:    &wrapperspb.UInt32Value{Value: 2},
+				HealthyThreshold:      &wrapperspb.UInt32Value{Value: 3},
+				NoTrafficInterval:     durationpb.New(30 * time.Second),
+				UnhealthyInterval:     durationpb.New(20 * time.Second),
+				UnhealthyEdgeInterval: durationpb.New(time.Second),
+				HealthyEdgeInterval:   durationpb.New(2 * time.Second),
+				TlsOptions: &envoycorev3.HealthCheck_TlsOptions{
+					AlpnProtocols: []string{"http/1.1"},
+				},
+				HealthChecker: &envoycorev3.HealthCheck_HttpHealthCheck_{
+					HttpHealthCheck: &envoycorev3.HealthCheck_HttpHealthCheck{
+						Path: "/health",
+						RequestHeadersToAdd: []*envoycorev3.HeaderValueOption{{
+							Header: &envoycorev3.HeaderValue{
+								Key:   "x-health-check",
+								Value: "true",
+							},
+						}},
+					},
+				},
+			},
+		},
+		{
+			name: "TCP health check with payloads",
+			config: &kgateway.HealthCheck{
+				Timeout:            metav1.Duration{Duration: 5 * time.Second},
+				Interval:           metav1.Duration{Duration: 10 * time.Second},
+				UnhealthyThreshold: 2,
+				HealthyThreshold:   1,
+				Tcp: &kgateway.HealthCheckTcp{
+					Send:    ptr.To("50494e470d0a"),
+					Receive: []string{"2b504f4e47"},
+				},
+			},
+			expected: &envoycorev3.HealthCheck{
+				Timeout:            durationpb.New(5 * time.Second),
+				Interval:           durationpb.New(10 * time.Second),
+				UnhealthyThreshold: &wrapperspb.UInt32Value{Value: 2},
+				HealthyThreshold:   &wrapperspb.UInt32Value{Value: 1},
+				HealthChecker: &envoycorev3.HealthCheck_TcpHealthCheck_{
+					TcpHealthCheck: &envoycorev3.HealthCheck_TcpHealthCheck{
+						Send: &envoycorev3.HealthCheck_Payload{
+							Payload: &envoycorev3.HealthCheck_Payload_Text{Text: "50494e470d0a"},
+						},
+						Receive: []*envoycorev3.HealthCheck_Payload{{
+							Payload: &envoycorev3.HealthCheck_Payload_Text{Text: "2b504f4e47"},
+						}},
 					},
 				},
 			},
 		},
+		{
+			name: "TCP connect only health check",
+			config: &kgateway.HealthCheck{
+				Timeout:            metav1.Duration{Duration: 5 * time.Second},
+				Interval:           metav1.Duration{Duration: 10 * time.Second},
+				UnhealthyThreshold: 2,
+				HealthyThreshold:   1,
+				Tcp:                &kgateway.HealthCheckTcp{},
+			},
+			expected: &envoycorev3.HealthCheck{
+				Timeout:            durationpb.New(5 * time.Second),
+				Interval:           durationpb.New(10 * time.Second),
+				UnhealthyThreshold: &wrapperspb.UInt32Value{Value: 2},
+				HealthyThreshold:   &wrapperspb.UInt32Value{Value: 1},
+				HealthChecker: &envoycorev3.HealthCheck_TcpHealthCheck_{
+					TcpHealthCheck: &envoycorev3.HealthCheck_TcpHealthCheck{},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -2,6 +2,7 @@ package backendconfigpolicy
 
 import (
 	"fmt"
+	"reflect"
 	"strconv"
 
 	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
@@ -17,8 +18,10 @@ import (
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
+	"k8s.io/utils/ptr"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/endpoints"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
 )
 
@@ -33,6 +36,8 @@ type LoadBalancerConfigIR struct {
 	commonLbConfig        *envoyclusterv3.Cluster_CommonLbConfig
 	loadBalancingPolicy   *envoyclusterv3.LoadBalancingPolicy
 	useHostnameForHashing bool
+	// localityLbInfo is applied to the endpoints of the backend rather than to the cluster
+	localityLbInfo *endpoints.LocalityLbInfo
 }
 
 func translateLoadBalancerConfig(config *kgateway.LoadBalancer, policyName, policyNamespace string) (*LoadBalancerConfigIR, error) {
@@ -54,6 +59,9 @@ func translateLoadBalancerConfig(config *kgateway.LoadBalancer, policyName, poli
 		out.commonLbConfig.CloseConnectionsOnHostSetChange = *config.CloseConnectionsOnHostSetChange
 	}
 
+	out.localityLbInfo = translateLocalityLbInfo(config)
+	localityWeighted := config.LocalityType != nil || out.localityLbInfo != nil
+
 	if config.LeastRequest != nil {
 		leastRequest := &envoyleastrequestv3.LeastRequest{
 			ChoiceCount: &wrapperspb.UInt32Value{
@@ -61,7 +69,7 @@ func translateLoadBalancerConfig(config *kgateway.LoadBalancer, policyName, poli
 			},
 			SlowStartConfig: toSlowStartConfig(config.LeastRequest.SlowStart, policyName, policyNamespace),
 		}
-		if config.LocalityType != nil {
+		if localityWeighted {
 			leastRequest.LocalityLbConfig = &envoycommonv3.LocalityLbConfig{
 				LocalityConfigSpecifier: &envoycommonv3.LocalityLbConfig_LocalityWeightedLbConfig_{
 					LocalityWeightedLbConfig: &envoycommonv3.LocalityLbConfig_LocalityWeightedLbConfig{},
@@ -84,7 +92,7 @@ func translateLoadBalancerConfig(config *kgateway.LoadBalancer, policyName, poli
 		roundRobin := &envoyroundrobinv3.RoundRobin{
 			SlowStartConfig: toSlowStartConfig(config.RoundRobin.SlowStart, policyName, policyNamespace),
 		}
-		if config.LocalityType != nil {
+		if localityWeighted {
 			roundRobin.LocalityLbConfig = &envoycommonv3.LocalityLbConfig{
 				LocalityConfigSpecifier: &envoycommonv3.LocalityLbConfig_LocalityWeightedLbConfig_{
 					LocalityWeightedLbConfig: &envoycommonv3.LocalityLbConfig_LocalityWeightedLbConfig{},
@@ -125,7 +133,7 @@ func translateLoadBalancerConfig(config *kgateway.LoadBalancer, policyName, poli
 			ringHash.ConsistentHashingLbConfig = hashingLBConfig
 		}
 
-		if config.LocalityType != nil {
+		if localityWeighted {
 			ringHash.LocalityWeightedLbConfig = &envoycommonv3.LocalityLbConfig_LocalityWeightedLbConfig{}
 		}
 		ringHashAny, err := utils.MessageToAny(ringHash)
@@ -151,7 +159,7 @@ func translateLoadBalancerConfig(config *kgateway.LoadBalancer, policyName, poli
 			hashingLBConfig.HashPolicy = constructHashPolicy(config.Maglev.HashPolicies)
 			maglev.ConsistentHashingLbConfig = hashingLBConfig
 		}
-		if config.LocalityType != nil {
+		if localityWeighted {
 			maglev.LocalityWeightedLbConfig = &envoycommonv3.LocalityLbConfig_LocalityWeightedLbConfig{}
 		}
 		maglevAny, err := utils.MessageToAny(maglev)
@@ -168,7 +176,7 @@ func translateLoadBalancerConfig(config *kgateway.LoadBalancer, policyName, poli
 		}
 	} else if config.Random != nil {
 		random := &envoyrandomv3.Random{}
-		if config.LocalityType != nil {
+		if localityWeighted {
 			random.LocalityLbConfig = &envoycommonv3.LocalityLbConfig{
 				LocalityConfigSpecifier: &envoycommonv3.LocalityLbConfig_LocalityWeightedLbConfig_{
 					LocalityWeightedLbConfig: &envoycommonv3.LocalityLbConfig_LocalityWeightedLbConfig{},
@@ -245,6 +253,36 @@ func disableUseHostnameForHashingIfPresent(typedCfg *envoycorev3.TypedExtensionC
 	}
 }
 
+// translateLocalityLbInfo translates the settings used to compute the locality weights
+// of the backend's endpoints. Returns nil if none are set.
+func translateLocalityLbInfo(config *kgateway.LoadBalancer) *endpoints.LocalityLbInfo {
+	if config.LocalZonePreference != nil {
+		localZone := &endpoints.LocalZoneInfo{
+			FailWithoutLocalEndpoints: ptr.Deref(config.LocalZonePreference.FailWithoutLocalEndpoints, false),
+		}
+		if config.LocalZonePreference.LocalPercentage != nil {
+			localZone.LocalPercentage = ptr.To(uint32(*config.LocalZonePreference.LocalPercentage)) // nolint:gosec // G115: kubebuilder validation ensures 0 <= value <= 100
+		}
+		if config.LocalZonePreference.MinEndpoints != nil {
+			localZone.MinEndpoints = ptr.To(uint32(*config.LocalZonePreference.MinEndpoints)) // nolint:gosec // G115: kubebuilder validation ensures value >= 1
+		}
+		return &endpoints.LocalityLbInfo{LocalZone: localZone}
+	}
+	if len(config.LocalityWeights) == 0 {
+		return nil
+	}
+	weights := make([]endpoints.LocalityWeight, 0, len(config.LocalityWeights))
+	for _, w := range config.LocalityWeights {
+		weights = append(weights, endpoints.LocalityWeight{
+			Region:  w.Region,
+			Zone:    ptr.Deref(w.Zone, ""),
+			SubZone: ptr.Deref(w.SubZone, ""),
+			Weight:  uint32(w.Weight), // nolint:gosec // G115: kubebuilder validation ensures 1 <= value <= 1000000
+		})
+	}
+	return &endpoints.LocalityLbInfo{Weights: weights}
+}
+
 func toSlowStartConfig(cfg *kgateway.SlowStart, name, namespace string) *envoycommonv3.SlowStartConfig {
 	if cfg == nil {
 		return nil
@@ -295,6 +333,9 @@ func (a *LoadBalancerConfigIR) Equals(b *LoadBalancerConfigIR) bool {
 	if !proto.Equal(a.loadBalancingPolicy, b.loadBalancingPolicy) {
 		return false
 	}
+	if !reflect.DeepEqual(a.localityLbInfo, b.localityLbInfo) {
+		return false
+	}
 
 	return true
 }
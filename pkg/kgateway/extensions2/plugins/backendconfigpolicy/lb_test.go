@@ -102,6 +102,36 @@ func TestApplyLoadBalancerConfig(t *testing.T) {
 				}
 			}(),
 		},
+		{
+			name: "RoundRobin with localZonePreference",
+			config: &kgateway.LoadBalancer{
+				RoundRobin: &kgateway.LoadBalancerRoundRobinConfig{},
+				LocalZonePreference: &kgateway.LocalZonePreference{
+					LocalPercentage: ptr.To(int32(80)),
+				},
+			},
+			expected: func() *envoyclusterv3.Cluster {
+				msg, _ := utils.MessageToAny(&roundrobinv3.RoundRobin{
+					LocalityLbConfig: &envoycommonv3.LocalityLbConfig{
+						LocalityConfigSpecifier: &envoycommonv3.LocalityLbConfig_LocalityWeightedLbConfig_{
+							LocalityWeightedLbConfig: &envoycommonv3.LocalityLbConfig_LocalityWeightedLbConfig{},
+						},
+					},
+				})
+				return &envoyclusterv3.Cluster{
+					Name: "test",
+					LoadBalancingPolicy: &envoyclusterv3.LoadBalancingPolicy{
+						Policies: []*envoyclusterv3.LoadBalancingPolicy_Policy{{
+							TypedExtensionConfig: &envoycorev3.TypedExtensionConfig{
+								Name:        "envoy.load_balancing_policies.round_robin",
+								TypedConfig: msg,
+							},
+						}},
+					},
+					CommonLbConfig: &envoyclusterv3.Cluster_CommonLbConfig{},
+				}
+			}(),
+		},
 		{
 			name: "RoundRobin full config",
 			config: &kgateway.LoadBalancer{
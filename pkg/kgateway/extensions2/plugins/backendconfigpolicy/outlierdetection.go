@@ -27,5 +27,50 @@ func translateOutlierDetection(od *kgateway.OutlierDetection) *envoyclusterv3.Ou
 	if od.MaxEjectionPercent != nil {
 		outlierDetection.MaxEjectionPercent = &wrapperspb.UInt32Value{Value: uint32(*od.MaxEjectionPercent)} // nolint:gosec // G115: kubebuilder validation ensures safe for uint32
 	}
+	if od.MaxEjectionTime != nil {
+		outlierDetection.MaxEjectionTime = durationpb.New(od.MaxEjectionTime.Duration)
+	}
+	if od.ConsecutiveGatewayFailure != nil {
+		outlierDetection.ConsecutiveGatewayFailure = &wrapperspb.UInt32Value{Value: uint32(*od.ConsecutiveGatewayFailure)} // nolint:gosec // G115: kubebuilder validation ensures safe for uint32
+		// envoy does not enforce gateway failure ejections by default
+		outlierDetection.EnforcingConsecutiveGatewayFailure = wrapperspb.UInt32(100)
+	}
+	if od.SplitExternalLocalOriginErrors != nil {
+		outlierDetection.SplitExternalLocalOriginErrors = *od.SplitExternalLocalOriginErrors
+	}
+	if od.ConsecutiveLocalOriginFailure != nil {
+		outlierDetection.ConsecutiveLocalOriginFailure = &wrapperspb.UInt32Value{Value: uint32(*od.ConsecutiveLocalOriginFailure)} // nolint:gosec // G115: kubebuilder validation ensures safe for uint32
+		outlierDetection.EnforcingConsecutiveLocalOriginFailure = wrapperspb.UInt32(100)
+	}
+	if sr := od.SuccessRate; sr != nil {
+		outlierDetection.EnforcingSuccessRate = toUInt32Value(sr.EnforcingPercent)
+		outlierDetection.SuccessRateMinimumHosts = toUInt32Value(sr.MinimumHosts)
+		outlierDetection.SuccessRateRequestVolume = toUInt32Value(sr.RequestVolume)
+		outlierDetection.SuccessRateStdevFactor = toUInt32Value(sr.StdevFactor)
+		if outlierDetection.GetSplitExternalLocalOriginErrors() {
+			outlierDetection.EnforcingLocalOriginSuccessRate = toUInt32Value(sr.EnforcingPercent)
+		}
+	}
+	if fp := od.FailurePercentage; fp != nil {
+		outlierDetection.FailurePercentageThreshold = toUInt32Value(fp.Threshold)
+		outlierDetection.FailurePercentageMinimumHosts = toUInt32Value(fp.MinimumHosts)
+		outlierDetection.FailurePercentageRequestVolume = toUInt32Value(fp.RequestVolume)
+		// envoy does not enforce failure percentage ejections by default
+		enforcing := wrapperspb.UInt32(100)
+		if fp.EnforcingPercent != nil {
+			enforcing = toUInt32Value(fp.EnforcingPercent)
+		}
+		outlierDetection.EnforcingFailurePercentage = enforcing
+		if outlierDetection.GetSplitExternalLocalOriginErrors() {
+			outlierDetection.EnforcingFailurePercentageLocalOrigin = enforcing
+		}
+	}
 	return outlierDetection
 }
+
+func toUInt32Value(v *int32) *wrapperspb.UInt32Value {
+	if v == nil {
+		return nil
+	}
+	return wrapperspb.UInt32(uint32(*v)) // nolint:gosec // G115: kubebuilder validation ensures safe for uint32
+}
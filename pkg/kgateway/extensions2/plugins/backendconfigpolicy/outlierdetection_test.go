@@ -54,6 +54,77 @@ func TestTranslateOutlierDetection(t *testing.T) {
 				MaxEjectionPercent: &wrapperspb.UInt32Value{Value: 99},
 			},
 		},
+		{
+			name: "gateway and local origin failures",
+			config: &kgateway.OutlierDetection{
+				ConsecutiveGatewayFailure:      ptr.To(int32(3)),
+				SplitExternalLocalOriginErrors: ptr.To(true),
+				ConsecutiveLocalOriginFailure:  ptr.To(int32(2)),
+				MaxEjectionTime:                &metav1.Duration{Duration: time.Minute},
+			},
+			expected: &envoyclusterv3.OutlierDetection{
+				ConsecutiveGatewayFailure:              &wrapperspb.UInt32Value{Value: 3},
+				EnforcingConsecutiveGatewayFailure:     &wrapperspb.UInt32Value{Value: 100},
+				SplitExternalLocalOriginErrors:         true,
+				ConsecutiveLocalOriginFailure:          &wrapperspb.UInt32Value{Value: 2},
+				EnforcingConsecutiveLocalOriginFailure: &wrapperspb.UInt32Value{Value: 100},
+				MaxEjectionTime:                        durationpb.New(time.Minute),
+			},
+		},
+		{
+			name: "success rate and failure percentage",
+			config: &kgateway.OutlierDetection{
+				SplitExternalLocalOriginErrors: ptr.To(true),
+				SuccessRate: &kgateway.OutlierDetectionSuccessRate{
+					EnforcingPercent: ptr.To(int32(50)),
+					MinimumHosts:     ptr.To(int32(3)),
+					RequestVolume:    ptr.To(int32(20)),
+					StdevFactor:      ptr.To(int32(1500)),
+				},
+				FailurePercentage: &kgateway.OutlierDetectionFailurePercentage{
+					Threshold:     ptr.To(int32(50)),
+					MinimumHosts:  ptr.To(int32(2)),
+					RequestVolume: ptr.To(int32(10)),
+				},
+			},
+			expected: &envoyclusterv3.OutlierDetection{
+				SplitExternalLocalOriginErrors:        true,
+				EnforcingSuccessRate:                  &wrapperspb.UInt32Value{Value: 50},
+				EnforcingLocalOriginSuccessRate:       &wrapperspb.UInt32Value{Value: 50},
+				SuccessRateMinimumHosts:               &wrapperspb.UInt32Value{Value: 3},
+				SuccessRateRequestVolume:              &wrapperspb.UInt32Value{Value: 20},
+				SuccessRateStdevFactor:                &wrapperspb.UInt32Value{Value: 1500},
+				FailurePercentageThreshold:            &wrapperspb.UInt32Value{Value: 50},
+				FailurePercentageMinimumHosts:         &wrapperspb.UInt32Value{Value: 2},
+				FailurePercentageRequestVolume:        &wrapperspb.UInt32Value{Value: 10},
+				EnforcingFailurePercentage:            &wrapperspb.UInt32Value{Value: 100},
+				EnforcingFailurePercentageLocalOrigin: &wrapperspb.UInt32Value{Value: 100},
+			},
+		},
+		{
+			name: "success rate without split local origin errors",
+			config: &kgateway.OutlierDetection{
+				SuccessRate: &kgateway.OutlierDetectionSuccessRate{
+					EnforcingPercent: ptr.To(int32(50)),
+				},
+			},
+			expected: &envoyclusterv3.OutlierDetection{
+				EnforcingSuccessRate: &wrapperspb.UInt32Value{Value: 50},
+			},
+		},
+		{
+			name: "success rate with split local origin errors and default enforcing",
+			config: &kgateway.OutlierDetection{
+				SplitExternalLocalOriginErrors: ptr.To(true),
+				SuccessRate: &kgateway.OutlierDetectionSuccessRate{
+					MinimumHosts: ptr.To(int32(3)),
+				},
+			},
+			expected: &envoyclusterv3.OutlierDetection{
+				SplitExternalLocalOriginErrors: true,
+				SuccessRateMinimumHosts:        &wrapperspb.UInt32Value{Value: 3},
+			},
+		},
 	}
 
 	for _, test := range tests {
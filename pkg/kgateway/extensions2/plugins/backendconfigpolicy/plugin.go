@@ -45,16 +45,22 @@ type BackendConfigPolicyIR struct {
 	healthCheck                   *envoycorev3.HealthCheck
 	outlierDetection              *envoyclusterv3.OutlierDetection
 	circuitBreakers               *envoyclusterv3.CircuitBreakers
+	upstreamProxyProtocol         *upstreamProxyProtocolIR
+	preconnectPolicy              *envoyclusterv3.Cluster_PreconnectPolicy
 }
 
 var logger = logging.New("plugin/backendconfigpolicy")
 
-var _ ir.PolicyIR = &BackendConfigPolicyIR{}
+var _ ir.UpstreamTLSPolicyIR = &BackendConfigPolicyIR{}
 
 func (d *BackendConfigPolicyIR) CreationTime() time.Time {
 	return d.ct
 }
 
+func (d *BackendConfigPolicyIR) UpstreamTLS() *envoytlsv3.UpstreamTlsContext {
+	return d.tlsConfig
+}
+
 func (d *BackendConfigPolicyIR) Equals(other any) bool {
 	d2, ok := other.(*BackendConfigPolicyIR)
 	if !ok {
@@ -111,6 +117,16 @@ func (d *BackendConfigPolicyIR) Equals(other any) bool {
 		return false
 	}
 
+	if !cmputils.CompareWithNils(d.upstreamProxyProtocol, d2.upstreamProxyProtocol, func(a, b *upstreamProxyProtocolIR) bool {
+		return a.Equals(b)
+	}) {
+		return false
+	}
+
+	if !proto.Equal(d.preconnectPolicy, d2.preconnectPolicy) {
+		return false
+	}
+
 	return true
 }
 
@@ -180,6 +196,8 @@ func NewPlugin(ctx context.Context, commoncol *collections.CommonCollections, v
 				Policies:                        backendConfigPolicyCol,
 				ProcessPolicyStaleStatusMarkers: processMarkers,
 				ProcessBackend:                  processBackend,
+				FinalizeBackend:                 finalizeBackend,
+				PerClientProcessEndpoints:       newProcessEndpoints(commoncol),
 				GetPolicyStatus:                 getPolicyStatusFn(cli),
 				PatchPolicyStatus:               patchPolicyStatusFn(cli),
 			},
@@ -234,6 +252,18 @@ func processBackend(_ context.Context, polir ir.PolicyIR, backend ir.BackendObje
 	if pol.circuitBreakers != nil {
 		out.CircuitBreakers = pol.circuitBreakers
 	}
+
+	if pol.preconnectPolicy != nil {
+		out.PreconnectPolicy = pol.preconnectPolicy
+	}
+}
+
+// finalizeBackend applies the settings that must build on the cluster produced by all the
+// policies attached to the backend.
+func finalizeBackend(_ context.Context, polir ir.PolicyIR, _ ir.BackendObjectIR, out *envoyclusterv3.Cluster) error {
+	pol := polir.(*BackendConfigPolicyIR)
+	// wraps the transport socket set by any policy, such as the TLS socket of a BackendTLSPolicy
+	return applyUpstreamProxyProtocol(pol.upstreamProxyProtocol, out)
 }
 
 func translate(
@@ -304,6 +334,22 @@ func translate(
 		}
 	}
 
+	if pol.Spec.UpstreamProxyProtocol != nil {
+		upstreamProxyProtocol, err := translateUpstreamProxyProtocol(pol.Spec.UpstreamProxyProtocol)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		ir.upstreamProxyProtocol = upstreamProxyProtocol
+	}
+
+	if pol.Spec.Preconnect != nil {
+		preconnectPolicy, err := translatePreconnect(pol.Spec.Preconnect)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		ir.preconnectPolicy = preconnectPolicy
+	}
+
 	return &ir, errs
 }
 
@@ -49,6 +49,7 @@ func TestBackendConfigPolicyTranslation(t *testing.T) {
 						MaxHeadersCount:          ptr.To(int32(100)),
 						MaxStreamDuration:        ptr.To(metav1.Duration{Duration: 30 * time.Second}),
 						MaxRequestsPerConnection: ptr.To(int32(100)),
+						MaxConnectionDuration:    ptr.To(metav1.Duration{Duration: 10 * time.Minute}),
 					},
 					Http1ProtocolOptions: &kgateway.Http1ProtocolOptions{
 						EnableTrailers:                          ptr.To(true),
@@ -74,6 +75,7 @@ func TestBackendConfigPolicyTranslation(t *testing.T) {
 							MaxHeadersCount:          &wrapperspb.UInt32Value{Value: 100},
 							MaxStreamDuration:        durationpb.New(30 * time.Second),
 							MaxRequestsPerConnection: &wrapperspb.UInt32Value{Value: 100},
+							MaxConnectionDuration:    durationpb.New(10 * time.Minute),
 						},
 						UpstreamProtocolOptions: &envoy_upstreams_http_v3.HttpProtocolOptions_ExplicitHttpConfig_{
 							ExplicitHttpConfig: &envoy_upstreams_http_v3.HttpProtocolOptions_ExplicitHttpConfig{
@@ -182,6 +184,11 @@ func TestBackendConfigPolicyTranslation(t *testing.T) {
 						InitialConnectionWindowSize:             ptr.To(resource.MustParse("64Ki")),
 						MaxConcurrentStreams:                    ptr.To(int32(100)),
 						OverrideStreamErrorOnInvalidHttpMessage: ptr.To(true),
+						ConnectionKeepalive: &kgateway.Http2ConnectionKeepalive{
+							Interval:               ptr.To(metav1.Duration{Duration: 30 * time.Second}),
+							Timeout:                metav1.Duration{Duration: 5 * time.Second},
+							ConnectionIdleInterval: ptr.To(metav1.Duration{Duration: time.Minute}),
+						},
 					},
 				},
 			},
@@ -212,6 +219,11 @@ func TestBackendConfigPolicyTranslation(t *testing.T) {
 										InitialConnectionWindowSize:             &wrapperspb.UInt32Value{Value: 65536},
 										MaxConcurrentStreams:                    &wrapperspb.UInt32Value{Value: 100},
 										OverrideStreamErrorOnInvalidHttpMessage: &wrapperspb.BoolValue{Value: true},
+										ConnectionKeepalive: &envoycorev3.KeepaliveSettings{
+											Interval:               durationpb.New(30 * time.Second),
+											Timeout:                durationpb.New(5 * time.Second),
+											ConnectionIdleInterval: durationpb.New(time.Minute),
+										},
 									},
 								},
 							},
@@ -281,6 +293,35 @@ func TestBackendConfigPolicyTranslation(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "preconnect",
+			policy: &kgateway.BackendConfigPolicy{
+				Spec: kgateway.BackendConfigPolicySpec{
+					Preconnect: &kgateway.Preconnect{
+						PerUpstreamRatio: ptr.To("1.5"),
+						PredictiveRatio:  ptr.To("2"),
+					},
+				},
+			},
+			want: &envoyclusterv3.Cluster{
+				PreconnectPolicy: &envoyclusterv3.Cluster_PreconnectPolicy{
+					PerUpstreamPreconnectRatio: &wrapperspb.DoubleValue{Value: 1.5},
+					PredictivePreconnectRatio:  &wrapperspb.DoubleValue{Value: 2},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "preconnect ratio out of range",
+			policy: &kgateway.BackendConfigPolicy{
+				Spec: kgateway.BackendConfigPolicySpec{
+					Preconnect: &kgateway.Preconnect{
+						PerUpstreamRatio: ptr.To("5"),
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
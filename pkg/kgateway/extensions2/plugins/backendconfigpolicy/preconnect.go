@@ -0,0 +1,42 @@
+package backendconfigpolicy
+
+import (
+	"fmt"
+	"strconv"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+)
+
+func translatePreconnect(preconnect *kgateway.Preconnect) (*envoyclusterv3.Cluster_PreconnectPolicy, error) {
+	out := &envoyclusterv3.Cluster_PreconnectPolicy{}
+	if preconnect.PerUpstreamRatio != nil {
+		ratio, err := parsePreconnectRatio(*preconnect.PerUpstreamRatio)
+		if err != nil {
+			return nil, fmt.Errorf("invalid preconnect perUpstreamRatio: %w", err)
+		}
+		out.PerUpstreamPreconnectRatio = ratio
+	}
+	if preconnect.PredictiveRatio != nil {
+		ratio, err := parsePreconnectRatio(*preconnect.PredictiveRatio)
+		if err != nil {
+			return nil, fmt.Errorf("invalid preconnect predictiveRatio: %w", err)
+		}
+		out.PredictivePreconnectRatio = ratio
+	}
+	return out, nil
+}
+
+func parsePreconnectRatio(s string) (*wrapperspb.DoubleValue, error) {
+	ratio, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, err
+	}
+	// this should ideally not happen due to CRD validation
+	if ratio < 1 || ratio > 3 {
+		return nil, fmt.Errorf("%v is not between 1 and 3", ratio)
+	}
+	return wrapperspb.Double(ratio), nil
+}
@@ -31,6 +31,10 @@ func translateCommonHttpProtocolOptions(commonHttpProtocolOptions *kgateway.Comm
 		out.MaxStreamDuration = durationpb.New(commonHttpProtocolOptions.MaxStreamDuration.Duration)
 	}
 
+	if commonHttpProtocolOptions.MaxConnectionDuration != nil {
+		out.MaxConnectionDuration = durationpb.New(commonHttpProtocolOptions.MaxConnectionDuration.Duration)
+	}
+
 	return out
 }
 
@@ -76,6 +80,17 @@ func translateHttp2ProtocolOptions(http2ProtocolOptions *kgateway.Http2ProtocolO
 	if http2ProtocolOptions.OverrideStreamErrorOnInvalidHttpMessage != nil {
 		out.OverrideStreamErrorOnInvalidHttpMessage = &wrapperspb.BoolValue{Value: *http2ProtocolOptions.OverrideStreamErrorOnInvalidHttpMessage}
 	}
+	if keepalive := http2ProtocolOptions.ConnectionKeepalive; keepalive != nil {
+		out.ConnectionKeepalive = &envoycorev3.KeepaliveSettings{
+			Timeout: durationpb.New(keepalive.Timeout.Duration),
+		}
+		if keepalive.Interval != nil {
+			out.ConnectionKeepalive.Interval = durationpb.New(keepalive.Interval.Duration)
+		}
+		if keepalive.ConnectionIdleInterval != nil {
+			out.ConnectionKeepalive.ConnectionIdleInterval = durationpb.New(keepalive.ConnectionIdleInterval.Duration)
+		}
+	}
 	return out
 }
 
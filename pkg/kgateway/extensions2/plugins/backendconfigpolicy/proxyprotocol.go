@@ -0,0 +1,138 @@
+package backendconfigpolicy
+
+import (
+	"fmt"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	proxyprotocolv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/proxy_protocol/v3"
+	rawbufferv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/raw_buffer/v3"
+	envoywellknown "github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/utils/ptr"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
+)
+
+// UpstreamProxyProtocolTransportSocket is the name of the Envoy transport socket that
+// sends a PROXY protocol header before handing off to the wrapped transport socket.
+const UpstreamProxyProtocolTransportSocket = "envoy.transport_sockets.upstream_proxy_protocol"
+
+// upstreamProxyProtocolIR holds the translated upstream PROXY protocol settings.
+// The transport socket itself is built in finalizeBackend, since it must wrap whatever
+// transport socket the cluster ends up with (e.g. TLS).
+type upstreamProxyProtocolIR struct {
+	config                  *envoycorev3.ProxyProtocolConfig
+	allowUnspecifiedAddress bool
+}
+
+func (u *upstreamProxyProtocolIR) Equals(other *upstreamProxyProtocolIR) bool {
+	if u.allowUnspecifiedAddress != other.allowUnspecifiedAddress {
+		return false
+	}
+	return proto.Equal(u.config, other.config)
+}
+
+func translateUpstreamProxyProtocol(pp *kgateway.UpstreamProxyProtocol) (*upstreamProxyProtocolIR, error) {
+	if pp == nil {
+		return nil, nil
+	}
+
+	config := &envoycorev3.ProxyProtocolConfig{
+		Version: envoycorev3.ProxyProtocolConfig_V1,
+	}
+	if pp.Version == kgateway.ProxyProtocolVersionV2 {
+		config.Version = envoycorev3.ProxyProtocolConfig_V2
+	}
+
+	if pp.PassThroughTLVs != nil {
+		passThrough := &envoycorev3.ProxyProtocolPassThroughTLVs{
+			MatchType: envoycorev3.ProxyProtocolPassThroughTLVs_INCLUDE_ALL,
+		}
+		if pp.PassThroughTLVs.MatchType == kgateway.ProxyProtocolTLVMatchInclude {
+			passThrough.MatchType = envoycorev3.ProxyProtocolPassThroughTLVs_INCLUDE
+			for _, t := range pp.PassThroughTLVs.Types {
+				passThrough.TlvType = append(passThrough.TlvType, uint32(t)) // nolint:gosec // G115: kubebuilder validation ensures 0 <= value <= 255, safe for uint32
+			}
+		}
+		config.PassThroughTlvs = passThrough
+	}
+
+	for _, tlv := range pp.AddedTLVs {
+		config.AddedTlvs = append(config.AddedTlvs, &envoycorev3.TlvEntry{
+			Type:  uint32(tlv.Type), // nolint:gosec // G115: kubebuilder validation ensures 0 <= value <= 255, safe for uint32
+			Value: []byte(tlv.Value),
+		})
+	}
+
+	if err := config.ValidateAll(); err != nil {
+		return nil, fmt.Errorf("invalid upstream proxy protocol: %w", err)
+	}
+	return &upstreamProxyProtocolIR{
+		config:                  config,
+		allowUnspecifiedAddress: ptr.Deref(pp.AllowUnspecifiedAddress, false),
+	}, nil
+}
+
+// applyUpstreamProxyProtocol wraps the cluster's transport socket, and the transport socket
+// of every transport socket match, with the upstream PROXY protocol transport socket.
+// Clusters without a transport socket are plaintext, so a raw_buffer socket is wrapped instead.
+func applyUpstreamProxyProtocol(pp *upstreamProxyProtocolIR, out *envoyclusterv3.Cluster) error {
+	if pp == nil {
+		return nil
+	}
+
+	wrapped, err := wrapWithUpstreamProxyProtocol(pp, out.GetTransportSocket())
+	if err != nil {
+		return fmt.Errorf("failed to wrap transport socket with upstream proxy protocol: %w", err)
+	}
+	out.TransportSocket = wrapped
+
+	for _, match := range out.GetTransportSocketMatches() {
+		wrapped, err := wrapWithUpstreamProxyProtocol(pp, match.GetTransportSocket())
+		if err != nil {
+			return fmt.Errorf("failed to wrap transport socket match %s with upstream proxy protocol: %w", match.GetName(), err)
+		}
+		match.TransportSocket = wrapped
+	}
+	return nil
+}
+
+func wrapWithUpstreamProxyProtocol(
+	pp *upstreamProxyProtocolIR,
+	inner *envoycorev3.TransportSocket,
+) (*envoycorev3.TransportSocket, error) {
+	// avoid double wrapping if the socket was already wrapped
+	if inner.GetName() == UpstreamProxyProtocolTransportSocket {
+		return inner, nil
+	}
+
+	if inner == nil {
+		rawBuffer, err := utils.MessageToAny(&rawbufferv3.RawBuffer{})
+		if err != nil {
+			return nil, err
+		}
+		inner = &envoycorev3.TransportSocket{
+			Name: envoywellknown.TransportSocketRawBuffer,
+			ConfigType: &envoycorev3.TransportSocket_TypedConfig{
+				TypedConfig: rawBuffer,
+			},
+		}
+	}
+
+	typedConfig, err := utils.MessageToAny(&proxyprotocolv3.ProxyProtocolUpstreamTransport{
+		Config:                  pp.config,
+		TransportSocket:         inner,
+		AllowUnspecifiedAddress: pp.allowUnspecifiedAddress,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &envoycorev3.TransportSocket{
+		Name: UpstreamProxyProtocolTransportSocket,
+		ConfigType: &envoycorev3.TransportSocket_TypedConfig{
+			TypedConfig: typedConfig,
+		},
+	}, nil
+}
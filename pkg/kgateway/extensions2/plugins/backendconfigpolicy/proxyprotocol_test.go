@@ -0,0 +1,148 @@
+package backendconfigpolicy
+
+import (
+	"testing"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	proxyprotocolv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/proxy_protocol/v3"
+	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	envoywellknown "github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/utils/ptr"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+)
+
+func TestTranslateUpstreamProxyProtocol(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *kgateway.UpstreamProxyProtocol
+		expected *upstreamProxyProtocolIR
+	}{
+		{
+			name:     "nil config",
+			config:   nil,
+			expected: nil,
+		},
+		{
+			name:   "defaults to v1",
+			config: &kgateway.UpstreamProxyProtocol{},
+			expected: &upstreamProxyProtocolIR{
+				config: &envoycorev3.ProxyProtocolConfig{
+					Version: envoycorev3.ProxyProtocolConfig_V1,
+				},
+			},
+		},
+		{
+			name: "v2 with pass through and added TLVs",
+			config: &kgateway.UpstreamProxyProtocol{
+				Version: kgateway.ProxyProtocolVersionV2,
+				PassThroughTLVs: &kgateway.ProxyProtocolPassThroughTLVs{
+					MatchType: kgateway.ProxyProtocolTLVMatchInclude,
+					Types:     []int32{0x1, 0xD0},
+				},
+				AddedTLVs: []kgateway.ProxyProtocolTLV{
+					{Type: 0xE0, Value: "gateway-a"},
+				},
+				AllowUnspecifiedAddress: ptr.To(true),
+			},
+			expected: &upstreamProxyProtocolIR{
+				config: &envoycorev3.ProxyProtocolConfig{
+					Version: envoycorev3.ProxyProtocolConfig_V2,
+					PassThroughTlvs: &envoycorev3.ProxyProtocolPassThroughTLVs{
+						MatchType: envoycorev3.ProxyProtocolPassThroughTLVs_INCLUDE,
+						TlvType:   []uint32{0x1, 0xD0},
+					},
+					AddedTlvs: []*envoycorev3.TlvEntry{
+						{Type: 0xE0, Value: []byte("gateway-a")},
+					},
+				},
+				allowUnspecifiedAddress: true,
+			},
+		},
+		{
+			name: "v2 passing through all TLVs",
+			config: &kgateway.UpstreamProxyProtocol{
+				Version: kgateway.ProxyProtocolVersionV2,
+				PassThroughTLVs: &kgateway.ProxyProtocolPassThroughTLVs{
+					MatchType: kgateway.ProxyProtocolTLVMatchIncludeAll,
+				},
+			},
+			expected: &upstreamProxyProtocolIR{
+				config: &envoycorev3.ProxyProtocolConfig{
+					Version: envoycorev3.ProxyProtocolConfig_V2,
+					PassThroughTlvs: &envoycorev3.ProxyProtocolPassThroughTLVs{
+						MatchType: envoycorev3.ProxyProtocolPassThroughTLVs_INCLUDE_ALL,
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := translateUpstreamProxyProtocol(test.config)
+			require.NoError(t, err)
+			if test.expected == nil {
+				assert.Nil(t, result)
+				return
+			}
+			require.NotNil(t, result)
+			assert.True(t, result.Equals(test.expected), "expected %v, got %v", test.expected, result)
+		})
+	}
+}
+
+func TestApplyUpstreamProxyProtocol(t *testing.T) {
+	pp, err := translateUpstreamProxyProtocol(&kgateway.UpstreamProxyProtocol{
+		Version: kgateway.ProxyProtocolVersionV2,
+	})
+	require.NoError(t, err)
+
+	unwrap := func(t *testing.T, ts *envoycorev3.TransportSocket) *proxyprotocolv3.ProxyProtocolUpstreamTransport {
+		t.Helper()
+		require.Equal(t, UpstreamProxyProtocolTransportSocket, ts.GetName())
+		out := &proxyprotocolv3.ProxyProtocolUpstreamTransport{}
+		require.NoError(t, ts.GetTypedConfig().UnmarshalTo(out))
+		return out
+	}
+
+	t.Run("plaintext cluster wraps raw buffer", func(t *testing.T) {
+		cluster := &envoyclusterv3.Cluster{}
+		require.NoError(t, applyUpstreamProxyProtocol(pp, cluster))
+
+		upstream := unwrap(t, cluster.GetTransportSocket())
+		assert.Equal(t, envoycorev3.ProxyProtocolConfig_V2, upstream.GetConfig().GetVersion())
+		assert.Equal(t, envoywellknown.TransportSocketRawBuffer, upstream.GetTransportSocket().GetName())
+	})
+
+	t.Run("tls cluster wraps tls socket", func(t *testing.T) {
+		tlsSocket := &envoycorev3.TransportSocket{
+			Name: envoywellknown.TransportSocketTls,
+			ConfigType: &envoycorev3.TransportSocket_TypedConfig{
+				TypedConfig: mustMessageToAny(t, &envoytlsv3.UpstreamTlsContext{Sni: "example.com"}),
+			},
+		}
+		cluster := &envoyclusterv3.Cluster{
+			TransportSocket: proto.Clone(tlsSocket).(*envoycorev3.TransportSocket),
+			TransportSocketMatches: []*envoyclusterv3.Cluster_TransportSocketMatch{
+				{Name: "tls", TransportSocket: proto.Clone(tlsSocket).(*envoycorev3.TransportSocket)},
+			},
+		}
+		require.NoError(t, applyUpstreamProxyProtocol(pp, cluster))
+
+		upstream := unwrap(t, cluster.GetTransportSocket())
+		assert.True(t, proto.Equal(tlsSocket, upstream.GetTransportSocket()))
+
+		matchUpstream := unwrap(t, cluster.GetTransportSocketMatches()[0].GetTransportSocket())
+		assert.True(t, proto.Equal(tlsSocket, matchUpstream.GetTransportSocket()))
+
+		// applying twice must not double wrap
+		require.NoError(t, applyUpstreamProxyProtocol(pp, cluster))
+		upstream = unwrap(t, cluster.GetTransportSocket())
+		assert.True(t, proto.Equal(tlsSocket, upstream.GetTransportSocket()))
+	})
+}
@@ -50,10 +50,11 @@ var (
 
 type backendTlsPolicy struct {
 	ct              time.Time
+	tlsContext      *envoytlsv3.UpstreamTlsContext
 	transportSocket *envoycorev3.TransportSocket
 }
 
-var _ ir.PolicyIR = &backendTlsPolicy{}
+var _ ir.UpstreamTLSPolicyIR = &backendTlsPolicy{}
 
 func (d *backendTlsPolicy) CreationTime() time.Time {
 	return d.ct
@@ -67,6 +68,10 @@ func (d *backendTlsPolicy) Equals(in any) bool {
 	return proto.Equal(d.transportSocket, d2.transportSocket)
 }
 
+func (d *backendTlsPolicy) UpstreamTLS() *envoytlsv3.UpstreamTlsContext {
+	return d.tlsContext
+}
+
 func NewPlugin(ctx context.Context, commoncol *collections.CommonCollections) sdk.Plugin {
 	cli := kclient.NewFilteredDelayed[*gwv1.BackendTLSPolicy](
 		commoncol.Client,
@@ -210,6 +215,7 @@ func buildTranslateFunc(
 			slog.Error("error converting TLS config to proto", "error", err, "policy", policyCR.Name)
 			return &policyIr, ErrParsingTLSConfig
 		}
+		policyIr.tlsContext = tlsContextDefault
 		policyIr.transportSocket = &envoycorev3.TransportSocket{
 			Name: wellknown.TransportSocketTls,
 			ConfigType: &envoycorev3.TransportSocket_TypedConfig{
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 
 	envoyaccesslogv3 "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
@@ -31,7 +32,10 @@ import (
 
 var ErrUnresolvedBackendRef = errors.New("unresolved backend reference")
 
-const serviceNameKey = "service.name"
+const (
+	serviceNameKey   = "service.name"
+	tcpGrpcAccessLog = "envoy.access_loggers.tcp_grpc"
+)
 
 // convertAccessLogConfig transforms a list of AccessLog configurations into Envoy AccessLog configurations
 // These access log configs can be either FileAccessLog, HttpGrpcAccessLogConfig or OpenTelemetryAccessLogConfig.
@@ -40,18 +44,16 @@ const serviceNameKey = "service.name"
 // we return partially translated configs. As these configs are of different types, we return an list of interfaces
 // that is stored in the IR to be fully translated during translation.
 func convertAccessLogConfig(
-	policy *kgateway.HTTPSettings,
+	configs []kgateway.AccessLog,
 	commoncol *collections.CommonCollections,
 	krtctx krt.HandlerContext,
 	parentSrc ir.ObjectSource,
 ) ([]proto.Message, error) {
-	configs := policy.AccessLog
-
 	if configs != nil && len(configs) == 0 {
 		return nil, nil
 	}
 
-	grpcBackends := make(map[string]*ir.BackendObjectIR, len(policy.AccessLog))
+	grpcBackends := make(map[string]*ir.BackendObjectIR, len(configs))
 	for idx, log := range configs {
 		if log.GrpcService != nil {
 			backend, err := commoncol.BackendIndex.GetBackendFromRef(krtctx, parentSrc, log.GrpcService.BackendRef.BackendObjectReference)
@@ -594,7 +596,7 @@ func generateAccessLogConfig(pCtx *ir.HcmContext, policies []kgateway.AccessLog,
 		case *envoygrpc.HttpGrpcAccessLogConfig:
 			cfg = newAccessLogWithConfig(wellknown.HTTPGRPCAccessLog, t)
 		case *envoy_open_telemetry.OpenTelemetryAccessLogConfig:
-			addDefaultResourceAttributes(pCtx, t)
+			addDefaultResourceAttributes(pCtx.Gateway, t)
 			cfg = newAccessLogWithConfig("envoy.access_loggers.open_telemetry", t)
 		}
 		// Add filter if specified
@@ -608,7 +610,63 @@ func generateAccessLogConfig(pCtx *ir.HcmContext, policies []kgateway.AccessLog,
 	return accessLogs, nil
 }
 
-func addDefaultResourceAttributes(pCtx *ir.HcmContext, config *envoy_open_telemetry.OpenTelemetryAccessLogConfig) {
+// generateTcpAccessLogConfig is the tcp_proxy counterpart of generateAccessLogConfig.
+// The HTTP gRPC sink is replaced with the TCP gRPC access logger, which only carries the common gRPC settings.
+func generateTcpAccessLogConfig(pCtx *ir.TcpProxyContext, policies []kgateway.AccessLog, configs []proto.Message) ([]*envoyaccesslogv3.AccessLog, error) {
+	accessLogs := make([]*envoyaccesslogv3.AccessLog, len(configs))
+	if len(configs) == 0 {
+		return accessLogs, nil
+	}
+
+	for i, config := range configs {
+		var cfg *envoyaccesslogv3.AccessLog
+		switch t := config.(type) {
+		case *envoyalfile.FileAccessLog:
+			cfg = newAccessLogWithConfig(wellknown.FileAccessLog, t)
+		case *envoygrpc.HttpGrpcAccessLogConfig:
+			cfg = newAccessLogWithConfig(tcpGrpcAccessLog, &envoygrpc.TcpGrpcAccessLogConfig{
+				CommonConfig: t.GetCommonConfig(),
+			})
+		case *envoy_open_telemetry.OpenTelemetryAccessLogConfig:
+			// the config is shared by every gateway the policy attaches to, so work on a copy
+			otelCfg := proto.Clone(t).(*envoy_open_telemetry.OpenTelemetryAccessLogConfig)
+			addDefaultResourceAttributes(pCtx.Gateway, otelCfg)
+			cfg = newAccessLogWithConfig("envoy.access_loggers.open_telemetry", otelCfg)
+		}
+		// Add filter if specified
+		if policies[i].Filter != nil {
+			if err := addAccessLogFilter(cfg, policies[i].Filter); err != nil {
+				return nil, err
+			}
+		}
+		accessLogs[i] = cfg
+	}
+	return accessLogs, nil
+}
+
+// validateTcpAccessLogFilter rejects the filters that can only match HTTP requests.
+func validateTcpAccessLogFilter(filter *kgateway.AccessLogFilter) error {
+	if filter == nil {
+		return nil
+	}
+	filterTypes := append(slices.Clone(filter.OrFilter), filter.AndFilter...)
+	if filter.FilterType != nil {
+		filterTypes = append(filterTypes, *filter.FilterType)
+	}
+	for _, f := range filterTypes {
+		switch {
+		case f.StatusCodeFilter != nil:
+			return errors.New("statusCodeFilter is not supported for TCP access logs")
+		case f.HeaderFilter != nil:
+			return errors.New("headerFilter is not supported for TCP access logs")
+		case f.GrpcStatusFilter != nil:
+			return errors.New("grpcStatusFilter is not supported for TCP access logs")
+		}
+	}
+	return nil
+}
+
+func addDefaultResourceAttributes(gw ir.GatewayIR, config *envoy_open_telemetry.OpenTelemetryAccessLogConfig) {
 	if config.GetResourceAttributes() == nil {
 		config.ResourceAttributes = &otelv1.KeyValueList{
 			Values: []*otelv1.KeyValue{
@@ -616,7 +674,7 @@ func addDefaultResourceAttributes(pCtx *ir.HcmContext, config *envoy_open_teleme
 					Key: serviceNameKey,
 					Value: &otelv1.AnyValue{
 						Value: &otelv1.AnyValue_StringValue{
-							StringValue: GenerateDefaultServiceName(pCtx.Gateway.SourceObject.GetName(), pCtx.Gateway.SourceObject.GetNamespace()),
+							StringValue: GenerateDefaultServiceName(gw.SourceObject.GetName(), gw.SourceObject.GetNamespace()),
 						},
 					},
 				},
@@ -634,7 +692,7 @@ func addDefaultResourceAttributes(pCtx *ir.HcmContext, config *envoy_open_teleme
 		Key: serviceNameKey,
 		Value: &otelv1.AnyValue{
 			Value: &otelv1.AnyValue_StringValue{
-				StringValue: GenerateDefaultServiceName(pCtx.Gateway.SourceObject.GetName(), pCtx.Gateway.SourceObject.GetNamespace()),
+				StringValue: GenerateDefaultServiceName(gw.SourceObject.GetName(), gw.SourceObject.GetNamespace()),
 			},
 		},
 	})
@@ -0,0 +1,101 @@
+package listenerpolicy
+
+import (
+	"testing"
+	"time"
+
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoy_type_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func methodMatch(method string, path *envoyroutev3.RouteMatch) *envoyroutev3.RouteMatch {
+	return &envoyroutev3.RouteMatch{
+		PathSpecifier: path.GetPathSpecifier(),
+		Headers: []*envoyroutev3.HeaderMatcher{
+			{
+				Name:                 "x-tenant",
+				HeaderMatchSpecifier: &envoyroutev3.HeaderMatcher_PresentMatch{PresentMatch: true},
+			},
+			{
+				Name: ":method",
+				HeaderMatchSpecifier: &envoyroutev3.HeaderMatcher_StringMatch{
+					StringMatch: &envoy_type_matcher_v3.StringMatcher{
+						MatchPattern: &envoy_type_matcher_v3.StringMatcher_Exact{Exact: method},
+					},
+				},
+			},
+		},
+	}
+}
+
+func prefix(path string) *envoyroutev3.RouteMatch {
+	return &envoyroutev3.RouteMatch{PathSpecifier: &envoyroutev3.RouteMatch_Prefix{Prefix: path}}
+}
+
+func connectRouteConfig() *envoyroutev3.RouteConfiguration {
+	route := func(match *envoyroutev3.RouteMatch) *envoyroutev3.Route {
+		return &envoyroutev3.Route{
+			Match:  match,
+			Action: &envoyroutev3.Route_Route{Route: &envoyroutev3.RouteAction{}},
+		}
+	}
+	return &envoyroutev3.RouteConfiguration{
+		VirtualHosts: []*envoyroutev3.VirtualHost{{
+			Routes: []*envoyroutev3.Route{
+				route(methodMatch("CONNECT", prefix("/"))),
+				route(methodMatch("CONNECT", prefix("/tunnel"))),
+				route(methodMatch("CONNECT", &envoyroutev3.RouteMatch{
+					PathSpecifier: &envoyroutev3.RouteMatch_Path{Path: "/tunnel"},
+				})),
+				route(methodMatch("GET", prefix("/"))),
+			},
+		}},
+	}
+}
+
+func TestApplyRouteConfigPluginConnect(t *testing.T) {
+	newPolicy := func(connect *kgateway.HTTPConnect) ir.PolicyIR {
+		pol, errs := NewListenerPolicyIR(nil, nil, time.Now(), &kgateway.ListenerPolicySpec{
+			Default: &kgateway.ListenerConfig{
+				HTTPSettings: &kgateway.HTTPSettings{Connect: connect},
+			},
+		}, ir.ObjectSource{Name: "policy", Namespace: "default"})
+		require.Empty(t, errs)
+		return pol
+	}
+
+	t.Run("connect enabled", func(t *testing.T) {
+		out := connectRouteConfig()
+		pass := NewGatewayTranslationPass(ir.GwTranslationCtx{}, nil)
+		pass.ApplyRouteConfigPlugin(&ir.RouteConfigContext{Policy: newPolicy(&kgateway.HTTPConnect{})}, out)
+
+		routes := out.GetVirtualHosts()[0].GetRoutes()
+		// the path matches are dropped, as CONNECT requests have no path
+		for _, connect := range routes[:3] {
+			assert.NotNil(t, connect.GetMatch().GetConnectMatcher())
+			require.Len(t, connect.GetMatch().GetHeaders(), 1)
+			assert.Equal(t, "x-tenant", connect.GetMatch().GetHeaders()[0].GetName())
+			require.Len(t, connect.GetRoute().GetUpgradeConfigs(), 1)
+			assert.Equal(t, connectUpgradeType, connect.GetRoute().GetUpgradeConfigs()[0].GetUpgradeType())
+			assert.NotNil(t, connect.GetRoute().GetUpgradeConfigs()[0].GetConnectConfig())
+		}
+
+		// other methods keep their matcher
+		expected := connectRouteConfig().GetVirtualHosts()[0].GetRoutes()
+		assert.True(t, proto.Equal(expected[3], routes[3]))
+	})
+
+	t.Run("connect not enabled", func(t *testing.T) {
+		out := connectRouteConfig()
+		pass := NewGatewayTranslationPass(ir.GwTranslationCtx{}, nil)
+		pass.ApplyRouteConfigPlugin(&ir.RouteConfigContext{Policy: newPolicy(nil)}, out)
+
+		assert.True(t, proto.Equal(connectRouteConfig(), out))
+	})
+}
@@ -50,6 +50,14 @@ type HttpListenerPolicyIr struct {
 	acceptHttp10                  *bool
 	defaultHostForHttp10          *string
 	earlyHeaderMutationExtensions []*envoycorev3.TypedExtensionConfig
+	localReplyConfig              *envoy_hcm.LocalReplyConfig
+	normalizePath                 *bool
+	mergeSlashes                  *bool
+	pathWithEscapedSlashesAction  *envoy_hcm.HttpConnectionManager_PathWithEscapedSlashesAction
+	headersWithUnderscoresAction  *envoycorev3.HttpProtocolOptions_HeadersWithUnderscoresAction
+	maxRequestHeadersKb           *uint32
+	maxHeadersCount               *uint32
+	connectMode                   *kgateway.HTTPConnectMode
 }
 
 func (d *HttpListenerPolicyIr) Equals(in any) bool {
@@ -143,6 +151,32 @@ func (d *HttpListenerPolicyIr) Equals(in any) bool {
 	}) {
 		return false
 	}
+
+	if !proto.Equal(d.localReplyConfig, d2.localReplyConfig) {
+		return false
+	}
+
+	if !cmputils.PointerValsEqual(d.normalizePath, d2.normalizePath) {
+		return false
+	}
+	if !cmputils.PointerValsEqual(d.mergeSlashes, d2.mergeSlashes) {
+		return false
+	}
+	if !cmputils.PointerValsEqual(d.pathWithEscapedSlashesAction, d2.pathWithEscapedSlashesAction) {
+		return false
+	}
+	if !cmputils.PointerValsEqual(d.headersWithUnderscoresAction, d2.headersWithUnderscoresAction) {
+		return false
+	}
+	if !cmputils.PointerValsEqual(d.maxRequestHeadersKb, d2.maxRequestHeadersKb) {
+		return false
+	}
+	if !cmputils.PointerValsEqual(d.maxHeadersCount, d2.maxHeadersCount) {
+		return false
+	}
+	if !cmputils.PointerValsEqual(d.connectMode, d2.connectMode) {
+		return false
+	}
 	return true
 }
 
@@ -151,7 +185,7 @@ func NewHttpListenerPolicy(krtctx krt.HandlerContext, commoncol *collections.Com
 		return nil, nil
 	}
 	errs := []error{}
-	accessLog, err := convertAccessLogConfig(h, commoncol, krtctx, objSrc)
+	accessLog, err := convertAccessLogConfig(h.AccessLog, commoncol, krtctx, objSrc)
 	if err != nil {
 		logger.Error("error translating access log", "error", err)
 		errs = append(errs, err)
@@ -163,6 +197,12 @@ func NewHttpListenerPolicy(krtctx krt.HandlerContext, commoncol *collections.Com
 		errs = append(errs, err)
 	}
 
+	localReplyConfig, err := convertLocalReplyConfig(krtctx, commoncol, objSrc, h.LocalReply)
+	if err != nil {
+		logger.Error("error translating local reply", "error", err)
+		errs = append(errs, err)
+	}
+
 	upgradeConfigs := convertUpgradeConfig(h)
 	serverHeaderTransformation := convertServerHeaderTransformation(h.ServerHeaderTransformation)
 
@@ -184,6 +224,14 @@ func NewHttpListenerPolicy(krtctx krt.HandlerContext, commoncol *collections.Com
 	if h.XffNumTrustedHops != nil {
 		xffNumTrustedHops = ptr.To(uint32(*h.XffNumTrustedHops)) // nolint:gosec // G115: kubebuilder validation ensures safe for uint32
 	}
+	var maxRequestHeadersKb *uint32
+	if h.MaxRequestHeadersKb != nil {
+		maxRequestHeadersKb = ptr.To(uint32(*h.MaxRequestHeadersKb)) // nolint:gosec // G115: kubebuilder validation ensures safe for uint32
+	}
+	var maxHeadersCount *uint32
+	if h.MaxHeadersCount != nil {
+		maxHeadersCount = ptr.To(uint32(*h.MaxHeadersCount)) // nolint:gosec // G115: kubebuilder validation ensures safe for uint32
+	}
 
 	return &HttpListenerPolicyIr{
 		accessLogConfig:               accessLog,
@@ -201,6 +249,14 @@ func NewHttpListenerPolicy(krtctx krt.HandlerContext, commoncol *collections.Com
 		acceptHttp10:                  h.AcceptHttp10,
 		defaultHostForHttp10:          h.DefaultHostForHttp10,
 		earlyHeaderMutationExtensions: convertHeaderMutations(h.EarlyRequestHeaderModifier),
+		localReplyConfig:              localReplyConfig,
+		normalizePath:                 h.NormalizePath,
+		mergeSlashes:                  h.MergeSlashes,
+		pathWithEscapedSlashesAction:  convertPathWithEscapedSlashesAction(h.PathWithEscapedSlashesAction),
+		headersWithUnderscoresAction:  convertHeadersWithUnderscoresAction(h.HeadersWithUnderscoresAction),
+		maxRequestHeadersKb:           maxRequestHeadersKb,
+		maxHeadersCount:               maxHeadersCount,
+		connectMode:                   convertConnectMode(h.Connect),
 	}, errs
 }
 
@@ -238,6 +294,52 @@ func convertServerHeaderTransformation(transformation *kgateway.ServerHeaderTran
 	}
 }
 
+func convertConnectMode(connect *kgateway.HTTPConnect) *kgateway.HTTPConnectMode {
+	if connect == nil {
+		return nil
+	}
+	if connect.Mode == "" {
+		return ptr.To(kgateway.HTTPConnectModeTerminate)
+	}
+	return ptr.To(connect.Mode)
+}
+
+func convertPathWithEscapedSlashesAction(action *kgateway.PathWithEscapedSlashesAction) *envoy_hcm.HttpConnectionManager_PathWithEscapedSlashesAction {
+	if action == nil {
+		return nil
+	}
+
+	switch *action {
+	case kgateway.KeepUnchangedPathWithEscapedSlashesAction:
+		return ptr.To(envoy_hcm.HttpConnectionManager_KEEP_UNCHANGED)
+	case kgateway.RejectRequestPathWithEscapedSlashesAction:
+		return ptr.To(envoy_hcm.HttpConnectionManager_REJECT_REQUEST)
+	case kgateway.UnescapeAndRedirectPathWithEscapedSlashesAction:
+		return ptr.To(envoy_hcm.HttpConnectionManager_UNESCAPE_AND_REDIRECT)
+	case kgateway.UnescapeAndForwardPathWithEscapedSlashesAction:
+		return ptr.To(envoy_hcm.HttpConnectionManager_UNESCAPE_AND_FORWARD)
+	default:
+		return nil
+	}
+}
+
+func convertHeadersWithUnderscoresAction(action *kgateway.HeadersWithUnderscoresAction) *envoycorev3.HttpProtocolOptions_HeadersWithUnderscoresAction {
+	if action == nil {
+		return nil
+	}
+
+	switch *action {
+	case kgateway.AllowHeadersWithUnderscoresAction:
+		return ptr.To(envoycorev3.HttpProtocolOptions_ALLOW)
+	case kgateway.RejectRequestHeadersWithUnderscoresAction:
+		return ptr.To(envoycorev3.HttpProtocolOptions_REJECT_REQUEST)
+	case kgateway.DropHeaderHeadersWithUnderscoresAction:
+		return ptr.To(envoycorev3.HttpProtocolOptions_DROP_HEADER)
+	default:
+		return nil
+	}
+}
+
 func convertHealthCheckPolicy(policy *kgateway.HTTPSettings) *healthcheckv3.HealthCheck {
 	if policy.HealthCheck != nil {
 		return &healthcheckv3.HealthCheck{
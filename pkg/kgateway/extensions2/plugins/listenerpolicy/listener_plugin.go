@@ -4,13 +4,17 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	healthcheckv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/health_check/v3"
 	proxy_protocol "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/listener/proxy_protocol/v3"
 	envoy_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	envoytcp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
 	preserve_case_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/header_formatters/preserve_case/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"google.golang.org/protobuf/proto"
@@ -43,6 +47,9 @@ import (
 
 var logger = logging.New("plugin/listenerpolicy")
 
+// connectUpgradeType is the upgrade type of HTTP CONNECT requests.
+const connectUpgradeType = "CONNECT"
+
 type ListenerPolicyIR struct {
 	ct            time.Time
 	defaultPolicy listenerPolicy
@@ -56,6 +63,7 @@ type listenerPolicy struct {
 	proxyProtocol                 *anypb.Any
 	perConnectionBufferLimitBytes *uint32
 	http                          *HttpListenerPolicyIr
+	tcp                           *TcpListenerPolicyIr
 }
 
 func newListenerPolicy(
@@ -69,11 +77,14 @@ func newListenerPolicy(
 		perConnectionBufferLimitBytes = ptr.To(uint32(*i.PerConnectionBufferLimitBytes)) //nolint:gosec // G115: kubebuilder validation ensures 0 <= value <= 2147483647, safe for uint32
 	}
 	http, errs := NewHttpListenerPolicy(krtctx, commoncol, i.HTTPSettings, objSrc)
+	tcp, tcpErrs := NewTcpListenerPolicy(krtctx, commoncol, i.TCPSettings, objSrc)
+	errs = append(errs, tcpErrs...)
 
 	return listenerPolicy{
 		proxyProtocol:                 convertProxyProtocolConfig(objSrc, i.ProxyProtocol),
 		perConnectionBufferLimitBytes: perConnectionBufferLimitBytes,
 		http:                          http,
+		tcp:                           tcp,
 	}, errs
 }
 
@@ -110,6 +121,10 @@ func (d listenerPolicy) Equals(d2 listenerPolicy) bool {
 		return false
 	}
 
+	if !d.tcp.Equals(d2.tcp) {
+		return false
+	}
+
 	return true
 }
 
@@ -416,6 +431,133 @@ func (p *listenerPolicyPluginGwPass) ApplyHCM(
 		out.HttpProtocolOptions.DefaultHostForHttp_10 = *policy.defaultHostForHttp10
 	}
 
+	// translate localReply
+	if policy.localReplyConfig != nil {
+		out.LocalReplyConfig = policy.localReplyConfig
+	}
+
+	// translate path normalization
+	if policy.normalizePath != nil {
+		out.NormalizePath = wrapperspb.Bool(*policy.normalizePath)
+	}
+	if policy.mergeSlashes != nil {
+		out.MergeSlashes = *policy.mergeSlashes
+	}
+	if policy.pathWithEscapedSlashesAction != nil {
+		out.PathWithEscapedSlashesAction = *policy.pathWithEscapedSlashesAction
+	}
+
+	// translate header limits
+	if policy.maxRequestHeadersKb != nil {
+		out.MaxRequestHeadersKb = wrapperspb.UInt32(*policy.maxRequestHeadersKb)
+	}
+	if policy.headersWithUnderscoresAction != nil {
+		if out.CommonHttpProtocolOptions == nil {
+			out.CommonHttpProtocolOptions = &envoycorev3.HttpProtocolOptions{}
+		}
+		out.GetCommonHttpProtocolOptions().HeadersWithUnderscoresAction = *policy.headersWithUnderscoresAction
+	}
+	if policy.maxHeadersCount != nil {
+		if out.CommonHttpProtocolOptions == nil {
+			out.CommonHttpProtocolOptions = &envoycorev3.HttpProtocolOptions{}
+		}
+		out.GetCommonHttpProtocolOptions().MaxHeadersCount = wrapperspb.UInt32(*policy.maxHeadersCount)
+	}
+
+	// translate connect
+	if policy.connectMode != nil && !slices.ContainsFunc(out.GetUpgradeConfigs(), isConnectUpgrade) {
+		out.UpgradeConfigs = append(out.GetUpgradeConfigs(), &envoy_hcm.HttpConnectionManager_UpgradeConfig{
+			UpgradeType: connectUpgradeType,
+		})
+	}
+
+	return nil
+}
+
+// ApplyRouteConfigPlugin matches CONNECT requests with the routes matching the CONNECT method,
+// and sets the CONNECT upgrade on them, as CONNECT requests can only be terminated at the route level.
+func (p *listenerPolicyPluginGwPass) ApplyRouteConfigPlugin(
+	pCtx *ir.RouteConfigContext,
+	out *envoyroutev3.RouteConfiguration,
+) {
+	policy := p.getPolicy(pCtx.Policy, pCtx.ListenerPort).http
+	if policy == nil || policy.connectMode == nil {
+		return
+	}
+
+	for _, vhost := range out.GetVirtualHosts() {
+		for _, route := range vhost.GetRoutes() {
+			action := route.GetRoute()
+			if action == nil || !toConnectMatch(route.GetMatch()) {
+				continue
+			}
+			action.UpgradeConfigs = slices.DeleteFunc(action.GetUpgradeConfigs(), func(uc *envoyroutev3.RouteAction_UpgradeConfig) bool {
+				return strings.EqualFold(uc.GetUpgradeType(), connectUpgradeType)
+			})
+			upgradeConfig := &envoyroutev3.RouteAction_UpgradeConfig{
+				UpgradeType: connectUpgradeType,
+			}
+			if *policy.connectMode == kgateway.HTTPConnectModeTerminate {
+				upgradeConfig.ConnectConfig = &envoyroutev3.RouteAction_UpgradeConfig_ConnectConfig{}
+			}
+			action.UpgradeConfigs = append(action.GetUpgradeConfigs(), upgradeConfig)
+		}
+	}
+}
+
+// toConnectMatch turns a match of the CONNECT method into a connect matcher, as CONNECT requests
+// have no path and are only matched by connect matchers. The path match is dropped, and the other
+// header matches are kept.
+func toConnectMatch(match *envoyroutev3.RouteMatch) bool {
+	if match.GetConnectMatcher() != nil {
+		return true
+	}
+	i := slices.IndexFunc(match.GetHeaders(), func(h *envoyroutev3.HeaderMatcher) bool {
+		return h.GetName() == ":method" && h.GetStringMatch().GetExact() == connectUpgradeType
+	})
+	if i < 0 {
+		return false
+	}
+	match.Headers = slices.Delete(match.GetHeaders(), i, i+1)
+	match.PathSpecifier = &envoyroutev3.RouteMatch_ConnectMatcher_{
+		ConnectMatcher: &envoyroutev3.RouteMatch_ConnectMatcher{},
+	}
+	return true
+}
+
+func isConnectUpgrade(uc *envoy_hcm.HttpConnectionManager_UpgradeConfig) bool {
+	return strings.EqualFold(uc.GetUpgradeType(), connectUpgradeType)
+}
+
+func (p *listenerPolicyPluginGwPass) ApplyTcpProxy(
+	pCtx *ir.TcpProxyContext,
+	out *envoytcp.TcpProxy,
+) error {
+	logger.Debug("applying to TcpProxy", "listener_port", pCtx.ListenerPort, "policy_type", fmt.Sprintf("%T", pCtx.Policy))
+
+	cfg := p.getPolicy(pCtx.Policy, pCtx.ListenerPort)
+	policy := cfg.tcp
+	if policy == nil {
+		return nil
+	}
+
+	// translate access logging configuration
+	accessLogs, err := generateTcpAccessLogConfig(pCtx, policy.accessLogPolicies, policy.accessLogConfig)
+	if err != nil {
+		return err
+	}
+	out.AccessLog = append(out.GetAccessLog(), accessLogs...)
+
+	// translate idleTimeout
+	if policy.idleTimeout != nil {
+		out.IdleTimeout = durationpb.New(*policy.idleTimeout)
+	}
+
+	// translate maxConnectionDuration
+	if policy.maxConnectionDuration != nil {
+		out.MaxDownstreamConnectionDuration = durationpb.New(*policy.maxConnectionDuration)
+	}
+
 	return nil
 }
 
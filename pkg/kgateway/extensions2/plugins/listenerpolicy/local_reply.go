@@ -0,0 +1,151 @@
+package listenerpolicy
+
+import (
+	"errors"
+	"fmt"
+
+	envoyaccesslogv3 "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"istio.io/istio/pkg/kube/krt"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+// convertLocalReplyConfig translates the LocalReply settings into the HCM LocalReplyConfig.
+// Bodies are always translated to format strings (rather than plain data sources) so that they
+// support command operators such as %LOCAL_REPLY_BODY% and %RESPONSE_CODE%.
+func convertLocalReplyConfig(
+	krtctx krt.HandlerContext,
+	commoncol *collections.CommonCollections,
+	objSrc ir.ObjectSource,
+	in *kgateway.LocalReply,
+) (*envoy_hcm.LocalReplyConfig, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	out := &envoy_hcm.LocalReplyConfig{}
+	for i, m := range in.Mappers {
+		filter, err := convertLocalReplyMatch(m.Match)
+		if err != nil {
+			return nil, fmt.Errorf("invalid localReply mapper %d: %w", i, err)
+		}
+		mapper := &envoy_hcm.ResponseMapper{
+			Filter: filter,
+		}
+		if m.StatusCode != nil {
+			mapper.StatusCode = wrapperspb.UInt32(uint32(*m.StatusCode)) //nolint:gosec // G115: kubebuilder validation ensures 200 <= value <= 599
+		}
+		if m.Body != nil {
+			mapper.BodyFormatOverride, err = convertLocalReplyBody(krtctx, commoncol, objSrc, m.Body)
+			if err != nil {
+				return nil, fmt.Errorf("invalid localReply mapper %d: %w", i, err)
+			}
+		}
+		out.Mappers = append(out.Mappers, mapper)
+	}
+
+	if in.Body != nil {
+		bodyFormat, err := convertLocalReplyBody(krtctx, commoncol, objSrc, in.Body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid localReply body: %w", err)
+		}
+		out.BodyFormat = bodyFormat
+	}
+	return out, nil
+}
+
+// convertLocalReplyMatch reuses the access log filters, as Envoy response mappers are matched with them.
+func convertLocalReplyMatch(match kgateway.LocalReplyMatch) (*envoyaccesslogv3.AccessLogFilter, error) {
+	var conditions []kgateway.FilterType
+	if match.StatusCode != nil {
+		conditions = append(conditions, kgateway.FilterType{StatusCodeFilter: match.StatusCode})
+	}
+	if len(match.ResponseFlags) > 0 {
+		conditions = append(conditions, kgateway.FilterType{
+			ResponseFlagFilter: &kgateway.ResponseFlagFilter{Flags: match.ResponseFlags},
+		})
+	}
+	for _, header := range match.Headers {
+		conditions = append(conditions, kgateway.FilterType{
+			HeaderFilter: &kgateway.HeaderFilter{Header: header},
+		})
+	}
+
+	switch len(conditions) {
+	case 0:
+		return nil, errors.New("match must specify at least one condition")
+	case 1:
+		return translateFilter(&conditions[0])
+	}
+	filters, err := translateFilters(conditions)
+	if err != nil {
+		return nil, err
+	}
+	return &envoyaccesslogv3.AccessLogFilter{
+		FilterSpecifier: &envoyaccesslogv3.AccessLogFilter_AndFilter{
+			AndFilter: &envoyaccesslogv3.AndFilter{Filters: filters},
+		},
+	}, nil
+}
+
+func convertLocalReplyBody(
+	krtctx krt.HandlerContext,
+	commoncol *collections.CommonCollections,
+	objSrc ir.ObjectSource,
+	body *kgateway.LocalReplyBody,
+) (*envoycorev3.SubstitutionFormatString, error) {
+	out := &envoycorev3.SubstitutionFormatString{}
+	switch {
+	case body.Text != nil:
+		out.Format = textFormatSource(*body.Text)
+	case body.JSON != nil:
+		jsonStruct, err := convertJsonFormat(body.JSON)
+		if err != nil {
+			return nil, err
+		}
+		out.Format = &envoycorev3.SubstitutionFormatString_JsonFormat{
+			JsonFormat: jsonStruct,
+		}
+	case body.ConfigMapRef != nil:
+		ref := body.ConfigMapRef
+		cm, err := commoncol.ConfigMaps.GetConfigMap(krtctx, krtcollections.From{
+			GroupKind: objSrc.GetGroupKind(),
+			Namespace: objSrc.Namespace,
+		}, gwv1.ObjectReference{
+			Kind: "ConfigMap",
+			Name: gwv1.ObjectName(ref.Name),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to find configmap %s: %w", ref.Name, err)
+		}
+		data, ok := cm.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("configmap %s does not contain key %s", ref.Name, ref.Key)
+		}
+		out.Format = textFormatSource(data)
+	default:
+		return nil, errors.New("no local reply body specified")
+	}
+
+	if body.ContentType != nil {
+		out.ContentType = *body.ContentType
+	}
+	return out, nil
+}
+
+func textFormatSource(format string) *envoycorev3.SubstitutionFormatString_TextFormatSource {
+	return &envoycorev3.SubstitutionFormatString_TextFormatSource{
+		TextFormatSource: &envoycorev3.DataSource{
+			Specifier: &envoycorev3.DataSource_InlineString{
+				InlineString: format,
+			},
+		},
+	}
+}
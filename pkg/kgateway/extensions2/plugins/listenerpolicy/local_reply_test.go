@@ -0,0 +1,85 @@
+package listenerpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestConvertLocalReplyConfig(t *testing.T) {
+	objSrc := ir.ObjectSource{Name: "policy", Namespace: "default"}
+
+	t.Run("nil", func(t *testing.T) {
+		out, err := convertLocalReplyConfig(nil, nil, objSrc, nil)
+		require.NoError(t, err)
+		assert.Nil(t, out)
+	})
+
+	t.Run("mappers and default body", func(t *testing.T) {
+		out, err := convertLocalReplyConfig(nil, nil, objSrc, &kgateway.LocalReply{
+			Mappers: []kgateway.LocalReplyMapper{
+				{
+					Match: kgateway.LocalReplyMatch{
+						ResponseFlags: []string{"UH"},
+					},
+					StatusCode: ptr.To(int32(503)),
+					Body: &kgateway.LocalReplyBody{
+						Text:        ptr.To("<h1>%RESPONSE_CODE%</h1>"),
+						ContentType: ptr.To("text/html"),
+					},
+				},
+				{
+					Match: kgateway.LocalReplyMatch{
+						StatusCode: &kgateway.StatusCodeFilter{Op: kgateway.EQ, Value: 404},
+						Headers: []gwv1.HTTPHeaderMatch{{
+							Type:  ptr.To(gwv1.HeaderMatchExact),
+							Name:  "accept",
+							Value: "application/json",
+						}},
+					},
+					StatusCode: ptr.To(int32(410)),
+				},
+			},
+			Body: &kgateway.LocalReplyBody{
+				JSON: &runtime.RawExtension{Raw: []byte(`{"code":"%RESPONSE_CODE%","message":"%LOCAL_REPLY_BODY%"}`)},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, out.GetMappers(), 2)
+
+		// a single condition is used as the filter directly
+		first := out.GetMappers()[0]
+		assert.Equal(t, []string{"UH"}, first.GetFilter().GetResponseFlagFilter().GetFlags())
+		assert.Equal(t, uint32(503), first.GetStatusCode().GetValue())
+		assert.Equal(t, "<h1>%RESPONSE_CODE%</h1>", first.GetBodyFormatOverride().GetTextFormatSource().GetInlineString())
+		assert.Equal(t, "text/html", first.GetBodyFormatOverride().GetContentType())
+
+		// multiple conditions must all match
+		second := out.GetMappers()[1]
+		and := second.GetFilter().GetAndFilter()
+		require.Len(t, and.GetFilters(), 2)
+		assert.NotNil(t, and.GetFilters()[0].GetStatusCodeFilter())
+		assert.NotNil(t, and.GetFilters()[1].GetHeaderFilter())
+		assert.Equal(t, uint32(410), second.GetStatusCode().GetValue())
+		assert.Nil(t, second.GetBodyFormatOverride())
+
+		fields := out.GetBodyFormat().GetJsonFormat().GetFields()
+		assert.Equal(t, "%RESPONSE_CODE%", fields["code"].GetStringValue())
+		assert.Equal(t, "%LOCAL_REPLY_BODY%", fields["message"].GetStringValue())
+		assert.Empty(t, out.GetBodyFormat().GetContentType())
+	})
+
+	t.Run("mapper without conditions", func(t *testing.T) {
+		_, err := convertLocalReplyConfig(nil, nil, objSrc, &kgateway.LocalReply{
+			Mappers: []kgateway.LocalReplyMapper{{StatusCode: ptr.To(int32(500))}},
+		})
+		assert.EqualError(t, err, "invalid localReply mapper 0: match must specify at least one condition")
+	})
+}
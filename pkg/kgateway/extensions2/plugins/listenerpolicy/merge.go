@@ -90,6 +90,7 @@ func mergeListenerPolicy(
 		mergeProxyProtocol,
 		mergePerConnectionBufferLimitBytes,
 		mergeHttpSettings,
+		mergeTcpSettings,
 	}
 
 	for _, mergeFunc := range mergeFuncs {
@@ -147,3 +148,23 @@ func mergeHttpSettings(
 	}
 	MergeHttpPolicies(origin, p1.http, p2.http, p2Ref, p2MergeOrigins, opts, mergeOrigins, "" /*no merge settings*/)
 }
+
+func mergeTcpSettings(
+	origin string,
+	p1, p2 *listenerPolicy,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+) {
+	if p2.tcp == nil {
+		return
+	}
+	if p1.tcp == nil {
+		p1.tcp = &TcpListenerPolicyIr{}
+	}
+	if origin != "" {
+		origin += "tcpSettings."
+	}
+	MergeTcpPolicies(origin, p1.tcp, p2.tcp, p2Ref, p2MergeOrigins, opts, mergeOrigins)
+}
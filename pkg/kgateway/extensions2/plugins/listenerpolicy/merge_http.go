@@ -34,6 +34,14 @@ func MergeHttpPolicies(
 		mergeAcceptHttp10,
 		mergeDefaultHostForHttp10,
 		mergeEarlyHeaderMutation,
+		mergeLocalReply,
+		mergeNormalizePath,
+		mergeMergeSlashes,
+		mergePathWithEscapedSlashesAction,
+		mergeHeadersWithUnderscoresAction,
+		mergeMaxRequestHeadersKb,
+		mergeMaxHeadersCount,
+		mergeConnectMode,
 	}
 	for _, mergeFunc := range mergeFuncs {
 		mergeFunc(origin, p1, p2, p2Ref, p2MergeOrigins, mergeOpts, mergeOrigins)
@@ -256,3 +264,131 @@ func mergeEarlyHeaderMutation(
 	p1.earlyHeaderMutationExtensions = slices.Clone(p2.earlyHeaderMutationExtensions)
 	mergeOrigins.SetOne(origin+"earlyHeaderMutationExtensions", p2Ref, p2MergeOrigins)
 }
+
+func mergeLocalReply(
+	origin string,
+	p1, p2 *HttpListenerPolicyIr,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+) {
+	if !policy.IsMergeable(p1.localReplyConfig, p2.localReplyConfig, opts) {
+		return
+	}
+
+	p1.localReplyConfig = p2.localReplyConfig
+	mergeOrigins.SetOne(origin+"localReply", p2Ref, p2MergeOrigins)
+}
+
+func mergeNormalizePath(
+	origin string,
+	p1, p2 *HttpListenerPolicyIr,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+) {
+	if !policy.IsMergeable(p1.normalizePath, p2.normalizePath, opts) {
+		return
+	}
+
+	p1.normalizePath = p2.normalizePath
+	mergeOrigins.SetOne(origin+"normalizePath", p2Ref, p2MergeOrigins)
+}
+
+func mergeMergeSlashes(
+	origin string,
+	p1, p2 *HttpListenerPolicyIr,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+) {
+	if !policy.IsMergeable(p1.mergeSlashes, p2.mergeSlashes, opts) {
+		return
+	}
+
+	p1.mergeSlashes = p2.mergeSlashes
+	mergeOrigins.SetOne(origin+"mergeSlashes", p2Ref, p2MergeOrigins)
+}
+
+func mergePathWithEscapedSlashesAction(
+	origin string,
+	p1, p2 *HttpListenerPolicyIr,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+) {
+	if !policy.IsMergeable(p1.pathWithEscapedSlashesAction, p2.pathWithEscapedSlashesAction, opts) {
+		return
+	}
+
+	p1.pathWithEscapedSlashesAction = p2.pathWithEscapedSlashesAction
+	mergeOrigins.SetOne(origin+"pathWithEscapedSlashesAction", p2Ref, p2MergeOrigins)
+}
+
+func mergeHeadersWithUnderscoresAction(
+	origin string,
+	p1, p2 *HttpListenerPolicyIr,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+) {
+	if !policy.IsMergeable(p1.headersWithUnderscoresAction, p2.headersWithUnderscoresAction, opts) {
+		return
+	}
+
+	p1.headersWithUnderscoresAction = p2.headersWithUnderscoresAction
+	mergeOrigins.SetOne(origin+"headersWithUnderscoresAction", p2Ref, p2MergeOrigins)
+}
+
+func mergeMaxRequestHeadersKb(
+	origin string,
+	p1, p2 *HttpListenerPolicyIr,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+) {
+	if !policy.IsMergeable(p1.maxRequestHeadersKb, p2.maxRequestHeadersKb, opts) {
+		return
+	}
+
+	p1.maxRequestHeadersKb = p2.maxRequestHeadersKb
+	mergeOrigins.SetOne(origin+"maxRequestHeadersKb", p2Ref, p2MergeOrigins)
+}
+
+func mergeMaxHeadersCount(
+	origin string,
+	p1, p2 *HttpListenerPolicyIr,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+) {
+	if !policy.IsMergeable(p1.maxHeadersCount, p2.maxHeadersCount, opts) {
+		return
+	}
+
+	p1.maxHeadersCount = p2.maxHeadersCount
+	mergeOrigins.SetOne(origin+"maxHeadersCount", p2Ref, p2MergeOrigins)
+}
+
+func mergeConnectMode(
+	origin string,
+	p1, p2 *HttpListenerPolicyIr,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+) {
+	if !policy.IsMergeable(p1.connectMode, p2.connectMode, opts) {
+		return
+	}
+
+	p1.connectMode = p2.connectMode
+	mergeOrigins.SetOne(origin+"connect", p2Ref, p2MergeOrigins)
+}
@@ -0,0 +1,82 @@
+package listenerpolicy
+
+import (
+	"slices"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/policy"
+)
+
+func MergeTcpPolicies(
+	origin string,
+	p1, p2 *TcpListenerPolicyIr,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	mergeOpts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+) {
+	if p1 == nil || p2 == nil {
+		return
+	}
+
+	mergeFuncs := []func(string, *TcpListenerPolicyIr, *TcpListenerPolicyIr, *ir.AttachedPolicyRef, ir.MergeOrigins, policy.MergeOptions, ir.MergeOrigins){
+		mergeTcpAccessLog,
+		mergeTcpIdleTimeout,
+		mergeTcpMaxConnectionDuration,
+	}
+	for _, mergeFunc := range mergeFuncs {
+		mergeFunc(origin, p1, p2, p2Ref, p2MergeOrigins, mergeOpts, mergeOrigins)
+	}
+}
+
+func mergeTcpAccessLog(
+	origin string,
+	p1, p2 *TcpListenerPolicyIr,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+) {
+	if !policy.IsMergeable(p1.accessLogConfig, p2.accessLogConfig, opts) {
+		return
+	}
+	if !policy.IsMergeable(p1.accessLogPolicies, p2.accessLogPolicies, opts) {
+		return
+	}
+
+	p1.accessLogConfig = slices.Clone(p2.accessLogConfig)
+	p1.accessLogPolicies = slices.Clone(p2.accessLogPolicies)
+	mergeOrigins.SetOne(origin+"accessLog", p2Ref, p2MergeOrigins)
+}
+
+func mergeTcpIdleTimeout(
+	origin string,
+	p1, p2 *TcpListenerPolicyIr,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+) {
+	if !policy.IsMergeable(p1.idleTimeout, p2.idleTimeout, opts) {
+		return
+	}
+
+	p1.idleTimeout = p2.idleTimeout
+	mergeOrigins.SetOne(origin+"idleTimeout", p2Ref, p2MergeOrigins)
+}
+
+func mergeTcpMaxConnectionDuration(
+	origin string,
+	p1, p2 *TcpListenerPolicyIr,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+) {
+	if !policy.IsMergeable(p1.maxConnectionDuration, p2.maxConnectionDuration, opts) {
+		return
+	}
+
+	p1.maxConnectionDuration = p2.maxConnectionDuration
+	mergeOrigins.SetOne(origin+"maxConnectionDuration", p2Ref, p2MergeOrigins)
+}
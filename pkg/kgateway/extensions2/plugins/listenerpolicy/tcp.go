@@ -0,0 +1,97 @@
+package listenerpolicy
+
+import (
+	"reflect"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"istio.io/istio/pkg/kube/krt"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/cmputils"
+)
+
+type TcpListenerPolicyIr struct {
+	// As for HTTP, the access log configs and policies are stored so that the default serviceName
+	// can be set during translation, once the gateway is known.
+	accessLogConfig       []proto.Message
+	accessLogPolicies     []kgateway.AccessLog
+	idleTimeout           *time.Duration
+	maxConnectionDuration *time.Duration
+}
+
+func (d *TcpListenerPolicyIr) Equals(in any) bool {
+	d2, ok := in.(*TcpListenerPolicyIr)
+	if !ok {
+		return false
+	}
+	if d == nil || d2 == nil {
+		return d == d2
+	}
+
+	if !slices.EqualFunc(d.accessLogConfig, d2.accessLogConfig, func(log proto.Message, log2 proto.Message) bool {
+		return proto.Equal(log, log2)
+	}) {
+		return false
+	}
+	if !slices.EqualFunc(d.accessLogPolicies, d2.accessLogPolicies, func(log kgateway.AccessLog, log2 kgateway.AccessLog) bool {
+		return reflect.DeepEqual(log, log2)
+	}) {
+		return false
+	}
+
+	if !cmputils.PointerValsEqual(d.idleTimeout, d2.idleTimeout) {
+		return false
+	}
+
+	if !cmputils.PointerValsEqual(d.maxConnectionDuration, d2.maxConnectionDuration) {
+		return false
+	}
+	return true
+}
+
+func NewTcpListenerPolicy(krtctx krt.HandlerContext, commoncol *collections.CommonCollections, t *kgateway.TCPSettings, objSrc ir.ObjectSource) (*TcpListenerPolicyIr, []error) {
+	if t == nil {
+		return nil, nil
+	}
+	errs := []error{}
+
+	// drop access logs that use HTTP-only filters, but keep the rest of the settings
+	var accessLogPolicies []kgateway.AccessLog
+	for _, log := range t.AccessLog {
+		if err := validateTcpAccessLogFilter(log.Filter); err != nil {
+			logger.Error("error translating tcp access log", "error", err)
+			errs = append(errs, err)
+			continue
+		}
+		accessLogPolicies = append(accessLogPolicies, log)
+	}
+	accessLog, err := convertAccessLogConfig(accessLogPolicies, commoncol, krtctx, objSrc)
+	if err != nil {
+		logger.Error("error translating tcp access log", "error", err)
+		errs = append(errs, err)
+		accessLogPolicies = nil
+	}
+
+	var idleTimeout *time.Duration
+	if t.IdleTimeout != nil {
+		duration := t.IdleTimeout.Duration
+		idleTimeout = &duration
+	}
+
+	var maxConnectionDuration *time.Duration
+	if t.MaxConnectionDuration != nil {
+		duration := t.MaxConnectionDuration.Duration
+		maxConnectionDuration = &duration
+	}
+
+	return &TcpListenerPolicyIr{
+		accessLogConfig:       accessLog,
+		accessLogPolicies:     accessLogPolicies,
+		idleTimeout:           idleTimeout,
+		maxConnectionDuration: maxConnectionDuration,
+	}, errs
+}
@@ -0,0 +1,206 @@
+package listenerpolicy
+
+import (
+	"testing"
+	"time"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoyalfile "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/file/v3"
+	envoygrpc "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/grpc/v3"
+	envoy_open_telemetry "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/open_telemetry/v3"
+	envoytcp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func tcpProxyContext(pol ir.PolicyIR) *ir.TcpProxyContext {
+	return &ir.TcpProxyContext{
+		ListenerPort: 5432,
+		Policy:       pol,
+		Gateway: ir.GatewayIR{
+			SourceObject: &ir.Gateway{
+				ObjectSource: ir.ObjectSource{
+					Name:      "gw",
+					Namespace: "default",
+				},
+			},
+		},
+	}
+}
+
+func TestApplyTcpProxy(t *testing.T) {
+	const format = "[%START_TIME%] %DOWNSTREAM_REMOTE_ADDRESS% %UPSTREAM_HOST% %BYTES_RECEIVED% %BYTES_SENT% %DURATION%\n"
+
+	pol, errs := NewListenerPolicyIR(nil, nil, time.Now(), &kgateway.ListenerPolicySpec{
+		Default: &kgateway.ListenerConfig{
+			TCPSettings: &kgateway.TCPSettings{
+				AccessLog: []kgateway.AccessLog{{
+					FileSink: &kgateway.FileSink{
+						Path:         "/dev/stdout",
+						StringFormat: ptr.To(format),
+					},
+					Filter: &kgateway.AccessLogFilter{
+						FilterType: &kgateway.FilterType{
+							CELFilter: &kgateway.CELFilter{Match: "connection.mtls"},
+						},
+					},
+				}},
+				IdleTimeout:           &metav1.Duration{Duration: 10 * time.Minute},
+				MaxConnectionDuration: &metav1.Duration{Duration: time.Hour},
+			},
+		},
+	}, ir.ObjectSource{Name: "policy", Namespace: "default"})
+	require.Empty(t, errs)
+
+	pass := NewGatewayTranslationPass(ir.GwTranslationCtx{}, nil)
+	out := &envoytcp.TcpProxy{StatPrefix: "listener~5432"}
+	require.NoError(t, pass.ApplyTcpProxy(tcpProxyContext(pol), out))
+
+	assert.Equal(t, 10*time.Minute, out.GetIdleTimeout().AsDuration())
+	assert.Equal(t, time.Hour, out.GetMaxDownstreamConnectionDuration().AsDuration())
+
+	require.Len(t, out.GetAccessLog(), 1)
+	al := out.GetAccessLog()[0]
+	assert.Equal(t, wellknown.FileAccessLog, al.GetName())
+	assert.NotNil(t, al.GetFilter().GetExtensionFilter())
+
+	var fileCfg envoyalfile.FileAccessLog
+	require.NoError(t, al.GetTypedConfig().UnmarshalTo(&fileCfg))
+	assert.Equal(t, format, fileCfg.GetLogFormat().GetTextFormatSource().GetInlineString())
+}
+
+func TestApplyTcpProxyPerPort(t *testing.T) {
+	pol, errs := NewListenerPolicyIR(nil, nil, time.Now(), &kgateway.ListenerPolicySpec{
+		Default: &kgateway.ListenerConfig{
+			TCPSettings: &kgateway.TCPSettings{
+				IdleTimeout: &metav1.Duration{Duration: time.Minute},
+			},
+		},
+		PerPort: []kgateway.ListenerPortConfig{{
+			Port: 5432,
+			Listener: kgateway.ListenerConfig{
+				TCPSettings: &kgateway.TCPSettings{
+					IdleTimeout: &metav1.Duration{Duration: 0},
+				},
+			},
+		}},
+	}, ir.ObjectSource{Name: "policy", Namespace: "default"})
+	require.Empty(t, errs)
+
+	pass := NewGatewayTranslationPass(ir.GwTranslationCtx{}, nil)
+	out := &envoytcp.TcpProxy{}
+	require.NoError(t, pass.ApplyTcpProxy(tcpProxyContext(pol), out))
+
+	// a zero idle timeout disables it, so it must be set explicitly
+	require.NotNil(t, out.GetIdleTimeout())
+	assert.Zero(t, out.GetIdleTimeout().AsDuration())
+	assert.Nil(t, out.GetMaxDownstreamConnectionDuration())
+	assert.Empty(t, out.GetAccessLog())
+}
+
+func TestTcpAccessLogRejectsHttpFilters(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *kgateway.AccessLogFilter
+		errMsg string
+	}{
+		{
+			name: "status code",
+			filter: &kgateway.AccessLogFilter{
+				FilterType: &kgateway.FilterType{
+					StatusCodeFilter: &kgateway.StatusCodeFilter{Op: kgateway.GE, Value: 400},
+				},
+			},
+			errMsg: "statusCodeFilter is not supported for TCP access logs",
+		},
+		{
+			name: "header in or filter",
+			filter: &kgateway.AccessLogFilter{
+				OrFilter: []kgateway.FilterType{
+					{DurationFilter: &kgateway.DurationFilter{Op: kgateway.GE, Value: 1000}},
+					{HeaderFilter: &kgateway.HeaderFilter{Header: gwv1.HTTPHeaderMatch{Name: "x-test", Value: "val"}}},
+				},
+			},
+			errMsg: "headerFilter is not supported for TCP access logs",
+		},
+		{
+			name: "grpc status in and filter",
+			filter: &kgateway.AccessLogFilter{
+				AndFilter: []kgateway.FilterType{
+					{ResponseFlagFilter: &kgateway.ResponseFlagFilter{Flags: []string{"UF"}}},
+					{GrpcStatusFilter: &kgateway.GrpcStatusFilter{Statuses: []kgateway.GrpcStatus{kgateway.NOT_FOUND}}},
+				},
+			},
+			errMsg: "grpcStatusFilter is not supported for TCP access logs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tcp, errs := NewTcpListenerPolicy(nil, nil, &kgateway.TCPSettings{
+				AccessLog: []kgateway.AccessLog{
+					{
+						FileSink: &kgateway.FileSink{Path: "/dev/stdout", StringFormat: ptr.To("%BYTES_SENT%")},
+						Filter:   tc.filter,
+					},
+					{
+						FileSink: &kgateway.FileSink{Path: "/dev/stderr", StringFormat: ptr.To("%BYTES_RECEIVED%")},
+					},
+				},
+				IdleTimeout: &metav1.Duration{Duration: time.Minute},
+			}, ir.ObjectSource{Name: "policy", Namespace: "default"})
+			require.Len(t, errs, 1)
+			assert.EqualError(t, errs[0], tc.errMsg)
+
+			// the invalid access log is dropped while the rest of the settings still apply
+			require.Len(t, tcp.accessLogPolicies, 1)
+			require.Len(t, tcp.accessLogConfig, 1)
+			assert.Equal(t, "/dev/stderr", tcp.accessLogConfig[0].(*envoyalfile.FileAccessLog).GetPath())
+			assert.Equal(t, time.Minute, *tcp.idleTimeout)
+		})
+	}
+}
+
+func TestGenerateTcpAccessLogConfig(t *testing.T) {
+	commonCfg := &envoygrpc.CommonGrpcAccessLogConfig{
+		LogName:             "tcp-log",
+		TransportApiVersion: envoycorev3.ApiVersion_V3,
+	}
+	otelCfg := &envoy_open_telemetry.OpenTelemetryAccessLogConfig{
+		CommonConfig: commonCfg,
+	}
+	configs := []proto.Message{
+		&envoygrpc.HttpGrpcAccessLogConfig{
+			CommonConfig:                  commonCfg,
+			AdditionalRequestHeadersToLog: []string{"x-ignored"},
+		},
+		otelCfg,
+	}
+	policies := []kgateway.AccessLog{{}, {}}
+
+	got, err := generateTcpAccessLogConfig(tcpProxyContext(nil), policies, configs)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	// the HTTP gRPC sink is replaced with the TCP gRPC access logger
+	assert.Equal(t, tcpGrpcAccessLog, got[0].GetName())
+	var tcpGrpc envoygrpc.TcpGrpcAccessLogConfig
+	require.NoError(t, got[0].GetTypedConfig().UnmarshalTo(&tcpGrpc))
+	assert.True(t, proto.Equal(commonCfg, tcpGrpc.GetCommonConfig()))
+
+	// the default service name is added without mutating the shared config
+	var otel envoy_open_telemetry.OpenTelemetryAccessLogConfig
+	require.NoError(t, got[1].GetTypedConfig().UnmarshalTo(&otel))
+	require.Len(t, otel.GetResourceAttributes().GetValues(), 1)
+	assert.Equal(t, serviceNameKey, otel.GetResourceAttributes().GetValues()[0].GetKey())
+	assert.Equal(t, "gw.default", otel.GetResourceAttributes().GetValues()[0].GetValue().GetStringValue())
+	assert.Nil(t, otelCfg.GetResourceAttributes())
+}
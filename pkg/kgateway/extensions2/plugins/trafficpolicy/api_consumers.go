@@ -0,0 +1,315 @@
+package trafficpolicy
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	ratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/common/ratelimit/v3"
+	envoy_ext_authz_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	localratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
+	envoymetadatav3 "github.com/envoyproxy/go-control-plane/envoy/type/metadata/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"istio.io/istio/pkg/kube/krt"
+	"k8s.io/utils/ptr"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/apiconsumer"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+const (
+	// apiConsumerFilterName is the ext_authz filter calling the kgateway API consumer verifier.
+	// The consumer and its plan are emitted under this dynamic metadata namespace.
+	apiConsumerFilterName = extauthFilterNamePrefix + "/api_consumer"
+	// apiConsumerRateLimitFilterName is the local rate limit filter enforcing the consumer quotas.
+	// It is separate from the local rate limit filter used by the rateLimit policy so that both can apply to a route.
+	apiConsumerRateLimitFilterName = localRateLimitFilterNamePrefix + "/api_consumer"
+	apiConsumerRateLimitStatPrefix = "api_consumer_local_rate_limiter"
+
+	apiConsumerRateLimitEnabledRuntimeKey  = "api_consumer_local_rate_limit_enabled"
+	apiConsumerRateLimitEnforcedRuntimeKey = "api_consumer_local_rate_limit_enforced"
+)
+
+type apiConsumersIR struct {
+	// consumers are the selected consumers, sorted by name
+	consumers       []apiConsumerIR
+	requireConsumer bool
+	// rateLimit is the per-route config of the local rate limit filter enforcing the consumer quotas,
+	// nil if no consumer has a quota
+	rateLimit *localratelimitv3.LocalRateLimit
+	// verifierCluster is the cluster of the kgateway auth verifier
+	verifierCluster string
+}
+
+type apiConsumerIR struct {
+	namespace string
+	// allowedRoutes are the HTTPRoutes the consumer may access, all routes if nil
+	allowedRoutes []string
+	config        apiconsumer.Consumer
+}
+
+var _ PolicySubIR = &apiConsumersIR{}
+
+func (a *apiConsumersIR) Equals(other PolicySubIR) bool {
+	otherConsumers, ok := other.(*apiConsumersIR)
+	if !ok {
+		return false
+	}
+	if a == nil || otherConsumers == nil {
+		return a == nil && otherConsumers == nil
+	}
+	if a.requireConsumer != otherConsumers.requireConsumer || a.verifierCluster != otherConsumers.verifierCluster {
+		return false
+	}
+	if !slices.EqualFunc(a.consumers, otherConsumers.consumers, func(c1, c2 apiConsumerIR) bool {
+		return c1.namespace == c2.namespace &&
+			slices.Equal(c1.allowedRoutes, c2.allowedRoutes) &&
+			reflect.DeepEqual(c1.config, c2.config)
+	}) {
+		return false
+	}
+	return proto.Equal(a.rateLimit, otherConsumers.rateLimit)
+}
+
+func (a *apiConsumersIR) Validate() error {
+	if a == nil || a.rateLimit == nil {
+		return nil
+	}
+	return a.rateLimit.ValidateAll()
+}
+
+// constructAPIConsumers resolves the APIConsumers selected by the policy.
+func constructAPIConsumers(
+	krtctx krt.HandlerContext,
+	in *kgateway.TrafficPolicy,
+	fetchConsumers func(krtctx krt.HandlerContext, namespace string, matchLabels map[string]string) []*kgateway.APIConsumer,
+	out *trafficPolicySpecIr,
+) {
+	spec := in.Spec.APIConsumers
+	if spec == nil {
+		return
+	}
+
+	var matchLabels map[string]string
+	if spec.ConsumerSelector != nil {
+		matchLabels = spec.ConsumerSelector.MatchLabels
+	}
+	consumers := fetchConsumers(krtctx, in.Namespace, matchLabels)
+	slices.SortFunc(consumers, func(a, b *kgateway.APIConsumer) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	res := &apiConsumersIR{
+		consumers:       make([]apiConsumerIR, 0, len(consumers)),
+		requireConsumer: ptr.Deref(spec.RequireConsumer, true),
+	}
+	var descriptors []*ratelimitv3.LocalRateLimitDescriptor
+	for _, c := range consumers {
+		consumer := toAPIConsumerIR(c)
+		res.consumers = append(res.consumers, consumer)
+		if c.Spec.Plan != nil && c.Spec.Plan.RateLimit != nil {
+			descriptors = append(descriptors, &ratelimitv3.LocalRateLimitDescriptor{
+				Entries: []*ratelimitv3.RateLimitDescriptor_Entry{{
+					Key:   apiconsumer.ConsumerMetadataKey,
+					Value: consumer.config.Name,
+				}},
+				TokenBucket: toTokenBucket(c.Spec.Plan.RateLimit),
+			})
+		}
+	}
+	if len(descriptors) > 0 {
+		res.rateLimit = apiConsumerRateLimit(descriptors)
+	}
+	out.apiConsumers = res
+}
+
+func toAPIConsumerIR(c *kgateway.APIConsumer) apiConsumerIR {
+	creds := c.Spec.Credentials
+	consumer := apiConsumerIR{
+		namespace: c.Namespace,
+		config: apiconsumer.Consumer{
+			Name:      c.Name,
+			APIKeys:   creds.APIKeys,
+			BasicAuth: creds.BasicAuth,
+			MTLS:      creds.MTLS,
+		},
+	}
+	for _, jwt := range creds.JWT {
+		consumer.config.JWT = append(consumer.config.JWT, apiconsumer.JWTPrincipal{
+			Issuer:   ptr.Deref(jwt.Issuer, ""),
+			Subject:  ptr.Deref(jwt.Subject, ""),
+			ClientID: ptr.Deref(jwt.ClientID, ""),
+		})
+	}
+
+	plan := c.Spec.Plan
+	if plan == nil {
+		return consumer
+	}
+	consumer.config.Plan = plan.Name
+	for _, h := range plan.RequestHeaders {
+		consumer.config.Headers = append(consumer.config.Headers, apiconsumer.Header{
+			Name:  strings.ToLower(string(h.Name)),
+			Value: h.Value,
+		})
+	}
+	if plan.AllowedRoutes != nil {
+		consumer.allowedRoutes = make([]string, 0, len(plan.AllowedRoutes))
+		for _, route := range plan.AllowedRoutes {
+			consumer.allowedRoutes = append(consumer.allowedRoutes, string(route))
+		}
+	}
+	return consumer
+}
+
+func toTokenBucket(t *kgateway.TokenBucket) *typev3.TokenBucket {
+	tokenBucket := &typev3.TokenBucket{
+		MaxTokens:    uint32(t.MaxTokens), // nolint:gosec // G115: kubebuilder validation ensures safe for uint32
+		FillInterval: durationpb.New(t.FillInterval.Duration),
+	}
+	if t.TokensPerFill != nil {
+		tokenBucket.TokensPerFill = wrapperspb.UInt32(uint32(*t.TokensPerFill)) // nolint:gosec // G115: kubebuilder validation ensures safe for uint32
+	}
+	return tokenBucket
+}
+
+// apiConsumerRateLimit builds the per-route local rate limit config enforcing the consumer quotas.
+// The descriptor of a request is the consumer emitted by the verifier.
+func apiConsumerRateLimit(descriptors []*ratelimitv3.LocalRateLimitDescriptor) *localratelimitv3.LocalRateLimit {
+	return &localratelimitv3.LocalRateLimit{
+		StatPrefix: apiConsumerRateLimitStatPrefix,
+		// Config per route requires a token bucket, and requests without a quota must not be limited
+		TokenBucket: &typev3.TokenBucket{
+			MaxTokens:     math.MaxUint32,
+			TokensPerFill: wrapperspb.UInt32(math.MaxUint32),
+			FillInterval:  durationpb.New(time.Second),
+		},
+		FilterEnabled: &envoycorev3.RuntimeFractionalPercent{
+			RuntimeKey: apiConsumerRateLimitEnabledRuntimeKey,
+			DefaultValue: &typev3.FractionalPercent{
+				Numerator:   100,
+				Denominator: typev3.FractionalPercent_HUNDRED,
+			},
+		},
+		FilterEnforced: &envoycorev3.RuntimeFractionalPercent{
+			RuntimeKey: apiConsumerRateLimitEnforcedRuntimeKey,
+			DefaultValue: &typev3.FractionalPercent{
+				Numerator:   100,
+				Denominator: typev3.FractionalPercent_HUNDRED,
+			},
+		},
+		Descriptors:                     descriptors,
+		AlwaysConsumeDefaultTokenBucket: wrapperspb.Bool(false),
+		RateLimits: []*envoyroutev3.RateLimit{{
+			Actions: []*envoyroutev3.RateLimit_Action{{
+				ActionSpecifier: &envoyroutev3.RateLimit_Action_Metadata{
+					Metadata: &envoyroutev3.RateLimit_Action_MetaData{
+						DescriptorKey: apiconsumer.ConsumerMetadataKey,
+						MetadataKey: &envoymetadatav3.MetadataKey{
+							Key: apiConsumerFilterName,
+							Path: []*envoymetadatav3.MetadataKey_PathSegment{
+								{Segment: &envoymetadatav3.MetadataKey_PathSegment_Key{Key: apiconsumer.ConsumerMetadataKey}},
+							},
+						},
+						Source: envoyroutev3.RateLimit_Action_MetaData_DYNAMIC,
+					},
+				},
+			}},
+		}},
+	}
+}
+
+// apiConsumerFilter is the ext_authz filter calling the kgateway API consumer verifier. The
+// principals authenticated by the API key verifier and the JWT filter are sent in the metadata
+// context, the basic auth username in the Authorization header.
+func apiConsumerFilter(cluster string) *envoy_ext_authz_v3.ExtAuthz {
+	filter := authVerifierFilter(cluster)
+	filter.AllowedHeaders = buildStringListMatcher([]string{"authorization"})
+	filter.MetadataContextNamespaces = []string{apiKeyAuthVerifierFilterName, jwtAuthnMetadataNamespace}
+	return filter
+}
+
+// apiKeyAuthForConsumers returns the API key auth of a policy, verified by the kgateway verifier
+// when the policy sets apiConsumers, so that API consumers resolve the keys of the merged policies.
+func apiKeyAuthForConsumers(spec trafficPolicySpecIr) *apiKeyAuthIR {
+	if spec.apiConsumers == nil {
+		return spec.apiKeyAuth
+	}
+	apiKeyAuth, err := spec.apiKeyAuth.withVerifier(spec.apiConsumers.verifierCluster)
+	if err != nil {
+		logger.Error("failed to encode API key verifier config", "error", err)
+		return spec.apiKeyAuth
+	}
+	return apiKeyAuth
+}
+
+// handleAPIConsumers configures the API consumer verifier of a route, which is nil for the
+// virtual host and route configuration levels. Consumers whose plan restricts the allowed
+// routes are denied unless the route is allowed.
+func (p *trafficPolicyPluginGwPass) handleAPIConsumers(
+	fcn string,
+	pCtxTypedFilterConfig *ir.TypedFilterConfigMap,
+	spec trafficPolicySpecIr,
+	route *ir.HttpRouteIR,
+) {
+	consumers := spec.apiConsumers
+	if consumers == nil {
+		return
+	}
+
+	cfg := apiconsumer.Config{
+		Consumers:       make([]apiconsumer.Consumer, 0, len(consumers.consumers)),
+		RequireConsumer: consumers.requireConsumer,
+	}
+	// The API key verifier only emits the client identifier of the keys it verified, so it is
+	// resolved wherever the keys are verified. Only resolve the other principals authenticated
+	// on the route, as the verifier cannot tell whether an Authorization header was verified.
+	cfg.APIKeyNamespace = apiKeyAuthVerifierFilterName
+	if spec.jwt != nil && !spec.jwt.disableAllProviders {
+		cfg.JWTNamespace = jwtAuthnMetadataNamespace
+	}
+	cfg.BasicAuth = spec.basicAuth != nil && !spec.basicAuth.disable
+	for _, c := range consumers.consumers {
+		consumer := c.config
+		if c.allowedRoutes != nil {
+			consumer.Forbidden = route == nil || route.Namespace != c.namespace || !slices.Contains(c.allowedRoutes, route.Name)
+		}
+		cfg.Consumers = append(cfg.Consumers, consumer)
+	}
+
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		logger.Error("failed to encode API consumer verifier config", "error", err)
+		return
+	}
+	pCtxTypedFilterConfig.AddTypedConfig(apiConsumerFilterName, &envoy_ext_authz_v3.ExtAuthzPerRoute{
+		Override: &envoy_ext_authz_v3.ExtAuthzPerRoute_CheckSettings{
+			CheckSettings: &envoy_ext_authz_v3.CheckSettings{
+				ContextExtensions: map[string]string{
+					apiconsumer.ConfigContextExtension: string(raw),
+				},
+			},
+		},
+	})
+	if p.apiConsumerInChain == nil {
+		p.apiConsumerInChain = make(map[string]string)
+	}
+	p.apiConsumerInChain[fcn] = consumers.verifierCluster
+
+	if consumers.rateLimit != nil {
+		pCtxTypedFilterConfig.AddTypedConfig(apiConsumerRateLimitFilterName, consumers.rateLimit)
+		if p.apiConsumerRateLimitInChain == nil {
+			p.apiConsumerRateLimitInChain = make(map[string]bool)
+		}
+		p.apiConsumerRateLimitInChain[fcn] = true
+	}
+}
@@ -0,0 +1,210 @@
+package trafficpolicy
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	envoyapikeyauthv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/api_key_auth/v3"
+	envoy_ext_authz_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	localratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"istio.io/istio/pkg/kube/krt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/apiconsumer"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/apikeyauth"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func testAPIConsumers() []*kgateway.APIConsumer {
+	return []*kgateway.APIConsumer{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "globex", Namespace: "default"},
+			Spec: kgateway.APIConsumerSpec{
+				Credentials: kgateway.APIConsumerCredentials{BasicAuth: []string{"globex"}},
+				Plan: &kgateway.APIConsumerPlan{
+					Name:          "free",
+					AllowedRoutes: []gwv1.ObjectName{"public"},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "acme", Namespace: "default"},
+			Spec: kgateway.APIConsumerSpec{
+				Credentials: kgateway.APIConsumerCredentials{
+					APIKeys: []string{"acme-key"},
+					JWT:     []kgateway.APIConsumerJWTPrincipal{{Subject: ptr.To("acme")}},
+				},
+				Plan: &kgateway.APIConsumerPlan{
+					Name: "gold",
+					RateLimit: &kgateway.TokenBucket{
+						MaxTokens:    100,
+						FillInterval: metav1.Duration{Duration: time.Minute},
+					},
+					RequestHeaders: []gwv1.HTTPHeader{{Name: "X-Plan", Value: "gold"}},
+				},
+			},
+		},
+	}
+}
+
+func TestConstructAPIConsumers(t *testing.T) {
+	var fetchedNamespace string
+	var fetchedLabels map[string]string
+	fetch := func(_ krt.HandlerContext, namespace string, matchLabels map[string]string) []*kgateway.APIConsumer {
+		fetchedNamespace = namespace
+		fetchedLabels = matchLabels
+		return testAPIConsumers()
+	}
+	policy := &kgateway.TrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"},
+		Spec: kgateway.TrafficPolicySpec{
+			APIConsumers: &kgateway.APIConsumerPolicy{
+				ConsumerSelector: &kgateway.LabelSelector{MatchLabels: map[string]string{"product": "payments"}},
+			},
+		},
+	}
+
+	out := &trafficPolicySpecIr{}
+	constructAPIConsumers(nil, policy, fetch, out)
+
+	require.NotNil(t, out.apiConsumers)
+	assert.Equal(t, "default", fetchedNamespace)
+	assert.Equal(t, map[string]string{"product": "payments"}, fetchedLabels)
+	assert.True(t, out.apiConsumers.requireConsumer)
+
+	// consumers are sorted by name
+	consumers := out.apiConsumers.consumers
+	require.Len(t, consumers, 2)
+	assert.Equal(t, apiconsumer.Consumer{
+		Name:    "acme",
+		Plan:    "gold",
+		APIKeys: []string{"acme-key"},
+		JWT:     []apiconsumer.JWTPrincipal{{Subject: "acme"}},
+		Headers: []apiconsumer.Header{{Name: "x-plan", Value: "gold"}},
+	}, consumers[0].config)
+	assert.Nil(t, consumers[0].allowedRoutes)
+	assert.Equal(t, "globex", consumers[1].config.Name)
+	assert.Equal(t, []string{"public"}, consumers[1].allowedRoutes)
+
+	// only acme has a quota
+	rateLimit := out.apiConsumers.rateLimit
+	require.NotNil(t, rateLimit)
+	require.NoError(t, out.apiConsumers.Validate())
+	require.Len(t, rateLimit.GetDescriptors(), 1)
+	descriptor := rateLimit.GetDescriptors()[0]
+	assert.Equal(t, apiconsumer.ConsumerMetadataKey, descriptor.GetEntries()[0].GetKey())
+	assert.Equal(t, "acme", descriptor.GetEntries()[0].GetValue())
+	assert.Equal(t, uint32(100), descriptor.GetTokenBucket().GetMaxTokens())
+	metadata := rateLimit.GetRateLimits()[0].GetActions()[0].GetMetadata()
+	assert.Equal(t, apiConsumerFilterName, metadata.GetMetadataKey().GetKey())
+}
+
+func TestHandleAPIConsumers(t *testing.T) {
+	consumers := &apiConsumersIR{}
+	for _, c := range testAPIConsumers() {
+		consumers.consumers = append(consumers.consumers, toAPIConsumerIR(c))
+	}
+	consumers.rateLimit = &localratelimitv3.LocalRateLimit{StatPrefix: apiConsumerRateLimitStatPrefix}
+	consumers.verifierCluster = "kube_kgateway-system_auth-verifier_9000"
+	spec := trafficPolicySpecIr{
+		apiConsumers: consumers,
+		basicAuth:    &basicAuthIR{},
+	}
+	fcn := "test-filter-chain"
+
+	routeConfig := func(t *testing.T, route *ir.HttpRouteIR) apiconsumer.Config {
+		plugin := &trafficPolicyPluginGwPass{}
+		typedFilterConfig := &ir.TypedFilterConfigMap{}
+		plugin.handleAPIConsumers(fcn, typedFilterConfig, spec, route)
+
+		assert.Equal(t, consumers.verifierCluster, plugin.apiConsumerInChain[fcn])
+		assert.True(t, plugin.apiConsumerRateLimitInChain[fcn])
+		assert.Equal(t, consumers.rateLimit, typedFilterConfig.GetTypedConfig(apiConsumerRateLimitFilterName))
+
+		perRoute, ok := typedFilterConfig.GetTypedConfig(apiConsumerFilterName).(*envoy_ext_authz_v3.ExtAuthzPerRoute)
+		require.True(t, ok)
+		var cfg apiconsumer.Config
+		require.NoError(t, json.Unmarshal([]byte(perRoute.GetCheckSettings().GetContextExtensions()[apiconsumer.ConfigContextExtension]), &cfg))
+		return cfg
+	}
+
+	t.Run("allowed route", func(t *testing.T) {
+		cfg := routeConfig(t, &ir.HttpRouteIR{ObjectSource: ir.ObjectSource{Namespace: "default", Name: "public"}})
+		assert.True(t, cfg.BasicAuth)
+		// the keys may be verified by a policy at another level
+		assert.Equal(t, apiKeyAuthVerifierFilterName, cfg.APIKeyNamespace)
+		assert.Empty(t, cfg.JWTNamespace)
+		require.Len(t, cfg.Consumers, 2)
+		assert.False(t, cfg.Consumers[0].Forbidden)
+		assert.False(t, cfg.Consumers[1].Forbidden)
+	})
+
+	t.Run("other route", func(t *testing.T) {
+		cfg := routeConfig(t, &ir.HttpRouteIR{ObjectSource: ir.ObjectSource{Namespace: "default", Name: "internal"}})
+		require.Len(t, cfg.Consumers, 2)
+		assert.True(t, cfg.Consumers[0].Forbidden)
+		assert.False(t, cfg.Consumers[1].Forbidden)
+	})
+
+	t.Run("virtual host", func(t *testing.T) {
+		cfg := routeConfig(t, nil)
+		require.Len(t, cfg.Consumers, 2)
+		assert.True(t, cfg.Consumers[0].Forbidden)
+		assert.False(t, cfg.Consumers[1].Forbidden)
+	})
+}
+
+func TestAPIKeyAuthForConsumers(t *testing.T) {
+	envoyAPIKeyAuth := &apiKeyAuthIR{
+		config: &envoyapikeyauthv3.ApiKeyAuthPerRoute{
+			Credentials: []*envoyapikeyauthv3.Credential{
+				{Key: "k-1", Client: "client1"},
+				{Key: "k-2", Client: "client2"},
+			},
+			KeySources: []*envoyapikeyauthv3.KeySource{{Header: "api-key"}, {Query: "api_key"}},
+			Forwarding: &envoyapikeyauthv3.Forwarding{
+				Header:          "X-Client-ID",
+				HideCredentials: true,
+			},
+		},
+	}
+	consumers := &apiConsumersIR{verifierCluster: "kube_kgateway-system_auth-verifier_9000"}
+
+	t.Run("without api consumers", func(t *testing.T) {
+		assert.Same(t, envoyAPIKeyAuth, apiKeyAuthForConsumers(trafficPolicySpecIr{apiKeyAuth: envoyAPIKeyAuth}))
+		assert.Nil(t, apiKeyAuthForConsumers(trafficPolicySpecIr{apiConsumers: consumers}))
+	})
+
+	t.Run("merged with api consumers", func(t *testing.T) {
+		out := apiKeyAuthForConsumers(trafficPolicySpecIr{apiKeyAuth: envoyAPIKeyAuth, apiConsumers: consumers})
+		require.NotNil(t, out)
+		assert.Nil(t, out.config)
+		assert.Equal(t, consumers.verifierCluster, out.verifierCluster)
+
+		var cfg apikeyauth.Config
+		raw := out.verifier.GetCheckSettings().GetContextExtensions()[apikeyauth.ConfigContextExtension]
+		require.NoError(t, json.Unmarshal([]byte(raw), &cfg))
+		assert.True(t, cfg.HideCredentials)
+		assert.Equal(t, []apikeyauth.Source{{Header: "api-key"}, {Query: "api_key"}}, cfg.Sources)
+		assert.Equal(t, map[string]string{"x-client-id": apikeyauth.ClientMetadataKey}, cfg.Headers)
+		assert.ElementsMatch(t, []apikeyauth.Key{
+			{Digest: apikeyauth.Digest("k-1"), Metadata: map[string]string{apikeyauth.ClientMetadataKey: "client1"}},
+			{Digest: apikeyauth.Digest("k-2"), Metadata: map[string]string{apikeyauth.ClientMetadataKey: "client2"}},
+		}, cfg.Keys)
+
+		// the merged policy is not modified
+		assert.NotNil(t, envoyAPIKeyAuth.config)
+		assert.Nil(t, envoyAPIKeyAuth.verifier)
+	})
+
+	t.Run("already verified", func(t *testing.T) {
+		verified := &apiKeyAuthIR{verifier: &envoy_ext_authz_v3.ExtAuthzPerRoute{}}
+		assert.Same(t, verified, apiKeyAuthForConsumers(trafficPolicySpecIr{apiKeyAuth: verified, apiConsumers: consumers}))
+	})
+}
@@ -1,14 +1,23 @@
 package trafficpolicy
 
 import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	envoyapikeyauthv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/api_key_auth/v3"
+	envoy_ext_authz_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
 	"google.golang.org/protobuf/proto"
 	"istio.io/istio/pkg/kube/krt"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/apikeyauth"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
@@ -17,11 +26,19 @@ import (
 
 const (
 	apiKeyAuthFilterNamePrefix = "envoy.filters.http.api_key_auth" //nolint:gosec
+
+	// apiKeyAuthVerifierFilterName is the ext_authz filter calling the kgateway API key verifier.
+	// The metadata of the API keys is emitted under this dynamic metadata namespace.
+	apiKeyAuthVerifierFilterName = extauthFilterNamePrefix + "/api_key_verifier"
 )
 
 // apiKeyAuthIR is the internal representation of an API key authentication policy.
 type apiKeyAuthIR struct {
 	config *envoyapikeyauthv3.ApiKeyAuthPerRoute
+	// verifier is set instead of config when the keys are verified by the kgateway verifier
+	verifier *envoy_ext_authz_v3.ExtAuthzPerRoute
+	// verifierCluster is the cluster of the kgateway auth verifier
+	verifierCluster string
 }
 
 func (a *apiKeyAuthIR) Equals(other *apiKeyAuthIR) bool {
@@ -31,14 +48,8 @@ func (a *apiKeyAuthIR) Equals(other *apiKeyAuthIR) bool {
 	if a == nil || other == nil {
 		return false
 	}
-	if a.config == nil && other.config == nil {
-		return true
-	}
-	if a.config == nil || other.config == nil {
-		return false
-	}
 	// Compare the serialized configs for equality using proto.Equal
-	return proto.Equal(a.config, other.config)
+	return proto.Equal(a.config, other.config) && proto.Equal(a.verifier, other.verifier) && a.verifierCluster == other.verifierCluster
 }
 
 // Validate performs validation on the API key auth component.
@@ -46,10 +57,15 @@ func (a *apiKeyAuthIR) Validate() error {
 	if a == nil {
 		return nil
 	}
-	if a.config == nil {
-		return nil
+	if a.config != nil {
+		if err := a.config.Validate(); err != nil {
+			return err
+		}
+	}
+	if a.verifier != nil {
+		return a.verifier.Validate()
 	}
-	return a.config.Validate()
+	return nil
 }
 
 // constructAPIKeyAuth translates the API key authentication spec into an Envoy API key auth per-route configuration
@@ -101,14 +117,26 @@ func constructAPIKeyAuth(
 		return fmt.Errorf("either secretRef or secretSelector must be specified")
 	}
 
+	// Secret data fields holding metadata are not API keys
+	metadataFields := sets.New[string]()
+	for _, m := range ak.Metadata {
+		if m.Field != nil {
+			metadataFields.Insert(*m.Field)
+		}
+	}
+	keyFormat := ptr.Deref(ak.KeyFormat, kgateway.APIKeyFormatPlaintext)
+	// API consumers resolve API keys through the client identifier emitted by the verifier
+	useVerifier := keyFormat == kgateway.APIKeyFormatSHA256 || len(ak.Metadata) > 0 || spec.APIConsumers != nil
+
 	// Parse secrets and build credentials
 	var credentials []*envoyapikeyauthv3.Credential
+	var verifierKeys []apikeyauth.Key
 	var errs []error
 
 	for _, secret := range secrets {
 		for keyName, keyValue := range secret.Data {
 			// Skip empty values
-			if len(keyValue) == 0 {
+			if len(keyValue) == 0 || metadataFields.Has(keyName) {
 				continue
 			}
 
@@ -120,6 +148,19 @@ func constructAPIKeyAuth(
 				continue
 			}
 
+			if useVerifier {
+				digest, err := apiKeyDigest(keyFormat, apiKey)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("secret %s key %s: %w", secret.ObjectSource.Name, keyName, err))
+					continue
+				}
+				verifierKeys = append(verifierKeys, apikeyauth.Key{
+					Digest:   digest,
+					Metadata: apiKeyMetadata(secret, keyName, ak.Metadata),
+				})
+				continue
+			}
+
 			credentials = append(credentials, &envoyapikeyauthv3.Credential{
 				Key:    apiKey,
 				Client: keyName,
@@ -131,7 +172,7 @@ func constructAPIKeyAuth(
 		return fmt.Errorf("errors processing API key secrets: %v", errs)
 	}
 
-	if len(credentials) == 0 {
+	if len(credentials) == 0 && len(verifierKeys) == 0 {
 		return fmt.Errorf("no valid API keys found in secrets")
 	}
 
@@ -171,6 +212,17 @@ func constructAPIKeyAuth(
 		hideCredentials = !(*ak.ForwardCredential)
 	}
 
+	if useVerifier {
+		verifier, err := apiKeyAuthVerifier(ak, verifierKeys, envoyKeySources, hideCredentials)
+		if err != nil {
+			return err
+		}
+		out.apiKeyAuth = &apiKeyAuthIR{
+			verifier: verifier,
+		}
+		return nil
+	}
+
 	// Build Envoy API key auth per-route configuration
 	apiKeyAuthPolicy := &envoyapikeyauthv3.ApiKeyAuthPerRoute{
 		Credentials: credentials,
@@ -192,6 +244,133 @@ func constructAPIKeyAuth(
 	return nil
 }
 
+// apiKeyDigest returns the hex encoded SHA-256 digest of a stored API key.
+func apiKeyDigest(format kgateway.APIKeyFormat, stored string) (string, error) {
+	if format != kgateway.APIKeyFormatSHA256 {
+		return apikeyauth.Digest(stored), nil
+	}
+	digest := strings.ToLower(strings.TrimSpace(stored))
+	if b, err := hex.DecodeString(digest); err != nil || len(b) != 32 {
+		// Don't include the value to avoid leaking sensitive info
+		return "", errors.New("invalid SHA-256 digest, expected 64 hex characters")
+	}
+	return digest, nil
+}
+
+// apiKeyMetadata returns the metadata of an API key, read from the Secret it is stored in.
+func apiKeyMetadata(secret ir.Secret, keyName string, metadata []kgateway.APIKeyMetadata) map[string]string {
+	res := map[string]string{
+		apikeyauth.ClientMetadataKey: keyName,
+	}
+	for _, m := range metadata {
+		switch {
+		case m.Label != nil && secret.Obj != nil:
+			if v, ok := secret.Obj.GetLabels()[*m.Label]; ok {
+				res[m.Name] = v
+			}
+		case m.Field != nil:
+			if v, ok := secret.Data[*m.Field]; ok {
+				res[m.Name] = strings.TrimSpace(string(v))
+			}
+		}
+	}
+	return res
+}
+
+// apiKeyAuthVerifier configures the ext_authz filter to verify the API keys with the kgateway verifier,
+// which receives the key digests and metadata in the context extensions of the route.
+func apiKeyAuthVerifier(
+	ak *kgateway.APIKeyAuthentication,
+	keys []apikeyauth.Key,
+	keySources []*envoyapikeyauthv3.KeySource,
+	hideCredentials bool,
+) (*envoy_ext_authz_v3.ExtAuthzPerRoute, error) {
+	cfg := apikeyauth.Config{
+		Keys:            keys,
+		HideCredentials: hideCredentials,
+	}
+	for _, ks := range keySources {
+		cfg.Sources = append(cfg.Sources, apikeyauth.Source{
+			Header: ks.GetHeader(),
+			Query:  ks.GetQuery(),
+			Cookie: ks.GetCookie(),
+		})
+	}
+	headers := map[string]string{}
+	if ak.ClientIdHeader != nil {
+		headers[strings.ToLower(*ak.ClientIdHeader)] = apikeyauth.ClientMetadataKey
+	}
+	for _, m := range ak.Metadata {
+		if m.Header != nil {
+			headers[strings.ToLower(*m.Header)] = m.Name
+		}
+	}
+	if len(headers) > 0 {
+		cfg.Headers = headers
+	}
+	return apiKeyAuthVerifierPerRoute(cfg)
+}
+
+// withVerifier returns the API key auth verifying the keys with the kgateway verifier, which emits
+// the client identifier of the keys that API consumers resolve. It converts the API key auth of a
+// policy merged with the one setting apiConsumers, whose own keys are already verified by it.
+func (a *apiKeyAuthIR) withVerifier(verifierCluster string) (*apiKeyAuthIR, error) {
+	if a == nil || a.config == nil {
+		return a, nil
+	}
+
+	cfg := apikeyauth.Config{
+		HideCredentials: a.config.GetForwarding().GetHideCredentials(),
+	}
+	for _, c := range a.config.GetCredentials() {
+		cfg.Keys = append(cfg.Keys, apikeyauth.Key{
+			Digest:   apikeyauth.Digest(c.GetKey()),
+			Metadata: map[string]string{apikeyauth.ClientMetadataKey: c.GetClient()},
+		})
+	}
+	for _, ks := range a.config.GetKeySources() {
+		cfg.Sources = append(cfg.Sources, apikeyauth.Source{
+			Header: ks.GetHeader(),
+			Query:  ks.GetQuery(),
+			Cookie: ks.GetCookie(),
+		})
+	}
+	if h := a.config.GetForwarding().GetHeader(); h != "" {
+		cfg.Headers = map[string]string{strings.ToLower(h): apikeyauth.ClientMetadataKey}
+	}
+
+	verifier, err := apiKeyAuthVerifierPerRoute(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &apiKeyAuthIR{
+		verifier:        verifier,
+		verifierCluster: verifierCluster,
+	}, nil
+}
+
+// apiKeyAuthVerifierPerRoute passes the verifier configuration in the context extensions of the route.
+func apiKeyAuthVerifierPerRoute(cfg apikeyauth.Config) (*envoy_ext_authz_v3.ExtAuthzPerRoute, error) {
+	// Secret data is iterated in random order, sort for a stable configuration
+	slices.SortFunc(cfg.Keys, func(a, b apikeyauth.Key) int {
+		return strings.Compare(a.Digest, b.Digest)
+	})
+
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode API key verifier config: %w", err)
+	}
+	return &envoy_ext_authz_v3.ExtAuthzPerRoute{
+		Override: &envoy_ext_authz_v3.ExtAuthzPerRoute_CheckSettings{
+			CheckSettings: &envoy_ext_authz_v3.CheckSettings{
+				ContextExtensions: map[string]string{
+					apikeyauth.ConfigContextExtension: string(raw),
+				},
+			},
+		},
+	}, nil
+}
+
 // handleAPIKeyAuth configures the API key auth filter and per-route API key auth configuration.
 // This follows the same pattern as CORS: add the policy to the typed_per_filter_config.
 // Also requires API key auth http_filter to be added to the filter chain.
@@ -200,7 +379,20 @@ func (p *trafficPolicyPluginGwPass) handleAPIKeyAuth(
 	pCtxTypedFilterConfig *ir.TypedFilterConfigMap,
 	apiKeyAuthIr *apiKeyAuthIR,
 ) {
-	if apiKeyAuthIr == nil || apiKeyAuthIr.config == nil {
+	if apiKeyAuthIr == nil {
+		return
+	}
+
+	// Keys verified by the kgateway verifier through the ext_authz filter
+	if apiKeyAuthIr.verifier != nil {
+		pCtxTypedFilterConfig.AddTypedConfig(apiKeyAuthVerifierFilterName, apiKeyAuthIr.verifier)
+		if p.apiKeyAuthVerifierInChain == nil {
+			p.apiKeyAuthVerifierInChain = make(map[string]string)
+		}
+		p.apiKeyAuthVerifierInChain[fcn] = apiKeyAuthIr.verifierCluster
+		return
+	}
+	if apiKeyAuthIr.config == nil {
 		return
 	}
 
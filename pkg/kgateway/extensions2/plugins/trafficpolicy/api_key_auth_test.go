@@ -1,12 +1,19 @@
 package trafficpolicy
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
 	envoyapikeyauthv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/api_key_auth/v3"
+	envoy_ext_authz_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/apikeyauth"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
 )
 
@@ -313,3 +320,134 @@ func TestHandleAPIKeyAuth(t *testing.T) {
 		})
 	}
 }
+
+func TestAPIKeyDigest(t *testing.T) {
+	// echo -n "k-123" | sha256sum
+	const digest = "3605a9e4358da4302f8acea41f0f52cef85d0e3f727c7b020fc7305aec8d56b4"
+
+	tests := []struct {
+		name      string
+		format    kgateway.APIKeyFormat
+		stored    string
+		want      string
+		wantError bool
+	}{
+		{
+			name:   "plaintext key is hashed",
+			format: kgateway.APIKeyFormatPlaintext,
+			stored: "k-123",
+			want:   apikeyauth.Digest("k-123"),
+		},
+		{
+			name:   "sha256 digest is normalized",
+			format: kgateway.APIKeyFormatSHA256,
+			stored: strings.ToUpper(digest) + "\n",
+			want:   digest,
+		},
+		{
+			name:      "sha256 digest with invalid length",
+			format:    kgateway.APIKeyFormatSHA256,
+			stored:    digest[:62],
+			wantError: true,
+		},
+		{
+			name:      "sha256 digest that is not hex",
+			format:    kgateway.APIKeyFormatSHA256,
+			stored:    "k-123",
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := apiKeyDigest(tt.format, tt.stored)
+			if tt.wantError {
+				require.Error(t, err)
+				assert.NotContains(t, err.Error(), tt.stored)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAPIKeyMetadata(t *testing.T) {
+	secret := ir.Secret{
+		Obj: &metav1.ObjectMeta{
+			Labels: map[string]string{"example.com/plan": "gold"},
+		},
+		Data: map[string][]byte{
+			"client1": []byte("k-123"),
+			"owner":   []byte("team-a\n"),
+		},
+	}
+	metadata := []kgateway.APIKeyMetadata{
+		{Name: "plan", Label: ptr.To("example.com/plan")},
+		{Name: "owner", Field: ptr.To("owner")},
+		{Name: "tier", Label: ptr.To("example.com/tier")},
+	}
+
+	assert.Equal(t, map[string]string{
+		apikeyauth.ClientMetadataKey: "client1",
+		"plan":                       "gold",
+		"owner":                      "team-a",
+	}, apiKeyMetadata(secret, "client1", metadata))
+
+	// the client identifier can be overridden
+	metadata = append(metadata, kgateway.APIKeyMetadata{Name: apikeyauth.ClientMetadataKey, Field: ptr.To("owner")})
+	assert.Equal(t, "team-a", apiKeyMetadata(secret, "client1", metadata)[apikeyauth.ClientMetadataKey])
+}
+
+func TestAPIKeyAuthVerifier(t *testing.T) {
+	ak := &kgateway.APIKeyAuthentication{
+		ClientIdHeader: ptr.To("X-Client-ID"),
+		Metadata: []kgateway.APIKeyMetadata{
+			{Name: "plan", Label: ptr.To("example.com/plan"), Header: ptr.To("X-Plan")},
+			{Name: "owner", Field: ptr.To("owner")},
+		},
+	}
+	keys := []apikeyauth.Key{
+		{Digest: apikeyauth.Digest("k-2"), Metadata: map[string]string{apikeyauth.ClientMetadataKey: "client2"}},
+		{Digest: apikeyauth.Digest("k-1"), Metadata: map[string]string{apikeyauth.ClientMetadataKey: "client1"}},
+	}
+	sources := []*envoyapikeyauthv3.KeySource{{Header: "api-key"}, {Query: "api_key"}}
+
+	verifier, err := apiKeyAuthVerifier(ak, keys, sources, true)
+	require.NoError(t, err)
+	require.NoError(t, verifier.Validate())
+
+	raw := verifier.GetCheckSettings().GetContextExtensions()[apikeyauth.ConfigContextExtension]
+	var cfg apikeyauth.Config
+	require.NoError(t, json.Unmarshal([]byte(raw), &cfg))
+
+	assert.True(t, cfg.HideCredentials)
+	assert.Equal(t, []apikeyauth.Source{{Header: "api-key"}, {Query: "api_key"}}, cfg.Sources)
+	assert.Equal(t, map[string]string{"x-client-id": apikeyauth.ClientMetadataKey, "x-plan": "plan"}, cfg.Headers)
+	require.Len(t, cfg.Keys, 2)
+	assert.True(t, cfg.Keys[0].Digest < cfg.Keys[1].Digest, "keys should be sorted by digest")
+
+	// the configuration is stable
+	again, err := apiKeyAuthVerifier(ak, []apikeyauth.Key{keys[1], keys[0]}, sources, true)
+	require.NoError(t, err)
+	assert.Equal(t, raw, again.GetCheckSettings().GetContextExtensions()[apikeyauth.ConfigContextExtension])
+}
+
+func TestHandleAPIKeyAuthVerifier(t *testing.T) {
+	plugin := &trafficPolicyPluginGwPass{}
+	fcn := "test-filter-chain"
+	typedFilterConfig := &ir.TypedFilterConfigMap{}
+	verifier := &envoy_ext_authz_v3.ExtAuthzPerRoute{
+		Override: &envoy_ext_authz_v3.ExtAuthzPerRoute_CheckSettings{
+			CheckSettings: &envoy_ext_authz_v3.CheckSettings{
+				ContextExtensions: map[string]string{apikeyauth.ConfigContextExtension: "{}"},
+			},
+		},
+	}
+
+	plugin.handleAPIKeyAuth(fcn, typedFilterConfig, &apiKeyAuthIR{verifier: verifier, verifierCluster: "kube_kgateway-system_auth-verifier_9000"})
+
+	assert.Equal(t, "kube_kgateway-system_auth-verifier_9000", plugin.apiKeyAuthVerifierInChain[fcn], "should add the verifier to chain")
+	assert.Nil(t, plugin.apiKeyAuthInChain[fcn], "should not add the api key auth filter to chain")
+	assert.Equal(t, verifier, typedFilterConfig.GetTypedConfig(apiKeyAuthVerifierFilterName))
+	assert.Nil(t, typedFilterConfig.GetTypedConfig(apiKeyAuthFilterNamePrefix))
+}
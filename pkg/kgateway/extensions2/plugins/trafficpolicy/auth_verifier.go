@@ -0,0 +1,107 @@
+package trafficpolicy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_ext_authz_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"istio.io/istio/pkg/kube/krt"
+	"k8s.io/utils/ptr"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+const authVerifierTimeout = 2 * time.Second
+
+// authVerifierFilter is an ext_authz filter calling the kgateway auth verifier through the cluster
+// of its Service. The verifier and its configuration are selected by the per-route context extensions.
+func authVerifierFilter(cluster string) *envoy_ext_authz_v3.ExtAuthz {
+	return &envoy_ext_authz_v3.ExtAuthz{
+		Services: &envoy_ext_authz_v3.ExtAuthz_GrpcService{
+			GrpcService: &envoycorev3.GrpcService{
+				TargetSpecifier: &envoycorev3.GrpcService_EnvoyGrpc_{
+					EnvoyGrpc: &envoycorev3.GrpcService_EnvoyGrpc{
+						ClusterName: cluster,
+					},
+				},
+				Timeout: durationpb.New(authVerifierTimeout),
+			},
+		},
+		TransportApiVersion: envoycorev3.ApiVersion_V3,
+	}
+}
+
+// usesAuthVerifier reports whether the policy is verified by the kgateway auth verifier.
+func (s *trafficPolicySpecIr) usesAuthVerifier() bool {
+	return (s.basicAuth != nil && s.basicAuth.verifier != nil) ||
+		(s.apiKeyAuth != nil && s.apiKeyAuth.verifier != nil) ||
+		s.apiConsumers != nil
+}
+
+// constructAuthVerifier resolves the cluster of the kgateway auth verifier for the policies it verifies.
+// The policy is rejected when the auth verifier is not configured, rather than leaving the routes
+// unauthenticated.
+func constructAuthVerifier(
+	krtctx krt.HandlerContext,
+	in *kgateway.TrafficPolicy,
+	commoncol *collections.CommonCollections,
+	out *trafficPolicySpecIr,
+) error {
+	if !out.usesAuthVerifier() {
+		return nil
+	}
+	cluster, err := resolveAuthVerifierCluster(krtctx, in, commoncol)
+	if err != nil {
+		return err
+	}
+	if out.basicAuth != nil && out.basicAuth.verifier != nil {
+		out.basicAuth.verifierCluster = cluster
+	}
+	if out.apiKeyAuth != nil && out.apiKeyAuth.verifier != nil {
+		out.apiKeyAuth.verifierCluster = cluster
+	}
+	if out.apiConsumers != nil {
+		out.apiConsumers.verifierCluster = cluster
+	}
+	return nil
+}
+
+// resolveAuthVerifierCluster returns the cluster of the auth verifier Service set by the AuthVerifierService setting.
+func resolveAuthVerifierCluster(
+	krtctx krt.HandlerContext,
+	in *kgateway.TrafficPolicy,
+	commoncol *collections.CommonCollections,
+) (string, error) {
+	service := commoncol.Settings.AuthVerifierService
+	if service == "" {
+		return "", errors.New("the kgateway auth verifier is not configured, set KGW_AUTH_VERIFIER_SERVICE on the controller")
+	}
+	namespace, name, ok := strings.Cut(service, "/")
+	if !ok || namespace == "" || name == "" {
+		return "", fmt.Errorf("invalid auth verifier service %q, expected namespace/name", service)
+	}
+	src := ir.ObjectSource{
+		Group:     wellknown.TrafficPolicyGVK.Group,
+		Kind:      wellknown.TrafficPolicyGVK.Kind,
+		Namespace: in.Namespace,
+		Name:      in.Name,
+	}
+	// The Service is set by the cluster administrator, so it does not need a ReferenceGrant.
+	backend, err := commoncol.BackendIndex.GetBackendFromRefWithoutRefGrantValidation(krtctx, src, gwv1.BackendObjectReference{
+		Name:      gwv1.ObjectName(name),
+		Namespace: ptr.To(gwv1.Namespace(namespace)),
+		Port:      ptr.To(gwv1.PortNumber(commoncol.Settings.AuthVerifierServicePort)), //nolint:gosec // G115: ports fit in int32
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the kgateway auth verifier service %s: %w", service, err)
+	}
+	return backend.ClusterName(), nil
+}
@@ -1,12 +1,14 @@
 package trafficpolicy
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	envoy_basic_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/basic_auth/v3"
+	envoy_ext_authz_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"istio.io/istio/pkg/kube/krt"
@@ -14,6 +16,7 @@ import (
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/basicauth"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
@@ -23,11 +26,18 @@ const (
 	basicAuthFilterName = "envoy.filters.http.basic_auth"
 	defaultSecretKey    = ".htpasswd"
 	shaPrefix           = "{SHA}"
+
+	// basicAuthVerifierFilterName is the ext_authz filter calling the kgateway basic auth verifier.
+	basicAuthVerifierFilterName = extauthFilterNamePrefix + "/basic_auth_verifier"
 )
 
 type basicAuthIR struct {
-	policy  *envoy_basic_auth_v3.BasicAuthPerRoute
-	disable bool
+	policy *envoy_basic_auth_v3.BasicAuthPerRoute
+	// verifier is set instead of policy when the passwords are verified by the kgateway verifier
+	verifier *envoy_ext_authz_v3.ExtAuthzPerRoute
+	// verifierCluster is the cluster of the kgateway auth verifier
+	verifierCluster string
+	disable         bool
 }
 
 var _ PolicySubIR = &basicAuthIR{}
@@ -40,17 +50,25 @@ func (b *basicAuthIR) Equals(other PolicySubIR) bool {
 	if b == nil || otherBasicAuth == nil {
 		return b == nil && otherBasicAuth == nil
 	}
-	if b.disable != otherBasicAuth.disable {
+	if b.disable != otherBasicAuth.disable || b.verifierCluster != otherBasicAuth.verifierCluster {
 		return false
 	}
-	return proto.Equal(b.policy, otherBasicAuth.policy)
+	return proto.Equal(b.policy, otherBasicAuth.policy) && proto.Equal(b.verifier, otherBasicAuth.verifier)
 }
 
 func (b *basicAuthIR) Validate() error {
-	if b == nil || b.policy == nil {
+	if b == nil {
 		return nil
 	}
-	return b.policy.Validate()
+	if b.policy != nil {
+		if err := b.policy.Validate(); err != nil {
+			return err
+		}
+	}
+	if b.verifier != nil {
+		return b.verifier.Validate()
+	}
+	return nil
 }
 
 // handleBasicAuth configures the per-route basic auth configuration and registers the disabled global filter
@@ -66,6 +84,17 @@ func (p *trafficPolicyPluginGwPass) handleBasicAuth(
 	// Handle disable case - enable the filter with empty config to override parent policy
 	if basicAuth.disable {
 		pCtxTypedFilterConfig.AddTypedConfig(basicAuthFilterName, &envoyroutev3.FilterConfig{Config: &anypb.Any{}, Disabled: true})
+		pCtxTypedFilterConfig.AddTypedConfig(basicAuthVerifierFilterName, &envoyroutev3.FilterConfig{Config: &anypb.Any{}, Disabled: true})
+		return
+	}
+
+	// Passwords verified by the kgateway verifier through the ext_authz filter
+	if basicAuth.verifier != nil {
+		pCtxTypedFilterConfig.AddTypedConfig(basicAuthVerifierFilterName, basicAuth.verifier)
+		if p.basicAuthVerifierInChain == nil {
+			p.basicAuthVerifierInChain = make(map[string]string)
+		}
+		p.basicAuthVerifierInChain[fcn] = basicAuth.verifierCluster
 		return
 	}
 
@@ -127,6 +156,10 @@ func constructBasicAuth(
 		return fmt.Errorf("basic auth: either users or secretRef must be specified")
 	}
 
+	if spec.Verifier != nil && *spec.Verifier == kgateway.BasicAuthVerifierKgateway {
+		return constructBasicAuthVerifier(htpasswdData, out)
+	}
+
 	// Validate and filter users to only include SHA hashed passwords
 	validUsers, invalidUsers := validateAndFilterSHAUsers(htpasswdData)
 
@@ -137,7 +170,7 @@ func constructBasicAuth(
 
 	// Report invalid users if any were found
 	if len(invalidUsers) > 0 {
-		err = fmt.Errorf("basic auth: dropped %d user(s) with invalid hash format (only {SHA} is supported) or duplicate usernames.", len(invalidUsers))
+		err = fmt.Errorf("basic auth: dropped %d user(s) with invalid hash format (only {SHA} is supported, set verifier to Kgateway for bcrypt, APR1 and SHA-crypt hashes) or duplicate usernames.", len(invalidUsers))
 	}
 
 	allUsers := strings.Join(validUsers, "\n")
@@ -160,6 +193,76 @@ func constructBasicAuth(
 	return err
 }
 
+// constructBasicAuthVerifier configures the ext_authz filter to verify the passwords with the kgateway
+// verifier, which receives the users in the context extensions of the route. Unlike the Envoy verifier,
+// invalid users are not dropped: the policy is rejected.
+func constructBasicAuthVerifier(htpasswdData string, out *trafficPolicySpecIr) error {
+	users, err := validateVerifierUsers(htpasswdData)
+	if err != nil {
+		return fmt.Errorf("basic auth: %w", err)
+	}
+	if len(users) == 0 {
+		return errors.New("basic auth: no users found")
+	}
+
+	out.basicAuth = &basicAuthIR{
+		verifier: &envoy_ext_authz_v3.ExtAuthzPerRoute{
+			Override: &envoy_ext_authz_v3.ExtAuthzPerRoute_CheckSettings{
+				CheckSettings: &envoy_ext_authz_v3.CheckSettings{
+					ContextExtensions: map[string]string{
+						basicauth.HtpasswdContextExtension: strings.Join(users, "\n"),
+					},
+				},
+			},
+		},
+	}
+	return nil
+}
+
+// validateVerifierUsers validates htpasswd entries for the kgateway verifier, returning the users
+// or an error listing every malformed entry, duplicate username and unsupported password hash.
+func validateVerifierUsers(htpasswdData string) ([]string, error) {
+	var (
+		users     []string
+		usernames = sets.New[string]()
+		errs      []error
+	)
+	for i, line := range strings.Split(htpasswdData, "\n") {
+		line = strings.TrimSpace(line)
+
+		// Skip empty lines and comments
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		username, passwordHash, ok := strings.Cut(line, ":")
+		if !ok {
+			// Don't include the entire line to avoid leaking sensitive info
+			errs = append(errs, fmt.Errorf("line %d: malformed htpasswd entry, missing colon", i+1))
+			continue
+		}
+		if usernames.Has(username) {
+			errs = append(errs, fmt.Errorf("user %s: duplicate username", username))
+			continue
+		}
+		if _, err := basicauth.DetectFormat(passwordHash); err != nil {
+			errs = append(errs, fmt.Errorf("user %s: %w", username, err))
+			continue
+		}
+		usernames.Insert(username)
+		users = append(users, line)
+	}
+	return users, errors.Join(errs...)
+}
+
+// basicAuthVerifierFilter is the ext_authz filter calling the kgateway basic auth verifier. Only
+// the Authorization header is sent, the users are sent in the per-route context extensions.
+func basicAuthVerifierFilter(cluster string) *envoy_ext_authz_v3.ExtAuthz {
+	filter := authVerifierFilter(cluster)
+	filter.AllowedHeaders = buildStringListMatcher([]string{"authorization"})
+	return filter
+}
+
 // fetchHtpasswdFromSecret retrieves htpasswd data from a Kubernetes secret
 func fetchHtpasswdFromSecret(
 	krtctx krt.HandlerContext,
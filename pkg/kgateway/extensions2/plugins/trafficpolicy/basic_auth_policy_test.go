@@ -147,3 +147,52 @@ user:{SHA}2kuSN7rMzfGcB2DKt67EqDWQELA=`,
 		})
 	}
 }
+
+func TestValidateVerifierUsers(t *testing.T) {
+	tests := []struct {
+		name          string
+		htpasswdData  string
+		expectedUsers []string
+		expectedError string
+	}{
+		{
+			name: "supported hash formats",
+			htpasswdData: `# comment
+sha:{SHA}NWoZK3kTsExUV00Ywo1G5jlUKKs=
+bcrypt:$2y$05$r3J4d3VepzFkedkd/q1vI.pBYIpSqjfN0qOARV3ScUHysatnS0cL2
+
+apr1:$apr1$3zSE0Abt$IuETi4l5yO87MuOrbSE4V.
+sha256:$5$saltstring$5B8vYYiY.CVt1RlTTf8KbXBH3hsxY/GNooZaBBGWEc5
+sha512:$6$saltstring$svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl/O817G3uBnIFNjnQJuesI68u4OTLiBFdcbYEdFCoEOfaS35inz1`,
+			expectedUsers: []string{
+				"sha:{SHA}NWoZK3kTsExUV00Ywo1G5jlUKKs=",
+				"bcrypt:$2y$05$r3J4d3VepzFkedkd/q1vI.pBYIpSqjfN0qOARV3ScUHysatnS0cL2",
+				"apr1:$apr1$3zSE0Abt$IuETi4l5yO87MuOrbSE4V.",
+				"sha256:$5$saltstring$5B8vYYiY.CVt1RlTTf8KbXBH3hsxY/GNooZaBBGWEc5",
+				"sha512:$6$saltstring$svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl/O817G3uBnIFNjnQJuesI68u4OTLiBFdcbYEdFCoEOfaS35inz1",
+			},
+		},
+		{
+			name: "invalid users are rejected",
+			htpasswdData: `alice:{SHA}NWoZK3kTsExUV00Ywo1G5jlUKKs=
+malformedentry
+alice:{SHA}2kuSN7rMzfGcB2DKt67EqDWQELA=
+bob:rl5FQ9fW/7E6A`,
+			expectedError: "line 2: malformed htpasswd entry, missing colon\n" +
+				"user alice: duplicate username\n" +
+				"user bob: unsupported password hash format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, err := validateVerifierUsers(tt.htpasswdData)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedUsers, users)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"istio.io/istio/pkg/kube/kclient"
 	"istio.io/istio/pkg/kube/krt"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
 )
@@ -22,6 +24,8 @@ type TrafficPolicyConstructor struct {
 	commoncol         *collections.CommonCollections
 	gatewayExtensions krt.Collection[TrafficPolicyGatewayExtensionIR]
 	extBuilder        func(krtctx krt.HandlerContext, gExt ir.GatewayExtension) *TrafficPolicyGatewayExtensionIR
+	apiConsumers      krt.Collection[*kgateway.APIConsumer]
+	apiConsumersByNs  krt.Index[string, *kgateway.APIConsumer]
 }
 
 func NewTrafficPolicyConstructor(
@@ -33,10 +37,17 @@ func NewTrafficPolicyConstructor(
 		return extBuilder(krtctx, gExt)
 	}
 	gatewayExtensions := krt.NewCollection(commoncol.GatewayExtensions, defaultExtBuilder)
+	apiConsumers := krt.WrapClient(kclient.NewFilteredDelayed[*kgateway.APIConsumer](
+		commoncol.Client,
+		wellknown.APIConsumerGVR,
+		kclient.Filter{ObjectFilter: commoncol.Client.ObjectFilter()},
+	), commoncol.KrtOpts.ToOptions("APIConsumers")...)
 	return &TrafficPolicyConstructor{
 		commoncol:         commoncol,
 		gatewayExtensions: gatewayExtensions,
 		extBuilder:        extBuilder,
+		apiConsumers:      apiConsumers,
+		apiConsumersByNs:  krt.NewNamespaceIndex(apiConsumers),
 	}
 }
 
@@ -112,6 +123,10 @@ func (c *TrafficPolicyConstructor) ConstructIR(
 	if err := constructOAuth2(krtctx, policyCR, c.FetchGatewayExtension, &outSpec); err != nil {
 		errors = append(errors, err)
 	}
+	// Construct token introspection specific IR
+	if err := constructOAuth2Introspection(krtctx, policyCR, c.FetchGatewayExtension, &outSpec); err != nil {
+		errors = append(errors, err)
+	}
 
 	// Construct url rewrite specific IR
 	constructURLRewrite(policyCR.Spec, &outSpec)
@@ -119,6 +134,12 @@ func (c *TrafficPolicyConstructor) ConstructIR(
 	if err := constructBasicAuth(krtctx, policyCR, &outSpec, c.commoncol.Secrets); err != nil {
 		errors = append(errors, err)
 	}
+	// Construct API consumers specific IR
+	constructAPIConsumers(krtctx, policyCR, c.FetchAPIConsumers, &outSpec)
+	// Resolve the kgateway auth verifier of the basic auth, API key auth and API consumers IR
+	if err := constructAuthVerifier(krtctx, policyCR, c.commoncol, &outSpec); err != nil {
+		errors = append(errors, err)
+	}
 
 	for _, err := range errors {
 		logger.Error("error translating traffic policy", "namespace", policyCR.GetNamespace(), "name", policyCR.GetName(), "error", err)
@@ -145,6 +166,15 @@ func (c *TrafficPolicyConstructor) FetchGatewayExtension(krtctx krt.HandlerConte
 	return gatewayExtension, nil
 }
 
+// FetchAPIConsumers returns the APIConsumers in a namespace matching the labels, or all of them if matchLabels is empty.
+func (c *TrafficPolicyConstructor) FetchAPIConsumers(krtctx krt.HandlerContext, namespace string, matchLabels map[string]string) []*kgateway.APIConsumer {
+	opts := []krt.FetchOption{krt.FilterIndex(c.apiConsumersByNs, namespace)}
+	if len(matchLabels) > 0 {
+		opts = append(opts, krt.FilterLabel(matchLabels))
+	}
+	return krt.Fetch(krtctx, c.apiConsumers, opts...)
+}
+
 func (c *TrafficPolicyConstructor) HasSynced() bool {
-	return c.gatewayExtensions.HasSynced()
+	return c.gatewayExtensions.HasSynced() && c.apiConsumers.HasSynced()
 }
@@ -0,0 +1,202 @@
+package trafficpolicy
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_ext_authz_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	envoyluav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	envoy_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+)
+
+const (
+	// extAuthCacheSourceCodeName is the name of the Lua source code that routes enable.
+	extAuthCacheSourceCodeName = "ext_auth_cache"
+
+	extAuthCacheFilterNamePrefix       = "ext_auth_cache"
+	extAuthCacheRecordFilterNamePrefix = "ext_auth_cache_record"
+	extAuthCacheSkipMetadataKey        = "skip"
+
+	defaultExtAuthCacheMaxEntries = 10000
+)
+
+// Envoy has no ext_authz decision cache, so decisions are cached in a Lua filter running before
+// the ext_authz filter, and recorded by a Lua filter running after it.
+var (
+	//go:embed extauth_cache.lua
+	extAuthCacheScript string
+	//go:embed extauth_cache_record.lua
+	extAuthCacheRecordScript string
+)
+
+// extAuthCacheRecordPerRoute enables the record filter of a provider.
+var extAuthCacheRecordPerRoute = &envoyluav3.LuaPerRoute{
+	Override: &envoyluav3.LuaPerRoute_Name{
+		Name: extAuthCacheSourceCodeName,
+	},
+}
+
+// extAuthCachePerProviderConfig holds the Lua filters caching the decisions of an ext auth provider.
+type extAuthCachePerProviderConfig struct {
+	cache  *envoyluav3.Lua
+	record *envoyluav3.Lua
+}
+
+func (a *extAuthCachePerProviderConfig) Equals(b *extAuthCachePerProviderConfig) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return proto.Equal(a.cache, b.cache) && proto.Equal(a.record, b.record)
+}
+
+func (a *extAuthCachePerProviderConfig) Validate() error {
+	if a == nil {
+		return nil
+	}
+	if err := a.cache.ValidateAll(); err != nil {
+		return err
+	}
+	return a.record.ValidateAll()
+}
+
+func extAuthCacheFilterName(provider string) string {
+	return fmt.Sprintf("%s/%s", extAuthCacheFilterNamePrefix, provider)
+}
+
+func extAuthCacheRecordFilterName(provider string) string {
+	return fmt.Sprintf("%s/%s", extAuthCacheRecordFilterNamePrefix, provider)
+}
+
+// extAuthCacheEnabledMetadataMatcher enables the ext_authz filter of a provider unless the
+// decision cache filter set the skip metadata, on a cache hit or when ext auth is disabled.
+func extAuthCacheEnabledMetadataMatcher(provider string) *envoy_matcher_v3.MetadataMatcher {
+	return &envoy_matcher_v3.MetadataMatcher{
+		Filter: extAuthCacheFilterName(provider),
+		Invert: true,
+		Path: []*envoy_matcher_v3.MetadataMatcher_PathSegment{
+			{
+				Segment: &envoy_matcher_v3.MetadataMatcher_PathSegment_Key{
+					Key: extAuthCacheSkipMetadataKey,
+				},
+			},
+		},
+		Value: &envoy_matcher_v3.ValueMatcher{
+			MatchPattern: &envoy_matcher_v3.ValueMatcher_BoolMatch{
+				BoolMatch: true,
+			},
+		},
+	}
+}
+
+// applyExtAuthDecisionCache configures the ext_authz filter of a provider for its decision cache,
+// and builds the filters caching its decisions.
+func applyExtAuthDecisionCache(provider string, in *kgateway.ExtAuthDecisionCache, extAuth *envoy_ext_authz_v3.ExtAuthz) *extAuthCachePerProviderConfig {
+	extAuth.FilterEnabledMetadata = extAuthCacheEnabledMetadataMatcher(provider)
+	// requests allowed by the failure mode are not cached
+	extAuth.FailureModeAllowHeaderAdd = true
+
+	maxEntries := int32(defaultExtAuthCacheMaxEntries)
+	if in.MaxEntries != nil {
+		maxEntries = *in.MaxEntries
+	}
+	headers := make([]string, 0, len(in.Key.Headers))
+	for _, h := range in.Key.Headers {
+		headers = append(headers, strings.ToLower(h))
+	}
+
+	var cacheConfig strings.Builder
+	cacheConfig.WriteString("local config = {\n")
+	fmt.Fprintf(&cacheConfig, "  namespace = %s,\n", luaLongString(extAuthCacheFilterName(provider)))
+	fmt.Fprintf(&cacheConfig, "  disable_namespace = %s,\n", luaLongString(ExtAuthGlobalDisableFilterMetadataNamespace))
+	fmt.Fprintf(&cacheConfig, "  disable_key = %s,\n", luaLongString(globalFilterDisableMetadataKey))
+	fmt.Fprintf(&cacheConfig, "  ttl = %d,\n", int64(in.TTL.Seconds()))
+	fmt.Fprintf(&cacheConfig, "  max_entries = %d,\n", maxEntries)
+	fmt.Fprintf(&cacheConfig, "  headers = %s,\n", luaStringList(headers))
+	if in.Key.PathPrefixSegments != nil {
+		fmt.Fprintf(&cacheConfig, "  path_segments = %d,\n", *in.Key.PathPrefixSegments)
+	}
+	cacheConfig.WriteString("}\n")
+
+	var recordConfig strings.Builder
+	recordConfig.WriteString("local config = {\n")
+	fmt.Fprintf(&recordConfig, "  namespace = %s,\n", luaLongString(extAuthCacheFilterName(provider)))
+	fmt.Fprintf(&recordConfig, "  metadata_namespaces = %s,\n", luaStringList([]string{
+		extAuthFilterName(provider),
+		extAuthFailureModeFilterName(provider, !extAuth.GetFailureModeAllow()),
+	}))
+	fmt.Fprintf(&recordConfig, "  auth_headers = %s,\n", luaStringList(extAuthCacheAuthHeaders(in, extAuth)))
+	recordConfig.WriteString("}\n")
+
+	return &extAuthCachePerProviderConfig{
+		cache:  inlineLuaSourceCode(cacheConfig.String() + extAuthCacheScript),
+		record: inlineLuaSourceCode(recordConfig.String() + extAuthCacheRecordScript),
+	}
+}
+
+// extAuthCacheAuthHeaders returns the request headers set or removed by the ext auth service, which
+// are replayed on cache hits whether the allowed request changed them or not.
+func extAuthCacheAuthHeaders(in *kgateway.ExtAuthDecisionCache, extAuth *envoy_ext_authz_v3.ExtAuthz) []string {
+	names := sets.New[string]()
+	for _, h := range in.AuthHeaders {
+		names.Insert(strings.ToLower(h))
+	}
+	for _, m := range extAuth.GetHttpService().GetAuthorizationResponse().GetAllowedUpstreamHeaders().GetPatterns() {
+		if exact := m.GetExact(); exact != "" {
+			names.Insert(strings.ToLower(exact))
+		}
+	}
+	return sets.List(names)
+}
+
+func inlineLuaSourceCode(code string) *envoyluav3.Lua {
+	return &envoyluav3.Lua{
+		SourceCodes: map[string]*envoycorev3.DataSource{
+			extAuthCacheSourceCodeName: {
+				Specifier: &envoycorev3.DataSource_InlineString{
+					InlineString: code,
+				},
+			},
+		},
+	}
+}
+
+func luaStringList(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, luaLongString(v))
+	}
+	return "{ " + strings.Join(quoted, ", ") + " }"
+}
+
+// buildExtAuthCachePerRoute builds the per route configuration of the decision cache filter.
+func buildExtAuthCachePerRoute(spec *kgateway.ExtAuthDecisionCachePolicy) (*envoyluav3.LuaPerRoute, error) {
+	perRoute := &envoyluav3.LuaPerRoute{
+		Override: &envoyluav3.LuaPerRoute_Name{
+			Name: extAuthCacheSourceCodeName,
+		},
+	}
+	if spec == nil {
+		return perRoute, nil
+	}
+
+	filterContext := map[string]any{}
+	if spec.Disable != nil {
+		filterContext["disabled"] = true
+	}
+	if spec.TTL != nil {
+		filterContext["ttl"] = int64(spec.TTL.Seconds())
+	}
+	ctx, err := structpb.NewStruct(filterContext)
+	if err != nil {
+		return nil, err
+	}
+	perRoute.FilterContext = ctx
+	return perRoute, nil
+}
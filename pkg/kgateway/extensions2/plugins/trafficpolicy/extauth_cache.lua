@@ -0,0 +1,173 @@
+-- External auth decision cache.
+--
+-- The controller prepends `local config = {...}` with the provider configuration:
+--   namespace:                       dynamic metadata namespace shared with the ext_authz and record filters
+--   disable_namespace, disable_key:  dynamic metadata set when ext auth is disabled on the route
+--   ttl:                             seconds decisions are cached for
+--   max_entries:                     upper bound for cached decisions
+--   headers:                         request headers whose values are part of the key
+--   path_segments:                   leading path segments part of the key, the path is not part of the key if nil
+--
+-- Routes enable the filter with a filter context that may carry `ttl` or `disabled`.
+--
+-- On a hit, the header changes and dynamic metadata of the cached decision are applied, and `skip`
+-- is set in the namespace, which disables the ext_authz filter. On a miss, the key is kept by the
+-- worker under an opaque `id` set in the namespace, along with the names of the request headers,
+-- for the record filter running after the ext_authz filter, which sets the `decision` of an allowed
+-- request. Decisions are cached when the response starts. The key is never set in the dynamic
+-- metadata. The record filter runs in its own Lua state, so the decision, including the values of
+-- the headers set by the ext auth service, can only reach this filter through the dynamic metadata.
+-- It is replaced with false once taken, so that it is not in the access logs of the request.
+
+-- Decisions are cached per worker, keyed by route and request attributes.
+local cache = {}
+local cache_size = 0
+
+-- Keys of the requests that missed the cache, by id, until their response starts.
+local pending = {}
+local pending_size = 0
+local next_id = 0
+
+local function cache_get(key, now)
+  local entry = cache[key]
+  if entry == nil then
+    return nil
+  end
+  if entry.expires > now then
+    return entry.decision
+  end
+  cache[key] = nil
+  cache_size = cache_size - 1
+  return nil
+end
+
+local function cache_put(key, decision, ttl, now)
+  if cache_size >= config.max_entries then
+    cache = {}
+    cache_size = 0
+  end
+  if cache[key] == nil then
+    cache_size = cache_size + 1
+  end
+  cache[key] = { decision = decision, expires = now + ttl }
+end
+
+local function pending_put(key)
+  if pending_size >= config.max_entries then
+    pending = {}
+    pending_size = 0
+  end
+  next_id = next_id + 1
+  local id = tostring(next_id)
+  pending[id] = key
+  pending_size = pending_size + 1
+  return id
+end
+
+local function pending_take(id)
+  local key = pending[id]
+  if key ~= nil then
+    pending[id] = nil
+    pending_size = pending_size - 1
+  end
+  return key
+end
+
+local function cache_key(handle, headers)
+  local parts = { handle:streamInfo():routeName() }
+  local found = false
+  for _, name in ipairs(config.headers) do
+    local value = headers:get(name)
+    if value ~= nil then
+      found = true
+    end
+    parts[#parts + 1] = value or ''
+  end
+  if not found then
+    return nil
+  end
+  if config.path_segments ~= nil then
+    local path = (headers:get(':path') or ''):match('^[^?#]*')
+    local segments = {}
+    for segment in path:gmatch('[^/]+') do
+      if #segments >= config.path_segments then
+        break
+      end
+      segments[#segments + 1] = segment
+    end
+    parts[#parts + 1] = table.concat(segments, '/')
+  end
+  return table.concat(parts, '\n')
+end
+
+local function header_names(headers)
+  local names = {}
+  for name in pairs(headers) do
+    names[name] = true
+  end
+  return names
+end
+
+local function ext_auth_disabled(metadata)
+  local disable = metadata:get(config.disable_namespace)
+  return disable ~= nil and disable[config.disable_key] == true
+end
+
+function envoy_on_request(handle)
+  local metadata = handle:streamInfo():dynamicMetadata()
+  if ext_auth_disabled(metadata) then
+    metadata:set(config.namespace, 'skip', true)
+    return
+  end
+  local ctx = handle:filterContext()
+  if ctx ~= nil and ctx.disabled == true then
+    return
+  end
+
+  local headers = handle:headers()
+  local key = cache_key(handle, headers)
+  if key == nil then
+    return
+  end
+
+  local decision = cache_get(key, os.time())
+  if decision == nil then
+    metadata:set(config.namespace, 'id', pending_put(key))
+    metadata:set(config.namespace, 'headers', header_names(headers))
+    return
+  end
+
+  for name, value in pairs(decision.set or {}) do
+    headers:replace(name, value)
+  end
+  for name in pairs(decision.remove or {}) do
+    headers:remove(name)
+  end
+  for namespace, values in pairs(decision.metadata or {}) do
+    for k, v in pairs(values) do
+      metadata:set(namespace, k, v)
+    end
+  end
+  metadata:set(config.namespace, 'skip', true)
+end
+
+function envoy_on_response(handle)
+  local state = handle:streamInfo():dynamicMetadata():get(config.namespace)
+  if state == nil or state.id == nil then
+    return
+  end
+  local key = pending_take(state.id)
+  if state.decision == nil or state.decision == false then
+    return
+  end
+  handle:streamInfo():dynamicMetadata():set(config.namespace, 'decision', false)
+  if key == nil then
+    return
+  end
+  local ttl = config.ttl
+  local ctx = handle:filterContext()
+  if ctx ~= nil and type(ctx.ttl) == 'number' then
+    ttl = ctx.ttl
+  end
+  cache_put(key, state.decision, ttl, os.time())
+end
@@ -0,0 +1,68 @@
+-- External auth decision recording.
+--
+-- The controller prepends `local config = {...}` with the provider configuration:
+--   namespace:            dynamic metadata namespace of the decision cache filter
+--   metadata_namespaces:  dynamic metadata namespaces of the ext_authz filters
+--   auth_headers:         request headers the ext auth service sets or removes
+--
+-- Runs after the ext_authz filter, so a request reaching it was allowed. When the cache filter
+-- missed, the request header changes and the dynamic metadata of the ext_authz filters are set as
+-- the `decision` of the request. The changes are the headers added or removed since the cache
+-- filter saved the header names, and the auth headers, set to their value or removed when absent,
+-- so that requests hitting the cache cannot carry their own values for them. The decision holds the
+-- header values, and is visible to the filters running before the response, e.g. ext_proc or tap,
+-- until the cache filter takes it.
+
+local failure_mode_allowed_header = 'x-envoy-auth-failure-mode-allowed'
+
+function envoy_on_request(handle)
+  local metadata = handle:streamInfo():dynamicMetadata()
+  local state = metadata:get(config.namespace)
+  if state == nil or state.id == nil or state.skip == true then
+    return
+  end
+  local headers = handle:headers()
+  if headers:get(failure_mode_allowed_header) ~= nil then
+    return
+  end
+
+  local current = {}
+  for name, value in pairs(headers) do
+    if current[name] == nil then
+      current[name] = value
+    else
+      current[name] = current[name] .. ',' .. value
+    end
+  end
+
+  local saved = state.headers or {}
+  local set = {}
+  local remove = {}
+  for name, value in pairs(current) do
+    if saved[name] == nil then
+      set[name] = value
+    end
+  end
+  for name in pairs(saved) do
+    if current[name] == nil then
+      remove[name] = true
+    end
+  end
+  for _, name in ipairs(config.auth_headers) do
+    if current[name] ~= nil then
+      set[name] = current[name]
+    else
+      remove[name] = true
+    end
+  end
+
+  local decision_metadata = {}
+  for _, namespace in ipairs(config.metadata_namespaces) do
+    local values = metadata:get(namespace)
+    if values ~= nil then
+      decision_metadata[namespace] = values
+    end
+  end
+
+  metadata:set(config.namespace, 'decision', { set = set, remove = remove, metadata = decision_metadata })
+end
@@ -0,0 +1,251 @@
+package trafficpolicy
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	envoy_ext_authz_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	envoyluav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/filters"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestApplyExtAuthDecisionCache(t *testing.T) {
+	extAuth := &envoy_ext_authz_v3.ExtAuthz{
+		FilterEnabledMetadata: ExtAuthzEnabledMetadataMatcher,
+	}
+	cache := applyExtAuthDecisionCache("default/ext-auth", &kgateway.ExtAuthDecisionCache{
+		TTL: metav1.Duration{Duration: 30 * time.Second},
+		Key: kgateway.ExtAuthDecisionCacheKey{
+			Headers:            []string{"Authorization"},
+			PathPrefixSegments: ptr.To(int32(2)),
+		},
+	}, extAuth)
+
+	require.NotNil(t, cache)
+	require.NoError(t, cache.Validate())
+	assert.True(t, extAuth.GetFailureModeAllowHeaderAdd())
+	assert.Equal(t, "ext_auth_cache/default/ext-auth", extAuth.GetFilterEnabledMetadata().GetFilter())
+	assert.True(t, extAuth.GetFilterEnabledMetadata().GetInvert())
+
+	cacheScript := cache.cache.GetSourceCodes()[extAuthCacheSourceCodeName].GetInlineString()
+	assert.Contains(t, cacheScript, `namespace = [[ext_auth_cache/default/ext-auth]],`)
+	assert.Contains(t, cacheScript, `disable_namespace = [[dev.kgateway.disable_ext_auth]],`)
+	assert.Contains(t, cacheScript, "ttl = 30,")
+	assert.Contains(t, cacheScript, "max_entries = 10000,")
+	assert.Contains(t, cacheScript, "headers = { [[authorization]] },")
+	assert.Contains(t, cacheScript, "path_segments = 2,")
+
+	recordScript := cache.record.GetSourceCodes()[extAuthCacheSourceCodeName].GetInlineString()
+	assert.Contains(t, recordScript, "metadata_namespaces = { [[ext_auth/default/ext-auth]], [[ext_auth/default/ext-auth/fail_open]] },")
+	assert.Contains(t, recordScript, "auth_headers = {  },")
+}
+
+func TestExtAuthCacheAuthHeaders(t *testing.T) {
+	extAuth := &envoy_ext_authz_v3.ExtAuthz{
+		Services: &envoy_ext_authz_v3.ExtAuthz_HttpService{
+			HttpService: &envoy_ext_authz_v3.HttpService{
+				AuthorizationResponse: &envoy_ext_authz_v3.AuthorizationResponse{
+					AllowedUpstreamHeaders: buildStringListMatcher([]string{"X-User-Id", "x-tenant"}),
+				},
+			},
+		},
+	}
+	headers := extAuthCacheAuthHeaders(&kgateway.ExtAuthDecisionCache{
+		AuthHeaders: []string{"x-internal", "X-Tenant"},
+	}, extAuth)
+	assert.Equal(t, []string{"x-internal", "x-tenant", "x-user-id"}, headers)
+}
+
+// extAuthCacheScripts runs the Lua filters of a decision cache around a fake ext auth service.
+type extAuthCacheScripts struct {
+	provider string
+	cache    *luaFilter
+	record   *luaFilter
+}
+
+func newExtAuthCacheScripts(t *testing.T, in *kgateway.ExtAuthDecisionCache) *extAuthCacheScripts {
+	provider := "default/ext-auth"
+	cache := applyExtAuthDecisionCache(provider, in, &envoy_ext_authz_v3.ExtAuthz{})
+	return &extAuthCacheScripts{
+		provider: provider,
+		cache:    newLuaFilter(t, cache.cache.GetSourceCodes()[extAuthCacheSourceCodeName].GetInlineString()),
+		record:   newLuaFilter(t, cache.record.GetSourceCodes()[extAuthCacheSourceCodeName].GetInlineString()),
+	}
+}
+
+// run sends a request through the filters, calling authz unless the cache filter skips ext auth,
+// and returns whether ext auth was skipped.
+func (e *extAuthCacheScripts) run(t *testing.T, s *luaStream, authz func(*luaStream)) bool {
+	e.cache.onRequest(t, s)
+	skipped := s.metadata[extAuthCacheFilterName(e.provider)]["skip"] == true
+	if !skipped {
+		authz(s)
+		e.record.onRequest(t, s)
+	}
+	e.cache.onResponse(t, s)
+	return skipped
+}
+
+func TestExtAuthCacheScripts(t *testing.T) {
+	decisionCache := &kgateway.ExtAuthDecisionCache{
+		TTL:         metav1.Duration{Duration: time.Minute},
+		Key:         kgateway.ExtAuthDecisionCacheKey{Headers: []string{"authorization"}},
+		AuthHeaders: []string{"x-user-id", "x-internal"},
+	}
+	// the ext auth service sets the user, strips x-internal and adds metadata
+	authz := func(s *luaStream) {
+		s.setHeader("x-user-id", "alice")
+		s.removeHeader("x-internal")
+		s.setHeader("x-added", "yes")
+		s.removeHeader("x-stripped")
+		s.setMetadata("ext_auth/default/ext-auth", "user", "alice")
+	}
+	notCalled := func(*luaStream) {
+		t.Fatal("ext auth called on a cache hit")
+	}
+
+	t.Run("replays all header changes on a hit", func(t *testing.T) {
+		e := newExtAuthCacheScripts(t, decisionCache)
+
+		first := newLuaStream("route", "authorization", "Bearer secret", "x-stripped", "1")
+		assert.False(t, e.run(t, first, authz))
+
+		second := newLuaStream("route",
+			"authorization", "Bearer secret",
+			"x-user-id", "mallory",
+			"x-internal", "spoofed",
+			"x-stripped", "2",
+		)
+		assert.True(t, e.run(t, second, notCalled))
+		user, _ := second.header("x-user-id")
+		assert.Equal(t, "alice", user)
+		_, ok := second.header("x-internal")
+		assert.False(t, ok)
+		_, ok = second.header("x-stripped")
+		assert.False(t, ok)
+		added, _ := second.header("x-added")
+		assert.Equal(t, "yes", added)
+		assert.Equal(t, "alice", second.metadata["ext_auth/default/ext-auth"]["user"])
+	})
+
+	t.Run("replays auth headers the request already had", func(t *testing.T) {
+		e := newExtAuthCacheScripts(t, decisionCache)
+
+		first := newLuaStream("route", "authorization", "Bearer secret", "x-user-id", "alice")
+		assert.False(t, e.run(t, first, authz))
+
+		second := newLuaStream("route", "authorization", "Bearer secret", "x-user-id", "mallory")
+		assert.True(t, e.run(t, second, notCalled))
+		user, _ := second.header("x-user-id")
+		assert.Equal(t, "alice", user)
+	})
+
+	t.Run("keeps credentials out of the metadata", func(t *testing.T) {
+		e := newExtAuthCacheScripts(t, decisionCache)
+
+		s := newLuaStream("route", "authorization", "Bearer secret", "cookie", "session=secret")
+		assert.False(t, e.run(t, s, authz))
+		state := s.metadata[extAuthCacheFilterName(e.provider)]
+		assert.Equal(t, map[string]any{"authorization": true, "cookie": true}, state["headers"])
+		assert.NotContains(t, fmt.Sprint(s.metadata), "secret")
+	})
+
+	t.Run("takes the decision out of the metadata", func(t *testing.T) {
+		e := newExtAuthCacheScripts(t, decisionCache)
+
+		s := newLuaStream("route", "authorization", "Bearer secret")
+		assert.False(t, e.run(t, s, authz))
+		// the values of the auth headers are not left for the access logs
+		assert.Equal(t, false, s.metadata[extAuthCacheFilterName(e.provider)]["decision"])
+		assert.NotContains(t, fmt.Sprint(s.metadata[extAuthCacheFilterName(e.provider)]), "alice")
+	})
+
+	t.Run("misses on other keys and expired decisions", func(t *testing.T) {
+		e := newExtAuthCacheScripts(t, decisionCache)
+
+		assert.False(t, e.run(t, newLuaStream("route", "authorization", "Bearer secret"), authz))
+		assert.False(t, e.run(t, newLuaStream("route", "authorization", "Bearer other"), authz))
+		assert.False(t, e.run(t, newLuaStream("other-route", "authorization", "Bearer secret"), authz))
+
+		expired := newLuaStream("route", "authorization", "Bearer secret")
+		expired.now += 61
+		assert.False(t, e.run(t, expired, authz))
+	})
+
+	t.Run("does not cache requests allowed by the failure mode", func(t *testing.T) {
+		e := newExtAuthCacheScripts(t, decisionCache)
+
+		failOpen := func(s *luaStream) {
+			s.setHeader("x-envoy-auth-failure-mode-allowed", "true")
+		}
+		assert.False(t, e.run(t, newLuaStream("route", "authorization", "Bearer secret"), failOpen))
+		assert.False(t, e.run(t, newLuaStream("route", "authorization", "Bearer secret"), authz))
+	})
+}
+
+func TestBuildExtAuthCachePerRoute(t *testing.T) {
+	perRoute, err := buildExtAuthCachePerRoute(nil)
+	require.NoError(t, err)
+	assert.Equal(t, extAuthCacheSourceCodeName, perRoute.GetName())
+	assert.Nil(t, perRoute.GetFilterContext())
+
+	perRoute, err = buildExtAuthCachePerRoute(&kgateway.ExtAuthDecisionCachePolicy{
+		TTL: &metav1.Duration{Duration: time.Minute},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, float64(60), perRoute.GetFilterContext().GetFields()["ttl"].GetNumberValue())
+
+	perRoute, err = buildExtAuthCachePerRoute(&kgateway.ExtAuthDecisionCachePolicy{
+		Disable: &shared.PolicyDisable{},
+	})
+	require.NoError(t, err)
+	assert.True(t, perRoute.GetFilterContext().GetFields()["disabled"].GetBoolValue())
+}
+
+func TestExtAuthDecisionCacheFilters(t *testing.T) {
+	extAuth := &envoy_ext_authz_v3.ExtAuthz{}
+	provider := &TrafficPolicyGatewayExtensionIR{
+		Name:    "test-extension",
+		ExtAuth: extAuth,
+		ExtAuthCache: applyExtAuthDecisionCache("test-extension", &kgateway.ExtAuthDecisionCache{
+			TTL: metav1.Duration{Duration: time.Minute},
+			Key: kgateway.ExtAuthDecisionCacheKey{Headers: []string{"authorization"}},
+		}, extAuth),
+	}
+	perRoute, err := buildExtAuthCachePerRoute(nil)
+	require.NoError(t, err)
+
+	plugin := &trafficPolicyPluginGwPass{}
+	typedFilterConfig := &ir.TypedFilterConfigMap{}
+	plugin.handleExtAuth("test-filter-chain", typedFilterConfig, &extAuthIR{
+		perProviderConfig: []*perProviderExtAuthConfig{
+			{
+				provider:      provider,
+				cachePerRoute: perRoute,
+			},
+		},
+	})
+
+	assert.Equal(t, perRoute, typedFilterConfig.GetTypedConfig(extAuthCacheFilterName("test-extension")))
+	assert.IsType(t, &envoyluav3.LuaPerRoute{}, typedFilterConfig.GetTypedConfig(extAuthCacheRecordFilterName("test-extension")))
+
+	httpFilters, err := plugin.HttpFilters(ir.HttpFiltersContext{}, ir.FilterChainCommon{FilterChainName: "test-filter-chain"})
+	require.NoError(t, err)
+	stages := map[string]filters.FilterStage[filters.WellKnownFilterStage]{}
+	for _, f := range httpFilters {
+		stages[f.Filter.GetName()] = f.Stage
+		assert.True(t, f.Filter.GetDisabled())
+	}
+	assert.Equal(t, filters.BeforeStage(filters.AuthNStage), stages[extAuthCacheFilterName("test-extension")])
+	assert.Equal(t, filters.DuringStage(filters.AuthNStage), stages[extAuthFilterName("test-extension")])
+	assert.Equal(t, filters.AfterStage(filters.AuthNStage), stages[extAuthCacheRecordFilterName("test-extension")])
+}
@@ -5,10 +5,12 @@ import (
 	"slices"
 
 	envoy_ext_authz_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	envoyluav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
 	envoy_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	"google.golang.org/protobuf/proto"
 	"istio.io/istio/pkg/kube/krt"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 
 	kgateway "github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/pluginutils"
@@ -55,6 +57,10 @@ type extAuthIR struct {
 type perProviderExtAuthConfig struct {
 	provider       *TrafficPolicyGatewayExtensionIR
 	perRouteConfig *envoy_ext_authz_v3.ExtAuthzPerRoute
+	// failOpen overrides the failure mode of the provider
+	failOpen *bool
+	// cachePerRoute enables the decision cache of the provider
+	cachePerRoute *envoyluav3.LuaPerRoute
 }
 
 var _ PolicySubIR = &extAuthIR{}
@@ -74,6 +80,8 @@ func (e *extAuthIR) Equals(other PolicySubIR) bool {
 	if !slices.EqualFunc(e.perProviderConfig, otherExtAuth.perProviderConfig, func(a, b *perProviderExtAuthConfig) bool {
 		// compare perRouteConfig
 		return proto.Equal(a.perRouteConfig, b.perRouteConfig) &&
+			ptr.Equal(a.failOpen, b.failOpen) &&
+			proto.Equal(a.cachePerRoute, b.cachePerRoute) &&
 			// compare provider config
 			cmputils.CompareWithNils(a.provider, b.provider, func(a, b *TrafficPolicyGatewayExtensionIR) bool {
 				return a.Equals(*b)
@@ -95,6 +103,11 @@ func (e *extAuthIR) Validate() error {
 				return err
 			}
 		}
+		if p.cachePerRoute != nil {
+			if err := p.cachePerRoute.ValidateAll(); err != nil {
+				return err
+			}
+		}
 		if p.provider != nil {
 			return p.provider.Validate()
 		}
@@ -130,14 +143,23 @@ func constructExtAuth(
 		return pluginutils.ErrInvalidExtensionType(kgateway.GatewayExtensionTypeExtAuth)
 	}
 
+	cfg := &perProviderExtAuthConfig{
+		provider:       provider,
+		perRouteConfig: buildExtAuthPerRouteFilterConfig(spec),
+		failOpen:       spec.FailOpen,
+	}
+	if provider.ExtAuthCache != nil {
+		cfg.cachePerRoute, err = buildExtAuthCachePerRoute(spec.DecisionCache)
+		if err != nil {
+			return fmt.Errorf("extauth: %w", err)
+		}
+	} else if spec.DecisionCache != nil {
+		return fmt.Errorf("extauth: decisionCache requires GatewayExtension %s to configure a decisionCache", providerName(provider))
+	}
+
 	out.extAuth = &extAuthIR{
-		perProviderConfig: []*perProviderExtAuthConfig{
-			{
-				provider:       provider,
-				perRouteConfig: buildExtAuthPerRouteFilterConfig(spec),
-			},
-		},
-		providerNames: sets.New(providerName(provider)),
+		perProviderConfig: []*perProviderExtAuthConfig{cfg},
+		providerNames:     sets.New(providerName(provider)),
 	}
 	return nil
 }
@@ -174,6 +196,22 @@ func extAuthFilterName(name string) string {
 	return fmt.Sprintf("%s/%s", extauthFilterNamePrefix, name)
 }
 
+// extAuthFailureModeFilterName is the name of the ext_authz filter of a provider overriding
+// its failure mode.
+func extAuthFailureModeFilterName(provider string, failOpen bool) string {
+	if failOpen {
+		return extAuthFilterName(provider) + "/fail_open"
+	}
+	return extAuthFilterName(provider) + "/fail_closed"
+}
+
+// extAuthFailureModeFilter returns the ext_authz filter of a provider with the opposite failure mode.
+func extAuthFailureModeFilter(extAuth *envoy_ext_authz_v3.ExtAuthz) *envoy_ext_authz_v3.ExtAuthz {
+	out := proto.Clone(extAuth).(*envoy_ext_authz_v3.ExtAuthz)
+	out.FailureModeAllow = !extAuth.GetFailureModeAllow()
+	return out
+}
+
 func (p *trafficPolicyPluginGwPass) handleExtAuth(filterChain string, pCtxTypedFilterConfig *ir.TypedFilterConfigMap, in *extAuthIR) {
 	if in == nil {
 		return
@@ -189,12 +227,32 @@ func (p *trafficPolicyPluginGwPass) handleExtAuth(filterChain string, pCtxTypedF
 		providerName := providerName(cfg.provider)
 		p.extAuthPerProvider.Add(filterChain, providerName, cfg.provider)
 
+		filterName := extAuthFilterName(providerName)
+		if cfg.failOpen != nil {
+			// the failure mode is not configurable per route, so a second filter of the provider
+			// with the opposite failure mode is enabled in place of the provider filter
+			failOpen := cfg.provider.ExtAuth.GetFailureModeAllow()
+			overrideFilterName := extAuthFailureModeFilterName(providerName, !failOpen)
+			if *cfg.failOpen != failOpen {
+				p.extAuthFailureModePerProvider.Add(filterChain, providerName, cfg.provider)
+				pCtxTypedFilterConfig.AddTypedConfig(filterName, DisableFilterPerRoute())
+				filterName = overrideFilterName
+			} else {
+				pCtxTypedFilterConfig.AddTypedConfig(overrideFilterName, DisableFilterPerRoute())
+			}
+		}
+
 		// Filter is not disabled, set the PerRouteConfig
 		if cfg.perRouteConfig != nil {
-			pCtxTypedFilterConfig.AddTypedConfig(extAuthFilterName(providerName), cfg.perRouteConfig)
+			pCtxTypedFilterConfig.AddTypedConfig(filterName, cfg.perRouteConfig)
 		} else {
 			// if you are on a route and not trying to disable it then we need to override the top level disable on the filter chain
-			pCtxTypedFilterConfig.AddTypedConfig(extAuthFilterName(providerName), EnableFilterPerRoute())
+			pCtxTypedFilterConfig.AddTypedConfig(filterName, EnableFilterPerRoute())
+		}
+
+		if cfg.cachePerRoute != nil {
+			pCtxTypedFilterConfig.AddTypedConfig(extAuthCacheFilterName(providerName), cfg.cachePerRoute)
+			pCtxTypedFilterConfig.AddTypedConfig(extAuthCacheRecordFilterName(providerName), extAuthCacheRecordPerRoute)
 		}
 	}
 }
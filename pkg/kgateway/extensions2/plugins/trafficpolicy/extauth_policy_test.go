@@ -312,3 +312,59 @@ func TestExtAuthPolicyPlugin(t *testing.T) {
 		assert.NotEmpty(t, pCtx.TypedFilterConfig[ExtAuthGlobalDisableFilterName])
 	})
 }
+
+func TestExtAuthFailureModeOverride(t *testing.T) {
+	provider := &TrafficPolicyGatewayExtensionIR{
+		Name: "test-extension",
+		ExtAuth: &envoy_ext_authz_v3.ExtAuthz{
+			FailureModeAllow: false,
+		},
+	}
+	applyFailOpen := func(failOpen bool) (*trafficPolicyPluginGwPass, ir.TypedFilterConfigMap) {
+		plugin := &trafficPolicyPluginGwPass{}
+		pCtx := &ir.RouteContext{
+			Policy: &TrafficPolicy{
+				spec: trafficPolicySpecIr{
+					extAuth: &extAuthIR{
+						perProviderConfig: []*perProviderExtAuthConfig{
+							{
+								provider: provider,
+								failOpen: &failOpen,
+							},
+						},
+					},
+				},
+			},
+		}
+		require.NoError(t, plugin.ApplyForRoute(pCtx, &envoyroutev3.Route{}))
+		return plugin, pCtx.TypedFilterConfig
+	}
+
+	t.Run("enables the provider filter with the opposite failure mode", func(t *testing.T) {
+		plugin, typedFilterConfig := applyFailOpen(true)
+
+		assert.Equal(t, EnableFilterPerRoute(), typedFilterConfig[extAuthFilterName("test-extension")+"/fail_open"])
+		assert.Equal(t, DisableFilterPerRoute(), typedFilterConfig[extAuthFilterName("test-extension")])
+
+		httpFilters, err := plugin.HttpFilters(ir.HttpFiltersContext{}, ir.FilterChainCommon{})
+		require.NoError(t, err)
+		var failOpenFilter *envoy_ext_authz_v3.ExtAuthz
+		for _, f := range httpFilters {
+			if f.Filter.GetName() == extAuthFilterName("test-extension")+"/fail_open" {
+				failOpenFilter = &envoy_ext_authz_v3.ExtAuthz{}
+				require.NoError(t, f.Filter.GetTypedConfig().UnmarshalTo(failOpenFilter))
+			}
+		}
+		require.NotNil(t, failOpenFilter)
+		assert.True(t, failOpenFilter.GetFailureModeAllow())
+		assert.False(t, provider.ExtAuth.GetFailureModeAllow())
+	})
+
+	t.Run("enables the provider filter with the same failure mode", func(t *testing.T) {
+		plugin, typedFilterConfig := applyFailOpen(false)
+
+		assert.Equal(t, EnableFilterPerRoute(), typedFilterConfig[extAuthFilterName("test-extension")])
+		assert.Equal(t, DisableFilterPerRoute(), typedFilterConfig[extAuthFilterName("test-extension")+"/fail_open"])
+		assert.Empty(t, plugin.extAuthFailureModePerProvider.Providers)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	xdscorev3 "github.com/cncf/xds/go/xds/core/v3"
 	xdsmatcherv3 "github.com/cncf/xds/go/xds/type/matcher/v3"
@@ -35,14 +36,21 @@ import (
 
 type TrafficPolicyGatewayExtensionIR struct {
 	// +krtEqualsTodo decide whether extension name should affect equality
-	Name             string
-	ExtAuth          *envoy_ext_authz_v3.ExtAuthz
-	ExtProc          *envoymatchingv3.ExtensionWithMatcher
-	RateLimit        *ratev3.RateLimit
-	Jwt              *envoymatchingv3.ExtensionWithMatcher
-	OAuth2           *oauthPerProviderConfig
-	PrecedenceWeight int32
-	Err              error
+	Name    string
+	ExtAuth *envoy_ext_authz_v3.ExtAuthz
+	// ExtAuthCache holds the Lua filters caching the decisions of ExtAuth.
+	ExtAuthCache *extAuthCachePerProviderConfig
+	ExtProc      *envoymatchingv3.ExtensionWithMatcher
+	RateLimit    *ratev3.RateLimit
+	Jwt          *envoymatchingv3.ExtensionWithMatcher
+	// JwtClearRouteCache is Jwt with the route cache cleared after validation, used on
+	// filter chains with routes matching on JWT claims.
+	JwtClearRouteCache *envoymatchingv3.ExtensionWithMatcher
+	OAuth2             *oauthPerProviderConfig
+	// OAuth2Introspection holds the Lua filter validating tokens against an introspection endpoint.
+	OAuth2Introspection *oauth2IntrospectionPerProviderConfig
+	PrecedenceWeight    int32
+	Err                 error
 }
 
 // ResourceName returns the unique name for this extension.
@@ -54,6 +62,9 @@ func (e TrafficPolicyGatewayExtensionIR) Equals(other TrafficPolicyGatewayExtens
 	if !proto.Equal(e.ExtAuth, other.ExtAuth) {
 		return false
 	}
+	if !e.ExtAuthCache.Equals(other.ExtAuthCache) {
+		return false
+	}
 	if !proto.Equal(e.ExtProc, other.ExtProc) {
 		return false
 	}
@@ -63,9 +74,15 @@ func (e TrafficPolicyGatewayExtensionIR) Equals(other TrafficPolicyGatewayExtens
 	if !proto.Equal(e.Jwt, other.Jwt) {
 		return false
 	}
+	if !proto.Equal(e.JwtClearRouteCache, other.JwtClearRouteCache) {
+		return false
+	}
 	if !e.OAuth2.Equals(other.OAuth2) {
 		return false
 	}
+	if !e.OAuth2Introspection.Equals(other.OAuth2Introspection) {
+		return false
+	}
 	if e.PrecedenceWeight != other.PrecedenceWeight {
 		return false
 	}
@@ -94,6 +111,9 @@ func (e TrafficPolicyGatewayExtensionIR) Validate() error {
 			return err
 		}
 	}
+	if err := e.ExtAuthCache.Validate(); err != nil {
+		return err
+	}
 	if e.ExtProc != nil {
 		if err := e.ExtProc.ValidateAll(); err != nil {
 			return err
@@ -179,6 +199,12 @@ func TranslateGatewayExtensionBuilder(
 			if len(gExt.ExtAuth.HeadersToForward) > 0 {
 				p.ExtAuth.AllowedHeaders = buildStringListMatcher(gExt.ExtAuth.HeadersToForward)
 			}
+			if len(gExt.ExtAuth.MetadataContextNamespaces) > 0 {
+				p.ExtAuth.MetadataContextNamespaces = slices.Clone(gExt.ExtAuth.MetadataContextNamespaces)
+			}
+			if gExt.ExtAuth.DecisionCache != nil {
+				p.ExtAuthCache = applyExtAuthDecisionCache(p.Name, gExt.ExtAuth.DecisionCache, p.ExtAuth)
+			}
 
 		case gExt.ExtProc != nil:
 			envoyGrpcService, err := ResolveExtGrpcService(krtctx, commoncol.BackendIndex, false, gExt.ObjectSource, &gExt.ExtProc.GrpcService)
@@ -203,6 +229,7 @@ func TranslateGatewayExtensionBuilder(
 			jwtConfig, err := resolveJwtProviders(
 				krtctx,
 				commoncol.ConfigMaps.Collection(),
+				commoncol.Secrets,
 				commoncol.BackendIndex,
 				gExt.ObjectSource,
 				gExt.Name,
@@ -214,6 +241,7 @@ func TranslateGatewayExtensionBuilder(
 				return p
 			}
 			p.Jwt = buildCompositeJwtFilter(jwtConfig)
+			p.JwtClearRouteCache = buildCompositeJwtFilter(withClearRouteCache(jwtConfig))
 
 		case gExt.OAuth2 != nil:
 			out, err := buildOAuth2ProviderConfig(krtctx, &gExt, commoncol.BackendIndex, commoncol.Secrets, oidcDiscoverer)
@@ -222,6 +250,14 @@ func TranslateGatewayExtensionBuilder(
 				return p
 			}
 			p.OAuth2 = out
+
+		case gExt.OAuth2Introspection != nil:
+			out, err := buildOAuth2IntrospectionProviderConfig(krtctx, &gExt, commoncol.BackendIndex, commoncol.Secrets)
+			if err != nil {
+				p.Err = fmt.Errorf("error building OAuth2 introspection config: %w", err)
+				return p
+			}
+			p.OAuth2Introspection = out
 		}
 		return p
 	}
@@ -230,6 +266,7 @@ func TranslateGatewayExtensionBuilder(
 func resolveJwtProviders(
 	krtctx krt.HandlerContext,
 	configMaps krt.Collection[*corev1.ConfigMap],
+	secrets secretGetter,
 	backendResolver backendResolver,
 	gwExtObj ir.ObjectSource,
 	policyName, policyNamespace string,
@@ -245,6 +282,7 @@ func resolveJwtProviders(
 			provider.JWTProvider,
 			policyNamespace,
 			configMaps,
+			secrets,
 			backendResolver,
 			gwExtObj,
 		)
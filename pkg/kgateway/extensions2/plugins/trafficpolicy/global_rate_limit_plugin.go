@@ -6,12 +6,14 @@ import (
 
 	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	ratev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ratelimit/v3"
+	envoymetadatav3 "github.com/envoyproxy/go-control-plane/envoy/type/metadata/v3"
 	"google.golang.org/protobuf/proto"
 	"istio.io/istio/pkg/kube/krt"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/pluginutils"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	ratelimitconfig "github.com/kgateway-dev/kgateway/v2/pkg/ratelimit/config"
 	"github.com/kgateway-dev/kgateway/v2/pkg/utils/cmputils"
 )
 
@@ -85,7 +87,7 @@ func constructGlobalRateLimit(
 
 	globalPolicy := in.Spec.RateLimit.Global
 	// Create rate limit actions for the route or vhost
-	actions, err := createRateLimitActions(globalPolicy.Descriptors)
+	rateLimits, err := createRateLimits(globalPolicy.Descriptors, in.GetNamespace()+"/"+in.GetName())
 	if err != nil {
 		return fmt.Errorf("failed to create rate limit actions: %w", err)
 	}
@@ -98,16 +100,45 @@ func constructGlobalRateLimit(
 	}
 	// Create route rate limits and store in the RateLimitIR struct
 	out.globalRateLimit = &globalRateLimitIR{
-		provider: gwExtIR,
-		rateLimitActions: []*envoyroutev3.RateLimit{
-			{
-				Actions: actions,
-			},
-		},
+		provider:         gwExtIR,
+		rateLimitActions: rateLimits,
 	}
 	return nil
 }
 
+// createRateLimits translates the API descriptors to Envoy route config rate limits. Descriptors
+// setting their limit are sent separately, so that the kgateway rate limit server can match each
+// of them with its limit, and start with a policy entry so that the descriptors of different
+// policies do not share their counters. Otherwise, the entries of all the descriptors form a
+// single descriptor.
+func createRateLimits(descriptors []kgateway.RateLimitDescriptor, policyName string) ([]*envoyroutev3.RateLimit, error) {
+	if len(descriptors) == 0 || descriptors[0].Limit == nil {
+		actions, err := createRateLimitActions(descriptors)
+		if err != nil {
+			return nil, err
+		}
+		return []*envoyroutev3.RateLimit{{Actions: actions}}, nil
+	}
+
+	rateLimits := make([]*envoyroutev3.RateLimit, 0, len(descriptors))
+	for _, descriptor := range descriptors {
+		actions, err := createRateLimitActions([]kgateway.RateLimitDescriptor{descriptor})
+		if err != nil {
+			return nil, err
+		}
+		policyAction := &envoyroutev3.RateLimit_Action{
+			ActionSpecifier: &envoyroutev3.RateLimit_Action_GenericKey_{
+				GenericKey: &envoyroutev3.RateLimit_Action_GenericKey{
+					DescriptorKey:   ratelimitconfig.PolicyKey,
+					DescriptorValue: policyName,
+				},
+			},
+		}
+		rateLimits = append(rateLimits, &envoyroutev3.RateLimit{Actions: append([]*envoyroutev3.RateLimit_Action{policyAction}, actions...)})
+	}
+	return rateLimits, nil
+}
+
 // createRateLimitActions translates the API descriptors to Envoy route config rate limit actions
 func createRateLimitActions(descriptors []kgateway.RateLimitDescriptor) ([]*envoyroutev3.RateLimit_Action, error) {
 	if len(descriptors) == 0 {
@@ -158,6 +189,23 @@ func createRateLimitActions(descriptors []kgateway.RateLimitDescriptor) ([]*envo
 						DescriptorKey: "path",
 					},
 				}
+			case kgateway.RateLimitDescriptorEntryTypeAPIKeyMetadata:
+				if entry.APIKeyMetadata == nil {
+					return nil, fmt.Errorf("apiKeyMetadata entry requires APIKeyMetadata field to be set")
+				}
+				// The API key verifier emits the metadata under the namespace of its filter
+				action.ActionSpecifier = &envoyroutev3.RateLimit_Action_Metadata{
+					Metadata: &envoyroutev3.RateLimit_Action_MetaData{
+						DescriptorKey: *entry.APIKeyMetadata,
+						MetadataKey: &envoymetadatav3.MetadataKey{
+							Key: apiKeyAuthVerifierFilterName,
+							Path: []*envoymetadatav3.MetadataKey_PathSegment{
+								{Segment: &envoymetadatav3.MetadataKey_PathSegment_Key{Key: *entry.APIKeyMetadata}},
+							},
+						},
+						Source: envoyroutev3.RateLimit_Action_MetaData_DYNAMIC,
+					},
+				}
 			default:
 				return nil, fmt.Errorf("unsupported entry type: %s", entry.Type)
 			}
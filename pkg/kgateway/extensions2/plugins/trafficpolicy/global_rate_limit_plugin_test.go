@@ -20,6 +20,7 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	ratelimitconfig "github.com/kgateway-dev/kgateway/v2/pkg/ratelimit/config"
 )
 
 func TestGlobalRateLimitIREquals(t *testing.T) {
@@ -238,6 +239,29 @@ func TestCreateRateLimitActions(t *testing.T) {
 				assert.Equal(t, "path", requestHeaders.DescriptorKey)
 			},
 		},
+		{
+			name: "with api key metadata descriptor",
+			descriptors: []kgateway.RateLimitDescriptor{
+				{
+					Entries: []kgateway.RateLimitDescriptorEntry{
+						{
+							Type:           kgateway.RateLimitDescriptorEntryTypeAPIKeyMetadata,
+							APIKeyMetadata: ptr.To("plan"),
+						},
+					},
+				},
+			},
+			validateResult: func(t *testing.T, actions []*envoyroutev3.RateLimit_Action) {
+				require.Len(t, actions, 1)
+				metadata := actions[0].GetMetadata()
+				require.NotNil(t, metadata)
+				assert.Equal(t, "plan", metadata.DescriptorKey)
+				assert.Equal(t, apiKeyAuthVerifierFilterName, metadata.GetMetadataKey().GetKey())
+				require.Len(t, metadata.GetMetadataKey().GetPath(), 1)
+				assert.Equal(t, "plan", metadata.GetMetadataKey().GetPath()[0].GetKey())
+				assert.Equal(t, envoyroutev3.RateLimit_Action_MetaData_DYNAMIC, metadata.Source)
+			},
+		},
 		{
 			name: "with multiple descriptors",
 			descriptors: []kgateway.RateLimitDescriptor{
@@ -338,6 +362,19 @@ func TestCreateRateLimitActions(t *testing.T) {
 			},
 			expectedError: "header entry requires Header field to be set",
 		},
+		{
+			name: "with missing api key metadata name",
+			descriptors: []kgateway.RateLimitDescriptor{
+				{
+					Entries: []kgateway.RateLimitDescriptorEntry{
+						{
+							Type: kgateway.RateLimitDescriptorEntryTypeAPIKeyMetadata,
+						},
+					},
+				},
+			},
+			expectedError: "apiKeyMetadata entry requires APIKeyMetadata field to be set",
+		},
 		{
 			name: "with unsupported entry type",
 			descriptors: []kgateway.RateLimitDescriptor{
@@ -370,6 +407,46 @@ func TestCreateRateLimitActions(t *testing.T) {
 	}
 }
 
+func TestCreateRateLimits(t *testing.T) {
+	generic := func(key string) kgateway.RateLimitDescriptorEntry {
+		return kgateway.RateLimitDescriptorEntry{
+			Type:    kgateway.RateLimitDescriptorEntryTypeGeneric,
+			Generic: &kgateway.RateLimitDescriptorEntryGeneric{Key: key, Value: "value"},
+		}
+	}
+	remoteAddress := kgateway.RateLimitDescriptorEntry{Type: kgateway.RateLimitDescriptorEntryTypeRemoteAddress}
+	limit := &kgateway.RateLimitDescriptorLimit{RequestsPerUnit: 10, Unit: kgateway.RateLimitUnitMinute}
+
+	t.Run("descriptors without limits form a single descriptor", func(t *testing.T) {
+		rateLimits, err := createRateLimits([]kgateway.RateLimitDescriptor{
+			{Entries: []kgateway.RateLimitDescriptorEntry{generic("a")}},
+			{Entries: []kgateway.RateLimitDescriptorEntry{remoteAddress}},
+		}, "default/policy")
+		require.NoError(t, err)
+		require.Len(t, rateLimits, 1)
+		assert.Len(t, rateLimits[0].GetActions(), 2)
+	})
+
+	t.Run("descriptors with limits are sent separately", func(t *testing.T) {
+		rateLimits, err := createRateLimits([]kgateway.RateLimitDescriptor{
+			{Entries: []kgateway.RateLimitDescriptorEntry{generic("a"), remoteAddress}, Limit: limit},
+			{Entries: []kgateway.RateLimitDescriptorEntry{generic("b")}, Limit: limit},
+		}, "default/policy")
+		require.NoError(t, err)
+		require.Len(t, rateLimits, 2)
+		for _, rateLimit := range rateLimits {
+			policyKey := rateLimit.GetActions()[0].GetGenericKey()
+			assert.Equal(t, ratelimitconfig.PolicyKey, policyKey.GetDescriptorKey())
+			assert.Equal(t, "default/policy", policyKey.GetDescriptorValue())
+		}
+		require.Len(t, rateLimits[0].GetActions(), 3)
+		assert.Equal(t, "a", rateLimits[0].GetActions()[1].GetGenericKey().GetDescriptorKey())
+		assert.NotNil(t, rateLimits[0].GetActions()[2].GetRemoteAddress())
+		require.Len(t, rateLimits[1].GetActions(), 2)
+		assert.Equal(t, "b", rateLimits[1].GetActions()[1].GetGenericKey().GetDescriptorKey())
+	})
+}
+
 func TestToRateLimitFilterConfig(t *testing.T) {
 	defaultExtensionName := "test-ratelimit"
 	defaultNamespace := "test-namespace"
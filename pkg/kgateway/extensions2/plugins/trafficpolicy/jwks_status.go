@@ -0,0 +1,202 @@
+package trafficpolicy
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"istio.io/istio/pkg/kube/controllers"
+	"istio.io/istio/pkg/kube/kclient"
+	"istio.io/istio/pkg/kube/krt"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/jwksstatus"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+// gatewayExtensionJWKSStatus is the JWKSResolved condition of a JWT GatewayExtension,
+// computed from the results of the controller probing its remote JWKS.
+type gatewayExtensionJWKSStatus struct {
+	ext       ir.ObjectSource
+	condition metav1.Condition
+}
+
+func (s gatewayExtensionJWKSStatus) ResourceName() string {
+	return s.ext.ResourceName()
+}
+
+func (s gatewayExtensionJWKSStatus) Equals(in gatewayExtensionJWKSStatus) bool {
+	return s.ext.Equals(in.ext) &&
+		s.condition.Status == in.condition.Status &&
+		s.condition.Reason == in.condition.Reason &&
+		s.condition.Message == in.condition.Message
+}
+
+// providerJWKSStatus is the probe result of the remote JWKS of a JWT provider, nil if not probed yet.
+type providerJWKSStatus struct {
+	provider string
+	status   *ir.JWKSStatus
+}
+
+// buildJWKSStatusCollection joins the JWT GatewayExtensions with the results of probing their remote JWKS.
+// GatewayExtensions without remote JWKS that can be probed, or that were never probed, have no status.
+func buildJWKSStatusCollection(commoncol *collections.CommonCollections) krt.Collection[gatewayExtensionJWKSStatus] {
+	return krt.NewCollection(commoncol.GatewayExtensions, func(kctx krt.HandlerContext, ext ir.GatewayExtension) *gatewayExtensionJWKSStatus {
+		if ext.JWT == nil {
+			return nil
+		}
+		var providers []providerJWKSStatus
+		for _, provider := range ext.JWT.Providers {
+			remote := provider.JWKS.RemoteJWKS
+			if remote == nil {
+				continue
+			}
+			// the backend index is initialized after the plugins, so it is only used in the transform
+			backend, err := commoncol.BackendIndex.GetBackendFromRef(kctx, ext.ObjectSource, remote.BackendRef)
+			if err != nil || backend == nil {
+				// unresolved backends are reported in the TrafficPolicy status
+				continue
+			}
+			if !jwksstatus.Probeable(backend) {
+				continue
+			}
+			providers = append(providers, providerJWKSStatus{
+				provider: provider.Name,
+				status:   krt.FetchOne(kctx, commoncol.JWKSStatus, krt.FilterKey(ir.JWKSStatusKey(remote.URL, backend.ClusterName()))),
+			})
+		}
+		cond := buildJWKSResolvedCondition(providers)
+		if cond == nil {
+			return nil
+		}
+		return &gatewayExtensionJWKSStatus{
+			ext:       ext.ObjectSource,
+			condition: *cond,
+		}
+	}, commoncol.KrtOpts.ToOptions("GatewayExtensionJWKSStatus")...)
+}
+
+// buildJWKSResolvedCondition builds the JWKSResolved condition from the probe results of the
+// remote JWKS of the providers. Returns nil if none of them were probed.
+func buildJWKSResolvedCondition(providers []providerJWKSStatus) *metav1.Condition {
+	var (
+		probed, keys int
+		reason       string
+		failures     []string
+	)
+	for _, p := range providers {
+		if p.status == nil {
+			continue
+		}
+		probed++
+		if p.status.Reason == "" {
+			keys += p.status.Keys
+			continue
+		}
+		// fetch failures take precedence over invalid keys
+		if reason != kgateway.GatewayExtensionReasonJWKSFetchFailed {
+			reason = p.status.Reason
+		}
+		failures = append(failures, fmt.Sprintf("provider %s: %s", p.provider, p.status.Message))
+	}
+
+	switch {
+	case probed == 0:
+		return nil
+	case len(failures) > 0:
+		return &metav1.Condition{
+			Type:    kgateway.GatewayExtensionConditionJWKSResolved,
+			Status:  metav1.ConditionFalse,
+			Reason:  reason,
+			Message: strings.Join(failures, "; "),
+		}
+	case probed < len(providers):
+		return &metav1.Condition{
+			Type:    kgateway.GatewayExtensionConditionJWKSResolved,
+			Status:  metav1.ConditionUnknown,
+			Reason:  kgateway.GatewayExtensionReasonJWKSPending,
+			Message: fmt.Sprintf("%d/%d remote JWKS probed", probed, len(providers)),
+		}
+	default:
+		return &metav1.Condition{
+			Type:    kgateway.GatewayExtensionConditionJWKSResolved,
+			Status:  metav1.ConditionTrue,
+			Reason:  kgateway.GatewayExtensionReasonJWKSResolved,
+			Message: fmt.Sprintf("%d remote JWKS resolved with %d keys", probed, keys),
+		}
+	}
+}
+
+// buildJWKSStatusCallback writes the JWKSResolved condition to the GatewayExtensions,
+// leaving their other conditions untouched.
+func buildJWKSStatusCallback(
+	cl kclient.Client[*kgateway.GatewayExtension],
+	scol krt.Collection[gatewayExtensionJWKSStatus],
+) func() {
+	return func() {
+		scol.Register(func(o krt.Event[gatewayExtensionJWKSStatus]) {
+			if o.Event == controllers.EventDelete {
+				return
+			}
+			latest := o.Latest()
+			resNN := types.NamespacedName{
+				Name:      latest.ext.Name,
+				Namespace: latest.ext.Namespace,
+			}
+
+			err := retry.Do(
+				func() error {
+					cur := cl.Get(resNN.Name, resNN.Namespace)
+					if cur == nil {
+						logger.Error("error getting gateway extension", "ref", resNN, "error", pluginsdk.ErrNotFound)
+						return pluginsdk.ErrNotFound
+					}
+
+					found := meta.FindStatusCondition(cur.Status.Conditions, latest.condition.Type)
+					if found != nil &&
+						found.Status == latest.condition.Status &&
+						found.Reason == latest.condition.Reason &&
+						found.Message == latest.condition.Message {
+						// condition is already up-to-date, nothing to do
+						return nil
+					}
+
+					conditions := append([]metav1.Condition(nil), cur.Status.Conditions...)
+					cond := latest.condition
+					cond.ObservedGeneration = cur.Generation
+					meta.SetStatusCondition(&conditions, cond)
+					if _, err := cl.UpdateStatus(&kgateway.GatewayExtension{
+						ObjectMeta: pluginsdk.CloneObjectMetaForStatus(cur.ObjectMeta),
+						Status: kgateway.GatewayExtensionStatus{
+							Conditions: conditions,
+						},
+					}); err != nil {
+						if errors.IsConflict(err) {
+							logger.Debug("error updating stale status", "ref", resNN, "error", err)
+							return nil // let the conflicting Status update trigger a KRT event to requeue the updated object
+						}
+						return fmt.Errorf("error updating status for GatewayExtension %s: %w", resNN, err)
+					}
+					return nil
+				},
+				retry.Attempts(5),
+				retry.Delay(100*time.Millisecond),
+				retry.DelayType(retry.BackOffDelay),
+			)
+			if err != nil {
+				logger.Error(
+					"all attempts failed updating gateway extension status",
+					"gateway_extension", resNN.String(),
+					"error", err,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package trafficpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestBuildJWKSResolvedCondition(t *testing.T) {
+	resolved := &ir.JWKSStatus{Keys: 2}
+	fetchFailed := &ir.JWKSStatus{Reason: kgateway.GatewayExtensionReasonJWKSFetchFailed, Message: "connection refused"}
+	invalidKeys := &ir.JWKSStatus{Reason: kgateway.GatewayExtensionReasonJWKSInvalidKeys, Message: "jwks contains no valid public keys"}
+
+	tests := []struct {
+		name      string
+		providers []providerJWKSStatus
+		want      *metav1.Condition
+	}{
+		{
+			name: "no remote jwks",
+		},
+		{
+			name:      "not probed yet",
+			providers: []providerJWKSStatus{{provider: "a"}},
+		},
+		{
+			name:      "resolved",
+			providers: []providerJWKSStatus{{provider: "a", status: resolved}, {provider: "b", status: resolved}},
+			want: &metav1.Condition{
+				Type:    kgateway.GatewayExtensionConditionJWKSResolved,
+				Status:  metav1.ConditionTrue,
+				Reason:  kgateway.GatewayExtensionReasonJWKSResolved,
+				Message: "2 remote JWKS resolved with 4 keys",
+			},
+		},
+		{
+			name:      "pending",
+			providers: []providerJWKSStatus{{provider: "a", status: resolved}, {provider: "b"}},
+			want: &metav1.Condition{
+				Type:    kgateway.GatewayExtensionConditionJWKSResolved,
+				Status:  metav1.ConditionUnknown,
+				Reason:  kgateway.GatewayExtensionReasonJWKSPending,
+				Message: "1/2 remote JWKS probed",
+			},
+		},
+		{
+			name: "fetch failures take precedence",
+			providers: []providerJWKSStatus{
+				{provider: "a", status: invalidKeys},
+				{provider: "b", status: fetchFailed},
+				{provider: "c", status: resolved},
+			},
+			want: &metav1.Condition{
+				Type:    kgateway.GatewayExtensionConditionJWKSResolved,
+				Status:  metav1.ConditionFalse,
+				Reason:  kgateway.GatewayExtensionReasonJWKSFetchFailed,
+				Message: "provider a: jwks contains no valid public keys; provider b: connection refused",
+			},
+		},
+		{
+			name:      "invalid keys",
+			providers: []providerJWKSStatus{{provider: "a", status: invalidKeys}, {provider: "b"}},
+			want: &metav1.Condition{
+				Type:    kgateway.GatewayExtensionConditionJWKSResolved,
+				Status:  metav1.ConditionFalse,
+				Reason:  kgateway.GatewayExtensionReasonJWKSInvalidKeys,
+				Message: "provider a: jwks contains no valid public keys",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, buildJWKSResolvedCondition(tt.providers))
+		})
+	}
+}
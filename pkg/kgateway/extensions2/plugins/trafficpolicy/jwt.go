@@ -14,6 +14,8 @@ import (
 
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	jwtauthnv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/jwt_authn/v3"
+	envoyauthz "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	envoymatcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	"github.com/go-jose/go-jose/v4"
 	"github.com/golang/protobuf/ptypes/empty"
 	"google.golang.org/protobuf/proto"
@@ -21,10 +23,12 @@ import (
 	"istio.io/istio/pkg/kube/krt"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/pluginutils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
 	"github.com/kgateway-dev/kgateway/v2/pkg/utils/cmputils"
@@ -42,6 +46,11 @@ const (
 type jwtIr struct {
 	perProviderConfig   []*perProviderJwtConfig
 	disableAllProviders bool
+	// claimRequirements is the per-route config of the RBAC filter enforcing the required
+	// scopes and claims.
+	claimRequirements *envoyauthz.RBACPerRoute
+	// routeMatch is added to the route match of the targeted route rules.
+	routeMatch []*envoymatcherv3.MetadataMatcher
 }
 
 type perProviderJwtConfig struct {
@@ -63,6 +72,14 @@ func (j *jwtIr) Equals(other PolicySubIR) bool {
 	if j.disableAllProviders != otherJwt.disableAllProviders {
 		return false
 	}
+	if !proto.Equal(j.claimRequirements, otherJwt.claimRequirements) {
+		return false
+	}
+	if !slices.EqualFunc(j.routeMatch, otherJwt.routeMatch, func(a, b *envoymatcherv3.MetadataMatcher) bool {
+		return proto.Equal(a, b)
+	}) {
+		return false
+	}
 
 	return slices.EqualFunc(j.perProviderConfig, otherJwt.perProviderConfig, func(a, b *perProviderJwtConfig) bool {
 		return proto.Equal(a.perRouteConfig, b.perRouteConfig) &&
@@ -141,6 +158,8 @@ func constructJwt(
 				perRouteConfig: perRouteConfig,
 			},
 		},
+		claimRequirements: translateJwtClaimRequirements(spec.RequiredScopes, spec.Claims),
+		routeMatch:        translateJwtRouteMatch(spec.RouteMatch),
 	}
 	return nil
 }
@@ -172,6 +191,16 @@ func (j *jwtIr) validate() error {
 			}
 		}
 	}
+	if j.claimRequirements != nil {
+		if err := j.claimRequirements.ValidateAll(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, m := range j.routeMatch {
+		if err := m.ValidateAll(); err != nil {
+			errs = append(errs, err)
+		}
+	}
 
 	return errors.Join(errs...)
 }
@@ -185,6 +214,7 @@ func translateProvider(
 	provider kgateway.JWTProvider,
 	policyNs string,
 	configMaps krt.Collection[*corev1.ConfigMap],
+	secrets secretGetter,
 	resolver backendResolver,
 	gwExtObj ir.ObjectSource,
 ) (*jwtauthnv3.JwtProvider, error) {
@@ -211,7 +241,7 @@ func translateProvider(
 		jwtProvider.ClearRouteCache = true
 	}
 	translateTokenSource(provider, jwtProvider)
-	err := translateJwks(krtctx, provider.JWKS, policyNs, jwtProvider, configMaps, resolver, gwExtObj)
+	err := translateJwks(krtctx, provider.JWKS, policyNs, jwtProvider, configMaps, secrets, resolver, gwExtObj)
 	if err != nil {
 		return nil, err
 	}
@@ -243,12 +273,17 @@ type backendResolver interface {
 	GetBackendFromRef(krt.HandlerContext, ir.ObjectSource, gwv1.BackendObjectReference) (*ir.BackendObjectIR, error)
 }
 
+type secretGetter interface {
+	GetSecret(krt.HandlerContext, krtcollections.From, gwv1.SecretObjectReference) (*ir.Secret, error)
+}
+
 func translateJwks(
 	krtctx krt.HandlerContext,
 	jwkConfig kgateway.JWKS,
 	policyNs string,
 	out *jwtauthnv3.JwtProvider,
 	configMaps krt.Collection[*corev1.ConfigMap],
+	secrets secretGetter,
 	resolver backendResolver,
 	gwExtObj ir.ObjectSource,
 ) error {
@@ -271,6 +306,22 @@ func translateJwks(
 				return err
 			}
 			out.JwksSourceSpecifier = jwkSource
+		case jwkConfig.LocalJWKS.SecretRef != nil:
+			if secrets == nil {
+				return errors.New("secrets not available")
+			}
+			secret, err := secrets.GetSecret(krtctx,
+				krtcollections.From{GroupKind: wellknown.GatewayExtensionGVK.GroupKind(), Namespace: policyNs},
+				gwv1.SecretObjectReference{Name: gwv1.ObjectName(jwkConfig.LocalJWKS.SecretRef.Name)},
+			)
+			if err != nil {
+				return fmt.Errorf("failed to find secret %s: %v", jwkConfig.LocalJWKS.SecretRef.Name, err)
+			}
+			jwkSource, err := translateJwksSecret(secret)
+			if err != nil {
+				return err
+			}
+			out.JwksSourceSpecifier = jwkSource
 		}
 	case jwkConfig.RemoteJWKS != nil:
 		remote := jwkConfig.RemoteJWKS
@@ -292,6 +343,14 @@ func translateJwks(
 		if remote.CacheDuration != nil {
 			jwksOut.RemoteJwks.CacheDuration = durationpb.New(remote.CacheDuration.Duration)
 		}
+		if asyncFetch := remote.AsyncFetch; asyncFetch != nil {
+			jwksOut.RemoteJwks.AsyncFetch = &jwtauthnv3.JwksAsyncFetch{
+				FastListener: ptr.Deref(asyncFetch.FastListener, false),
+			}
+			if asyncFetch.FailedRefetchDuration != nil {
+				jwksOut.RemoteJwks.AsyncFetch.FailedRefetchDuration = durationpb.New(asyncFetch.FailedRefetchDuration.Duration)
+			}
+		}
 		out.JwksSourceSpecifier = jwksOut
 	}
 	return nil
@@ -305,6 +364,14 @@ func translateJwksConfigMap(cm *corev1.ConfigMap) (*jwtauthnv3.JwtProvider_Local
 	return translateJwksInline(data)
 }
 
+func translateJwksSecret(secret *ir.Secret) (*jwtauthnv3.JwtProvider_LocalJwks, error) {
+	data := secret.Data[jwtConfigMapKey]
+	if len(data) == 0 {
+		return nil, fmt.Errorf("secret key '%s' not found", jwtConfigMapKey)
+	}
+	return translateJwksInline(string(data))
+}
+
 func translateJwksInline(inlineKey string) (*jwtauthnv3.JwtProvider_LocalJwks, error) {
 	keyset, err := TranslateKey(inlineKey)
 	if err != nil {
@@ -0,0 +1,203 @@
+package trafficpolicy
+
+import (
+	"fmt"
+	"regexp"
+
+	envoyrbacv3 "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	jwtauthnv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/jwt_authn/v3"
+	envoyauthz "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	envoymatcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+const (
+	// jwtAuthnMetadataNamespace is the dynamic metadata namespace the jwt_authn filter
+	// writes the validated payload to, under the PayloadInMetadata key.
+	jwtAuthnMetadataNamespace = "envoy.filters.http.jwt_authn"
+	// jwtClaimsFilterName is the RBAC filter enforcing the JWT claim requirements.
+	// It is separate from the RBAC filter used by the rbac policy so that both can apply to a route.
+	jwtClaimsFilterName = "envoy.filters.http.rbac/jwt_claims"
+	jwtClaimsPolicyName = "jwt-claims"
+	jwtScopeClaim       = "scope"
+)
+
+// translateJwtClaimRequirements builds the per-route RBAC config that only allows requests
+// whose validated JWT grants all the required scopes and satisfies all the claim matches.
+// Returns nil if there are no requirements.
+func translateJwtClaimRequirements(requiredScopes []string, claims []kgateway.JWTClaimMatch) *envoyauthz.RBACPerRoute {
+	if len(requiredScopes) == 0 && len(claims) == 0 {
+		return nil
+	}
+
+	principals := make([]*envoyrbacv3.Principal, 0, len(requiredScopes)+len(claims))
+	for _, scope := range requiredScopes {
+		principals = append(principals, jwtScopePrincipal(scope))
+	}
+	for _, claim := range claims {
+		principals = append(principals, metadataPrincipal(jwtClaimMetadataMatcher(claim)))
+	}
+
+	return &envoyauthz.RBACPerRoute{
+		Rbac: &envoyauthz.RBAC{
+			Rules: &envoyrbacv3.RBAC{
+				Action: envoyrbacv3.RBAC_ALLOW,
+				Policies: map[string]*envoyrbacv3.Policy{
+					jwtClaimsPolicyName: {
+						Permissions: []*envoyrbacv3.Permission{
+							{Rule: &envoyrbacv3.Permission_Any{Any: true}},
+						},
+						Principals: []*envoyrbacv3.Principal{
+							{Identifier: &envoyrbacv3.Principal_AndIds{
+								AndIds: &envoyrbacv3.Principal_Set{Ids: principals},
+							}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// jwtScopePrincipal matches a scope in the 'scope' claim, which is either a space-delimited
+// string as defined by RFC 8693 or an array as issued by some providers.
+func jwtScopePrincipal(scope string) *envoyrbacv3.Principal {
+	path := []string{jwtScopeClaim}
+	return &envoyrbacv3.Principal{
+		Identifier: &envoyrbacv3.Principal_OrIds{
+			OrIds: &envoyrbacv3.Principal_Set{
+				Ids: []*envoyrbacv3.Principal{
+					metadataPrincipal(jwtPayloadMetadataMatcher(path, &envoymatcherv3.ValueMatcher{
+						MatchPattern: &envoymatcherv3.ValueMatcher_StringMatch{
+							StringMatch: &envoymatcherv3.StringMatcher{
+								MatchPattern: &envoymatcherv3.StringMatcher_SafeRegex{
+									SafeRegex: &envoymatcherv3.RegexMatcher{
+										// safe_regex must match the whole value
+										Regex: `(.* )?` + regexp.QuoteMeta(scope) + `( .*)?`,
+									},
+								},
+							},
+						},
+					})),
+					metadataPrincipal(jwtPayloadMetadataMatcher(path, listContains(scope))),
+				},
+			},
+		},
+	}
+}
+
+func metadataPrincipal(m *envoymatcherv3.MetadataMatcher) *envoyrbacv3.Principal {
+	return &envoyrbacv3.Principal{
+		Identifier: &envoyrbacv3.Principal_Metadata{Metadata: m},
+	}
+}
+
+// translateJwtRouteMatch builds the route dynamic metadata matchers for the claim matches.
+func translateJwtRouteMatch(claims []kgateway.JWTClaimMatch) []*envoymatcherv3.MetadataMatcher {
+	if len(claims) == 0 {
+		return nil
+	}
+	matchers := make([]*envoymatcherv3.MetadataMatcher, 0, len(claims))
+	for _, claim := range claims {
+		matchers = append(matchers, jwtClaimMetadataMatcher(claim))
+	}
+	return matchers
+}
+
+func jwtClaimMetadataMatcher(claim kgateway.JWTClaimMatch) *envoymatcherv3.MetadataMatcher {
+	var value *envoymatcherv3.ValueMatcher
+	switch {
+	case claim.Exact != nil:
+		value = &envoymatcherv3.ValueMatcher{
+			MatchPattern: &envoymatcherv3.ValueMatcher_StringMatch{
+				StringMatch: &envoymatcherv3.StringMatcher{
+					MatchPattern: &envoymatcherv3.StringMatcher_Exact{Exact: *claim.Exact},
+				},
+			},
+		}
+	case claim.Contains != nil:
+		value = listContains(*claim.Contains)
+	}
+	return jwtPayloadMetadataMatcher(claim.Path, value)
+}
+
+func listContains(s string) *envoymatcherv3.ValueMatcher {
+	return &envoymatcherv3.ValueMatcher{
+		MatchPattern: &envoymatcherv3.ValueMatcher_ListMatch{
+			ListMatch: &envoymatcherv3.ListMatcher{
+				MatchPattern: &envoymatcherv3.ListMatcher_OneOf{
+					OneOf: &envoymatcherv3.ValueMatcher{
+						MatchPattern: &envoymatcherv3.ValueMatcher_StringMatch{
+							StringMatch: &envoymatcherv3.StringMatcher{
+								MatchPattern: &envoymatcherv3.StringMatcher_Exact{Exact: s},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// jwtPayloadMetadataMatcher matches the value at path in the validated JWT payload.
+func jwtPayloadMetadataMatcher(path []string, value *envoymatcherv3.ValueMatcher) *envoymatcherv3.MetadataMatcher {
+	segments := make([]*envoymatcherv3.MetadataMatcher_PathSegment, 0, len(path)+1)
+	for _, key := range append([]string{PayloadInMetadata}, path...) {
+		segments = append(segments, &envoymatcherv3.MetadataMatcher_PathSegment{
+			Segment: &envoymatcherv3.MetadataMatcher_PathSegment_Key{Key: key},
+		})
+	}
+	return &envoymatcherv3.MetadataMatcher{
+		Filter: jwtAuthnMetadataNamespace,
+		Path:   segments,
+		Value:  value,
+	}
+}
+
+// withClearRouteCache returns a copy of the JWT config with the route cache cleared after
+// validation, so that routes matching on the JWT claims are re-evaluated.
+func withClearRouteCache(in *jwtauthnv3.JwtAuthentication) *jwtauthnv3.JwtAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := proto.Clone(in).(*jwtauthnv3.JwtAuthentication)
+	for _, provider := range out.GetProviders() {
+		provider.ClearRouteCache = true
+	}
+	return out
+}
+
+// handleJwtClaims configures the claim requirements of a route and marks the filter chain
+// as needing the claims RBAC filter.
+func (p *trafficPolicyPluginGwPass) handleJwtClaims(fcn string, pCtxTypedFilterConfig *ir.TypedFilterConfigMap, jwtIr *jwtIr) {
+	if jwtIr == nil || jwtIr.claimRequirements == nil {
+		return
+	}
+	if p.jwtClaimsInChain == nil {
+		p.jwtClaimsInChain = make(map[string]bool)
+	}
+	p.jwtClaimsInChain[fcn] = true
+	pCtxTypedFilterConfig.AddTypedConfig(jwtClaimsFilterName, jwtIr.claimRequirements)
+}
+
+// handleJwtRouteMatch adds the JWT claim matches to the route match. The JWT filters of
+// the filter chain then clear the route cache so that the route is selected once the
+// claims are known.
+func (p *trafficPolicyPluginGwPass) handleJwtRouteMatch(fcn string, jwtIr *jwtIr, out *envoyroutev3.Route) error {
+	if jwtIr == nil || len(jwtIr.routeMatch) == 0 {
+		return nil
+	}
+	if out.GetMatch() == nil {
+		return fmt.Errorf("jwt: route %s has no match to add the claim matches to", out.GetName())
+	}
+	if p.jwtRouteMatchInChain == nil {
+		p.jwtRouteMatchInChain = make(map[string]bool)
+	}
+	p.jwtRouteMatchInChain[fcn] = true
+	out.Match.DynamicMetadata = append(out.Match.DynamicMetadata, jwtIr.routeMatch...)
+	return nil
+}
@@ -0,0 +1,53 @@
+package trafficpolicy
+
+import (
+	"regexp"
+	"testing"
+
+	jwtauthnv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/jwt_authn/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJwtScopePrincipalRegex(t *testing.T) {
+	principal := jwtScopePrincipal("api:admin")
+	ids := principal.GetOrIds().GetIds()
+	require.Len(t, ids, 2)
+	// Envoy requires safe_regex to match the whole value
+	re := regexp.MustCompile("^(?:" + ids[0].GetMetadata().GetValue().GetStringMatch().GetSafeRegex().GetRegex() + ")$")
+
+	tests := []struct {
+		scope string
+		want  bool
+	}{
+		{scope: "api:admin", want: true},
+		{scope: "read api:admin write", want: true},
+		{scope: "read api:admin", want: true},
+		{scope: "api:admin write", want: true},
+		{scope: "api:administrator", want: false},
+		{scope: "super-api:admin", want: false},
+		{scope: "apiXadmin", want: false},
+		{scope: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scope, func(t *testing.T) {
+			assert.Equal(t, tt.want, re.MatchString(tt.scope))
+		})
+	}
+}
+
+func TestWithClearRouteCache(t *testing.T) {
+	in := &jwtauthnv3.JwtAuthentication{
+		Providers: map[string]*jwtauthnv3.JwtProvider{
+			"a": {Issuer: "a"},
+			"b": {Issuer: "b", ClearRouteCache: true},
+		},
+	}
+
+	out := withClearRouteCache(in)
+	for name, provider := range out.GetProviders() {
+		assert.True(t, provider.GetClearRouteCache(), name)
+	}
+	assert.False(t, in.GetProviders()["a"].GetClearRouteCache(), "input must not be modified")
+	assert.Nil(t, withClearRouteCache(nil))
+}
@@ -206,6 +206,44 @@ func TestTranslateJwksConfigMap(t *testing.T) {
 	}
 }
 
+func TestTranslateJwksSecret(t *testing.T) {
+	tests := []struct {
+		name          string
+		secret        *ir.Secret
+		expectedError bool
+	}{
+		{
+			name: "valid secret",
+			secret: &ir.Secret{
+				Data: map[string][]byte{
+					"jwks": []byte(`{"keys":[{"kty":"RSA","kid":"test-key","use":"sig","alg":"RS256","n":"test-n","e":"AQAB"}]}`),
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "missing key in secret",
+			secret: &ir.Secret{
+				Data: map[string][]byte{},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jwks, err := translateJwksSecret(tt.secret)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, jwks)
+			assert.NotNil(t, jwks.LocalJwks)
+		})
+	}
+}
+
 func TestConvertJwtValidationConfig(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -437,7 +475,7 @@ func TestConvertJwtValidationConfig(t *testing.T) {
 			jwt := &kgateway.JWT{
 				Providers: tt.providers,
 			}
-			config, err := resolveJwtProviders(nil, nil, nil, ir.ObjectSource{}, "test-policy", "test-ns", jwt)
+			config, err := resolveJwtProviders(nil, nil, nil, nil, ir.ObjectSource{}, "test-policy", "test-ns", jwt)
 			if tt.expectedError {
 				assert.Error(t, err)
 				return
@@ -567,7 +605,7 @@ func TestResolveJwtProvidersWithValidationMode(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			config, err := resolveJwtProviders(nil, nil, nil, ir.ObjectSource{}, "test-policy", "test-ns", tt.jwt)
+			config, err := resolveJwtProviders(nil, nil, nil, nil, ir.ObjectSource{}, "test-policy", "test-ns", tt.jwt)
 			require.NoError(t, err)
 			assert.NotNil(t, config)
 			assert.NotNil(t, config.RequirementMap)
@@ -650,6 +688,7 @@ func TestTranslateJwksRemote(t *testing.T) {
 			"policy-ns",
 			out,
 			nil,
+			nil,
 			resolver,
 			ir.ObjectSource{Namespace: "policy-ns"},
 		)
@@ -663,6 +702,48 @@ func TestTranslateJwksRemote(t *testing.T) {
 		assert.Equal(t, time.Minute, remote.RemoteJwks.GetCacheDuration().AsDuration())
 	})
 
+	t.Run("async fetch", func(t *testing.T) {
+		t.Parallel()
+		backend := &ir.BackendObjectIR{
+			ObjectSource: ir.ObjectSource{
+				Kind:      "Service",
+				Namespace: "backend-ns",
+				Name:      "backend",
+			},
+			GvPrefix: "svc",
+			Port:     8443,
+		}
+		resolver := &fakeBackendResolver{backend: backend}
+		out := &jwtauthnv3.JwtProvider{}
+
+		err := translateJwks(
+			nil,
+			kgateway.JWKS{
+				RemoteJWKS: &kgateway.RemoteJWKS{
+					URL:        "https://example.com/jwks",
+					BackendRef: makeBackendRef("backend", "backend-ns", 8443),
+					AsyncFetch: &kgateway.JWKSAsyncFetch{
+						FastListener:          ptr.To(true),
+						FailedRefetchDuration: &metav1.Duration{Duration: 10 * time.Second},
+					},
+				},
+			},
+			"policy-ns",
+			out,
+			nil,
+			nil,
+			resolver,
+			ir.ObjectSource{Namespace: "policy-ns"},
+		)
+		require.NoError(t, err)
+
+		remote, ok := out.JwksSourceSpecifier.(*jwtauthnv3.JwtProvider_RemoteJwks)
+		require.True(t, ok, "expected remote jwks config to be set")
+		require.NotNil(t, remote.RemoteJwks.GetAsyncFetch())
+		assert.True(t, remote.RemoteJwks.GetAsyncFetch().GetFastListener())
+		assert.Equal(t, 10*time.Second, remote.RemoteJwks.GetAsyncFetch().GetFailedRefetchDuration().AsDuration())
+	})
+
 	t.Run("missing backend ref errors", func(t *testing.T) {
 		t.Parallel()
 		resolver := &fakeBackendResolver{err: errors.New("backend missing")}
@@ -679,6 +760,7 @@ func TestTranslateJwksRemote(t *testing.T) {
 			"policy-ns",
 			out,
 			nil,
+			nil,
 			resolver,
 			ir.ObjectSource{Namespace: "policy-ns"},
 		)
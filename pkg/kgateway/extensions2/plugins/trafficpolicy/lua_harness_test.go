@@ -0,0 +1,287 @@
+package trafficpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// luaPairsPrelude makes pairs honor __pairs, as the LuaJIT of Envoy does for header maps.
+const luaPairsPrelude = `
+local raw_pairs = pairs
+function pairs(t)
+  local mt = getmetatable(t)
+  if mt ~= nil and mt.__pairs ~= nil then
+    return mt.__pairs(t)
+  end
+  return raw_pairs(t)
+end
+`
+
+// luaStream is a request going through the Lua filters under test. It implements the subset of
+// the Envoy Lua filter API the scripts use, sharing the headers and the dynamic metadata between
+// filters, which run in their own Lua states as they do in Envoy.
+type luaStream struct {
+	routeName     string
+	headers       [][2]string
+	metadata      map[string]map[string]any
+	filterContext map[string]any
+	now           int64
+
+	// httpCall handles the handle:httpCall requests of the filters.
+	httpCall func(cluster string, headers map[string]string, body string) (map[string]string, string)
+	// response is set when a filter responds to the request.
+	response *luaResponse
+}
+
+type luaResponse struct {
+	headers map[string]string
+	body    string
+}
+
+func newLuaStream(routeName string, headers ...string) *luaStream {
+	s := &luaStream{
+		routeName: routeName,
+		metadata:  map[string]map[string]any{},
+		now:       1000,
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		s.headers = append(s.headers, [2]string{headers[i], headers[i+1]})
+	}
+	return s
+}
+
+// luaFilter is a Lua filter script loaded in its own state.
+type luaFilter struct {
+	state *lua.LState
+	now   *int64
+}
+
+func newLuaFilter(t *testing.T, code string) *luaFilter {
+	t.Helper()
+	L := lua.NewState()
+	t.Cleanup(L.Close)
+	f := &luaFilter{state: L, now: new(int64)}
+	L.GetGlobal("os").(*lua.LTable).RawSetString("time", L.NewFunction(func(L *lua.LState) int {
+		L.Push(lua.LNumber(*f.now))
+		return 1
+	}))
+	require.NoError(t, L.DoString(luaPairsPrelude))
+	require.NoError(t, L.DoString(code))
+	return f
+}
+
+func (f *luaFilter) onRequest(t *testing.T, s *luaStream) {
+	t.Helper()
+	f.call(t, "envoy_on_request", s)
+}
+
+func (f *luaFilter) onResponse(t *testing.T, s *luaStream) {
+	t.Helper()
+	f.call(t, "envoy_on_response", s)
+}
+
+func (f *luaFilter) call(t *testing.T, fn string, s *luaStream) {
+	t.Helper()
+	*f.now = s.now
+	L := f.state
+	require.NoError(t, L.CallByParam(lua.P{Fn: L.GetGlobal(fn), Protect: true}, s.handle(L)))
+}
+
+func (s *luaStream) header(name string) (string, bool) {
+	for _, h := range s.headers {
+		if h[0] == name {
+			return h[1], true
+		}
+	}
+	return "", false
+}
+
+func (s *luaStream) setHeader(name, value string) {
+	s.removeHeader(name)
+	s.headers = append(s.headers, [2]string{name, value})
+}
+
+func (s *luaStream) removeHeader(name string) {
+	kept := s.headers[:0]
+	for _, h := range s.headers {
+		if h[0] != name {
+			kept = append(kept, h)
+		}
+	}
+	s.headers = kept
+}
+
+func (s *luaStream) setMetadata(namespace, key string, value any) {
+	if s.metadata[namespace] == nil {
+		s.metadata[namespace] = map[string]any{}
+	}
+	s.metadata[namespace][key] = value
+}
+
+func (s *luaStream) handle(L *lua.LState) *lua.LTable {
+	headers := L.NewTable()
+	headers.RawSetString("get", L.NewFunction(func(L *lua.LState) int {
+		if v, ok := s.header(L.CheckString(2)); ok {
+			L.Push(lua.LString(v))
+		} else {
+			L.Push(lua.LNil)
+		}
+		return 1
+	}))
+	headers.RawSetString("replace", L.NewFunction(func(L *lua.LState) int {
+		s.setHeader(L.CheckString(2), L.CheckString(3))
+		return 0
+	}))
+	headers.RawSetString("add", L.NewFunction(func(L *lua.LState) int {
+		s.headers = append(s.headers, [2]string{L.CheckString(2), L.CheckString(3)})
+		return 0
+	}))
+	headers.RawSetString("remove", L.NewFunction(func(L *lua.LState) int {
+		s.removeHeader(L.CheckString(2))
+		return 0
+	}))
+	headersMeta := L.NewTable()
+	headersMeta.RawSetString("__pairs", L.NewFunction(func(L *lua.LState) int {
+		snapshot := append([][2]string(nil), s.headers...)
+		i := 0
+		L.Push(L.NewFunction(func(L *lua.LState) int {
+			if i >= len(snapshot) {
+				L.Push(lua.LNil)
+				return 1
+			}
+			h := snapshot[i]
+			i++
+			L.Push(lua.LString(h[0]))
+			L.Push(lua.LString(h[1]))
+			return 2
+		}))
+		return 1
+	}))
+	L.SetMetatable(headers, headersMeta)
+
+	metadata := L.NewTable()
+	metadata.RawSetString("get", L.NewFunction(func(L *lua.LState) int {
+		values, ok := s.metadata[L.CheckString(2)]
+		if !ok {
+			L.Push(lua.LNil)
+			return 1
+		}
+		L.Push(goToLua(L, values))
+		return 1
+	}))
+	metadata.RawSetString("set", L.NewFunction(func(L *lua.LState) int {
+		s.setMetadata(L.CheckString(2), L.CheckString(3), luaToGo(L.Get(4)))
+		return 0
+	}))
+
+	streamInfo := L.NewTable()
+	streamInfo.RawSetString("routeName", L.NewFunction(func(L *lua.LState) int {
+		L.Push(lua.LString(s.routeName))
+		return 1
+	}))
+	streamInfo.RawSetString("dynamicMetadata", L.NewFunction(func(L *lua.LState) int {
+		L.Push(metadata)
+		return 1
+	}))
+
+	handle := L.NewTable()
+	handle.RawSetString("headers", L.NewFunction(func(L *lua.LState) int {
+		L.Push(headers)
+		return 1
+	}))
+	handle.RawSetString("streamInfo", L.NewFunction(func(L *lua.LState) int {
+		L.Push(streamInfo)
+		return 1
+	}))
+	handle.RawSetString("httpCall", L.NewFunction(func(L *lua.LState) int {
+		cluster := L.CheckString(2)
+		headers := map[string]string{}
+		L.CheckTable(3).ForEach(func(k, v lua.LValue) {
+			headers[k.String()] = v.String()
+		})
+		respHeaders, body := s.httpCall(cluster, headers, L.OptString(4, ""))
+		t := L.NewTable()
+		for k, v := range respHeaders {
+			t.RawSetString(k, lua.LString(v))
+		}
+		L.Push(t)
+		L.Push(lua.LString(body))
+		return 2
+	}))
+	handle.RawSetString("respond", L.NewFunction(func(L *lua.LState) int {
+		resp := &luaResponse{headers: map[string]string{}, body: L.OptString(3, "")}
+		L.CheckTable(2).ForEach(func(k, v lua.LValue) {
+			resp.headers[k.String()] = v.String()
+		})
+		s.response = resp
+		return 0
+	}))
+	handle.RawSetString("logWarn", L.NewFunction(func(L *lua.LState) int {
+		return 0
+	}))
+	handle.RawSetString("filterContext", L.NewFunction(func(L *lua.LState) int {
+		if s.filterContext == nil {
+			L.Push(lua.LNil)
+		} else {
+			L.Push(goToLua(L, s.filterContext))
+		}
+		return 1
+	}))
+	return handle
+}
+
+// luaToGo converts a Lua value to the Go value Envoy would store in the dynamic metadata.
+func luaToGo(v lua.LValue) any {
+	switch v := v.(type) {
+	case lua.LBool:
+		return bool(v)
+	case lua.LNumber:
+		return float64(v)
+	case lua.LString:
+		return string(v)
+	case *lua.LTable:
+		if n := v.Len(); n > 0 && v.MaxN() == n {
+			list := make([]any, 0, n)
+			for i := 1; i <= n; i++ {
+				list = append(list, luaToGo(v.RawGetInt(i)))
+			}
+			return list
+		}
+		m := map[string]any{}
+		v.ForEach(func(k, value lua.LValue) {
+			m[k.String()] = luaToGo(value)
+		})
+		return m
+	default:
+		return nil
+	}
+}
+
+func goToLua(L *lua.LState, v any) lua.LValue {
+	switch v := v.(type) {
+	case bool:
+		return lua.LBool(v)
+	case float64:
+		return lua.LNumber(v)
+	case int:
+		return lua.LNumber(v)
+	case string:
+		return lua.LString(v)
+	case []any:
+		t := L.NewTable()
+		for _, value := range v {
+			t.Append(goToLua(L, value))
+		}
+		return t
+	case map[string]any:
+		t := L.NewTable()
+		for k, value := range v {
+			t.RawSetString(k, goToLua(L, value))
+		}
+		return t
+	default:
+		return lua.LNil
+	}
+}
@@ -57,6 +57,8 @@ func MergeTrafficPolicies(
 		mergeURLRewrite,
 		mergeAPIKeyAuth,
 		mergeOAuth,
+		mergeOAuth2Introspection,
+		mergeAPIConsumers,
 	}
 
 	for _, mergeFunc := range mergeFuncs {
@@ -349,6 +351,22 @@ func mergeOAuth(
 	defaultMerge(p1, p2, p2Ref, p2MergeOrigins, opts, mergeOrigins, accessor, "oidc")
 }
 
+func mergeOAuth2Introspection(
+	p1, p2 *TrafficPolicy,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+	_ TrafficPolicyMergeOpts,
+) {
+	accessor := fieldAccessor[oauth2IntrospectionIR]{
+		Get: func(spec *trafficPolicySpecIr) *oauth2IntrospectionIR { return spec.oauth2Introspection },
+		Set: func(spec *trafficPolicySpecIr, val *oauth2IntrospectionIR) { spec.oauth2Introspection = val },
+	}
+
+	defaultMerge(p1, p2, p2Ref, p2MergeOrigins, opts, mergeOrigins, accessor, "oauth2Introspection")
+}
+
 func mergeLocalRateLimit(
 	p1, p2 *TrafficPolicy,
 	p2Ref *ir.AttachedPolicyRef,
@@ -500,6 +518,21 @@ func mergeAPIKeyAuth(
 	defaultMerge(p1, p2, p2Ref, p2MergeOrigins, opts, mergeOrigins, accessor, "apiKeyAuth")
 }
 
+func mergeAPIConsumers(
+	p1, p2 *TrafficPolicy,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+	_ TrafficPolicyMergeOpts,
+) {
+	accessor := fieldAccessor[apiConsumersIR]{
+		Get: func(spec *trafficPolicySpecIr) *apiConsumersIR { return spec.apiConsumers },
+		Set: func(spec *trafficPolicySpecIr, val *apiConsumersIR) { spec.apiConsumers = val },
+	}
+	defaultMerge(p1, p2, p2Ref, p2MergeOrigins, opts, mergeOrigins, accessor, "apiConsumers")
+}
+
 func mergeRetry(
 	p1, p2 *TrafficPolicy,
 	p2Ref *ir.AttachedPolicyRef,
@@ -0,0 +1,373 @@
+package trafficpolicy
+
+import (
+	_ "embed"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoyaggregatev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/aggregate/v3"
+	envoycredentialinjectorv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/credential_injector/v3"
+	envoyluav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	envoyupstreamcodecv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/upstream_codec/v3"
+	envoyhcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	envoygenericcredentialv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/injected_credentials/generic/v3"
+	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	envoyupstreamsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"istio.io/istio/pkg/kube/krt"
+	"k8s.io/utils/ptr"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	kgwv1a1 "github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/pluginutils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+const (
+	// oauth2IntrospectionSourceCodeName is the name of the Lua source code that routes enable.
+	oauth2IntrospectionSourceCodeName = "oauth2_introspection"
+
+	defaultIntrospectionTimeout          = time.Second
+	defaultIntrospectionMaxCacheDuration = 5 * time.Minute
+)
+
+// oauth2IntrospectionScript validates bearer tokens against an RFC 7662 introspection endpoint.
+// Envoy has no native introspection filter, so it runs in the Lua filter.
+//
+//go:embed oauth2_introspection.lua
+var oauth2IntrospectionScript string
+
+type oauth2IntrospectionIR struct {
+	perRoute *envoyluav3.LuaPerRoute
+	source   *TrafficPolicyGatewayExtensionIR
+}
+
+// oauth2IntrospectionPerProviderConfig holds the Lua filter of a provider, and the cluster its
+// introspection requests are sent to. The cluster aggregates the cluster of the introspection
+// backend, and injects the client credentials of the secret, delivered over SDS so that they are
+// not part of the Lua source code.
+type oauth2IntrospectionPerProviderConfig struct {
+	cfg     *envoyluav3.Lua
+	cluster *envoyclusterv3.Cluster
+	secret  *envoytlsv3.Secret
+}
+
+func (a *oauth2IntrospectionPerProviderConfig) Equals(b *oauth2IntrospectionPerProviderConfig) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return proto.Equal(a.cfg, b.cfg) && proto.Equal(a.cluster, b.cluster) && proto.Equal(a.secret, b.secret)
+}
+
+func (a *oauth2IntrospectionPerProviderConfig) Validate() error {
+	if err := a.cfg.ValidateAll(); err != nil {
+		return err
+	}
+	if err := a.cluster.ValidateAll(); err != nil {
+		return err
+	}
+	return a.secret.ValidateAll()
+}
+
+var _ PolicySubIR = (*oauth2IntrospectionIR)(nil)
+
+func (a *oauth2IntrospectionIR) Equals(other PolicySubIR) bool {
+	b, ok := other.(*oauth2IntrospectionIR)
+	if !ok {
+		return false
+	}
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return proto.Equal(a.perRoute, b.perRoute) && a.source.Equals(*b.source)
+}
+
+func (o *oauth2IntrospectionIR) Validate() error {
+	if o == nil {
+		return nil
+	}
+	if err := o.perRoute.ValidateAll(); err != nil {
+		return err
+	}
+	if o.source.OAuth2Introspection != nil {
+		return o.source.OAuth2Introspection.Validate()
+	}
+	return nil
+}
+
+func constructOAuth2Introspection(
+	krtctx krt.HandlerContext,
+	in *kgwv1a1.TrafficPolicy,
+	fetchGatewayExtension FetchGatewayExtensionFunc,
+	out *trafficPolicySpecIr,
+) error {
+	spec := in.Spec.OAuth2Introspection
+	if spec == nil {
+		return nil
+	}
+
+	provider, err := fetchGatewayExtension(krtctx, spec.ExtensionRef, in.GetNamespace())
+	if err != nil {
+		return fmt.Errorf("oauth2 introspection: %w", err)
+	}
+	if provider.OAuth2Introspection == nil {
+		return pluginutils.ErrInvalidExtensionType(kgwv1a1.GatewayExtensionTypeOAuth2Introspection)
+	}
+
+	perRoute := &envoyluav3.LuaPerRoute{
+		Override: &envoyluav3.LuaPerRoute_Name{
+			Name: oauth2IntrospectionSourceCodeName,
+		},
+	}
+	if len(spec.RequiredScopes) > 0 {
+		scopes := make([]any, 0, len(spec.RequiredScopes))
+		for _, s := range spec.RequiredScopes {
+			scopes = append(scopes, s)
+		}
+		filterContext, err := structpb.NewStruct(map[string]any{"required_scopes": scopes})
+		if err != nil {
+			return fmt.Errorf("oauth2 introspection: %w", err)
+		}
+		perRoute.FilterContext = filterContext
+	}
+
+	out.oauth2Introspection = &oauth2IntrospectionIR{
+		perRoute: perRoute,
+		source:   provider,
+	}
+	return nil
+}
+
+// oauth2IntrospectionConfig is the provider configuration handed to the Lua script.
+type oauth2IntrospectionConfig struct {
+	Cluster         string                       `json:"cluster"`
+	Authority       string                       `json:"authority"`
+	Path            string                       `json:"path"`
+	TimeoutMs       int64                        `json:"timeout_ms"`
+	MaxCacheSeconds int64                        `json:"max_cache_seconds"`
+	ClaimsToHeaders []oauth2IntrospectionClaimTo `json:"claims_to_headers"`
+}
+
+type oauth2IntrospectionClaimTo struct {
+	Claim  string `json:"claim"`
+	Header string `json:"header"`
+}
+
+func buildOAuth2IntrospectionProviderConfig(
+	krtctx krt.HandlerContext,
+	ext *ir.GatewayExtension,
+	backends *krtcollections.BackendIndex,
+	secrets *krtcollections.SecretIndex,
+) (*oauth2IntrospectionPerProviderConfig, error) {
+	in := ext.OAuth2Introspection
+
+	endpoint, err := url.Parse(in.IntrospectionEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid introspectionEndpoint: %w", err)
+	}
+
+	backend, err := resolveBackend(krtctx, backends, false, ext.ObjectSource, in.BackendRef.BackendObjectReference)
+	if err != nil || backend == nil {
+		return nil, fmt.Errorf("error resolving oauth2 introspection backend %v: %w", in.BackendRef.BackendObjectReference, err)
+	}
+
+	credSecret, err := secrets.GetSecret(krtctx,
+		krtcollections.From{GroupKind: wellknown.GatewayExtensionGVK.GroupKind(), Namespace: ext.Namespace},
+		gwv1.SecretObjectReference{
+			Name: gwv1.ObjectName(in.Credentials.ClientSecretRef.Name), Namespace: ptr.To(gwv1.Namespace(ext.Namespace)),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	clientSecretData, ok := credSecret.Data[clientSecretKey]
+	if !ok || len(clientSecretData) == 0 {
+		return nil, fmt.Errorf("%s not found or empty in secret %s referenced by GatewayExtension %s",
+			clientSecretKey, credSecret.ResourceName(), ext.ResourceName())
+	}
+
+	timeout := defaultIntrospectionTimeout
+	if in.Timeout != nil {
+		timeout = in.Timeout.Duration
+	}
+	maxCacheDuration := defaultIntrospectionMaxCacheDuration
+	if in.MaxCacheDuration != nil {
+		maxCacheDuration = in.MaxCacheDuration.Duration
+	}
+
+	cfg := oauth2IntrospectionConfig{
+		Cluster:         oauth2IntrospectionClusterName(ext.ResourceName()),
+		Authority:       endpoint.Host,
+		Path:            endpoint.RequestURI(),
+		TimeoutMs:       timeout.Milliseconds(),
+		MaxCacheSeconds: int64(maxCacheDuration.Seconds()),
+		ClaimsToHeaders: make([]oauth2IntrospectionClaimTo, 0, len(in.ClaimsToHeaders)),
+	}
+	for _, c := range in.ClaimsToHeaders {
+		cfg.ClaimsToHeaders = append(cfg.ClaimsToHeaders, oauth2IntrospectionClaimTo{
+			Claim:  c.Name,
+			Header: strings.ToLower(c.Header),
+		})
+	}
+	cfgJSON, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	secretName := oauth2IntrospectionSecretName(ext.ResourceName())
+	cluster, err := buildOAuth2IntrospectionCluster(cfg.Cluster, backend.ClusterName(), secretName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth2IntrospectionPerProviderConfig{
+		cfg: &envoyluav3.Lua{
+			SourceCodes: map[string]*envoycorev3.DataSource{
+				oauth2IntrospectionSourceCodeName: {
+					Specifier: &envoycorev3.DataSource_InlineString{
+						InlineString: "local config_json = " + luaLongString(string(cfgJSON)) + "\n" + oauth2IntrospectionScript,
+					},
+				},
+			},
+		},
+		cluster: cluster,
+		secret: &envoytlsv3.Secret{
+			Name: secretName,
+			Type: &envoytlsv3.Secret_GenericSecret{
+				GenericSecret: &envoytlsv3.GenericSecret{
+					Secret: &envoycorev3.DataSource{
+						Specifier: &envoycorev3.DataSource_InlineString{
+							InlineString: clientSecretBasicAuth(in.Credentials.ClientID, string(clientSecretData)),
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// buildOAuth2IntrospectionCluster builds the cluster the Lua filter sends introspection requests to.
+// It aggregates the backend cluster, and injects the Authorization header of the secret in an
+// upstream filter, as the Lua filter cannot read secrets.
+func buildOAuth2IntrospectionCluster(name, backendCluster, secretName string) (*envoyclusterv3.Cluster, error) {
+	aggregate, err := utils.MessageToAny(&envoyaggregatev3.ClusterConfig{
+		Clusters: []string{backendCluster},
+	})
+	if err != nil {
+		return nil, err
+	}
+	credential, err := utils.MessageToAny(&envoygenericcredentialv3.Generic{
+		Credential: &envoytlsv3.SdsSecretConfig{
+			Name:      secretName,
+			SdsConfig: adsConfigSource(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	injector, err := utils.MessageToAny(&envoycredentialinjectorv3.CredentialInjector{
+		Overwrite: true,
+		Credential: &envoycorev3.TypedExtensionConfig{
+			Name:        "envoy.http.injected_credentials.generic",
+			TypedConfig: credential,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	codec, err := utils.MessageToAny(&envoyupstreamcodecv3.UpstreamCodec{})
+	if err != nil {
+		return nil, err
+	}
+	protocolOptions, err := utils.MessageToAny(&envoyupstreamsv3.HttpProtocolOptions{
+		UpstreamProtocolOptions: &envoyupstreamsv3.HttpProtocolOptions_ExplicitHttpConfig_{
+			ExplicitHttpConfig: &envoyupstreamsv3.HttpProtocolOptions_ExplicitHttpConfig{
+				ProtocolConfig: &envoyupstreamsv3.HttpProtocolOptions_ExplicitHttpConfig_HttpProtocolOptions{
+					HttpProtocolOptions: &envoycorev3.Http1ProtocolOptions{},
+				},
+			},
+		},
+		HttpFilters: []*envoyhcmv3.HttpFilter{
+			{
+				Name:       "envoy.filters.http.credential_injector",
+				ConfigType: &envoyhcmv3.HttpFilter_TypedConfig{TypedConfig: injector},
+			},
+			{
+				Name:       "envoy.filters.http.upstream_codec",
+				ConfigType: &envoyhcmv3.HttpFilter_TypedConfig{TypedConfig: codec},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &envoyclusterv3.Cluster{
+		Name:           name,
+		ConnectTimeout: durationpb.New(5 * time.Second),
+		LbPolicy:       envoyclusterv3.Cluster_CLUSTER_PROVIDED,
+		ClusterDiscoveryType: &envoyclusterv3.Cluster_ClusterType{
+			ClusterType: &envoyclusterv3.Cluster_CustomClusterType{
+				Name:        "envoy.clusters.aggregate",
+				TypedConfig: aggregate,
+			},
+		},
+		TypedExtensionProtocolOptions: map[string]*anypb.Any{
+			"envoy.extensions.upstreams.http.v3.HttpProtocolOptions": protocolOptions,
+		},
+	}, nil
+}
+
+func oauth2IntrospectionClusterName(provider string) string {
+	return "oauth2_introspection/" + provider
+}
+
+func oauth2IntrospectionSecretName(provider string) string {
+	return "oauth2_introspection/" + provider
+}
+
+// clientSecretBasicAuth builds the Authorization header value for the client_secret_basic
+// authentication method. Refer to https://datatracker.ietf.org/doc/html/rfc6749#section-2.3.1.
+func clientSecretBasicAuth(clientID, clientSecret string) string {
+	creds := url.QueryEscape(clientID) + ":" + url.QueryEscape(clientSecret)
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(creds))
+}
+
+// luaLongString quotes s as a Lua long string, picking a level that does not occur in s.
+func luaLongString(s string) string {
+	level := ""
+	for strings.Contains(s, "]"+level+"]") {
+		level += "="
+	}
+	return "[" + level + "[" + s + "]" + level + "]"
+}
+
+func oauth2IntrospectionFilterName(provider string) string {
+	return "envoy.filters.http.lua/oauth2_introspection/" + provider
+}
+
+func (p *trafficPolicyPluginGwPass) handleOAuth2Introspection(filterChain string, perFilterConfig *ir.TypedFilterConfigMap, in *oauth2IntrospectionIR) {
+	if in == nil {
+		return
+	}
+
+	p.oauth2IntrospectionPerProvider.Add(filterChain, in.source.Name, in.source)
+	perFilterConfig.AddTypedConfig(oauth2IntrospectionFilterName(in.source.Name), in.perRoute)
+	if provider := in.source.OAuth2Introspection; provider != nil {
+		p.clusters[provider.cluster.GetName()] = provider.cluster
+		p.secrets[provider.secret.GetName()] = provider.secret
+	}
+}
@@ -0,0 +1,289 @@
+-- OAuth 2.0 Token Introspection (RFC 7662).
+--
+-- The controller prepends `local config_json = [[...]]` with the provider configuration:
+--   cluster, authority, path:  where to send introspection requests, the cluster injects the client
+--                              credentials
+--   timeout_ms:                introspection request timeout
+--   max_cache_seconds:         upper bound for caching active results, 0 disables caching
+--   claims_to_headers:         list of {claim, header} to copy upstream
+--
+-- Routes enable the filter with a filter context that may carry `required_scopes`.
+
+local json = {}
+do
+  local escapes = { ['"'] = '"', ['\\'] = '\\', ['/'] = '/', b = '\b', f = '\f', n = '\n', r = '\r', t = '\t' }
+
+  local function skip(s, i)
+    return s:find('[^ \t\r\n]', i) or (#s + 1)
+  end
+
+  local function utf8char(cp)
+    if cp < 0x80 then
+      return string.char(cp)
+    elseif cp < 0x800 then
+      return string.char(0xC0 + math.floor(cp / 0x40), 0x80 + cp % 0x40)
+    elseif cp < 0x10000 then
+      return string.char(0xE0 + math.floor(cp / 0x1000), 0x80 + math.floor(cp / 0x40) % 0x40, 0x80 + cp % 0x40)
+    end
+    return string.char(0xF0 + math.floor(cp / 0x40000), 0x80 + math.floor(cp / 0x1000) % 0x40,
+      0x80 + math.floor(cp / 0x40) % 0x40, 0x80 + cp % 0x40)
+  end
+
+  local function decode_string(s, i)
+    local parts = {}
+    local j = i + 1
+    while true do
+      local c = s:sub(j, j)
+      if c == '' then
+        error('unterminated string')
+      elseif c == '"' then
+        return table.concat(parts), j + 1
+      elseif c == '\\' then
+        local e = s:sub(j + 1, j + 1)
+        if e == 'u' then
+          local cp = tonumber(s:sub(j + 2, j + 5), 16)
+          if not cp then
+            error('invalid unicode escape')
+          end
+          j = j + 6
+          if cp >= 0xD800 and cp <= 0xDBFF and s:sub(j, j + 1) == '\\u' then
+            local lo = tonumber(s:sub(j + 2, j + 5), 16)
+            if lo and lo >= 0xDC00 and lo <= 0xDFFF then
+              cp = 0x10000 + (cp - 0xD800) * 0x400 + (lo - 0xDC00)
+              j = j + 6
+            end
+          end
+          parts[#parts + 1] = utf8char(cp)
+        else
+          local r = escapes[e]
+          if not r then
+            error('invalid escape')
+          end
+          parts[#parts + 1] = r
+          j = j + 2
+        end
+      else
+        local k = s:find('["\\]', j) or (#s + 1)
+        parts[#parts + 1] = s:sub(j, k - 1)
+        j = k
+      end
+    end
+  end
+
+  local decode_value
+
+  local function decode_array(s, i)
+    local res = {}
+    i = skip(s, i + 1)
+    if s:sub(i, i) == ']' then
+      return res, i + 1
+    end
+    while true do
+      local v
+      v, i = decode_value(s, i)
+      res[#res + 1] = v
+      i = skip(s, i)
+      local c = s:sub(i, i)
+      if c == ']' then
+        return res, i + 1
+      elseif c ~= ',' then
+        error('expected , or ] in array')
+      end
+      i = skip(s, i + 1)
+    end
+  end
+
+  local function decode_object(s, i)
+    local res = {}
+    i = skip(s, i + 1)
+    if s:sub(i, i) == '}' then
+      return res, i + 1
+    end
+    while true do
+      if s:sub(i, i) ~= '"' then
+        error('expected string key in object')
+      end
+      local k
+      k, i = decode_string(s, i)
+      i = skip(s, i)
+      if s:sub(i, i) ~= ':' then
+        error('expected : in object')
+      end
+      local v
+      v, i = decode_value(s, skip(s, i + 1))
+      res[k] = v
+      i = skip(s, i)
+      local c = s:sub(i, i)
+      if c == '}' then
+        return res, i + 1
+      elseif c ~= ',' then
+        error('expected , or } in object')
+      end
+      i = skip(s, i + 1)
+    end
+  end
+
+  decode_value = function(s, i)
+    i = skip(s, i)
+    local c = s:sub(i, i)
+    if c == '{' then
+      return decode_object(s, i)
+    elseif c == '[' then
+      return decode_array(s, i)
+    elseif c == '"' then
+      return decode_string(s, i)
+    elseif s:sub(i, i + 3) == 'true' then
+      return true, i + 4
+    elseif s:sub(i, i + 4) == 'false' then
+      return false, i + 5
+    elseif s:sub(i, i + 3) == 'null' then
+      return nil, i + 4
+    end
+    local num = s:match('^-?%d+%.?%d*[eE]?[-+]?%d*', i)
+    if not num or not tonumber(num) then
+      error('unexpected character at position ' .. i)
+    end
+    return tonumber(num), i + #num
+  end
+
+  function json.decode(s)
+    local v, i = decode_value(s, 1)
+    if skip(s, i) <= #s then
+      error('trailing characters')
+    end
+    return v
+  end
+end
+
+local config = json.decode(config_json)
+local claims_to_headers = config.claims_to_headers or {}
+
+-- Active results are cached per worker, keyed by token.
+local max_cache_entries = 10000
+local cache = {}
+local cache_size = 0
+
+local function cache_get(token, now)
+  local entry = cache[token]
+  if entry == nil then
+    return nil
+  end
+  if entry.expires > now then
+    return entry.claims
+  end
+  cache[token] = nil
+  cache_size = cache_size - 1
+  return nil
+end
+
+local function cache_put(token, claims, now)
+  local expires = now + (config.max_cache_seconds or 0)
+  if type(claims.exp) == 'number' and claims.exp < expires then
+    expires = claims.exp
+  end
+  if expires <= now then
+    return
+  end
+  if cache_size >= max_cache_entries then
+    cache = {}
+    cache_size = 0
+  end
+  if cache[token] == nil then
+    cache_size = cache_size + 1
+  end
+  cache[token] = { claims = claims, expires = expires }
+end
+
+local function url_encode(s)
+  return (s:gsub('[^%w%-%._~]', function(c)
+    return string.format('%%%02X', string.byte(c))
+  end))
+end
+
+local function introspect(handle, token)
+  local headers, body = handle:httpCall(config.cluster, {
+    [':method'] = 'POST',
+    [':path'] = config.path,
+    [':authority'] = config.authority,
+    ['content-type'] = 'application/x-www-form-urlencoded',
+    ['accept'] = 'application/json',
+  }, 'token=' .. url_encode(token) .. '&token_type_hint=access_token', config.timeout_ms)
+  local status = headers and headers[':status']
+  if status ~= '200' or body == nil then
+    return nil, 'introspection request failed with status ' .. tostring(status)
+  end
+  local ok, res = pcall(json.decode, body)
+  if not ok or type(res) ~= 'table' then
+    return nil, 'invalid introspection response'
+  end
+  return res
+end
+
+local function missing_scopes(claims, required)
+  local granted = {}
+  if type(claims.scope) == 'string' then
+    for s in claims.scope:gmatch('%S+') do
+      granted[s] = true
+    end
+  end
+  for _, s in ipairs(required) do
+    if not granted[s] then
+      return true
+    end
+  end
+  return false
+end
+
+local function deny(handle, status, challenge)
+  handle:respond({ [':status'] = status, ['www-authenticate'] = challenge }, '')
+end
+
+function envoy_on_request(handle)
+  local headers = handle:headers()
+  for _, c in ipairs(claims_to_headers) do
+    headers:remove(c.header)
+  end
+
+  local auth = headers:get('authorization')
+  local token = auth and auth:match('^[Bb][Ee][Aa][Rr][Ee][Rr] +(%S+)$')
+  if token == nil then
+    deny(handle, '401', 'Bearer')
+    return
+  end
+
+  local now = os.time()
+  local claims = cache_get(token, now)
+  if claims == nil then
+    local res, err = introspect(handle, token)
+    if res == nil then
+      handle:logWarn('oauth2 introspection: ' .. err)
+      handle:respond({ [':status'] = '503' }, 'token introspection failed')
+      return
+    end
+    if res.active ~= true or (type(res.exp) == 'number' and res.exp <= now) then
+      deny(handle, '401', 'Bearer error="invalid_token"')
+      return
+    end
+    claims = res
+    cache_put(token, claims, now)
+  end
+
+  local ctx = handle:filterContext()
+  local required = ctx and ctx.required_scopes
+  if required ~= nil and missing_scopes(claims, required) then
+    deny(handle, '403', 'Bearer error="insufficient_scope", scope="' .. table.concat(required, ' ') .. '"')
+    return
+  end
+
+  for _, c in ipairs(claims_to_headers) do
+    local v = claims[c.claim]
+    local t = type(v)
+    if t == 'string' then
+      headers:replace(c.header, v)
+    elseif t == 'number' then
+      headers:replace(c.header, string.format('%.14g', v))
+    elseif t == 'boolean' then
+      headers:replace(c.header, tostring(v))
+    end
+  end
+end
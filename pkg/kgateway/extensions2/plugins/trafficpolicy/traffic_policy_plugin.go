@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	exteniondynamicmodulev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/dynamic_modules/v3"
 	envoy_api_key_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/api_key_auth/v3"
@@ -105,6 +106,10 @@ type trafficPolicySpecIr struct {
 	urlRewrite      *urlRewriteIR
 	apiKeyAuth      *apiKeyAuthIR
 	oauth2          *oauthIR
+	// oauth2Introspection validates opaque tokens against an introspection endpoint
+	oauth2Introspection *oauth2IntrospectionIR
+	// apiConsumers resolves the authenticated principals to APIConsumers
+	apiConsumers *apiConsumersIR
 }
 
 func (d *TrafficPolicy) CreationTime() time.Time {
@@ -183,6 +188,12 @@ func (d *TrafficPolicy) Equals(in any) bool {
 	if !d.spec.oauth2.Equals(d2.spec.oauth2) {
 		return false
 	}
+	if !d.spec.oauth2Introspection.Equals(d2.spec.oauth2Introspection) {
+		return false
+	}
+	if !d.spec.apiConsumers.Equals(d2.spec.apiConsumers) {
+		return false
+	}
 	return true
 }
 
@@ -210,6 +221,8 @@ func (p *TrafficPolicy) Validate() error {
 	validators = append(validators, p.spec.urlRewrite.Validate)
 	validators = append(validators, p.spec.apiKeyAuth.Validate)
 	validators = append(validators, p.spec.oauth2.Validate)
+	validators = append(validators, p.spec.oauth2Introspection.Validate)
+	validators = append(validators, p.spec.apiConsumers.Validate)
 	for _, validator := range validators {
 		if err := validator(); err != nil {
 			return err
@@ -222,25 +235,35 @@ type trafficPolicyPluginGwPass struct {
 	reporter reporter.Reporter
 	ir.UnimplementedProxyTranslationPass
 
-	setTransformationInChain map[string]bool // TODO(nfuden): make this multi stage
-	listenerTransform        *transformationpb.RouteTransformations
-	localRateLimitInChain    map[string]*localratelimitv3.LocalRateLimit
-	extAuthPerProvider       ProviderNeededMap
-	extProcPerProvider       ProviderNeededMap
-	jwtPerProvider           ProviderNeededMap
-	rateLimitPerProvider     ProviderNeededMap
-	oauth2PerProvider        ProviderNeededMap
-	rbacInChain              map[string]*envoyrbacv3.RBAC
-	corsInChain              map[string]*corsv3.Cors
-	csrfInChain              map[string]*envoy_csrf_v3.CsrfPolicy
-	headerMutationInChain    map[string]*header_mutationv3.HeaderMutationPerRoute
-	bufferInChain            map[string]*bufferv3.Buffer
-	compressorInChain        map[string]*compressorv3.Compressor
-	decompressorInChain      map[string]*decompressorv3.Decompressor
-	basicAuthInChain         map[string]*envoy_basic_auth_v3.BasicAuth
-	apiKeyAuthInChain        map[string]*envoy_api_key_auth_v3.ApiKeyAuth
+	setTransformationInChain       map[string]bool // TODO(nfuden): make this multi stage
+	listenerTransform              *transformationpb.RouteTransformations
+	localRateLimitInChain          map[string]*localratelimitv3.LocalRateLimit
+	extAuthPerProvider             ProviderNeededMap
+	extAuthFailureModePerProvider  ProviderNeededMap
+	extProcPerProvider             ProviderNeededMap
+	jwtPerProvider                 ProviderNeededMap
+	rateLimitPerProvider           ProviderNeededMap
+	oauth2PerProvider              ProviderNeededMap
+	oauth2IntrospectionPerProvider ProviderNeededMap
+	rbacInChain                    map[string]*envoyrbacv3.RBAC
+	jwtClaimsInChain               map[string]bool
+	jwtRouteMatchInChain           map[string]bool
+	corsInChain                    map[string]*corsv3.Cors
+	csrfInChain                    map[string]*envoy_csrf_v3.CsrfPolicy
+	headerMutationInChain          map[string]*header_mutationv3.HeaderMutationPerRoute
+	bufferInChain                  map[string]*bufferv3.Buffer
+	compressorInChain              map[string]*compressorv3.Compressor
+	decompressorInChain            map[string]*decompressorv3.Decompressor
+	basicAuthInChain               map[string]*envoy_basic_auth_v3.BasicAuth
+	basicAuthVerifierInChain       map[string]string
+	apiKeyAuthInChain              map[string]*envoy_api_key_auth_v3.ApiKeyAuth
+	apiKeyAuthVerifierInChain      map[string]string
+	apiConsumerInChain             map[string]string
+	apiConsumerRateLimitInChain    map[string]bool
 	// maps secret name to secret in case the same secret is referenced in multiple attachment points (e.g., vhost and route)
 	secrets map[string]*envoytlsv3.Secret
+	// maps cluster name to the clusters the filters send requests to, such as token introspection
+	clusters map[string]*envoyclusterv3.Cluster
 }
 
 var _ ir.ProxyTranslationPass = &trafficPolicyPluginGwPass{}
@@ -321,6 +344,18 @@ func NewPlugin(ctx context.Context, commoncol *collections.CommonCollections, me
 		}
 	}
 
+	var leaderActions map[schema.GroupKind]func()
+	if commoncol.Settings.EnableJwksStatus && commoncol.JWKSStatus != nil {
+		gwExtCli := kclient.NewFilteredDelayed[*kgateway.GatewayExtension](
+			commoncol.Client,
+			wellknown.GatewayExtensionGVR,
+			kclient.Filter{ObjectFilter: commoncol.Client.ObjectFilter()},
+		)
+		leaderActions = map[schema.GroupKind]func(){
+			wellknown.GatewayExtensionGVK.GroupKind(): buildJWKSStatusCallback(gwExtCli, buildJWKSStatusCollection(commoncol)),
+		}
+	}
+
 	return sdk.Plugin{
 		ContributesPolicies: map[schema.GroupKind]sdk.PolicyPlugin{
 			wellknown.TrafficPolicyGVK.GroupKind(): {
@@ -334,7 +369,8 @@ func NewPlugin(ctx context.Context, commoncol *collections.CommonCollections, me
 				PatchPolicyStatus: patchPolicyStatusFn(cli),
 			},
 		},
-		ExtraHasSynced: constructor.HasSynced,
+		ContributesLeaderAction: leaderActions,
+		ExtraHasSynced:          constructor.HasSynced,
 	}
 }
 
@@ -343,6 +379,7 @@ func NewGatewayTranslationPass(tctx ir.GwTranslationCtx, reporter reporter.Repor
 		reporter:                 reporter,
 		setTransformationInChain: map[string]bool{},
 		secrets:                  map[string]*envoytlsv3.Secret{},
+		clusters:                 map[string]*envoyclusterv3.Cluster{},
 	}
 }
 
@@ -360,6 +397,7 @@ func (p *trafficPolicyPluginGwPass) ApplyRouteConfigPlugin(
 	}
 
 	p.handlePolicies(pCtx.FilterChainName, &pCtx.TypedFilterConfig, policy.spec)
+	p.handleAPIConsumers(pCtx.FilterChainName, &pCtx.TypedFilterConfig, policy.spec, nil)
 }
 
 func (p *trafficPolicyPluginGwPass) ApplyVhostPlugin(
@@ -373,6 +411,7 @@ func (p *trafficPolicyPluginGwPass) ApplyVhostPlugin(
 
 	p.handlePerVHostPolicies(policy.spec, out)
 	p.handlePolicies(pCtx.FilterChainName, &pCtx.TypedFilterConfig, policy.spec)
+	p.handleAPIConsumers(pCtx.FilterChainName, &pCtx.TypedFilterConfig, policy.spec, nil)
 }
 
 // called 0 or more times
@@ -384,8 +423,9 @@ func (p *trafficPolicyPluginGwPass) ApplyForRoute(pCtx *ir.RouteContext, outputR
 
 	p.handlePerRoutePolicies(policy.spec, outputRoute)
 	p.handlePolicies(pCtx.FilterChainName, &pCtx.TypedFilterConfig, policy.spec)
+	p.handleAPIConsumers(pCtx.FilterChainName, &pCtx.TypedFilterConfig, policy.spec, pCtx.In.Parent)
 
-	return nil
+	return p.handleJwtRouteMatch(pCtx.FilterChainName, policy.spec.jwt, outputRoute)
 }
 
 func (p *trafficPolicyPluginGwPass) ApplyForRouteBackend(
@@ -398,6 +438,7 @@ func (p *trafficPolicyPluginGwPass) ApplyForRouteBackend(
 	}
 
 	p.handlePolicies(pCtx.FilterChainName, &pCtx.TypedFilterConfig, rtPolicy.spec)
+	p.handleAPIConsumers(pCtx.FilterChainName, &pCtx.TypedFilterConfig, rtPolicy.spec, nil)
 
 	return nil
 }
@@ -496,6 +537,39 @@ func (p *trafficPolicyPluginGwPass) HttpFilters(_ ir.HttpFiltersContext, fcc ir.
 
 		stagedExtAuthFilter.Filter.Disabled = true
 		stagedFilters = append(stagedFilters, stagedExtAuthFilter)
+
+		// add the filters caching the decisions of the auth filter, around it
+		if cache := provider.Extension.ExtAuthCache; cache != nil {
+			cacheFilter := filters.MustNewStagedFilterWithWeight(extAuthCacheFilterName(provider.Name),
+				cache.cache,
+				filters.BeforeStage(filters.AuthNStage),
+				provider.Extension.PrecedenceWeight,
+			)
+			cacheFilter.Filter.Disabled = true
+			recordFilter := filters.MustNewStagedFilterWithWeight(extAuthCacheRecordFilterName(provider.Name),
+				cache.record,
+				filters.AfterStage(filters.AuthNStage),
+				provider.Extension.PrecedenceWeight,
+			)
+			recordFilter.Filter.Disabled = true
+			stagedFilters = append(stagedFilters, cacheFilter, recordFilter)
+		}
+	}
+	// Add the Ext_authz filters overriding the failure mode of providers on some routes
+	for _, provider := range p.extAuthFailureModePerProvider.Providers[fcc.FilterChainName] {
+		extAuthFilter := provider.Extension.ExtAuth
+		if extAuthFilter == nil {
+			continue
+		}
+
+		stagedExtAuthFilter := filters.MustNewStagedFilterWithWeight(
+			extAuthFailureModeFilterName(provider.Name, !extAuthFilter.GetFailureModeAllow()),
+			extAuthFailureModeFilter(extAuthFilter),
+			filters.DuringStage(filters.AuthNStage),
+			provider.Extension.PrecedenceWeight,
+		)
+		stagedExtAuthFilter.Filter.Disabled = true
+		stagedFilters = append(stagedFilters, stagedExtAuthFilter)
 	}
 
 	// Add OIDC filters for providers
@@ -518,11 +592,32 @@ func (p *trafficPolicyPluginGwPass) HttpFilters(_ ir.HttpFiltersContext, fcc ir.
 		stagedFilters = append(stagedFilters, stagedFilter)
 	}
 
+	// Add token introspection filters for providers
+	for _, provider := range p.oauth2IntrospectionPerProvider.Providers[fcc.FilterChainName] {
+		introspectionFilter := provider.Extension.OAuth2Introspection
+		if introspectionFilter == nil {
+			continue
+		}
+
+		stagedFilter := filters.MustNewStagedFilterWithWeight(
+			oauth2IntrospectionFilterName(provider.Name),
+			introspectionFilter.cfg,
+			filters.DuringStage(filters.AuthNStage),
+			provider.Extension.PrecedenceWeight,
+		)
+
+		stagedFilter.Filter.Disabled = true
+		stagedFilters = append(stagedFilters, stagedFilter)
+	}
+
 	if len(p.jwtPerProvider.Providers[fcc.FilterChainName]) > 0 {
 		stagedFilters = AddDisableFilterIfNeeded(stagedFilters, jwtGlobalDisableFilterName, jwtGlobalDisableFilterMetadataNamespace)
 	}
 	for _, provider := range p.jwtPerProvider.Providers[fcc.FilterChainName] {
 		jwtFilter := provider.Extension.Jwt
+		if p.jwtRouteMatchInChain[fcc.FilterChainName] {
+			jwtFilter = provider.Extension.JwtClearRouteCache
+		}
 		if jwtFilter == nil {
 			continue
 		}
@@ -597,6 +692,14 @@ func (p *trafficPolicyPluginGwPass) HttpFilters(_ ir.HttpFiltersContext, fcc ir.
 		stagedFilters = append(stagedFilters, filter)
 	}
 
+	// Add the RBAC filter enforcing JWT claim requirements.
+	// Requires the requirements to be set as typed_per_filter_config.
+	if p.jwtClaimsInChain[fcc.FilterChainName] {
+		filter := filters.MustNewStagedFilter(jwtClaimsFilterName, &envoyrbacv3.RBAC{}, filters.DuringStage(filters.AuthZStage))
+		filter.Filter.Disabled = true
+		stagedFilters = append(stagedFilters, filter)
+	}
+
 	// Add compression and decompression filters after CORS
 	stagedFilters = addCompressionFiltersIfNeeded(stagedFilters, p, fcc.FilterChainName)
 	// Add Basic Auth filter
@@ -605,6 +708,11 @@ func (p *trafficPolicyPluginGwPass) HttpFilters(_ ir.HttpFiltersContext, fcc ir.
 		filter.Filter.Disabled = true
 		stagedFilters = append(stagedFilters, filter)
 	}
+	if cluster := p.basicAuthVerifierInChain[fcc.FilterChainName]; cluster != "" {
+		filter := filters.MustNewStagedFilter(basicAuthVerifierFilterName, basicAuthVerifierFilter(cluster), filters.DuringStage(filters.AuthNStage))
+		filter.Filter.Disabled = true
+		stagedFilters = append(stagedFilters, filter)
+	}
 
 	// Add API key auth filter to the chain
 	if f := p.apiKeyAuthInChain[fcc.FilterChainName]; f != nil {
@@ -612,6 +720,27 @@ func (p *trafficPolicyPluginGwPass) HttpFilters(_ ir.HttpFiltersContext, fcc ir.
 		filter.Filter.Disabled = true
 		stagedFilters = append(stagedFilters, filter)
 	}
+	if cluster := p.apiKeyAuthVerifierInChain[fcc.FilterChainName]; cluster != "" {
+		// all headers are sent, as the API key and cookie headers are configured per route
+		filter := filters.MustNewStagedFilter(apiKeyAuthVerifierFilterName, authVerifierFilter(cluster), filters.DuringStage(filters.AuthNStage))
+		filter.Filter.Disabled = true
+		stagedFilters = append(stagedFilters, filter)
+	}
+
+	// Add the API consumer verifier, after the authentication filters, and the local rate limit
+	// filter enforcing the consumer quotas.
+	if cluster := p.apiConsumerInChain[fcc.FilterChainName]; cluster != "" {
+		filter := filters.MustNewStagedFilter(apiConsumerFilterName, apiConsumerFilter(cluster), filters.DuringStage(filters.AuthZStage))
+		filter.Filter.Disabled = true
+		stagedFilters = append(stagedFilters, filter)
+	}
+	if p.apiConsumerRateLimitInChain[fcc.FilterChainName] {
+		filter := filters.MustNewStagedFilter(apiConsumerRateLimitFilterName, &localratelimitv3.LocalRateLimit{
+			StatPrefix: apiConsumerRateLimitStatPrefix,
+		}, filters.DuringStage(filters.RateLimitStage))
+		filter.Filter.Disabled = true
+		stagedFilters = append(stagedFilters, filter)
+	}
 
 	if len(stagedFilters) == 0 {
 		return nil, nil
@@ -625,6 +754,9 @@ func (p *trafficPolicyPluginGwPass) ResourcesToAdd() ir.Resources {
 	for _, secret := range p.secrets {
 		resources.Secrets = append(resources.Secrets, secret)
 	}
+	for _, cluster := range p.clusters {
+		resources.Clusters = append(resources.Clusters, cluster)
+	}
 	return resources
 }
 
@@ -647,6 +779,7 @@ func (p *trafficPolicyPluginGwPass) handlePolicies(
 	p.handleExtAuth(fcn, typedFilterConfig, spec.extAuth)
 	p.handleExtProc(fcn, typedFilterConfig, spec.extProc)
 	p.handleJwt(fcn, typedFilterConfig, spec.jwt)
+	p.handleJwtClaims(fcn, typedFilterConfig, spec.jwt)
 	p.handleGlobalRateLimit(fcn, typedFilterConfig, spec.globalRateLimit)
 	p.handleLocalRateLimit(fcn, typedFilterConfig, spec.localRateLimit)
 	p.handleCors(fcn, typedFilterConfig, spec.cors)
@@ -657,8 +790,9 @@ func (p *trafficPolicyPluginGwPass) handlePolicies(
 	p.handleCompression(fcn, typedFilterConfig, spec.compression)
 	p.handleDecompression(fcn, typedFilterConfig, spec.decompression)
 	p.handleBasicAuth(fcn, typedFilterConfig, spec.basicAuth)
-	p.handleAPIKeyAuth(fcn, typedFilterConfig, spec.apiKeyAuth)
+	p.handleAPIKeyAuth(fcn, typedFilterConfig, apiKeyAuthForConsumers(spec))
 	p.handleOauth2(fcn, typedFilterConfig, spec.oauth2)
+	p.handleOAuth2Introspection(fcn, typedFilterConfig, spec.oauth2Introspection)
 }
 
 // handlePerRoutePolicies handles policies that are meant to be processed at the route level
@@ -5,6 +5,9 @@ import (
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
 )
 
 func BuildCondition(resource string, errs []error) metav1.Condition {
@@ -36,3 +39,27 @@ func BuildCondition(resource string, errs []error) metav1.Condition {
 		Message: aggErrs.String(),
 	}
 }
+
+// BuildEndpointsHealthyCondition builds the "EndpointsHealthy" condition from the
+// endpoint health reported by the gateway proxies for a backend. The message only holds the
+// endpoint counts, so that scaling the proxies does not rewrite the status.
+func BuildEndpointsHealthyCondition(h ir.BackendHealth) metav1.Condition {
+	cond := metav1.Condition{
+		Type: string(shared.PolicyConditionEndpointsHealthy),
+		Message: fmt.Sprintf("%d/%d endpoints ejected, %d/%d healthy",
+			h.Ejected, h.Total, h.Healthy, h.Total),
+	}
+	switch {
+	case h.Total == 0:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = string(shared.PolicyReasonNoEndpoints)
+		cond.Message = "no endpoints"
+	case h.Healthy < h.Total || h.Ejected > 0:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = string(shared.PolicyReasonEndpointsUnhealthy)
+	default:
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = string(shared.PolicyReasonEndpointsHealthy)
+	}
+	return cond
+}
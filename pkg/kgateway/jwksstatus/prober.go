@@ -0,0 +1,282 @@
+package jwksstatus
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/go-jose/go-jose/v4"
+	"google.golang.org/protobuf/proto"
+	"istio.io/istio/pkg/kube/krt"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/agentgateway/jwks"
+	"github.com/kgateway-dev/kgateway/v2/pkg/logging"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+// Prober periodically fetches the remote JWKS of JWT GatewayExtensions and checks that
+// they contain valid keys. The results are published to CommonCollections so that they
+// can be written to GatewayExtension status.
+
+var logger = logging.New("jwks_status")
+
+const RunnableName = "jwks-status"
+
+type Prober struct {
+	commonCols *collections.CommonCollections
+	sink       func([]ir.JWKSStatus)
+	interval   time.Duration
+	timeout    time.Duration
+}
+
+func NewProber(commonCols *collections.CommonCollections, interval time.Duration) *Prober {
+	return &Prober{
+		commonCols: commonCols,
+		sink:       commonCols.SetJWKSStatus,
+		interval:   interval,
+		timeout:    min(interval, 5*time.Second),
+	}
+}
+
+func (p *Prober) Start(ctx context.Context) error {
+	logger.Info("starting jwks status prober", "interval", p.interval)
+	// the backend index is only available once the plugins are initialized, so the
+	// targets are built on start
+	targets := buildTargets(p.commonCols.GatewayExtensions, p.commonCols.BackendIndex.GetBackendFromRef, p.commonCols.KrtOpts.ToOptions("JWKSStatusTargets")...)
+	if !targets.WaitUntilSynced(ctx.Done()) {
+		return nil
+	}
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		p.probeAll(ctx, targets.List())
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection returns true since only the leader writes status.
+func (p *Prober) NeedLeaderElection() bool {
+	return true
+}
+
+func (p *Prober) RunnableName() string {
+	return RunnableName
+}
+
+// target is a remote JWKS to probe.
+type target struct {
+	key string
+	url string
+	// address is the host:port of the backend the JWKS is fetched through.
+	address string
+	// tls is the TLS context of the backend, nil if the proxies connect to it in plaintext.
+	tls *envoytlsv3.UpstreamTlsContext
+}
+
+func (t target) ResourceName() string {
+	return t.key
+}
+
+func (t target) Equals(in target) bool {
+	return t.key == in.key && t.url == in.url && t.address == in.address && proto.Equal(t.tls, in.tls)
+}
+
+type backendResolver func(krt.HandlerContext, ir.ObjectSource, gwv1.BackendObjectReference) (*ir.BackendObjectIR, error)
+
+// buildTargets resolves the remote JWKS of the JWT GatewayExtensions to the address of their backend.
+// JWKS with unresolved backends are skipped, they are reported through the TrafficPolicy status.
+func buildTargets(
+	gwExts krt.Collection[ir.GatewayExtension],
+	resolve backendResolver,
+	opts ...krt.CollectionOption,
+) krt.Collection[target] {
+	return krt.NewManyCollection(gwExts, func(kctx krt.HandlerContext, ext ir.GatewayExtension) []target {
+		if ext.JWT == nil {
+			return nil
+		}
+		var res []target
+		for _, provider := range ext.JWT.Providers {
+			remote := provider.JWKS.RemoteJWKS
+			if remote == nil {
+				continue
+			}
+			backend, err := resolve(kctx, ext.ObjectSource, remote.BackendRef)
+			if err != nil || backend == nil {
+				continue
+			}
+			t, err := newTarget(remote.URL, backend)
+			if err != nil {
+				logger.Debug("not probing remote jwks", "url", remote.URL, "backend", backend.ClusterName(), "error", err)
+				continue
+			}
+			res = append(res, t)
+		}
+		return res
+	}, opts...)
+}
+
+// Probeable returns whether the remote JWKS fetched through the backend are probed. The controller
+// only connects to the address of the backend, with the TLS settings the proxies use for it, so the
+// backends without an address, or whose TLS settings refer to files of the proxies, are not probed.
+func Probeable(backend *ir.BackendObjectIR) bool {
+	_, err := newTarget("", backend)
+	return err == nil
+}
+
+func newTarget(url string, backend *ir.BackendObjectIR) (target, error) {
+	if backend.CanonicalHostname == "" || backend.Port == 0 {
+		return target{}, errors.New("backend has no address")
+	}
+	tlsCtx := upstreamTLS(backend)
+	if tlsCtx != nil {
+		if _, err := clientTLSConfig(tlsCtx); err != nil {
+			return target{}, err
+		}
+	}
+	return target{
+		key:     ir.JWKSStatusKey(url, backend.ClusterName()),
+		url:     url,
+		address: net.JoinHostPort(backend.CanonicalHostname, strconv.Itoa(int(backend.Port))),
+		tls:     tlsCtx,
+	}, nil
+}
+
+// upstreamTLS returns the TLS context the policies attached to the backend configure, nil if none.
+// As when translating the backend, the last attachment wins.
+func upstreamTLS(backend *ir.BackendObjectIR) *envoytlsv3.UpstreamTlsContext {
+	gks := slices.SortedFunc(maps.Keys(backend.AttachedPolicies.Policies), func(a, b schema.GroupKind) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	var res *envoytlsv3.UpstreamTlsContext
+	for _, gk := range gks {
+		for _, att := range backend.AttachedPolicies.Policies[gk] {
+			if len(att.Errors) > 0 {
+				continue
+			}
+			if pol, ok := att.PolicyIr.(ir.UpstreamTLSPolicyIR); ok && pol.UpstreamTLS() != nil {
+				res = pol.UpstreamTLS()
+			}
+		}
+	}
+	return res
+}
+
+// probeAll probes all the targets and publishes the results.
+func (p *Prober) probeAll(ctx context.Context, targets []target) {
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		res = make([]ir.JWKSStatus, 0, len(targets))
+	)
+	for _, t := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status := p.probe(ctx, t)
+			mu.Lock()
+			res = append(res, status)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+	slices.SortFunc(res, func(a, b ir.JWKSStatus) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	p.sink(res)
+}
+
+func (p *Prober) probe(ctx context.Context, t target) ir.JWKSStatus {
+	res := ir.JWKSStatus{Key: t.key}
+
+	keySet, err := p.fetch(ctx, t)
+	if err != nil {
+		logger.Debug("failed to fetch jwks", "url", t.url, "error", err)
+		res.Reason = kgateway.GatewayExtensionReasonJWKSFetchFailed
+		res.Message = err.Error()
+		return res
+	}
+
+	keys, err := validKeys(keySet)
+	if err != nil {
+		logger.Debug("invalid jwks", "url", t.url, "error", err)
+		res.Reason = kgateway.GatewayExtensionReasonJWKSInvalidKeys
+		res.Message = err.Error()
+		return res
+	}
+	res.Keys = keys
+	return res
+}
+
+// fetch fetches the JWKS as the proxies do: from the backend address, over TLS only if the backend
+// uses TLS, with the host of the URL in the host header.
+func (p *Prober) fetch(ctx context.Context, t target) (jose.JSONWebKeySet, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	u, err := url.Parse(t.url)
+	if err != nil {
+		return jose.JSONWebKeySet{}, err
+	}
+	dialer := &net.Dialer{}
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, t.address)
+		},
+	}
+	defer transport.CloseIdleConnections()
+	u.Scheme = "http"
+	if t.tls != nil {
+		tlsConfig, err := clientTLSConfig(t.tls)
+		if err != nil {
+			return jose.JSONWebKeySet{}, err
+		}
+		u.Scheme = "https"
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	keySet, err := jwks.NewJwksHttpClient(&http.Client{Transport: transport}).FetchJwks(ctx, u.String())
+	if err != nil {
+		// do not surface the full url error, the url is already known to the user
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return jose.JSONWebKeySet{}, err
+	}
+	return keySet, nil
+}
+
+// validKeys returns the number of valid public keys in a JWKS.
+func validKeys(keySet jose.JSONWebKeySet) (int, error) {
+	keys := 0
+	for _, k := range keySet.Keys {
+		if k.Valid() && k.IsPublic() {
+			keys++
+		}
+	}
+	if keys == 0 {
+		return 0, errors.New("jwks contains no valid public keys")
+	}
+	return keys, nil
+}
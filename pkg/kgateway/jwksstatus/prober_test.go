@@ -0,0 +1,223 @@
+package jwksstatus
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	envoymatcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	eiutils "github.com/kgateway-dev/kgateway/v2/internal/envoyinit/pkg/utils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+const validJWKS = `{"keys":[{"kty":"EC","crv":"P-256","kid":"test","x":"MKBCTNIcKUSDii11ySs3526iDZ8AiTo7Tu6KPAqv7D4","y":"4Etl6SRW2YiLUrN5vfvVHuhp7x8PxltmWWlbbM4IFyM"}]}`
+
+func TestValidKeys(t *testing.T) {
+	r := require.New(t)
+
+	var keySet jose.JSONWebKeySet
+	r.NoError(json.Unmarshal([]byte(validJWKS), &keySet))
+	keys, err := validKeys(keySet)
+	r.NoError(err)
+	r.Equal(1, keys)
+
+	_, err = validKeys(jose.JSONWebKeySet{})
+	r.ErrorContains(err, "no valid public keys")
+}
+
+func jwksHandler(w http.ResponseWriter, req *http.Request) {
+	if req.Host != "issuer.example.com" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	switch req.URL.Path {
+	case "/jwks":
+		_, _ = w.Write([]byte(validJWKS))
+	case "/empty":
+		_, _ = w.Write([]byte(`{"keys":[]}`))
+	case "/invalid":
+		_, _ = w.Write([]byte(`not json`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestProbeAll(t *testing.T) {
+	r := require.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(jwksHandler))
+	defer srv.Close()
+	addr := srv.Listener.Addr().String()
+
+	var got []ir.JWKSStatus
+	p := &Prober{
+		sink:     func(s []ir.JWKSStatus) { got = s },
+		interval: time.Second,
+		timeout:  time.Second,
+	}
+	p.probeAll(context.Background(), []target{
+		// the backend address is dialed, whatever the host of the url
+		{key: "a", url: "http://issuer.example.com/jwks", address: addr},
+		// the proxies use plaintext to a backend without TLS
+		{key: "b", url: "https://issuer.example.com/jwks", address: addr},
+		{key: "c", url: "http://issuer.example.com/empty", address: addr},
+		{key: "d", url: "http://issuer.example.com/invalid", address: addr},
+		{key: "e", url: "http://issuer.example.com/missing", address: addr},
+	})
+
+	r.Len(got, 5)
+	r.Equal(ir.JWKSStatus{Key: "a", Keys: 1}, got[0])
+	r.Equal(ir.JWKSStatus{Key: "b", Keys: 1}, got[1])
+	r.Equal(ir.JWKSStatus{Key: "c", Reason: kgateway.GatewayExtensionReasonJWKSInvalidKeys, Message: "jwks contains no valid public keys"}, got[2])
+	r.Equal(kgateway.GatewayExtensionReasonJWKSFetchFailed, got[3].Reason)
+	r.Contains(got[3].Message, "could not decode jwks")
+	r.Equal(kgateway.GatewayExtensionReasonJWKSFetchFailed, got[4].Reason)
+	r.Contains(got[4].Message, "unexpected status code")
+}
+
+func TestProbeTLS(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(jwksHandler))
+	defer srv.Close()
+	addr := srv.Listener.Addr().String()
+	ca := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+
+	trustedCA := func(sans ...*envoytlsv3.SubjectAltNameMatcher) *envoytlsv3.UpstreamTlsContext {
+		return &envoytlsv3.UpstreamTlsContext{
+			CommonTlsContext: &envoytlsv3.CommonTlsContext{
+				ValidationContextType: &envoytlsv3.CommonTlsContext_ValidationContext{
+					ValidationContext: &envoytlsv3.CertificateValidationContext{
+						TrustedCa: &envoycorev3.DataSource{
+							Specifier: &envoycorev3.DataSource_InlineString{InlineString: ca},
+						},
+						MatchTypedSubjectAltNames: sans,
+					},
+				},
+			},
+		}
+	}
+	dnsSAN := func(name string) *envoytlsv3.SubjectAltNameMatcher {
+		return &envoytlsv3.SubjectAltNameMatcher{
+			SanType: envoytlsv3.SubjectAltNameMatcher_DNS,
+			Matcher: &envoymatcherv3.StringMatcher{
+				MatchPattern: &envoymatcherv3.StringMatcher_Exact{Exact: name},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		tls        *envoytlsv3.UpstreamTlsContext
+		wantReason string
+	}{
+		{
+			name: "trusted CA",
+			tls:  trustedCA(),
+		},
+		{
+			name: "matching subject alt name",
+			tls:  trustedCA(dnsSAN("example.com")),
+		},
+		{
+			name:       "subject alt name mismatch",
+			tls:        trustedCA(dnsSAN("other.example.com")),
+			wantReason: kgateway.GatewayExtensionReasonJWKSFetchFailed,
+		},
+		{
+			name: "untrusted CA",
+			tls: &envoytlsv3.UpstreamTlsContext{
+				CommonTlsContext: &envoytlsv3.CommonTlsContext{
+					ValidationContextType: &envoytlsv3.CommonTlsContext_CombinedValidationContext{
+						CombinedValidationContext: &envoytlsv3.CommonTlsContext_CombinedCertificateValidationContext{
+							DefaultValidationContext:         &envoytlsv3.CertificateValidationContext{},
+							ValidationContextSdsSecretConfig: &envoytlsv3.SdsSecretConfig{Name: eiutils.SystemCaSecretName},
+						},
+					},
+				},
+			},
+			wantReason: kgateway.GatewayExtensionReasonJWKSFetchFailed,
+		},
+		{
+			name: "no validation context",
+			tls:  &envoytlsv3.UpstreamTlsContext{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Prober{timeout: time.Second}
+			got := p.probe(context.Background(), target{
+				key:     "a",
+				url:     "http://issuer.example.com/jwks",
+				address: addr,
+				tls:     tt.tls,
+			})
+			require.Equal(t, tt.wantReason, got.Reason, got.Message)
+		})
+	}
+}
+
+type tlsPolicy struct {
+	tls *envoytlsv3.UpstreamTlsContext
+}
+
+func (p tlsPolicy) CreationTime() time.Time                     { return time.Time{} }
+func (p tlsPolicy) Equals(any) bool                             { return false }
+func (p tlsPolicy) UpstreamTLS() *envoytlsv3.UpstreamTlsContext { return p.tls }
+
+func TestNewTarget(t *testing.T) {
+	r := require.New(t)
+
+	backend := func(host string, port int32, tls *envoytlsv3.UpstreamTlsContext) *ir.BackendObjectIR {
+		b := ir.NewBackendObjectIR(ir.ObjectSource{Kind: "Service", Namespace: "default", Name: "idp"}, port, "")
+		b.CanonicalHostname = host
+		if tls != nil {
+			gk := schema.GroupKind{Group: "gateway.kgateway.dev", Kind: "BackendConfigPolicy"}
+			b.AttachedPolicies.Policies = map[schema.GroupKind][]ir.PolicyAtt{
+				gk: {{GroupKind: gk, PolicyIr: tlsPolicy{tls: tls}}},
+			}
+		}
+		return &b
+	}
+	fileCA := &envoytlsv3.UpstreamTlsContext{
+		CommonTlsContext: &envoytlsv3.CommonTlsContext{
+			ValidationContextType: &envoytlsv3.CommonTlsContext_ValidationContext{
+				ValidationContext: &envoytlsv3.CertificateValidationContext{
+					TrustedCa: &envoycorev3.DataSource{
+						Specifier: &envoycorev3.DataSource_Filename{Filename: "/etc/ca.crt"},
+					},
+				},
+			},
+		},
+	}
+	sni := &envoytlsv3.UpstreamTlsContext{Sni: "issuer.example.com"}
+
+	b := backend("idp.default.svc.cluster.local", 8443, sni)
+	got, err := newTarget("https://issuer.example.com/jwks", b)
+	r.NoError(err)
+	r.Equal("idp.default.svc.cluster.local:8443", got.address)
+	r.Equal(ir.JWKSStatusKey("https://issuer.example.com/jwks", b.ClusterName()), got.key)
+	r.Equal("issuer.example.com", got.tls.GetSni())
+	r.True(Probeable(b))
+
+	// the controller only connects to the backend address
+	b = backend("", 0, nil)
+	_, err = newTarget("https://issuer.example.com/jwks", b)
+	r.ErrorContains(err, "no address")
+	r.False(Probeable(b))
+
+	// the CA files of the proxies are not available to the controller
+	b = backend("idp.default.svc.cluster.local", 8443, fileCA)
+	_, err = newTarget("https://issuer.example.com/jwks", b)
+	r.ErrorContains(err, "only available to the proxies")
+	r.False(Probeable(b))
+}
@@ -0,0 +1,171 @@
+package jwksstatus
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	envoymatcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+
+	eiutils "github.com/kgateway-dev/kgateway/v2/internal/envoyinit/pkg/utils"
+)
+
+// clientTLSConfig returns the TLS config to connect to a backend as the proxies do with the
+// upstream TLS context of the backend. Like the proxies, the server certificate is verified
+// against the trusted CAs and the subject alt names of the validation context, but not against
+// the SNI, and it is not verified at all without a validation context.
+func clientTLSConfig(tlsCtx *envoytlsv3.UpstreamTlsContext) (*tls.Config, error) {
+	common := tlsCtx.GetCommonTlsContext()
+	cfg := &tls.Config{
+		ServerName: tlsCtx.GetSni(),
+		NextProtos: common.GetAlpnProtocols(),
+		// the server certificate is verified by VerifyConnection
+		InsecureSkipVerify: true, //nolint:gosec // see above
+	}
+
+	for _, cert := range common.GetTlsCertificates() {
+		certChain, err := inlineData(cert.GetCertificateChain())
+		if err != nil {
+			return nil, fmt.Errorf("client certificate: %w", err)
+		}
+		privateKey, err := inlineData(cert.GetPrivateKey())
+		if err != nil {
+			return nil, fmt.Errorf("client certificate key: %w", err)
+		}
+		keyPair, err := tls.X509KeyPair(certChain, privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		cfg.Certificates = append(cfg.Certificates, keyPair)
+	}
+
+	var (
+		validation *envoytlsv3.CertificateValidationContext
+		// nil roots verify the server certificate with the system CAs
+		roots *x509.CertPool
+	)
+	switch vc := common.GetValidationContextType().(type) {
+	case nil:
+		return cfg, nil
+	case *envoytlsv3.CommonTlsContext_ValidationContext:
+		validation = vc.ValidationContext
+		if validation.GetTrustedCa() == nil {
+			// an empty validation context skips the verification
+			return cfg, nil
+		}
+		ca, err := inlineData(validation.GetTrustedCa())
+		if err != nil {
+			return nil, fmt.Errorf("trusted CA: %w", err)
+		}
+		roots = x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(ca) {
+			return nil, errors.New("trusted CA contains no valid certificates")
+		}
+	case *envoytlsv3.CommonTlsContext_CombinedValidationContext:
+		if name := vc.CombinedValidationContext.GetValidationContextSdsSecretConfig().GetName(); name != eiutils.SystemCaSecretName {
+			return nil, fmt.Errorf("unsupported validation context secret %q", name)
+		}
+		validation = vc.CombinedValidationContext.GetDefaultValidationContext()
+	default:
+		return nil, fmt.Errorf("unsupported validation context %T", vc)
+	}
+
+	sans := validation.GetMatchTypedSubjectAltNames()
+	cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+		return verifyServerCertificate(cs.PeerCertificates, roots, sans)
+	}
+	return cfg, nil
+}
+
+// inlineData returns the inline content of a data source. The files and environment variables
+// of the proxies are not available to the controller.
+func inlineData(ds *envoycorev3.DataSource) ([]byte, error) {
+	switch s := ds.GetSpecifier().(type) {
+	case *envoycorev3.DataSource_InlineString:
+		return []byte(s.InlineString), nil
+	case *envoycorev3.DataSource_InlineBytes:
+		return s.InlineBytes, nil
+	case *envoycorev3.DataSource_Filename:
+		return nil, fmt.Errorf("file %s is only available to the proxies", s.Filename)
+	default:
+		return nil, errors.New("only inline data is supported")
+	}
+}
+
+func verifyServerCertificate(certs []*x509.Certificate, roots *x509.CertPool, sans []*envoytlsv3.SubjectAltNameMatcher) error {
+	if len(certs) == 0 {
+		return errors.New("no server certificate")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+	}); err != nil {
+		return err
+	}
+
+	if len(sans) == 0 {
+		return nil
+	}
+	for _, san := range sans {
+		if slices.ContainsFunc(subjectAltNames(certs[0], san.GetSanType()), func(name string) bool {
+			return matchString(san.GetMatcher(), name)
+		}) {
+			return nil
+		}
+	}
+	return errors.New("server certificate does not match the subject alt names")
+}
+
+func subjectAltNames(cert *x509.Certificate, sanType envoytlsv3.SubjectAltNameMatcher_SanType) []string {
+	var names []string
+	switch sanType {
+	case envoytlsv3.SubjectAltNameMatcher_DNS:
+		names = cert.DNSNames
+	case envoytlsv3.SubjectAltNameMatcher_EMAIL:
+		names = cert.EmailAddresses
+	case envoytlsv3.SubjectAltNameMatcher_URI:
+		for _, uri := range cert.URIs {
+			names = append(names, uri.String())
+		}
+	case envoytlsv3.SubjectAltNameMatcher_IP_ADDRESS:
+		for _, ip := range cert.IPAddresses {
+			names = append(names, ip.String())
+		}
+	}
+	return names
+}
+
+func matchString(m *envoymatcherv3.StringMatcher, s string) bool {
+	// ignore_case does not apply to regular expressions
+	fold := func(v string) string {
+		if m.GetIgnoreCase() {
+			return strings.ToLower(v)
+		}
+		return v
+	}
+	switch p := m.GetMatchPattern().(type) {
+	case *envoymatcherv3.StringMatcher_Exact:
+		return fold(s) == fold(p.Exact)
+	case *envoymatcherv3.StringMatcher_Prefix:
+		return strings.HasPrefix(fold(s), fold(p.Prefix))
+	case *envoymatcherv3.StringMatcher_Suffix:
+		return strings.HasSuffix(fold(s), fold(p.Suffix))
+	case *envoymatcherv3.StringMatcher_Contains:
+		return strings.Contains(fold(s), fold(p.Contains))
+	case *envoymatcherv3.StringMatcher_SafeRegex:
+		re, err := regexp.Compile("^(?:" + p.SafeRegex.GetRegex() + ")$")
+		return err == nil && re.MatchString(s)
+	default:
+		return false
+	}
+}
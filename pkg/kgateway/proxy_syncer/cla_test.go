@@ -6,7 +6,9 @@ import (
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoyendpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 	"github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/endpoints"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
@@ -146,3 +148,96 @@ func TestTranslatesDestrulesFailover(t *testing.T) {
 	g.Expect(localLocality.Priority).To(gomega.Equal(uint32(0)))
 	g.Expect(remoteLocality.Priority).To(gomega.Equal(uint32(1)))
 }
+
+func TestLocalZonePreferenceLocalityWeights(t *testing.T) {
+	g := gomega.NewWithT(t)
+	efu := ir.NewEndpointsForBackend(ir.BackendObjectIR{
+		ObjectSource: ir.ObjectSource{
+			Namespace: "ns",
+			Name:      "name",
+		},
+	})
+	addPipeEndpoints(efu, ir.PodLocality{Region: "R1", Zone: "Z1"}, "a", "b")
+	addPipeEndpoints(efu, ir.PodLocality{Region: "R1", Zone: "Z2"}, "c", "d", "e", "f")
+	ucc := ir.UniqlyConnectedClient{
+		Namespace: "ns",
+		Locality:  ir.PodLocality{Region: "R1", Zone: "Z1"},
+	}
+
+	localZone := func(info endpoints.LocalZoneInfo) map[string]uint32 {
+		cla := endpoints.PrioritizeEndpoints(nil, ucc, endpoints.EndpointsInputs{
+			EndpointsForBackend: *efu,
+			LocalityLbInfo:      &endpoints.LocalityLbInfo{LocalZone: &info},
+		})
+		weights := map[string]uint32{}
+		for _, localityEps := range cla.Endpoints {
+			weights[localityEps.Locality.Zone] = localityEps.GetLoadBalancingWeight().GetValue()
+		}
+		return weights
+	}
+
+	// 80% of the traffic stays local, the rest is spread by endpoint count.
+	// Weights are the traffic percentages times the number of endpoints:
+	// Z1 gets (80 + 20*2/6)*6 and Z2 gets (20*4/6)*6.
+	g.Expect(localZone(endpoints.LocalZoneInfo{
+		LocalPercentage: ptr.To(uint32(80)),
+	})).To(gomega.Equal(map[string]uint32{"Z1": 520, "Z2": 80}))
+
+	// remote zones keep a non-zero weight when all traffic is kept local
+	g.Expect(localZone(endpoints.LocalZoneInfo{})).To(gomega.Equal(map[string]uint32{"Z1": 600, "Z2": 1}))
+
+	// backends smaller than the minimum cluster size keep the endpoint count weights
+	g.Expect(localZone(endpoints.LocalZoneInfo{
+		MinEndpoints: ptr.To(uint32(10)),
+	})).To(gomega.Equal(map[string]uint32{"Z1": 2, "Z2": 4}))
+
+	// no endpoints in the proxy zone
+	ucc.Locality = ir.PodLocality{Region: "R1", Zone: "Z3"}
+	g.Expect(localZone(endpoints.LocalZoneInfo{})).To(gomega.Equal(map[string]uint32{"Z1": 2, "Z2": 4}))
+	g.Expect(localZone(endpoints.LocalZoneInfo{FailWithoutLocalEndpoints: true})).To(gomega.BeEmpty())
+}
+
+func TestExplicitLocalityWeights(t *testing.T) {
+	g := gomega.NewWithT(t)
+	efu := ir.NewEndpointsForBackend(ir.BackendObjectIR{
+		ObjectSource: ir.ObjectSource{
+			Namespace: "ns",
+			Name:      "name",
+		},
+	})
+	addPipeEndpoints(efu, ir.PodLocality{Region: "R1", Zone: "Z1"}, "a")
+	addPipeEndpoints(efu, ir.PodLocality{Region: "R1", Zone: "Z2"}, "b")
+	addPipeEndpoints(efu, ir.PodLocality{Region: "R2", Zone: "Z3"}, "c", "d")
+
+	cla := endpoints.PrioritizeEndpoints(nil, ir.UniqlyConnectedClient{}, endpoints.EndpointsInputs{
+		EndpointsForBackend: *efu,
+		LocalityLbInfo: &endpoints.LocalityLbInfo{
+			Weights: []endpoints.LocalityWeight{
+				{Region: "R1", Zone: "Z1", Weight: 10},
+				{Region: "R1", Weight: 5},
+			},
+		},
+	})
+	weights := map[string]uint32{}
+	for _, localityEps := range cla.Endpoints {
+		weights[localityEps.Locality.Zone] = localityEps.GetLoadBalancingWeight().GetValue()
+	}
+	g.Expect(weights).To(gomega.Equal(map[string]uint32{"Z1": 10, "Z2": 5, "Z3": 2}))
+}
+
+func addPipeEndpoints(efu *ir.EndpointsForBackend, locality ir.PodLocality, paths ...string) {
+	for _, path := range paths {
+		efu.Add(locality, ir.EndpointWithMd{
+			LbEndpoint: &envoyendpointv3.LbEndpoint{
+				HostIdentifier: &envoyendpointv3.LbEndpoint_Endpoint{
+					Endpoint: &envoyendpointv3.Endpoint{
+						Address: &envoycorev3.Address{
+							Address: &envoycorev3.Address_Pipe{Pipe: &envoycorev3.Pipe{Path: path}},
+						},
+					},
+				},
+				LoadBalancingWeight: wrapperspb.UInt32(1),
+			},
+		})
+	}
+}
@@ -201,6 +201,9 @@ func (r report) Equals(in report) bool {
 	if !maps.Equal(r.reportMap.TLSRoutes, in.reportMap.TLSRoutes) {
 		return false
 	}
+	if !maps.Equal(r.reportMap.UDPRoutes, in.reportMap.UDPRoutes) {
+		return false
+	}
 	if !maps.Equal(r.reportMap.Policies, in.reportMap.Policies) {
 		return false
 	}
@@ -260,7 +263,14 @@ func (s *ProxySyncer) Init(ctx context.Context, krtopts krtutil.KrtOptions) {
 
 	s.backendPolicyReport = krt.NewSingleton(func(kctx krt.HandlerContext) *report {
 		backends := krt.Fetch(kctx, finalBackendsWithPolicyStatus)
-		merged := GenerateBackendPolicyReport(backends)
+		var health map[string]ir.BackendHealth
+		if s.commonCols.BackendHealth != nil {
+			health = make(map[string]ir.BackendHealth)
+			for _, h := range krt.Fetch(kctx, s.commonCols.BackendHealth) {
+				health[h.ClusterName] = h
+			}
+		}
+		merged := GenerateBackendPolicyReport(backends, health)
 
 		for _, plugin := range s.plugins.ContributesPolicies {
 			if plugin.ProcessPolicyStaleStatusMarkers != nil && plugin.ProcessBackend != nil {
@@ -350,6 +360,18 @@ func mergeProxyReports(
 			maps.Copy(merged.TLSRoutes[rnn].Parents, rr.Parents)
 		}
 
+		for rnn, rr := range p.reports.UDPRoutes {
+			// if we haven't encountered this route, just copy it over completely
+			old := merged.UDPRoutes[rnn]
+			if old == nil {
+				merged.UDPRoutes[rnn] = rr
+				continue
+			}
+			// else, this route has already been seen for a proxy, merge this proxy's parents
+			// into the merged report
+			maps.Copy(merged.UDPRoutes[rnn].Parents, rr.Parents)
+		}
+
 		for rnn, rr := range p.reports.GRPCRoutes {
 			// if we haven't encountered this route, just copy it over completely
 			old := merged.GRPCRoutes[rnn]
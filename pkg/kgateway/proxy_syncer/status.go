@@ -6,6 +6,8 @@ import (
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/pluginutils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
 	reportssdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/reporter"
 	"github.com/kgateway-dev/kgateway/v2/pkg/reports"
@@ -20,7 +22,9 @@ var _ ObjWithAttachedPolicies = ir.BackendObjectIR{}
 
 // GenerateBackendPolicyReport generates a report map for all policies attached to the given backends.
 // Exported for testing.
-func GenerateBackendPolicyReport(in []*ir.BackendObjectIR) reports.ReportMap {
+// health is the endpoint health reported by the gateway proxies, keyed by cluster name. When a backend
+// has reported health, the EndpointsHealthy condition is set on its BackendConfigPolicy ancestors.
+func GenerateBackendPolicyReport(in []*ir.BackendObjectIR, health map[string]ir.BackendHealth) reports.ReportMap {
 	merged := reports.NewReportMap()
 	reporter := reports.NewReporter(&merged)
 
@@ -71,6 +75,15 @@ func GenerateBackendPolicyReport(in []*ir.BackendObjectIR) reports.ReportMap {
 					Reason:  string(shared.PolicyReasonAttached),
 					Message: reportssdk.PolicyAttachedMsg,
 				})
+				if h, ok := health[obj.ClusterName()]; ok && key.Group == wellknown.BackendConfigPolicyGVK.Group && key.Kind == wellknown.BackendConfigPolicyGVK.Kind {
+					cond := pluginutils.BuildEndpointsHealthyCondition(h)
+					r.SetCondition(reportssdk.PolicyCondition{
+						Type:    cond.Type,
+						Status:  cond.Status,
+						Reason:  cond.Reason,
+						Message: cond.Message,
+					})
+				}
 			}
 		}
 	}
@@ -97,7 +97,7 @@ func TestBackendPolicyStatus(t *testing.T) {
 	backends := []*ir.BackendObjectIR{&backend1, &backend2}
 
 	a := assert.New(t)
-	rm := GenerateBackendPolicyReport(backends)
+	rm := GenerateBackendPolicyReport(backends, nil)
 
 	// assert 3 unique policies: conn-policy-1, conn-policy-2, tls-policy
 	a.Len(rm.Policies, 3)
@@ -211,3 +211,76 @@ func TestBackendPolicyStatus(t *testing.T) {
 	)
 	a.Empty(diff)
 }
+
+func TestBackendPolicyStatusEndpointsHealthy(t *testing.T) {
+	bcpAtt := ir.PolicyAtt{
+		GroupKind: wellknown.BackendConfigPolicyGVK.GroupKind(),
+		PolicyRef: &ir.AttachedPolicyRef{
+			Group:     wellknown.BackendConfigPolicyGVK.Group,
+			Kind:      wellknown.BackendConfigPolicyGVK.Kind,
+			Name:      "bcp",
+			Namespace: "default",
+		},
+	}
+	backend := ir.NewBackendObjectIR(ir.ObjectSource{
+		Group:     "",
+		Kind:      "Service",
+		Namespace: "default",
+		Name:      "reviews",
+	}, 8080, "")
+	backend.AttachedPolicies = ir.AttachedPolicies{
+		Policies: map[schema.GroupKind][]ir.PolicyAtt{
+			wellknown.BackendConfigPolicyGVK.GroupKind(): {bcpAtt},
+		},
+	}
+	health := map[string]ir.BackendHealth{
+		backend.ClusterName(): {
+			ClusterName: backend.ClusterName(),
+			Total:       5,
+			Healthy:     3,
+			Ejected:     2,
+		},
+	}
+
+	a := assert.New(t)
+	rm := GenerateBackendPolicyReport([]*ir.BackendObjectIR{&backend}, health)
+
+	report := rm.Policies[reporter.PolicyKey{
+		Group:     bcpAtt.PolicyRef.Group,
+		Kind:      bcpAtt.PolicyRef.Kind,
+		Namespace: bcpAtt.PolicyRef.Namespace,
+		Name:      bcpAtt.PolicyRef.Name,
+	}]
+	a.NotNil(report)
+	ancestor := report.Ancestors[reports.ParentRefKey{
+		Group:          backend.Group,
+		Kind:           backend.Kind,
+		NamespacedName: types.NamespacedName{Namespace: backend.Namespace, Name: backend.Name},
+	}]
+	a.NotNil(ancestor)
+	diff := cmp.Diff(
+		ancestor.Conditions,
+		[]metav1.Condition{
+			{
+				Type:    string(shared.PolicyConditionAccepted),
+				Status:  metav1.ConditionTrue,
+				Reason:  string(shared.PolicyReasonValid),
+				Message: reporter.PolicyAcceptedMsg,
+			},
+			{
+				Type:    string(shared.PolicyConditionAttached),
+				Status:  metav1.ConditionTrue,
+				Reason:  string(shared.PolicyReasonAttached),
+				Message: reporter.PolicyAttachedMsg,
+			},
+			{
+				Type:    string(shared.PolicyConditionEndpointsHealthy),
+				Status:  metav1.ConditionFalse,
+				Reason:  string(shared.PolicyReasonEndpointsUnhealthy),
+				Message: "2/5 endpoints ejected, 3/5 healthy",
+			},
+		},
+		cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime"),
+	)
+	a.Empty(diff)
+}
@@ -183,6 +183,7 @@ func parentRefMatchListener(ref *gwv1.ParentReference, l *gwv1.Listener) bool {
 //   - HTTPRoute
 //   - TCPRoute
 //   - TLSRoute
+//   - UDPRoute
 //   - GRPCRoute
 func getParentRefsForResource(resource client.Object, obj ir.Route) []gwv1.ParentReference {
 	var ret []gwv1.ParentReference
@@ -33,7 +33,9 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/pkg/deployer"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/admin"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/agentgatewaysyncer"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/backendhealth"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/controller"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/jwksstatus"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/proxy_syncer"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/xds"
@@ -470,6 +472,18 @@ func (s *setup) Start(ctx context.Context) error {
 		}
 	}
 
+	if _, exists := runnablesRegistry[backendhealth.RunnableName]; !exists && s.globalSettings.EnableBackendHealthStatus {
+		if err := mgr.Add(backendhealth.NewCollector(commoncol, s.globalSettings.BackendHealthStatusInterval)); err != nil {
+			return fmt.Errorf("error adding backend health collector to manager: %w", err)
+		}
+	}
+
+	if _, exists := runnablesRegistry[jwksstatus.RunnableName]; !exists && s.globalSettings.EnableJwksStatus {
+		if err := mgr.Add(jwksstatus.NewProber(commoncol, s.globalSettings.JwksStatusInterval)); err != nil {
+			return fmt.Errorf("error adding jwks status prober to manager: %w", err)
+		}
+	}
+
 	agw, err := s.buildKgatewayWithConfig(ctx, mgr, setupOpts, commoncol, agwCollections, uccBuilder)
 	if err != nil {
 		return err
@@ -20,6 +20,11 @@ type translatorTestCase struct {
 	gwNN       types.NamespacedName
 }
 
+// withAuthVerifier configures the kgateway auth verifier Service of the test inputs.
+func withAuthVerifier(s *apisettings.Settings) {
+	s.AuthVerifierService = "kgateway-system/auth-verifier"
+}
+
 func TestBasic(t *testing.T) {
 	test := func(t *testing.T, in translatorTestCase, settingOpts ...translatortest.SettingsOpts) {
 		ctx, cancel := context.WithCancel(context.Background())
@@ -329,6 +334,17 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("TrafficPolicy ExtAuth decision cache and failure mode override", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "traffic-policy/extauth-decision-cache.yaml",
+			outputFile: "traffic-policy/extauth-decision-cache.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "infra",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	// test the default and fully configured values for HTTP ExtAuth
 	t.Run("TrafficPolicy HTTP ExtAuth Full Config", func(t *testing.T) {
 		test(t, translatorTestCase{
@@ -407,6 +423,39 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("TrafficPolicy API Key Authentication with hashed keys and metadata", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "traffic-policy/api-key-auth-hashed-metadata.yaml",
+			outputFile: "traffic-policy/api-key-auth-hashed-metadata.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		}, withAuthVerifier)
+	})
+
+	t.Run("TrafficPolicy API consumers", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "traffic-policy/api-consumers.yaml",
+			outputFile: "traffic-policy/api-consumers.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		}, withAuthVerifier)
+	})
+
+	t.Run("TrafficPolicy API consumers with API keys of a merged policy", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "traffic-policy/api-consumers-merged-api-key-auth.yaml",
+			outputFile: "traffic-policy/api-consumers-merged-api-key-auth.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		}, withAuthVerifier)
+	})
+
 	t.Run("TrafficPolicy API Key Authentication with SecretRef and ReferenceGrant", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "traffic-policy/api-key-auth-secretref-with-refgrant.yaml",
@@ -484,6 +533,28 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("Basic auth with kgateway verifier", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "basic-auth/kgateway-verifier.yaml",
+			outputFile: "basic-auth/kgateway-verifier.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		}, withAuthVerifier)
+	})
+
+	t.Run("Basic auth with kgateway verifier not configured", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "basic-auth/kgateway-verifier.yaml",
+			outputFile: "basic-auth/kgateway-verifier-not-configured.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("Basic auth with secret reference", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "basic-auth/secret-ref.yaml",
@@ -777,6 +848,50 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("tcp gateway with redis backend", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "tcp-routing/redis-backend.yaml",
+			outputFile: "tcp-routing/redis-backend.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
+	t.Run("udp gateway with weighted backends and session idle timeout", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "udp-routing/basic.yaml",
+			outputFile: "udp-routing/basic.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
+	t.Run("udproute with missing backend reports correctly", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "udp-routing/missing-backend.yaml",
+			outputFile: "udp-routing/missing-backend.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
+	t.Run("udproutes conflicting on a listener report the newer route", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "udp-routing/conflict.yaml",
+			outputFile: "udp-routing/conflict.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("tls gateway with tcproute", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "tcp-routing/tls.yaml",
@@ -909,6 +1024,28 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("AWS Lambda backend with function URL", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "backends/aws_lambda_function_url.yaml",
+			outputFile: "backends/aws_lambda_function_url.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
+	t.Run("AWS service backend", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "backends/aws_service.yaml",
+			outputFile: "backends/aws_service.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("DFP Backend with TLS", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "dfp/tls.yaml",
@@ -931,6 +1068,28 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("DFP Backend with allowed hosts and DNS cache", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "dfp/allowlist-dns-cache.yaml",
+			outputFile: "dfp/allowlist-dns-cache.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
+	t.Run("DFP Backend with allowed hosts on a route rewriting the host", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "dfp/allowlist-host-rewrite.yaml",
+			outputFile: "dfp/allowlist-host-rewrite.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("Backend TLS Policy", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "backendtlspolicy/tls.yaml",
@@ -1217,6 +1376,39 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("ListenerPolicy with localReply", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "listener-policy-http/local-reply.yaml",
+			outputFile: "listener-policy-http/local-reply.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
+	t.Run("ListenerPolicy with path normalization and header limits", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "listener-policy-http/path-normalization-and-header-limits.yaml",
+			outputFile: "listener-policy-http/path-normalization-and-header-limits.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
+	t.Run("ListenerPolicy with connect", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "listener-policy-http/connect.yaml",
+			outputFile: "listener-policy-http/connect.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("ListenerPolicy with preserveHttp1HeaderCase", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "listener-policy-http/preserve-http1-header-case.yaml",
@@ -1404,6 +1596,17 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("Backend Config Policy with LB locality weights", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "backendconfigpolicy/lb-locality.yaml",
+			outputFile: "backendconfigpolicy/lb-locality.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("Backend Config Policy with Health Check", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "backendconfigpolicy/healthcheck.yaml",
@@ -1414,6 +1617,16 @@ func TestBasic(t *testing.T) {
 			},
 		})
 	})
+	t.Run("Backend Config Policy with TCP Health Check and HTTP status ranges", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "backendconfigpolicy/healthcheck-tcp.yaml",
+			outputFile: "backendconfigpolicy/healthcheck-tcp.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
 
 	t.Run("Backend Config Policy with OutlierDetection", func(t *testing.T) {
 		test(t, translatorTestCase{
@@ -1425,6 +1638,27 @@ func TestBasic(t *testing.T) {
 			},
 		})
 	})
+	t.Run("Backend Config Policy with Outlier Detection modes", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "backendconfigpolicy/outlierdetection-modes.yaml",
+			outputFile: "backendconfigpolicy/outlierdetection-modes.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
+	t.Run("Backend Config Policy with Preconnect and HTTP connection pool options", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "backendconfigpolicy/preconnect.yaml",
+			outputFile: "backendconfigpolicy/preconnect.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
 
 	t.Run("Backend Config Policy with Common HTTP Protocol - HTTP backend", func(t *testing.T) {
 		test(t, translatorTestCase{
@@ -1525,6 +1759,17 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("Backend Config Policy with upstream PROXY protocol", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "backendconfigpolicy/upstream-proxy-protocol.yaml",
+			outputFile: "backendconfigpolicy/upstream-proxy-protocol.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("TrafficPolicy with explicit generation", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "traffic-policy/generation.yaml",
@@ -1890,6 +2135,17 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("ListenerPolicy with tcp settings on TCP and TLS listeners", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "listener-policy/tcp-settings.yaml",
+			outputFile: "listener-policy/tcp-settings.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-tcp-gateway",
+			},
+		})
+	})
+
 	t.Run("ListenerPolicy with per connection buffer limit", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "listener-policy/per-connection-buffer-limit.yaml",
@@ -2011,6 +2267,17 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("JWT Policy with claim requirements and claim route matching", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "jwt/claims.yaml",
+			outputFile: "jwt/claims.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("JWT Policy and RBAC", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "jwt/rbac.yaml",
@@ -2033,6 +2300,17 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("JWT Policy with Secret JWKS and async remote JWKS fetch", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "jwt/secret-jwks.yaml",
+			outputFile: "jwt/secret-jwks.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("JWT Policy at route level using remote JWKS", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "jwt/httproute-remote-jwks.yaml",
@@ -2065,6 +2343,17 @@ func TestBasic(t *testing.T) {
 			},
 		})
 	})
+
+	t.Run("OAuth2 introspection policy", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "traffic-policy/oauth2-introspection.yaml",
+			outputFile: "traffic-policy/oauth2-introspection.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "test",
+			},
+		})
+	})
 }
 
 func TestValidation(t *testing.T) {
@@ -3,6 +3,8 @@ package irtranslator
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
 	"time"
 
 	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
@@ -138,6 +140,27 @@ func (t *BackendTranslator) runPolicies(
 		}
 	}
 
+	// finalize the cluster once all policies were applied, in a stable order
+	finalizers := make([]schema.GroupKind, 0, len(t.ContributedPolicies))
+	for gk, policyPlugin := range t.ContributedPolicies {
+		if policyPlugin.FinalizeBackend != nil {
+			finalizers = append(finalizers, gk)
+		}
+	}
+	slices.SortFunc(finalizers, func(a, b schema.GroupKind) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	for _, gk := range finalizers {
+		for _, polAttachment := range backend.AttachedPolicies.Policies[gk] {
+			if len(polAttachment.Errors) > 0 {
+				continue
+			}
+			if err := t.ContributedPolicies[gk].FinalizeBackend(ctx, polAttachment.PolicyIr, *backend, out); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
 	// for clusters that want a CLA _and_ initialized with inlineEps, build the CLA.
 	// never overwrite the CLA that was already initialized (potentially within a plugin).
 	if out.GetLoadAssignment() == nil && endpointInputs != nil && clusterSupportsInlineCLA(out) {
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_upstreams_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -173,6 +174,72 @@ func TestBackendTranslatorPropagatesPolicyErrors(t *testing.T) {
 	assert.Empty(t, backend.Errors)
 }
 
+// TestBackendTranslatorFinalizesAfterAllPolicies validates that FinalizeBackend runs once every
+// policy plugin processed the cluster, whatever the iteration order of the plugins, and that its
+// errors are propagated.
+func TestBackendTranslatorFinalizesAfterAllPolicies(t *testing.T) {
+	wrapperGK := schema.GroupKind{Group: "gateway.kgateway.dev", Kind: "BackendConfigPolicy"}
+	tlsGK := schema.GroupKind{Group: "gateway-api", Kind: "BackendTLSPolicy"}
+	backend := &ir.BackendObjectIR{
+		ObjectSource: ir.ObjectSource{
+			Group:     "group",
+			Kind:      "kind",
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		AttachedPolicies: ir.AttachedPolicies{
+			Policies: map[schema.GroupKind][]ir.PolicyAtt{
+				wrapperGK: {{GroupKind: wrapperGK}},
+				tlsGK:     {{GroupKind: tlsGK}},
+			},
+		},
+	}
+
+	var finalizeErr error
+	var bt irtranslator.BackendTranslator
+	bt.ContributedBackends = map[schema.GroupKind]ir.BackendInit{
+		{Group: "group", Kind: "kind"}: {
+			InitEnvoyBackend: func(ctx context.Context, in ir.BackendObjectIR, out *envoyclusterv3.Cluster) *ir.EndpointsForBackend {
+				return nil
+			},
+		},
+	}
+	bt.ContributedPolicies = map[schema.GroupKind]sdk.PolicyPlugin{
+		wrapperGK: {
+			Name: "BackendConfigPolicy",
+			ProcessBackend: func(ctx context.Context, polir ir.PolicyIR, backend ir.BackendObjectIR, out *envoyclusterv3.Cluster) {
+			},
+			FinalizeBackend: func(ctx context.Context, polir ir.PolicyIR, backend ir.BackendObjectIR, out *envoyclusterv3.Cluster) error {
+				if finalizeErr != nil {
+					return finalizeErr
+				}
+				out.TransportSocket = &envoycorev3.TransportSocket{Name: "wrapped/" + out.GetTransportSocket().GetName()}
+				return nil
+			},
+		},
+		tlsGK: {
+			Name: "BackendTLSPolicy",
+			ProcessBackend: func(ctx context.Context, polir ir.PolicyIR, backend ir.BackendObjectIR, out *envoyclusterv3.Cluster) {
+				out.TransportSocket = &envoycorev3.TransportSocket{Name: "tls"}
+			},
+		},
+	}
+
+	var ucc ir.UniqlyConnectedClient
+	var kctx krt.TestingDummyContext
+	for range 20 {
+		cluster, err := bt.TranslateBackend(context.Background(), kctx, ucc, backend)
+		require.NoError(t, err)
+		assert.Equal(t, "wrapped/tls", cluster.GetTransportSocket().GetName())
+	}
+
+	finalizeErr = errors.New("failed to wrap transport socket")
+	cluster, err := bt.TranslateBackend(context.Background(), kctx, ucc, backend)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to wrap transport socket")
+	assert.Equal(t, envoyclusterv3.Cluster_STATIC, cluster.GetType())
+}
+
 // TestBackendTranslatorHandlesXDSValidationErrors validates that when xDS validation fails
 // in strict mode, the translator returns a blackhole cluster and error.
 func TestBackendTranslatorHandlesXDSValidationErrors(t *testing.T) {
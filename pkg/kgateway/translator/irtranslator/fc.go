@@ -374,9 +374,26 @@ func sortHttpFilters(filters filters.StagedHttpFilterList) []*envoyhttp.HttpFilt
 	return sortedFilters
 }
 
-func (h *filterChainTranslator) computeTcpFilters(l ir.TcpIR, reporter sdkreporter.ListenerReporter) []*envoylistenerv3.Filter {
+// computeTcpFilters returns the network filters of a TCP filter chain. It returns an error, reported
+// on the listener, when the filter provided by the plugin of the backend fails to translate: the
+// filter chain must then be dropped rather than proxy the raw TCP stream to the backend.
+func (h *filterChainTranslator) computeTcpFilters(l ir.TcpIR, reporter sdkreporter.ListenerReporter) ([]*envoylistenerv3.Filter, error) {
 	networkFilters := sortNetworkFilters(h.computeCustomFilters(l.CustomNetworkFilters, reporter))
 
+	backendFilter, err := h.computeTcpBackendFilter(l)
+	if err != nil {
+		reporter.SetCondition(sdkreporter.ListenerCondition{
+			Type:    gwv1.ListenerConditionProgrammed,
+			Reason:  gwv1.ListenerReasonInvalid,
+			Status:  metav1.ConditionFalse,
+			Message: "Error processing TCP backend plugin: " + err.Error(),
+		})
+		return nil, err
+	}
+	if backendFilter != nil {
+		return append(networkFilters, backendFilter), nil
+	}
+
 	cfg := &envoytcp.TcpProxy{
 		StatPrefix: l.FilterChainName,
 	}
@@ -401,9 +418,58 @@ func (h *filterChainTranslator) computeTcpFilters(l ir.TcpIR, reporter sdkreport
 		}
 	}
 
+	// Allow any TcpProxy plugins to make their changes, such as access logging and timeouts
+	h.applyTcpProxyPlugins(cfg, reporter)
+
 	tcpFilter, _ := NewFilterWithTypedConfig(wellknown.TCPProxy, cfg)
 
-	return append(networkFilters, tcpFilter)
+	return append(networkFilters, tcpFilter), nil
+}
+
+// computeTcpBackendFilter returns the network filter provided by the plugin of the backend, if any,
+// to use instead of the TcpProxy filter. Only filter chains with a single backend are considered.
+func (h *filterChainTranslator) computeTcpBackendFilter(l ir.TcpIR) (*envoylistenerv3.Filter, error) {
+	if len(l.BackendRefs) != 1 || l.BackendRefs[0].BackendObject == nil {
+		return nil, nil
+	}
+	backend := l.BackendRefs[0].BackendObject
+	pass := h.pluginPass[backend.GetGroupKind()]
+	if pass == nil {
+		return nil, nil
+	}
+	return pass.ApplyForTcpBackend(&ir.TcpBackendContext{
+		FilterChainName: l.FilterChainName,
+		Backend:         backend,
+	})
+}
+
+func (h *filterChainTranslator) applyTcpProxyPlugins(cfg *envoytcp.TcpProxy, reporter sdkreporter.ListenerReporter) {
+	var attachedPolicies ir.AttachedPolicies
+	// Listener policies take precedence over gateway policies, so they are ordered first
+	attachedPolicies.Append(h.listener.AttachedPolicies, h.gateway.AttachedHttpPolicies)
+	for _, gk := range attachedPolicies.ApplyOrderedGroupKinds() {
+		pass := h.pluginPass[gk]
+		if pass == nil {
+			continue
+		}
+		// policy acceptance and attachment are reported by the listener plugins
+		policies, _ := mergePolicies(pass, attachedPolicies.Policies[gk])
+		for _, pol := range policies {
+			pctx := &ir.TcpProxyContext{
+				ListenerPort: h.listener.BindPort,
+				Policy:       pol.PolicyIr,
+				Gateway:      h.gateway,
+			}
+			if err := pass.ApplyTcpProxy(pctx, cfg); err != nil {
+				reporter.SetCondition(sdkreporter.ListenerCondition{
+					Type:    gwv1.ListenerConditionProgrammed,
+					Reason:  gwv1.ListenerReasonInvalid,
+					Status:  metav1.ConditionFalse,
+					Message: "Error processing TCP proxy plugin: " + err.Error(),
+				})
+			}
+		}
+	}
 }
 
 func NewFilterWithTypedConfig(name string, config proto.Message) (*envoylistenerv3.Filter, error) {
@@ -2,12 +2,15 @@ package irtranslator_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	"github.com/stretchr/testify/assert"
 	"istio.io/istio/pkg/ptr"
 	"istio.io/istio/pkg/slices"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
@@ -109,3 +112,61 @@ func TestFilterChains(t *testing.T) {
 		}
 	}
 }
+
+var tcpBackendGK = schema.GroupKind{
+	Group: "test.kgateway.dev",
+	Kind:  "TcpBackendForTest",
+}
+
+// failingTcpBackend implements a test translation pass whose TCP backend filter fails to translate
+type failingTcpBackend struct {
+	ir.UnimplementedProxyTranslationPass
+}
+
+func (failingTcpBackend) ApplyForTcpBackend(*ir.TcpBackendContext) (*envoylistenerv3.Filter, error) {
+	return nil, errors.New("invalid backend")
+}
+
+func TestTcpFilterChainBackendFilterError(t *testing.T) {
+	gw := &gwv1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw"}}
+	gateway := ir.GatewayIR{SourceObject: &ir.Gateway{
+		Obj: gw,
+		Listeners: []ir.Listener{{
+			Listener: gwv1.Listener{Name: "tcp"},
+			Parent:   gw,
+		}},
+	}}
+	backend := ir.NewBackendObjectIR(ir.ObjectSource{Group: tcpBackendGK.Group, Kind: tcpBackendGK.Kind, Namespace: "default", Name: "backend"}, 6379, "")
+	listener := ir.ListenerIR{
+		Name: "listener~6379",
+		TcpFilterChain: []ir.TcpIR{{
+			FilterChainCommon: ir.FilterChainCommon{FilterChainName: "listener~6379-default.route-rule-0"},
+			BackendRefs:       []ir.BackendRefIR{{ClusterName: backend.ClusterName(), BackendObject: &backend}},
+			ListenerName:      "tcp",
+		}},
+	}
+
+	reportMap := reports.NewReportMap()
+	reporter := reports.NewReporter(&reportMap)
+
+	translator := irtranslator.Translator{}
+	envoyListener, _ := translator.ComputeListener(
+		context.Background(),
+		irtranslator.TranslationPassPlugins{
+			tcpBackendGK: &irtranslator.TranslationPass{ProxyTranslationPass: failingTcpBackend{}},
+		},
+		gateway,
+		listener,
+		reporter,
+	)
+
+	// the filter chain is dropped rather than proxying the TCP stream without the backend filter
+	assert.Empty(t, envoyListener.GetFilterChains())
+
+	lisReport := reportMap.Gateway(gw).Listener(&gwv1.Listener{Name: "tcp"}).(*reports.ListenerReport)
+	programmed := meta.FindStatusCondition(lisReport.Status.Conditions, string(gwv1.ListenerConditionProgrammed))
+	if assert.NotNil(t, programmed) {
+		assert.Equal(t, metav1.ConditionFalse, programmed.Status)
+		assert.Contains(t, programmed.Message, "invalid backend")
+	}
+}
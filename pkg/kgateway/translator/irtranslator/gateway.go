@@ -1,6 +1,7 @@
 package irtranslator
 
 import (
+	"cmp"
 	"context"
 	"sort"
 	"strconv"
@@ -50,7 +51,8 @@ func (t *Translator) Translate(ctx context.Context, gw ir.GatewayIR, reporter sd
 	for _, l := range gw.Listeners {
 		outListener, routes := t.ComputeListener(ctx, pass, gw, l, reporter)
 		// Envoy rejects listeners with no filter chains; skip adding such listeners.
-		if outListener == nil || len(outListener.GetFilterChains()) == 0 {
+		// UDP listeners are the exception, as they proxy datagrams with a listener filter.
+		if outListener == nil || (len(outListener.GetFilterChains()) == 0 && l.UdpListener == nil) {
 			originalListenerName := findOriginalListenerName(gw, l)
 			logger.Warn("invalid listener due to no filter chains generated", "listener", originalListenerName)
 			continue
@@ -100,6 +102,9 @@ func (t *Translator) ComputeListener(
 	reporter sdkreporter.Reporter,
 ) (*envoylistenerv3.Listener, []*envoyroutev3.RouteConfiguration) {
 	gwreporter := reporter.Gateway(gw.SourceObject.Obj)
+	if lis.UdpListener != nil {
+		return computeUdpListener(lis, gwreporter), nil
+	}
 	ret := &envoylistenerv3.Listener{
 		Name:    lis.Name,
 		Address: computeListenerAddress(lis.BindAddress, lis.BindPort, gwreporter),
@@ -176,9 +181,14 @@ func (t *Translator) ComputeListener(
 	}
 
 	for _, tfc := range lis.TcpFilterChain {
-		rl := getReporterForFilterChain(gw, reporter, tfc.FilterChainName)
+		rl := getReporterForFilterChain(gw, reporter, cmp.Or(tfc.ListenerName, tfc.FilterChainName))
+		networkFilters, err := fct.computeTcpFilters(tfc, rl)
+		if err != nil {
+			logger.Error("dropping tcp filter chain", "filter_chain", tfc.FilterChainName, "error", err)
+			continue
+		}
 		fc := fct.initFilterChain(tfc.FilterChainCommon)
-		fc.Filters = fct.computeTcpFilters(tfc, rl)
+		fc.Filters = networkFilters
 		ret.FilterChains = append(ret.GetFilterChains(), fc)
 		if len(tfc.Matcher.SniDomains) > 0 {
 			hasTls = true
@@ -96,6 +96,7 @@ func (h *httpRouteConfigurationTranslator) ComputeRouteConfiguration(
 				TypedFilterConfig: typedPerFilterConfigRoute,
 				Policy:            pol.PolicyIr,
 				GatewayContext:    ir.GatewayContext{GatewayClassName: h.gw.GatewayClassName()},
+				ListenerPort:      h.listener.BindPort,
 			}, cfg)
 		}
 		cfg.Metadata = addMergeOriginsToFilterMetadata(gk, mergeOrigins, cfg.GetMetadata())
@@ -229,18 +230,24 @@ func (h *httpRouteConfigurationTranslator) envoyRoutes(
 	out := h.initRoutes(in, generatedName)
 
 	backendConfigCtx := backendConfigContext{typedPerFilterConfigRoute: ir.TypedFilterConfigMap(map[string]proto.Message{})}
+	var hostRewriteErr error
 	if len(in.Backends) == 1 {
 		// If there's only one backend, we need to reuse typedPerFilterConfigRoute in both translateRouteAction and runRoutePlugins
-		out.Action = h.translateRouteAction(in, out, &backendConfigCtx)
+		out.Action, hostRewriteErr = h.translateRouteAction(in, out, &backendConfigCtx)
 	} else if len(in.Backends) > 0 {
 		// If there is more than one backend, we translate the backends as WeightedClusters and each weighted cluster
 		// will have a TypedPerFilterConfig that overrides the parent route-level config.
-		out.Action = h.translateRouteAction(in, out, nil)
+		out.Action, hostRewriteErr = h.translateRouteAction(in, out, nil)
 	}
 
 	// Run plugins here that may set action. Handle the routeProcessingErr error later.
 	routeProcessingErr := h.runRoutePlugins(in, out, backendConfigCtx.typedPerFilterConfigRoute)
 
+	// The host may only be rewritten by the route plugins, so check it once they have run
+	if hostRewriteErr != nil && out.GetRoute().GetHostRewriteSpecifier() != nil {
+		routeProcessingErr = errors.Join(routeProcessingErr, hostRewriteErr)
+	}
+
 	// Apply typed per filter config from translating route action and route plugins
 	typedPerFilterConfig := backendConfigCtx.typedPerFilterConfigRoute.ToAnyMap()
 	if out.GetTypedPerFilterConfig() == nil {
@@ -491,12 +498,16 @@ func (h *httpRouteConfigurationTranslator) runBackend(in ir.HttpBackend, pCtx *i
 	return errors.Join(errs...)
 }
 
+// translateRouteAction also returns the errors of the backends that do not allow the route to rewrite the host.
 func (h *httpRouteConfigurationTranslator) translateRouteAction(
 	in ir.HttpRouteRuleMatchIR,
 	outRoute *envoyroutev3.Route,
 	parentBackendConfigCtx *backendConfigContext,
-) *envoyroutev3.Route_Route {
-	var clusters []*envoyroutev3.WeightedCluster_ClusterWeight
+) (*envoyroutev3.Route_Route, error) {
+	var (
+		clusters        []*envoyroutev3.WeightedCluster_ClusterWeight
+		hostRewriteErrs []error
+	)
 	for _, backend := range in.Backends {
 		clusterName := backend.Backend.ClusterName
 
@@ -537,6 +548,9 @@ func (h *httpRouteConfigurationTranslator) translateRouteAction(
 			// TODO: error on status
 			h.logger.Error("error processing backends with policies", "error", err)
 		}
+		if pCtx.HostRewriteError != nil {
+			hostRewriteErrs = append(hostRewriteErrs, pCtx.HostRewriteError)
+		}
 
 		backendConfigCtx.RequestHeadersToAdd = pCtx.RequestHeadersToAdd
 		backendConfigCtx.RequestHeadersToRemove = pCtx.RequestHeadersToRemove
@@ -597,7 +611,7 @@ func (h *httpRouteConfigurationTranslator) translateRouteAction(
 			}
 		}
 	}
-	return routeAction
+	return routeAction, errors.Join(hostRewriteErrs...)
 }
 
 // creates Envoy routes for each matcher provided on our Gateway route
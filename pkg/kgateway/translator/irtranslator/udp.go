@@ -0,0 +1,177 @@
+package irtranslator
+
+import (
+	xdscorev3 "github.com/cncf/xds/go/xds/core/v3"
+	xdsmatcherv3 "github.com/cncf/xds/go/xds/type/matcher/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	udpproxyv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/udp/udp_proxy/v3"
+	networkinputsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/matching/common_inputs/network/v3"
+	consistenthashingv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/matching/input_matchers/consistent_hashing/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	sdkreporter "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/reporter"
+)
+
+const (
+	UdpProxyListenerFilterName = "envoy.filters.udp_listener.udp_proxy"
+
+	udpRouteActionName         = "route"
+	sourcePortInputName        = "envoy.matching.inputs.source_port"
+	consistentHashingMatchName = "envoy.matching.matchers.consistent_hashing"
+)
+
+// computeUdpListener builds a listener that binds a UDP socket and proxies datagrams
+// with the udp_proxy listener filter. UDP listeners have no filter chains.
+func computeUdpListener(
+	lis ir.ListenerIR,
+	gwreporter sdkreporter.GatewayReporter,
+) *envoylistenerv3.Listener {
+	address := computeListenerAddress(lis.BindAddress, lis.BindPort, gwreporter)
+	address.GetSocketAddress().Protocol = envoycorev3.SocketAddress_UDP
+
+	udpFilter, err := newUdpProxyListenerFilter(lis.UdpListener)
+	if err != nil {
+		// this should never happen
+		logger.Error("failed to build udp_proxy listener filter", "listener", lis.Name, "error", err)
+		return nil
+	}
+
+	return &envoylistenerv3.Listener{
+		Name:            lis.Name,
+		Address:         address,
+		ListenerFilters: []*envoylistenerv3.ListenerFilter{udpFilter},
+	}
+}
+
+func newUdpProxyListenerFilter(udp *ir.UdpIR) (*envoylistenerv3.ListenerFilter, error) {
+	matcher, err := udpRouteMatcher(udp.BackendRefs)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &udpproxyv3.UdpProxyConfig{
+		StatPrefix: udp.StatPrefix,
+		RouteSpecifier: &udpproxyv3.UdpProxyConfig_Matcher{
+			Matcher: matcher,
+		},
+	}
+	if udp.SessionIdleTimeout != nil {
+		cfg.IdleTimeout = durationpb.New(*udp.SessionIdleTimeout)
+	}
+
+	typedConfig, err := utils.MessageToAny(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &envoylistenerv3.ListenerFilter{
+		Name: UdpProxyListenerFilterName,
+		ConfigType: &envoylistenerv3.ListenerFilter_TypedConfig{
+			TypedConfig: typedConfig,
+		},
+	}, nil
+}
+
+// udpRouteMatcher builds the udp_proxy matcher that selects the upstream cluster of a session.
+// The udp_proxy route action does not support weighted clusters, so weights are implemented by
+// consistently hashing the downstream source port of the session into the range [0, total weight)
+// and matching each backend on its slice of that range. The first backend is the fallback when
+// no other backend matches.
+func udpRouteMatcher(backends []ir.BackendRefIR) (*xdsmatcherv3.Matcher, error) {
+	if len(backends) == 0 {
+		return nil, nil
+	}
+
+	var total uint32
+	weights := make([]uint32, len(backends))
+	for i, b := range backends {
+		w := b.Weight
+		if w == 0 {
+			w = 1
+		}
+		weights[i] = w
+		total += w
+	}
+
+	fallback, err := udpRouteAction(backends[0].ClusterName)
+	if err != nil {
+		return nil, err
+	}
+	matcher := &xdsmatcherv3.Matcher{
+		OnNoMatch: fallback,
+	}
+	if len(backends) == 1 {
+		return matcher, nil
+	}
+
+	input, err := typedExtensionConfig(sourcePortInputName, &networkinputsv3.SourcePortInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	// backend i owns the hashes in [sum(weights[:i]), sum(weights[:i+1])); evaluating the
+	// backends from the highest threshold down means the first match is the owning backend.
+	thresholds := make([]uint32, len(backends))
+	for i := 1; i < len(backends); i++ {
+		thresholds[i] = thresholds[i-1] + weights[i-1]
+	}
+	var fieldMatchers []*xdsmatcherv3.Matcher_MatcherList_FieldMatcher
+	for i := len(backends) - 1; i > 0; i-- {
+		hashMatch, err := typedExtensionConfig(consistentHashingMatchName, &consistenthashingv3.ConsistentHashing{
+			Threshold: thresholds[i],
+			Modulo:    total,
+		})
+		if err != nil {
+			return nil, err
+		}
+		action, err := udpRouteAction(backends[i].ClusterName)
+		if err != nil {
+			return nil, err
+		}
+		fieldMatchers = append(fieldMatchers, &xdsmatcherv3.Matcher_MatcherList_FieldMatcher{
+			Predicate: &xdsmatcherv3.Matcher_MatcherList_Predicate{
+				MatchType: &xdsmatcherv3.Matcher_MatcherList_Predicate_SinglePredicate_{
+					SinglePredicate: &xdsmatcherv3.Matcher_MatcherList_Predicate_SinglePredicate{
+						Input: input,
+						Matcher: &xdsmatcherv3.Matcher_MatcherList_Predicate_SinglePredicate_CustomMatch{
+							CustomMatch: hashMatch,
+						},
+					},
+				},
+			},
+			OnMatch: action,
+		})
+	}
+	matcher.MatcherType = &xdsmatcherv3.Matcher_MatcherList_{
+		MatcherList: &xdsmatcherv3.Matcher_MatcherList{
+			Matchers: fieldMatchers,
+		},
+	}
+	return matcher, nil
+}
+
+func udpRouteAction(cluster string) (*xdsmatcherv3.Matcher_OnMatch, error) {
+	action, err := typedExtensionConfig(udpRouteActionName, &udpproxyv3.Route{Cluster: cluster})
+	if err != nil {
+		return nil, err
+	}
+	return &xdsmatcherv3.Matcher_OnMatch{
+		OnMatch: &xdsmatcherv3.Matcher_OnMatch_Action{
+			Action: action,
+		},
+	}, nil
+}
+
+func typedExtensionConfig(name string, msg proto.Message) (*xdscorev3.TypedExtensionConfig, error) {
+	typedConfig, err := utils.MessageToAny(msg)
+	if err != nil {
+		return nil, err
+	}
+	return &xdscorev3.TypedExtensionConfig{
+		Name:        name,
+		TypedConfig: typedConfig,
+	}, nil
+}
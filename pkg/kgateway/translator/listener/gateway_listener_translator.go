@@ -33,6 +33,7 @@ var logger = logging.New("translator/listener")
 
 const (
 	TcpTlsListenerNoBackendsMessage = "TCP/TLS listener has no valid backends or routes"
+	UdpListenerNoBackendsMessage    = "UDP listener has no valid backends or routes"
 	ResourceNotFoundMessageTemplate = "%s %s/%s not found."
 )
 
@@ -119,6 +120,8 @@ func (ml *MergedListeners) AppendListener(
 		ml.AppendTcpListener(listener, routes, reporter)
 	case gwv1.TLSProtocolType:
 		ml.AppendTlsListener(listener, routes, reporter)
+	case gwv1.UDPProtocolType:
+		ml.AppendUdpListener(listener, routes, reporter)
 	default:
 		return fmt.Errorf("unsupported protocol: %v", listener.Protocol)
 	}
@@ -285,6 +288,58 @@ func (ml *MergedListeners) AppendTlsListener(
 	})
 }
 
+func (ml *MergedListeners) AppendUdpListener(
+	listener ir.Listener,
+	routeInfos []*query.RouteInfo,
+	reporter reports.ListenerReporter,
+) {
+	udp := &udpListener{
+		gatewayListenerName: query.GenerateRouteKey(listener.Parent, string(listener.Name)),
+		listener:            listener,
+		listenerReporter:    reporter,
+		routesWithHosts:     routeInfos,
+	}
+
+	// UDP listeners are not merged, as envoy would need a separate UDP socket for each of them.
+	// Listener validation rejects the other UDP listeners on the port, and the protocol
+	// validation the listeners with another protocol, but report the conflict in case any
+	// listener is still on the port.
+	finalPort := getListenerPortNumber(listener)
+	for _, lis := range ml.Listeners {
+		if lis.port == finalPort {
+			logger.Error("skipping UDP listener on a port that is already in use", "listener", udp.gatewayListenerName, "port", finalPort)
+			reporter.SetCondition(reports.ListenerCondition{
+				Type:    gwv1.ListenerConditionConflicted,
+				Status:  metav1.ConditionTrue,
+				Reason:  gwv1.ListenerReasonProtocolConflict,
+				Message: ListenerMessageUdpPortConflict,
+			})
+			reporter.SetCondition(reports.ListenerCondition{
+				Type:    gwv1.ListenerConditionAccepted,
+				Status:  metav1.ConditionFalse,
+				Reason:  gwv1.ListenerReasonProtocolConflict,
+				Message: ListenerMessageUdpPortConflict,
+			})
+			reporter.SetCondition(reports.ListenerCondition{
+				Type:    gwv1.ListenerConditionProgrammed,
+				Status:  metav1.ConditionFalse,
+				Reason:  gwv1.ListenerReasonProtocolConflict,
+				Message: ListenerMessageUdpPortConflict,
+			})
+			return
+		}
+	}
+
+	ml.Listeners = append(ml.Listeners, &MergedListener{
+		name:        GenerateListenerName(listener),
+		port:        finalPort,
+		udpListener: udp,
+		listener:    listener,
+		gateway:     ml.parentGw,
+		settings:    ml.settings,
+	})
+}
+
 func (ml *MergedListeners) translateListeners(
 	kctx krt.HandlerContext,
 	ctx context.Context,
@@ -305,6 +360,7 @@ type MergedListener struct {
 	httpFilterChain   *httpFilterChain
 	httpsFilterChains []httpsFilterChain
 	TcpFilterChains   []tcpFilterChain
+	udpListener       *udpListener
 	listener          ir.Listener
 	gateway           ir.Gateway
 	settings          ListenerTranslatorConfig
@@ -368,6 +424,20 @@ func (ml *MergedListener) TranslateListener(
 		}
 	}
 
+	// Translate the UDP listener (if any)
+	var udp *ir.UdpIR
+	if ml.udpListener != nil {
+		udp = ml.udpListener.translateUdpListener(ml.name, reporter)
+		if udp == nil {
+			ml.udpListener.listenerReporter.SetCondition(reports.ListenerCondition{
+				Type:    gwv1.ListenerConditionProgrammed,
+				Status:  metav1.ConditionFalse,
+				Reason:  gwv1.ListenerReasonInvalid,
+				Message: UdpListenerNoBackendsMessage,
+			})
+		}
+	}
+
 	// Get bind address based on ListenerBindIpv6 setting
 	bindAddress := "0.0.0.0"
 	if ml.settings.ListenerBindIpv6 {
@@ -382,10 +452,103 @@ func (ml *MergedListener) TranslateListener(
 		AttachedPolicies:  ir.AttachedPolicies{}, // TODO: find policies attached to listener and attach them <- this might not be possible due to listener merging. also a gw listener ~= envoy filter chain; and i don't believe we need policies there
 		HttpFilterChain:   httpFilterChains,
 		TcpFilterChain:    matchedTcpListeners,
+		UdpListener:       udp,
 		PolicyAncestorRef: ml.listener.PolicyAncestorRef,
 	}
 }
 
+// udpListener represents a single Gateway listener with the UDP protocol. Unlike TCP listeners,
+// UDP listeners have no filter chains, so they are never merged with other Gateway listeners.
+type udpListener struct {
+	gatewayListenerName string
+	listener            ir.Listener
+	listenerReporter    reports.ListenerReporter
+	routesWithHosts     []*query.RouteInfo
+}
+
+func (ul *udpListener) translateUdpListener(
+	parentName string,
+	reporter reports.Reporter,
+) *ir.UdpIR {
+	if len(ul.routesWithHosts) == 0 {
+		return nil
+	}
+
+	// Only one route per listener is supported, the oldest route wins
+	r := slices.MinFunc(ul.routesWithHosts, func(a, b *query.RouteInfo) int {
+		return a.Object.GetSourceObject().GetCreationTimestamp().Compare(b.Object.GetSourceObject().GetCreationTimestamp().Time)
+	})
+	uRoute, ok := r.Object.(*ir.UdpRouteIR)
+	if !ok {
+		return nil
+	}
+	for _, other := range ul.routesWithHosts {
+		src := other.Object.GetSourceObject()
+		if src.GetNamespace() == uRoute.Namespace && src.GetName() == uRoute.Name {
+			continue
+		}
+		reporter.Route(src).ParentRef(&other.ParentRef).SetCondition(reports.RouteCondition{
+			Type:   gwv1.RouteConditionAccepted,
+			Status: metav1.ConditionFalse,
+			Reason: gwv1.RouteConditionReason(reports.RouteConflictedReason),
+			Message: fmt.Sprintf("only one UDPRoute is supported per listener, and listener %s uses the older UDPRoute %s/%s",
+				ul.gatewayListenerName, uRoute.Namespace, uRoute.Name),
+		})
+	}
+
+	var condition reports.RouteCondition
+	if len(uRoute.SourceObject.Spec.Rules) == 1 {
+		condition = reports.RouteCondition{
+			Type:   gwv1.RouteConditionAccepted,
+			Status: metav1.ConditionTrue,
+			Reason: gwv1.RouteReasonAccepted,
+		}
+	} else {
+		condition = reports.RouteCondition{
+			Type:   gwv1.RouteConditionAccepted,
+			Status: metav1.ConditionFalse,
+			Reason: gwv1.RouteReasonUnsupportedValue,
+		}
+	}
+
+	parentRefReporters := make([]reports.ParentRefReporter, 0, len(uRoute.ParentRefs))
+	for _, parentRef := range uRoute.ParentRefs {
+		parentRefReporter := reporter.Route(uRoute.SourceObject).ParentRef(&parentRef)
+		parentRefReporter.SetCondition(condition)
+		parentRefReporters = append(parentRefReporters, parentRefReporter)
+	}
+	if condition.Status != metav1.ConditionTrue {
+		return nil
+	}
+
+	var backends []ir.BackendRefIR
+	for _, backend := range uRoute.Backends {
+		// validate that we don't have an error:
+		if backend.Err != nil || backend.BackendObject == nil {
+			err := backend.Err
+			if err == nil {
+				err = errors.New("not found")
+			}
+			for _, parentRefReporter := range parentRefReporters {
+				query.ProcessBackendError(err, parentRefReporter)
+			}
+		}
+		// add backend even if we have errors, as according to spec, with multiple destinations,
+		// they should fail based of the weights.
+		backends = append(backends, backend)
+	}
+	if len(backends) == 0 {
+		return nil
+	}
+
+	return &ir.UdpIR{
+		// Ensure unique names by appending the rule index to the UDPRoute name
+		StatPrefix:         fmt.Sprintf("%s-%s.%s-rule-%d", parentName, uRoute.Namespace, uRoute.Name, 0),
+		BackendRefs:        backends,
+		SessionIdleTimeout: uRoute.SessionIdleTimeout,
+	}
+}
+
 // tcpFilterChain each one represents a Gateway listener that has been merged into a single kgateway Listener
 // (with distinct filter chains). In the case where no Gateway listener merging takes place, every listener
 // will use a kgateway AggregatedListener with one TCP filter chain.
@@ -501,7 +664,8 @@ func (tc *tcpFilterChain) translateTcpFilterChain(
 				FilterChainName: tcpHostName,
 				TLS:             tlsConfig,
 			},
-			BackendRefs: backends,
+			BackendRefs:  backends,
+			ListenerName: parentName,
 		}
 	case *ir.TlsRouteIR:
 		tRoute := r.Object.(*ir.TlsRouteIR)
@@ -564,7 +728,8 @@ func (tc *tcpFilterChain) translateTcpFilterChain(
 				FilterChainName: tcpHostName,
 				Matcher:         matcher,
 			},
-			BackendRefs: backends,
+			BackendRefs:  backends,
+			ListenerName: parentName,
 		}
 	default:
 		return nil
@@ -62,6 +62,11 @@ func getSupportedProtocolsRoutes() map[protocol]map[groupName][]routeKind {
 				wellknown.TCPRouteKind,
 			},
 		},
+		string(gwv1.UDPProtocolType): {
+			gwv1.GroupName: []string{
+				wellknown.UDPRouteKind,
+			},
+		},
 		string(gwv1.ProtocolType(istioprotocol.HBONE)): {
 			gwv1.GroupName: []string{
 				wellknown.HTTPRouteKind,
@@ -266,6 +271,9 @@ func validateListeners(gw *ir.Gateway, reporter reports.Reporter, settings Liste
 			parentReporter := listener.GetParentReporter(reporter)
 			if protocolConflict(*pp, listener) {
 				rejectConflictedListener(parentReporter, listener, gwv1.ListenerReasonProtocolConflict, ListenerMessageProtocolConflict)
+			} else if udpPortConflict(*pp, listener) {
+				// UDP listeners have no hostnames to tell them apart, so only one of them can use a port
+				rejectConflictedListener(parentReporter, listener, gwv1.ListenerReasonProtocolConflict, ListenerMessageUdpPortConflict)
 			} else if hostNameConflict(*pp, listener) {
 				// If a listener does not have a protocol conflict with one listener,
 				// it could still have a hostname conflict with another listener
@@ -372,6 +380,22 @@ func protocolConflict(portProtocol portProtocol, listener ir.Listener) bool {
 	return false
 }
 
+// udpPortConflict returns true if the listener is a UDP listener and another UDP listener
+// with a higher precedence is on the same port.
+func udpPortConflict(portProtocol portProtocol, listener ir.Listener) bool {
+	if listener.Protocol != gwv1.UDPProtocolType {
+		return false
+	}
+	first := slices.IndexFunc(portProtocol.listeners, func(l ir.Listener) bool {
+		return l.Protocol == gwv1.UDPProtocolType
+	})
+	if generateUniqueListenerName(portProtocol.listeners[first]) == generateUniqueListenerName(listener) {
+		return false
+	}
+	logger.Error("rejected UDP listener on a port that is already in use", "name", listener.Name, "parent", listener.Parent.GetName())
+	return true
+}
+
 func hostNameConflict(portProtocol portProtocol, listener ir.Listener) bool {
 	// An example of the portProtocol passed to this method :
 	//	{
@@ -293,6 +293,79 @@ func TestHBONEProtocol(t *testing.T) {
 	assertExpectedListenerStatuses(t, g, report.Gateway(gateway), gateway.Spec.Listeners, expectedGwStatuses)
 }
 
+func TestUDPProtocol(t *testing.T) {
+	gateway := udpProtocolGw()
+	report := reports.NewReportMap()
+	reporter := reports.NewReporter(&report)
+
+	validListeners := validateGateway(gwToIr(gateway, nil, nil), reporter, settings)
+	g := NewWithT(t)
+	g.Expect(validListeners).To(HaveLen(1))
+
+	expectedGwStatuses := map[string]gwv1.ListenerStatus{
+		"udp": {
+			Name: "udp",
+			SupportedKinds: []gwv1.RouteGroupKind{
+				{
+					Group: GroupNameHelper(),
+					Kind:  "UDPRoute",
+				},
+			},
+		},
+	}
+	assertExpectedListenerStatuses(t, g, report.Gateway(gateway), gateway.Spec.Listeners, expectedGwStatuses)
+}
+
+func TestUDPPortConflict(t *testing.T) {
+	gateway := udpPortConflictGw()
+	report := reports.NewReportMap()
+	reporter := reports.NewReporter(&report)
+
+	validListeners := validateGateway(gwToIr(gateway, nil, nil), reporter, settings)
+	g := NewWithT(t)
+	g.Expect(validListeners).To(HaveLen(1))
+	g.Expect(string(validListeners[0].Name)).To(Equal("udp"))
+
+	udpKinds := []gwv1.RouteGroupKind{
+		{
+			Group: GroupNameHelper(),
+			Kind:  "UDPRoute",
+		},
+	}
+	expectedGwStatuses := map[string]gwv1.ListenerStatus{
+		"udp": {
+			Name:           "udp",
+			SupportedKinds: udpKinds,
+			Conditions:     []metav1.Condition{},
+		},
+		"udp-conflict": {
+			Name:           "udp-conflict",
+			SupportedKinds: udpKinds,
+			Conditions: []metav1.Condition{
+				{
+					Type:    string(gwv1.ListenerConditionConflicted),
+					Status:  metav1.ConditionTrue,
+					Reason:  string(gwv1.ListenerReasonProtocolConflict),
+					Message: ListenerMessageUdpPortConflict,
+				},
+				{
+					Type:    string(gwv1.ListenerConditionAccepted),
+					Status:  metav1.ConditionFalse,
+					Reason:  string(gwv1.ListenerReasonProtocolConflict),
+					Message: ListenerMessageUdpPortConflict,
+				},
+				{
+					Type:    string(gwv1.ListenerConditionProgrammed),
+					Status:  metav1.ConditionFalse,
+					Reason:  string(gwv1.ListenerReasonProtocolConflict),
+					Message: ListenerMessageUdpPortConflict,
+				},
+			},
+		},
+	}
+	assertExpectedListenerStatuses(t, g, report.Gateway(gateway), gateway.Spec.Listeners, expectedGwStatuses)
+}
+
 func TestUnsupportedProtocol(t *testing.T) {
 	gateway := unsupportedProtocolGw()
 	report := reports.NewReportMap()
@@ -303,15 +376,15 @@ func TestUnsupportedProtocol(t *testing.T) {
 	g.Expect(validListeners).To(BeEmpty())
 
 	expectedGwStatuses := map[string]gwv1.ListenerStatus{
-		"udp": {
-			Name:           "udp",
+		"sctp": {
+			Name:           "sctp",
 			SupportedKinds: []gwv1.RouteGroupKind{},
 			Conditions: []metav1.Condition{
 				{
 					Type:    string(gwv1.ListenerConditionAccepted),
 					Status:  metav1.ConditionFalse,
 					Reason:  string(gwv1.ListenerReasonUnsupportedProtocol),
-					Message: "Protocol UDP is unsupported.",
+					Message: "Protocol SCTP is unsupported.",
 				},
 			},
 		},
@@ -2204,6 +2277,25 @@ func unsupportedProtocolGw() *gwv1.Gateway {
 			Namespace: "default",
 			Name:      "unsupported-protocol-gateway",
 		},
+		Spec: gwv1.GatewaySpec{
+			GatewayClassName: "kgateway",
+			Listeners: []gwv1.Listener{
+				{
+					Name:     "sctp",
+					Port:     8080,
+					Protocol: gwv1.ProtocolType("SCTP"),
+				},
+			},
+		},
+	}
+}
+
+func udpProtocolGw() *gwv1.Gateway {
+	return &gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "udp-protocol-gateway",
+		},
 		Spec: gwv1.GatewaySpec{
 			GatewayClassName: "kgateway",
 			Listeners: []gwv1.Listener{
@@ -2217,6 +2309,34 @@ func unsupportedProtocolGw() *gwv1.Gateway {
 	}
 }
 
+func udpPortConflictGw() *gwv1.Gateway {
+	hostnameA := gwv1.Hostname("a.example.com")
+	hostnameB := gwv1.Hostname("b.example.com")
+	return &gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "udp-port-conflict-gateway",
+		},
+		Spec: gwv1.GatewaySpec{
+			GatewayClassName: "kgateway",
+			Listeners: []gwv1.Listener{
+				{
+					Name:     "udp",
+					Port:     8080,
+					Protocol: gwv1.UDPProtocolType,
+					Hostname: &hostnameA,
+				},
+				{
+					Name:     "udp-conflict",
+					Port:     8080,
+					Protocol: gwv1.UDPProtocolType,
+					Hostname: &hostnameB,
+				},
+			},
+		},
+	}
+}
+
 func hboneProtocolGw() *gwv1.Gateway {
 	return &gwv1.Gateway{
 		ObjectMeta: metav1.ObjectMeta{
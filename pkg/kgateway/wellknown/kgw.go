@@ -29,6 +29,7 @@ var (
 	HTTPListenerPolicyGVK  = buildKgatewayGvk("HTTPListenerPolicy")
 	ListenerPolicyGVK      = buildKgatewayGvk("ListenerPolicy")
 	BackendConfigPolicyGVK = buildKgatewayGvk("BackendConfigPolicy")
+	APIConsumerGVK         = buildKgatewayGvk("APIConsumer")
 	GatewayParametersGVR   = GatewayParametersGVK.GroupVersion().WithResource("gatewayparameters")
 	GatewayExtensionGVR    = GatewayExtensionGVK.GroupVersion().WithResource("gatewayextensions")
 	DirectResponseGVR      = DirectResponseGVK.GroupVersion().WithResource("directresponses")
@@ -37,6 +38,7 @@ var (
 	HTTPListenerPolicyGVR  = HTTPListenerPolicyGVK.GroupVersion().WithResource("httplistenerpolicies")
 	ListenerPolicyGVR      = ListenerPolicyGVK.GroupVersion().WithResource("listenerpolicies")
 	BackendConfigPolicyGVR = BackendConfigPolicyGVK.GroupVersion().WithResource("backendconfigpolicies")
+	APIConsumerGVR         = APIConsumerGVK.GroupVersion().WithResource("apiconsumers")
 )
 
 // GVKToGVR maps a known kgateway GVK to its corresponding GVR
@@ -72,6 +74,8 @@ func GVKToGVR(gvk schema.GroupVersionKind) (schema.GroupVersionResource, error)
 		return ListenerPolicyGVR, nil
 	case BackendConfigPolicyGVK:
 		return BackendConfigPolicyGVR, nil
+	case APIConsumerGVK:
+		return APIConsumerGVR, nil
 	case AgentgatewayPolicyGVK:
 		return AgentgatewayPolicyGVR, nil
 	case AgentgatewayBackendGVK:
@@ -38,12 +38,13 @@ func NewGatewayExtensionsCollection(
 				Namespace: cr.Namespace,
 				Name:      cr.Name,
 			},
-			ExtAuth:          cr.Spec.ExtAuth,
-			ExtProc:          cr.Spec.ExtProc,
-			RateLimit:        cr.Spec.RateLimit,
-			JWT:              cr.Spec.JWT,
-			OAuth2:           cr.Spec.OAuth2,
-			PrecedenceWeight: weight,
+			ExtAuth:             cr.Spec.ExtAuth,
+			ExtProc:             cr.Spec.ExtProc,
+			RateLimit:           cr.Spec.RateLimit,
+			JWT:                 cr.Spec.JWT,
+			OAuth2:              cr.Spec.OAuth2,
+			OAuth2Introspection: cr.Spec.OAuth2Introspection,
+			PrecedenceWeight:    weight,
 		}
 		return gwExt
 	})
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"istio.io/istio/pkg/config/labels"
 	"istio.io/istio/pkg/kube/krt"
@@ -438,8 +439,12 @@ func GatewaysForDeployerTransformationFunc(config *GatewayIndexConfig) func(kctx
 			return nil
 		}
 		ports := sets.New[int32]()
+		udpPorts := sets.New[int32]()
 		for _, l := range gw.Spec.Listeners {
 			ports.Insert(l.Port)
+			if l.Protocol == gwv1.UDPProtocolType {
+				udpPorts.Insert(l.Port)
+			}
 		}
 
 		listenerSets := krt.Fetch(kctx, config.ListenerSets, krt.FilterIndex(config.byParentRefIndex, TargetRefIndexKey{
@@ -452,6 +457,9 @@ func GatewaysForDeployerTransformationFunc(config *GatewayIndexConfig) func(kctx
 		for _, ls := range listenerSets {
 			for _, l := range ls.Spec.Listeners {
 				ports.Insert(l.Port)
+				if l.Protocol == gwv1.UDPProtocolType {
+					udpPorts.Insert(l.Port)
+				}
 			}
 		}
 		ir := &ir.GatewayForDeployer{
@@ -463,6 +471,7 @@ func GatewaysForDeployerTransformationFunc(config *GatewayIndexConfig) func(kctx
 			},
 			ControllerName: string(gwClass.Spec.ControllerName),
 			Ports:          smallset.New(ports.UnsortedList()...),
+			UDPPorts:       smallset.New(udpPorts.UnsortedList()...),
 		}
 		return ir
 	}
@@ -1084,6 +1093,12 @@ func (c RouteWrapper) Equals(in RouteWrapper) bool {
 		} else {
 			return a.Equals(*bhttp)
 		}
+	case *ir.UdpRouteIR:
+		if bhttp, ok := in.Route.(*ir.UdpRouteIR); !ok {
+			return false
+		} else {
+			return a.Equals(*bhttp)
+		}
 	}
 	panic("unknown route type")
 }
@@ -1147,6 +1162,7 @@ func NewRoutesIndex(
 	grpcroutes krt.Collection[*gwv1.GRPCRoute],
 	tcproutes krt.Collection[*gwv1a2.TCPRoute],
 	tlsroutes krt.Collection[*gwv1a2.TLSRoute],
+	udproutes krt.Collection[*gwv1a2.UDPRoute],
 	policies *PolicyIndex,
 	backends *BackendIndex,
 	refgrants *RefGrantIndex,
@@ -1159,7 +1175,7 @@ func NewRoutesIndex(
 		weightedRoutePrecedence:              globalSettings.WeightedRoutePrecedence,
 		enableExperimentalGatewayAPIFeatures: globalSettings.EnableExperimentalGatewayAPIFeatures,
 	}
-	h.hasSyncedFuncs = append(h.hasSyncedFuncs, httproutes.HasSynced, grpcroutes.HasSynced, tcproutes.HasSynced, tlsroutes.HasSynced)
+	h.hasSyncedFuncs = append(h.hasSyncedFuncs, httproutes.HasSynced, grpcroutes.HasSynced, tcproutes.HasSynced, tlsroutes.HasSynced, udproutes.HasSynced)
 
 	h.httpRouteStatusMarkers, h.httpRoutes = krt.NewStatusCollection(httproutes, func(kctx krt.HandlerContext, i *gwv1.HTTPRoute) (*StatusMarker, *ir.HttpRouteIR) {
 		return h.transformHttpRoute(kctx, i, controllerName)
@@ -1178,11 +1194,16 @@ func NewRoutesIndex(
 		t := h.transformTlsRoute(kctx, i)
 		return &RouteWrapper{Route: t}
 	}, krtopts.ToOptions("routes-tls-routes-with-policy")...)
+
+	udpRoutesCollection := krt.NewCollection(udproutes, func(kctx krt.HandlerContext, i *gwv1a2.UDPRoute) *RouteWrapper {
+		t := h.transformUdpRoute(kctx, i)
+		return &RouteWrapper{Route: t}
+	}, krtopts.ToOptions("routes-udp-routes-with-policy")...)
 	grpcRoutesCollection := krt.NewCollection(grpcroutes, func(kctx krt.HandlerContext, i *gwv1.GRPCRoute) *RouteWrapper {
 		t := h.transformGRPCRoute(kctx, i)
 		return &RouteWrapper{Route: t}
 	}, krtopts.ToOptions("routes-grpc-routes-with-policy")...)
-	h.routes = krt.JoinCollection([]krt.Collection[RouteWrapper]{httpRouteCollection, grpcRoutesCollection, tcpRoutesCollection, tlsRoutesCollection}, krtopts.ToOptions("all-routes-with-policy")...)
+	h.routes = krt.JoinCollection([]krt.Collection[RouteWrapper]{httpRouteCollection, grpcRoutesCollection, tcpRoutesCollection, tlsRoutesCollection, udpRoutesCollection}, krtopts.ToOptions("all-routes-with-policy")...)
 
 	httpBySelector := krtpkg.UnnamedIndex(h.httpRoutes, func(i ir.HttpRouteIR) []HTTPRouteSelector {
 		value, ok := i.SourceObject.GetLabels()[apilabels.DelegationLabelSelector]
@@ -1331,6 +1352,38 @@ func (h *RoutesIndex) transformTlsRoute(kctx krt.HandlerContext, i *gwv1a2.TLSRo
 	}
 }
 
+func (h *RoutesIndex) transformUdpRoute(kctx krt.HandlerContext, i *gwv1a2.UDPRoute) *ir.UdpRouteIR {
+	src := ir.ObjectSource{
+		Group:     gwv1a2.GroupVersion.Group,
+		Kind:      "UDPRoute",
+		Namespace: i.Namespace,
+		Name:      i.Name,
+	}
+	var backends []gwv1.BackendRef
+	if len(i.Spec.Rules) > 0 {
+		backends = i.Spec.Rules[0].BackendRefs
+	}
+
+	var sessionIdleTimeout *time.Duration
+	if v, ok := i.Annotations[apiannotations.UDPSessionIdleTimeout]; ok {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			logger.Error("invalid UDP session idle timeout; using the default", "resource_ref", src, "value", v, "error", err)
+		} else {
+			sessionIdleTimeout = &d
+		}
+	}
+
+	return &ir.UdpRouteIR{
+		ObjectSource:       src,
+		SourceObject:       i,
+		ParentRefs:         i.Spec.ParentRefs,
+		Backends:           h.getTcpBackends(kctx, src, backends),
+		AttachedPolicies:   ToAttachedPolicies(h.policies.GetTargetingPolicies(kctx, src, "", i.GetLabels())),
+		SessionIdleTimeout: sessionIdleTimeout,
+	}
+}
+
 func (h *RoutesIndex) transformHttpRoute(kctx krt.HandlerContext, i *gwv1.HTTPRoute, controllerName string) (*StatusMarker, *ir.HttpRouteIR) {
 	src := ir.ObjectSource{
 		Group:     gwv1.GroupVersion.Group,
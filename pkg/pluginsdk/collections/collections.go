@@ -41,6 +41,14 @@ type CommonCollections struct {
 	LocalityPods krt.Collection[krtcollections.LocalityPod]
 	RefGrants    *krtcollections.RefGrantIndex
 
+	// BackendHealth holds the endpoint health reported by gateway proxies, keyed by cluster name.
+	// It is empty unless backend health status is enabled in Settings.
+	BackendHealth krt.Collection[ir.BackendHealth]
+
+	// JWKSStatus holds the results of the controller probing the remote JWKS of JWT GatewayExtensions,
+	// keyed by ir.JWKSStatusKey. It is empty unless JWKS status is enabled in Settings.
+	JWKSStatus krt.Collection[ir.JWKSStatus]
+
 	DiscoveryNamespacesFilter kubetypes.DynamicObjectFilter
 
 	// static set of global Settings, non-krt based for dev speed
@@ -50,6 +58,9 @@ type CommonCollections struct {
 	ControllerName             string
 	AgentgatewayControllerName string
 
+	backendHealth krt.StaticCollection[ir.BackendHealth]
+	jwksStatus    krt.StaticCollection[ir.JWKSStatus]
+
 	options *option
 }
 
@@ -158,6 +169,9 @@ func NewCommonCollections(
 
 	localityPods, wrappedPods := krtcollections.NewPodsCollection(client, krtOptions)
 
+	backendHealth := krt.NewStaticCollection[ir.BackendHealth](nil, nil, krtOptions.ToOptions("BackendHealth")...)
+	jwksStatus := krt.NewStaticCollection[ir.JWKSStatus](nil, nil, krtOptions.ToOptions("JWKSStatus")...)
+
 	return &CommonCollections{
 		Client:            client,
 		KrtOpts:           krtOptions,
@@ -171,6 +185,10 @@ func NewCommonCollections(
 		Services:          services,
 		ServiceEntries:    serviceEntries,
 		GatewayExtensions: gwExts,
+		BackendHealth:     backendHealth,
+		backendHealth:     backendHealth,
+		JWKSStatus:        jwksStatus,
+		jwksStatus:        jwksStatus,
 
 		DiscoveryNamespacesFilter: discoveryNamespacesFilter,
 
@@ -181,6 +199,16 @@ func NewCommonCollections(
 	}, nil
 }
 
+// SetBackendHealth replaces the backend health reported by gateway proxies.
+func (c *CommonCollections) SetBackendHealth(health []ir.BackendHealth) {
+	c.backendHealth.Reset(health)
+}
+
+// SetJWKSStatus replaces the results of probing the remote JWKS.
+func (c *CommonCollections) SetJWKSStatus(status []ir.JWKSStatus) {
+	c.jwksStatus.Reset(status)
+}
+
 // InitPlugins set up collections that rely on plugins.
 // This can't be part of NewCommonCollections because the setup
 // of plugins themselves rely on a reference to CommonCollections.
@@ -314,6 +314,8 @@ type GatewayForDeployer struct {
 	ControllerName string
 	// All ports from all listeners
 	Ports smallset.Set[int32]
+	// Ports from listeners that serve UDP; these are exposed with the UDP protocol
+	UDPPorts smallset.Set[int32]
 }
 
 func (c GatewayForDeployer) ResourceName() string {
@@ -323,7 +325,8 @@ func (c GatewayForDeployer) ResourceName() string {
 func (c GatewayForDeployer) Equals(in GatewayForDeployer) bool {
 	return c.ObjectSource.Equals(in.ObjectSource) &&
 		c.ControllerName == in.ControllerName &&
-		slices.Equal(c.Ports.List(), in.Ports.List())
+		slices.Equal(c.Ports.List(), in.Ports.List()) &&
+		slices.Equal(c.UDPPorts.List(), in.UDPPorts.List())
 }
 
 type ListenerForDeployer struct {
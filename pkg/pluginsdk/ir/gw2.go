@@ -1,6 +1,8 @@
 package ir
 
 import (
+	"time"
+
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -58,6 +60,9 @@ type ListenerIR struct {
 
 	HttpFilterChain []HttpFilterChainIR
 	TcpFilterChain  []TcpIR
+	// UdpListener is set for listeners that proxy UDP traffic. UDP listeners have no
+	// filter chains, so HttpFilterChain and TcpFilterChain are empty when this is set.
+	UdpListener *UdpIR
 
 	PolicyAncestorRef gwv1.ParentReference
 
@@ -137,6 +142,17 @@ type HttpFilterChainIR struct {
 type TcpIR struct {
 	FilterChainCommon
 	BackendRefs []BackendRefIR
+	// ListenerName is the route key of the Gateway listener of the filter chain, whose status
+	// reports the errors of the filter chain. Empty for filter chains without a Gateway listener.
+	ListenerName string
+}
+
+type UdpIR struct {
+	// StatPrefix is used as the stat prefix of the UDP proxy; it is unique per route.
+	StatPrefix  string
+	BackendRefs []BackendRefIR
+	// SessionIdleTimeout is the idle timeout for UDP sessions; nil uses Envoy's default.
+	SessionIdleTimeout *time.Duration
 }
 
 // this is 1:1 with envoy deployments
@@ -0,0 +1,34 @@
+package ir
+
+import (
+	"istio.io/istio/pkg/kube/krt"
+)
+
+// BackendHealth is the endpoint health of a single Envoy cluster, aggregated across
+// all the gateway proxies that reported it.
+type BackendHealth struct {
+	// ClusterName is the name of the Envoy cluster, see BackendObjectIR.ClusterName.
+	ClusterName string
+
+	// Total is the number of endpoints in the cluster.
+	Total uint64
+
+	// Healthy is the number of endpoints that pass active health checking.
+	Healthy uint64
+
+	// Ejected is the number of endpoints currently ejected by outlier detection.
+	Ejected uint64
+}
+
+var (
+	_ krt.ResourceNamer          = BackendHealth{}
+	_ krt.Equaler[BackendHealth] = BackendHealth{}
+)
+
+func (h BackendHealth) ResourceName() string {
+	return h.ClusterName
+}
+
+func (h BackendHealth) Equals(in BackendHealth) bool {
+	return h == in
+}
@@ -12,6 +12,7 @@ import (
 	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	envoy_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	envoytcp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
 	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -46,6 +47,8 @@ type RouteConfigContext struct {
 	FilterChainName   string
 	TypedFilterConfig TypedFilterConfigMap
 	GatewayContext    GatewayContext
+	// ListenerPort is the port of the Gateway listener that this route configuration is for
+	ListenerPort uint32
 }
 
 type VirtualHostContext struct {
@@ -102,6 +105,9 @@ type RouteBackendContext struct {
 	RequestHeadersToRemove  []string
 	ResponseHeadersToAdd    []*envoycorev3.HeaderValueOption
 	ResponseHeadersToRemove []string
+	// HostRewriteError is set by backend plugins when the backend depends on the host of the request.
+	// It is reported as the error of the route when the route rewrites the host.
+	HostRewriteError error
 }
 
 type RouteContext struct {
@@ -123,6 +129,17 @@ type HcmContext struct {
 	Gateway      GatewayIR
 }
 
+type TcpProxyContext struct {
+	ListenerPort uint32
+	Policy       PolicyIR
+	Gateway      GatewayIR
+}
+
+type TcpBackendContext struct {
+	FilterChainName string
+	Backend         *BackendObjectIR
+}
+
 // ProxyTranslationPass represents a single translation pass for a gateway using envoy. It can hold state
 // for the duration of the translation.
 // Each of the functions here will be called in the order they appear in the interface.
@@ -190,6 +207,17 @@ type ProxyTranslationPass interface {
 		pCtx *HcmContext,
 		out *envoy_hcm.HttpConnectionManager) error
 
+	// called 1 time per TCP filter chain (TCPRoute and TLSRoute) with a single backend, on the pass of the backend's group kind.
+	// Returning a filter replaces the TcpProxy filter, for backends that need a protocol-aware network filter.
+	ApplyForTcpBackend(
+		pCtx *TcpBackendContext,
+	) (*envoylistenerv3.Filter, error)
+
+	// called 1 time per TCP filter chain (TCPRoute and TLSRoute) after listeners and allows tweaking TcpProxy settings.
+	ApplyTcpProxy(
+		pCtx *TcpProxyContext,
+		out *envoytcp.TcpProxy) error
+
 	// called 1 time (per envoy proxy). replaces GeneratedResources and allows adding clusters to the envoy.
 	ResourcesToAdd() Resources
 }
@@ -241,6 +269,14 @@ func (s UnimplementedProxyTranslationPass) ApplyHCM(pCtx *HcmContext, out *envoy
 	return nil
 }
 
+func (s UnimplementedProxyTranslationPass) ApplyForTcpBackend(pCtx *TcpBackendContext) (*envoylistenerv3.Filter, error) {
+	return nil, nil
+}
+
+func (s UnimplementedProxyTranslationPass) ApplyTcpProxy(pCtx *TcpProxyContext, out *envoytcp.TcpProxy) error {
+	return nil
+}
+
 func (s UnimplementedProxyTranslationPass) ApplyForBackend(pCtx *RouteBackendContext, in HttpBackend, out *envoyroutev3.Route) error {
 	return nil
 }
@@ -284,6 +320,14 @@ type PolicyIR interface {
 	Equals(in any) bool
 }
 
+// UpstreamTLSPolicyIR is implemented by the backend policies that configure the TLS connections
+// to their backends, so that the controller can connect to a backend as the proxies do.
+type UpstreamTLSPolicyIR interface {
+	PolicyIR
+	// UpstreamTLS returns the TLS context of the connections to the backend, nil if none.
+	UpstreamTLS() *envoytlsv3.UpstreamTlsContext
+}
+
 type PolicyWrapper struct {
 	// A reference to the original policy object
 	ObjectSource `json:",inline"`
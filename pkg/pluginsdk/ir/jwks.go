@@ -0,0 +1,39 @@
+package ir
+
+import (
+	"istio.io/istio/pkg/kube/krt"
+)
+
+// JWKSStatus is the result of the controller probing a remote JWKS.
+type JWKSStatus struct {
+	// Key identifies the probed JWKS, see JWKSStatusKey.
+	Key string
+
+	// Keys is the number of valid keys in the fetched JWKS.
+	Keys int
+
+	// Reason is empty when the JWKS was fetched and contained valid keys,
+	// otherwise one of the GatewayExtension JWKS condition reasons.
+	Reason string
+
+	// Message describes why the probe failed.
+	Message string
+}
+
+var (
+	_ krt.ResourceNamer       = JWKSStatus{}
+	_ krt.Equaler[JWKSStatus] = JWKSStatus{}
+)
+
+func (s JWKSStatus) ResourceName() string {
+	return s.Key
+}
+
+func (s JWKSStatus) Equals(in JWKSStatus) bool {
+	return s == in
+}
+
+// JWKSStatusKey identifies a remote JWKS by its URL and the cluster it is fetched through.
+func JWKSStatusKey(url, clusterName string) string {
+	return clusterName + "/" + url
+}
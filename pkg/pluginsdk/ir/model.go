@@ -93,6 +93,9 @@ type EndpointsForBackend struct {
 	// for use in endpoints plugins
 	// +krtEqualsTodo include backend labels in equality or confirm omission
 	BackendLabels map[string]string
+	// the backend object the endpoints belong to, so that endpoints
+	// plugins can look up the policies attached to it
+	Backend ObjectSource
 
 	// +krtEqualsTodo compare load-balanced endpoint map
 	LbEps LocalityLbMap
@@ -139,6 +142,7 @@ func NewEndpointsForBackend(us BackendObjectIR) *EndpointsForBackend {
 
 	return &EndpointsForBackend{
 		BackendLabels:        labels,
+		Backend:              us.ObjectSource,
 		LbEps:                make(map[PodLocality][]EndpointWithMd),
 		ClusterName:          us.ClusterName(),
 		UpstreamResourceName: us.ResourceName(),
@@ -155,6 +159,7 @@ func NewEndpointsForBackend(us BackendObjectIR) *EndpointsForBackend {
 func (e EndpointsForBackend) EmptyCopy() EndpointsForBackend {
 	return EndpointsForBackend{
 		BackendLabels:        e.BackendLabels,
+		Backend:              e.Backend,
 		LbEps:                make(map[PodLocality][]EndpointWithMd),
 		ClusterName:          e.ClusterName,
 		UpstreamResourceName: e.UpstreamResourceName,
@@ -202,5 +207,5 @@ func (c EndpointsForBackend) ResourceName() string {
 }
 
 func (c EndpointsForBackend) Equals(in EndpointsForBackend) bool {
-	return c.UpstreamResourceName == in.UpstreamResourceName && c.ClusterName == in.ClusterName && c.Port == in.Port && c.LbEpsEqualityHash == in.LbEpsEqualityHash && c.Hostname == in.Hostname && c.TrafficDistribution == in.TrafficDistribution
+	return c.UpstreamResourceName == in.UpstreamResourceName && c.ClusterName == in.ClusterName && c.Port == in.Port && c.LbEpsEqualityHash == in.LbEpsEqualityHash && c.Hostname == in.Hostname && c.TrafficDistribution == in.TrafficDistribution && c.Backend == in.Backend
 }
@@ -1,6 +1,8 @@
 package ir
 
 import (
+	"time"
+
 	"istio.io/istio/pkg/kube/krt"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -203,3 +205,42 @@ func (c *TlsRouteIR) GetHostnames() []string {
 }
 
 var _ Route = &TlsRouteIR{}
+
+type UdpRouteIR struct {
+	ObjectSource `json:",inline"`
+	SourceObject *gwv1a2.UDPRoute
+	// +krtEqualsTodo include parent references when computing equality
+	ParentRefs       []gwv1.ParentReference
+	AttachedPolicies AttachedPolicies
+	Backends         []BackendRefIR
+	// SessionIdleTimeout is the idle timeout for UDP sessions created by this route.
+	// When nil, Envoy's default of 60s is used.
+	SessionIdleTimeout *time.Duration
+}
+
+func (c *UdpRouteIR) GetParentRefs() []gwv1.ParentReference {
+	return c.ParentRefs
+}
+
+func (c *UdpRouteIR) GetSourceObject() metav1.Object {
+	return c.SourceObject
+}
+
+func (c UdpRouteIR) ResourceName() string {
+	return c.ObjectSource.ResourceName()
+}
+
+func (c UdpRouteIR) Equals(in UdpRouteIR) bool {
+	// the session idle timeout is sourced from an annotation, which does not bump the
+	// generation, so it must be compared explicitly
+	if (c.SessionIdleTimeout == nil) != (in.SessionIdleTimeout == nil) ||
+		(c.SessionIdleTimeout != nil && *c.SessionIdleTimeout != *in.SessionIdleTimeout) {
+		return false
+	}
+	return c.ObjectSource == in.ObjectSource &&
+		versionEquals(c.SourceObject, in.SourceObject) &&
+		c.AttachedPolicies.Equals(in.AttachedPolicies) &&
+		backendsEqual(c.Backends, in.Backends)
+}
+
+var _ Route = &UdpRouteIR{}
@@ -26,6 +26,10 @@ const (
 	// with a direct response.
 	RouteRuleReplacedReason = "RouteRuleReplaced"
 
+	// RouteConflictedReason is used with the Accepted=False condition when the route is not translated
+	// because an older route is attached to the same listener, which only supports one route.
+	RouteConflictedReason = "RouteConflicted"
+
 	// ListenerReplacedReason is used with the Accepted=False condition when an individual listener
 	// on a Gateway or XListenerSet is replaced due to an error in a policy targeting that listener.
 	ListenerReplacedReason = "ListenerReplaced"
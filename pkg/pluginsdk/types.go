@@ -26,6 +26,9 @@ var ErrNotFound = errors.New("not found")
 type (
 	EndpointsInputs = endpoints.EndpointsInputs
 	ProcessBackend  func(ctx context.Context, pol ir.PolicyIR, in ir.BackendObjectIR, out *envoyclusterv3.Cluster)
+	// FinalizeBackend runs after the ProcessBackend of every policy plugin, so that it can
+	// build on the cluster they produced, e.g. wrap its transport socket. An error drops the cluster.
+	FinalizeBackend func(ctx context.Context, pol ir.PolicyIR, in ir.BackendObjectIR, out *envoyclusterv3.Cluster) error
 	EndpointPlugin  func(
 		kctx krt.HandlerContext,
 		ctx context.Context,
@@ -59,6 +62,7 @@ type PolicyPlugin struct {
 
 	// Backend processing for envoy proxy
 	ProcessBackend            ProcessBackend
+	FinalizeBackend           FinalizeBackend
 	PerClientProcessBackend   PerClientProcessBackend
 	PerClientProcessEndpoints EndpointPlugin
 
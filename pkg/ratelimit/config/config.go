@@ -0,0 +1,202 @@
+package config
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+
+	rlconfv3 "github.com/envoyproxy/go-control-plane/ratelimit/config/ratelimit/v3"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+)
+
+// Descriptor keys of the entries without a configurable key, matching the rate limit actions of
+// the TrafficPolicy global rate limits.
+const (
+	RemoteAddressKey = "remote_address"
+	PathKey          = "path"
+	// PolicyKey is the key of the first entry of the descriptors with a limit, whose value is the
+	// namespace/name of their TrafficPolicy.
+	PolicyKey = "policy"
+)
+
+// Limits is the rate limit configuration of each domain.
+type Limits struct {
+	Configs []*rlconfv3.RateLimitConfig
+}
+
+func (l Limits) Equals(other Limits) bool {
+	return slices.EqualFunc(l.Configs, other.Configs, func(a, b *rlconfv3.RateLimitConfig) bool {
+		return proto.Equal(a, b)
+	})
+}
+
+// Translate builds the rate limit configuration of each domain from the limits of the
+// descriptors of the TrafficPolicy global rate limits, in the domain of the GatewayExtension
+// they reference.
+//
+// Descriptors form a tree per domain, in the format of the Envoy rate limit service: each entry
+// is a node keyed by the descriptor key, and by the descriptor value for static entries. Entries
+// without a static value match any value, which is limited separately. The root of the tree of
+// each TrafficPolicy is its policy entry, so that the policies do not share their counters.
+func Translate(policies []*kgateway.TrafficPolicy, extensions []*kgateway.GatewayExtension) Limits {
+	domains := map[string]string{}
+	for _, ext := range extensions {
+		if ext.Spec.RateLimit != nil {
+			domains[ext.Namespace+"/"+ext.Name] = ext.Spec.RateLimit.Domain
+		}
+	}
+
+	policies = slices.Clone(policies)
+	slices.SortFunc(policies, func(a, b *kgateway.TrafficPolicy) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+
+	configs := map[string]*rlconfv3.RateLimitConfig{}
+	for _, policy := range policies {
+		if policy.Spec.RateLimit == nil || policy.Spec.RateLimit.Global == nil {
+			continue
+		}
+		global := policy.Spec.RateLimit.Global
+		namespace := policy.Namespace
+		if global.ExtensionRef.Namespace != nil {
+			namespace = string(*global.ExtensionRef.Namespace)
+		}
+		domain, ok := domains[namespace+"/"+string(global.ExtensionRef.Name)]
+		if !ok {
+			continue
+		}
+		config := configs[domain]
+		if config == nil {
+			config = &rlconfv3.RateLimitConfig{Name: domain, Domain: domain}
+			configs[domain] = config
+		}
+		for _, descriptor := range global.Descriptors {
+			if descriptor.Limit == nil {
+				continue
+			}
+			addDescriptor(config, policy.Namespace+"/"+policy.Name, descriptor)
+		}
+	}
+
+	var limits Limits
+	for _, domain := range slices.Sorted(maps.Keys(configs)) {
+		config := configs[domain]
+		sortDescriptors(config.Descriptors)
+		limits.Configs = append(limits.Configs, config)
+	}
+	return limits
+}
+
+func addDescriptor(config *rlconfv3.RateLimitConfig, policyName string, descriptor kgateway.RateLimitDescriptor) {
+	if len(descriptor.Entries) == 0 {
+		return
+	}
+	entries := [][2]string{{PolicyKey, policyName}}
+	for _, entry := range descriptor.Entries {
+		key, value, ok := descriptorEntry(entry)
+		if !ok {
+			return
+		}
+		entries = append(entries, [2]string{key, value})
+	}
+
+	level := &config.Descriptors
+	var node *rlconfv3.RateLimitDescriptor
+	for _, entry := range entries {
+		key, value := entry[0], entry[1]
+		i := slices.IndexFunc(*level, func(d *rlconfv3.RateLimitDescriptor) bool {
+			return d.GetKey() == key && d.GetValue() == value
+		})
+		if i < 0 {
+			*level = append(*level, &rlconfv3.RateLimitDescriptor{Key: key, Value: value})
+			i = len(*level) - 1
+		}
+		node = (*level)[i]
+		level = &node.Descriptors
+	}
+
+	limit := &rlconfv3.RateLimitPolicy{
+		Name:            policyName,
+		Unit:            unit(descriptor.Limit.Unit),
+		RequestsPerUnit: uint32(descriptor.Limit.RequestsPerUnit), //nolint:gosec // G115: kubebuilder validation ensures the value is positive
+	}
+	// descriptors of the policy sharing their entries share their counters, so the lowest limit applies
+	if node.RateLimit == nil || lowerRate(limit, node.RateLimit) {
+		node.RateLimit = limit
+	}
+}
+
+// descriptorEntry returns the key and value of the descriptor entry sent by the proxy for an
+// entry, with an empty value for entries without a static value.
+func descriptorEntry(entry kgateway.RateLimitDescriptorEntry) (string, string, bool) {
+	switch entry.Type {
+	case kgateway.RateLimitDescriptorEntryTypeGeneric:
+		if entry.Generic == nil {
+			return "", "", false
+		}
+		return entry.Generic.Key, entry.Generic.Value, true
+	case kgateway.RateLimitDescriptorEntryTypeHeader:
+		if entry.Header == nil {
+			return "", "", false
+		}
+		return *entry.Header, "", true
+	case kgateway.RateLimitDescriptorEntryTypeRemoteAddress:
+		return RemoteAddressKey, "", true
+	case kgateway.RateLimitDescriptorEntryTypePath:
+		return PathKey, "", true
+	case kgateway.RateLimitDescriptorEntryTypeAPIKeyMetadata:
+		if entry.APIKeyMetadata == nil {
+			return "", "", false
+		}
+		return *entry.APIKeyMetadata, "", true
+	default:
+		return "", "", false
+	}
+}
+
+func unit(u kgateway.RateLimitUnit) rlconfv3.RateLimitUnit {
+	switch u {
+	case kgateway.RateLimitUnitSecond:
+		return rlconfv3.RateLimitUnit_SECOND
+	case kgateway.RateLimitUnitMinute:
+		return rlconfv3.RateLimitUnit_MINUTE
+	case kgateway.RateLimitUnitHour:
+		return rlconfv3.RateLimitUnit_HOUR
+	case kgateway.RateLimitUnitDay:
+		return rlconfv3.RateLimitUnit_DAY
+	default:
+		return rlconfv3.RateLimitUnit_UNKNOWN
+	}
+}
+
+// UnitSeconds returns the length of a rate limit unit in seconds, 0 if it is unknown.
+func UnitSeconds(u rlconfv3.RateLimitUnit) int64 {
+	switch u {
+	case rlconfv3.RateLimitUnit_SECOND:
+		return 1
+	case rlconfv3.RateLimitUnit_MINUTE:
+		return 60
+	case rlconfv3.RateLimitUnit_HOUR:
+		return 60 * 60
+	case rlconfv3.RateLimitUnit_DAY:
+		return 24 * 60 * 60
+	default:
+		return 0
+	}
+}
+
+func lowerRate(a, b *rlconfv3.RateLimitPolicy) bool {
+	return int64(a.GetRequestsPerUnit())*UnitSeconds(b.GetUnit()) < int64(b.GetRequestsPerUnit())*UnitSeconds(a.GetUnit())
+}
+
+func sortDescriptors(descriptors []*rlconfv3.RateLimitDescriptor) {
+	slices.SortFunc(descriptors, func(a, b *rlconfv3.RateLimitDescriptor) int {
+		return cmp.Or(strings.Compare(a.GetKey(), b.GetKey()), strings.Compare(a.GetValue(), b.GetValue()))
+	})
+	for _, d := range descriptors {
+		sortDescriptors(d.Descriptors)
+	}
+}
@@ -0,0 +1,211 @@
+package config
+
+import (
+	"testing"
+
+	rlconfv3 "github.com/envoyproxy/go-control-plane/ratelimit/config/ratelimit/v3"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+)
+
+func extension(namespace, name, domain string) *kgateway.GatewayExtension {
+	return &kgateway.GatewayExtension{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: kgateway.GatewayExtensionSpec{
+			RateLimit: &kgateway.RateLimitProvider{Domain: domain},
+		},
+	}
+}
+
+func policy(namespace, name string, ref shared.NamespacedObjectReference, descriptors ...kgateway.RateLimitDescriptor) *kgateway.TrafficPolicy {
+	return &kgateway.TrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: kgateway.TrafficPolicySpec{
+			RateLimit: &kgateway.RateLimit{
+				Global: &kgateway.RateLimitPolicy{
+					Descriptors:  descriptors,
+					ExtensionRef: ref,
+				},
+			},
+		},
+	}
+}
+
+func limit(requests int32, unit kgateway.RateLimitUnit) *kgateway.RateLimitDescriptorLimit {
+	return &kgateway.RateLimitDescriptorLimit{RequestsPerUnit: requests, Unit: unit}
+}
+
+func TestTranslate(t *testing.T) {
+	ref := shared.NamespacedObjectReference{Name: "ratelimit"}
+	extensions := []*kgateway.GatewayExtension{
+		extension("default", "ratelimit", "api"),
+		extension("infra", "ratelimit", "shared"),
+	}
+	policies := []*kgateway.TrafficPolicy{
+		policy("default", "per-plan", ref, kgateway.RateLimitDescriptor{
+			Entries: []kgateway.RateLimitDescriptorEntry{
+				{
+					Type:    kgateway.RateLimitDescriptorEntryTypeGeneric,
+					Generic: &kgateway.RateLimitDescriptorEntryGeneric{Key: "service", Value: "orders"},
+				},
+				{Type: kgateway.RateLimitDescriptorEntryTypeHeader, Header: ptr.To("x-plan")},
+			},
+			Limit: limit(100, kgateway.RateLimitUnitMinute),
+		}),
+		policy("default", "per-client", ref,
+			kgateway.RateLimitDescriptor{
+				Entries: []kgateway.RateLimitDescriptorEntry{{Type: kgateway.RateLimitDescriptorEntryTypeRemoteAddress}},
+				Limit:   limit(10, kgateway.RateLimitUnitSecond),
+			},
+			kgateway.RateLimitDescriptor{
+				Entries: []kgateway.RateLimitDescriptorEntry{{Type: kgateway.RateLimitDescriptorEntryTypePath}},
+				Limit:   limit(1000, kgateway.RateLimitUnitHour),
+			},
+		),
+		// the lowest rate of the descriptors of a policy sharing their entries applies
+		policy("default", "strict", ref,
+			kgateway.RateLimitDescriptor{
+				Entries: []kgateway.RateLimitDescriptorEntry{{Type: kgateway.RateLimitDescriptorEntryTypeRemoteAddress}},
+				Limit:   limit(60, kgateway.RateLimitUnitMinute),
+			},
+			kgateway.RateLimitDescriptor{
+				Entries: []kgateway.RateLimitDescriptorEntry{{Type: kgateway.RateLimitDescriptorEntryTypeRemoteAddress}},
+				Limit:   limit(2, kgateway.RateLimitUnitSecond),
+			},
+		),
+		policy("team", "shared", shared.NamespacedObjectReference{Name: "ratelimit", Namespace: ptr.To(gwv1.Namespace("infra"))},
+			kgateway.RateLimitDescriptor{
+				Entries: []kgateway.RateLimitDescriptorEntry{{Type: kgateway.RateLimitDescriptorEntryTypeAPIKeyMetadata, APIKeyMetadata: ptr.To("tier")}},
+				Limit:   limit(1, kgateway.RateLimitUnitDay),
+			},
+		),
+		// descriptors without limits are limited by another rate limit service
+		policy("default", "external", ref, kgateway.RateLimitDescriptor{
+			Entries: []kgateway.RateLimitDescriptorEntry{{Type: kgateway.RateLimitDescriptorEntryTypeRemoteAddress}},
+		}),
+		policy("default", "missing", shared.NamespacedObjectReference{Name: "missing"}, kgateway.RateLimitDescriptor{
+			Entries: []kgateway.RateLimitDescriptorEntry{{Type: kgateway.RateLimitDescriptorEntryTypeRemoteAddress}},
+			Limit:   limit(1, kgateway.RateLimitUnitSecond),
+		}),
+	}
+
+	expected := Limits{Configs: []*rlconfv3.RateLimitConfig{
+		{
+			Name:   "api",
+			Domain: "api",
+			Descriptors: []*rlconfv3.RateLimitDescriptor{
+				{
+					Key:   PolicyKey,
+					Value: "default/per-client",
+					Descriptors: []*rlconfv3.RateLimitDescriptor{
+						{
+							Key: PathKey,
+							RateLimit: &rlconfv3.RateLimitPolicy{
+								Name:            "default/per-client",
+								Unit:            rlconfv3.RateLimitUnit_HOUR,
+								RequestsPerUnit: 1000,
+							},
+						},
+						{
+							Key: RemoteAddressKey,
+							RateLimit: &rlconfv3.RateLimitPolicy{
+								Name:            "default/per-client",
+								Unit:            rlconfv3.RateLimitUnit_SECOND,
+								RequestsPerUnit: 10,
+							},
+						},
+					},
+				},
+				{
+					Key:   PolicyKey,
+					Value: "default/per-plan",
+					Descriptors: []*rlconfv3.RateLimitDescriptor{{
+						Key:   "service",
+						Value: "orders",
+						Descriptors: []*rlconfv3.RateLimitDescriptor{{
+							Key: "x-plan",
+							RateLimit: &rlconfv3.RateLimitPolicy{
+								Name:            "default/per-plan",
+								Unit:            rlconfv3.RateLimitUnit_MINUTE,
+								RequestsPerUnit: 100,
+							},
+						}},
+					}},
+				},
+				{
+					Key:   PolicyKey,
+					Value: "default/strict",
+					Descriptors: []*rlconfv3.RateLimitDescriptor{{
+						Key: RemoteAddressKey,
+						RateLimit: &rlconfv3.RateLimitPolicy{
+							Name:            "default/strict",
+							Unit:            rlconfv3.RateLimitUnit_MINUTE,
+							RequestsPerUnit: 60,
+						},
+					}},
+				},
+			},
+		},
+		{
+			Name:   "shared",
+			Domain: "shared",
+			Descriptors: []*rlconfv3.RateLimitDescriptor{{
+				Key:   PolicyKey,
+				Value: "team/shared",
+				Descriptors: []*rlconfv3.RateLimitDescriptor{{
+					Key: "tier",
+					RateLimit: &rlconfv3.RateLimitPolicy{
+						Name:            "team/shared",
+						Unit:            rlconfv3.RateLimitUnit_DAY,
+						RequestsPerUnit: 1,
+					},
+				}},
+			}},
+		},
+	}}
+
+	limits := Translate(policies, extensions)
+	require.True(t, limits.Equals(expected), "unexpected limits:\n%v", limits.Configs)
+}
+
+func TestTranslatePoliciesInDifferentNamespaces(t *testing.T) {
+	ref := shared.NamespacedObjectReference{Name: "ratelimit", Namespace: ptr.To(gwv1.Namespace("infra"))}
+	extensions := []*kgateway.GatewayExtension{extension("infra", "ratelimit", "shared")}
+	descriptor := kgateway.RateLimitDescriptor{
+		Entries: []kgateway.RateLimitDescriptorEntry{{Type: kgateway.RateLimitDescriptorEntryTypeRemoteAddress}},
+		Limit:   limit(10, kgateway.RateLimitUnitMinute),
+	}
+	policies := []*kgateway.TrafficPolicy{
+		policy("team-b", "limit", ref, descriptor),
+		policy("team-a", "limit", ref, descriptor),
+	}
+
+	// identical descriptors of policies in different namespaces are limited separately
+	perPolicy := func(name string) *rlconfv3.RateLimitDescriptor {
+		return &rlconfv3.RateLimitDescriptor{
+			Key:   PolicyKey,
+			Value: name,
+			Descriptors: []*rlconfv3.RateLimitDescriptor{{
+				Key: RemoteAddressKey,
+				RateLimit: &rlconfv3.RateLimitPolicy{
+					Name:            name,
+					Unit:            rlconfv3.RateLimitUnit_MINUTE,
+					RequestsPerUnit: 10,
+				},
+			}},
+		}
+	}
+	expected := Limits{Configs: []*rlconfv3.RateLimitConfig{{
+		Name:        "shared",
+		Domain:      "shared",
+		Descriptors: []*rlconfv3.RateLimitDescriptor{perPolicy("team-a/limit"), perPolicy("team-b/limit")},
+	}}}
+
+	limits := Translate(policies, extensions)
+	require.True(t, limits.Equals(expected), "unexpected limits:\n%v", limits.Configs)
+}
@@ -0,0 +1,150 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"log/slog"
+	"net"
+	"os/signal"
+	"syscall"
+
+	rlsv3 "github.com/envoyproxy/go-control-plane/envoy/service/ratelimit/v3"
+	"github.com/kelseyhightower/envconfig"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"istio.io/istio/pkg/kube/kclient"
+	"istio.io/istio/pkg/kube/krt"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/logging"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
+	"github.com/kgateway-dev/kgateway/v2/pkg/ratelimit/config"
+	"github.com/kgateway-dev/kgateway/v2/pkg/ratelimit/server"
+	"github.com/kgateway-dev/kgateway/v2/pkg/ratelimit/storage"
+)
+
+var logger = logging.New("ratelimit_server")
+
+// The rate limit server is a reference Envoy global rate limit service. It is referenced by a
+// RateLimit GatewayExtension, and enforces the limits set on the descriptors of the TrafficPolicy
+// global rate limits referencing it, which it watches, so that limits do not have to be kept in
+// sync with a separate rate limit service configuration.
+
+const (
+	StorageMemory = "memory"
+	StorageRedis  = "redis"
+)
+
+// Config is read from the environment variables prefixed with RATELIMIT_, for example
+// RATELIMIT_STORAGE. Counters are kept in memory by default, which limits requests per replica;
+// Redis shares them between the replicas.
+type Config struct {
+	ServerAddress string `split_words:"true" default:"0.0.0.0:8081"`
+	Storage       string `default:"memory"`
+	RedisAddress  string `split_words:"true" default:"localhost:6379"`
+	RedisPassword string `split_words:"true"`
+	RedisDB       int    `envconfig:"REDIS_DB"`
+	RedisTLS      bool   `envconfig:"REDIS_TLS"`
+	RedisPoolSize int    `split_words:"true" default:"10"`
+}
+
+func RunMain() {
+	var c Config
+	if err := envconfig.Process("ratelimit", &c); err != nil {
+		log.Fatalf("failed to process env config: %v", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	client, err := apiclient.New(ctrl.GetConfigOrDie())
+	if err != nil {
+		log.Fatalf("failed to create API client: %v", err)
+	}
+	if err := Run(ctx, c, client, logger); err != nil {
+		log.Fatalf("failed to run rate limit server: %v", err)
+	}
+}
+
+// Run serves the limits of the TrafficPolicies until the context is done.
+func Run(ctx context.Context, c Config, client apiclient.Client, logger *slog.Logger) error {
+	store, err := newStorage(c)
+	if err != nil {
+		return err
+	}
+	if closer, ok := store.(interface{ Close() error }); ok {
+		defer closer.Close()
+	}
+	rlServer := server.NewServer(store)
+
+	krtOpts := krtutil.NewKrtOptions(ctx.Done(), nil)
+	policies := krt.WrapClient(kclient.NewFilteredDelayed[*kgateway.TrafficPolicy](
+		client,
+		wellknown.TrafficPolicyGVR,
+		kclient.Filter{ObjectFilter: client.ObjectFilter()},
+	), krtOpts.ToOptions("TrafficPolicy")...)
+	extensions := krt.WrapClient(kclient.NewFilteredDelayed[*kgateway.GatewayExtension](
+		client,
+		wellknown.GatewayExtensionGVR,
+		kclient.Filter{ObjectFilter: client.ObjectFilter()},
+	), krtOpts.ToOptions("GatewayExtension")...)
+	limits := krt.NewSingleton(func(kctx krt.HandlerContext) *config.Limits {
+		limits := config.Translate(krt.Fetch(kctx, policies), krt.Fetch(kctx, extensions))
+		return &limits
+	}, krtOpts.ToOptions("RateLimits")...)
+	limits.Register(func(o krt.Event[config.Limits]) {
+		latest := o.Latest()
+		rlServer.SetLimits(latest)
+		logger.Info("limits updated", "domains", len(latest.Configs))
+	})
+
+	client.RunAndWait(ctx.Done())
+	if !limits.AsCollection().WaitUntilSynced(ctx.Done()) {
+		return ctx.Err()
+	}
+
+	grpcServer := grpc.NewServer()
+	rlsv3.RegisterRateLimitServiceServer(grpcServer, rlServer)
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+
+	lis, err := net.Listen("tcp", c.ServerAddress)
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		grpcServer.GracefulStop()
+	}()
+	logger.Info("rate limit server listening", "address", c.ServerAddress, "storage", c.Storage)
+	return grpcServer.Serve(lis)
+}
+
+func newStorage(c Config) (storage.Storage, error) {
+	switch c.Storage {
+	case StorageMemory:
+		return storage.NewMemory(), nil
+	case StorageRedis:
+		opts := storage.RedisOptions{
+			Address:  c.RedisAddress,
+			Password: c.RedisPassword,
+			DB:       c.RedisDB,
+			PoolSize: c.RedisPoolSize,
+		}
+		if c.RedisTLS {
+			host, _, err := net.SplitHostPort(c.RedisAddress)
+			if err != nil {
+				return nil, fmt.Errorf("invalid redis address: %w", err)
+			}
+			opts.TLS = &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}
+		}
+		return storage.NewRedis(opts), nil
+	default:
+		return nil, fmt.Errorf("unknown storage %q, must be %s or %s", c.Storage, StorageMemory, StorageRedis)
+	}
+}
@@ -0,0 +1,167 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	ratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/common/ratelimit/v3"
+	rlsv3 "github.com/envoyproxy/go-control-plane/envoy/service/ratelimit/v3"
+	rlconfv3 "github.com/envoyproxy/go-control-plane/ratelimit/config/ratelimit/v3"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/ratelimit/config"
+	"github.com/kgateway-dev/kgateway/v2/pkg/ratelimit/storage"
+)
+
+// Server is an Envoy rate limit service enforcing fixed window limits. The limits can be swapped
+// while the server runs, when the TrafficPolicies change.
+type Server struct {
+	rlsv3.UnimplementedRateLimitServiceServer
+	storage storage.Storage
+	domains atomic.Pointer[map[string][]*rlconfv3.RateLimitDescriptor]
+	now     func() time.Time
+}
+
+var _ rlsv3.RateLimitServiceServer = &Server{}
+
+func NewServer(storage storage.Storage) *Server {
+	s := &Server{
+		storage: storage,
+		now:     time.Now,
+	}
+	s.SetLimits(config.Limits{})
+	return s
+}
+
+// SetLimits sets the limits applied to the next requests. Counters are kept, so unchanged limits
+// carry on with their current windows.
+func (s *Server) SetLimits(limits config.Limits) {
+	domains := map[string][]*rlconfv3.RateLimitDescriptor{}
+	for _, c := range limits.Configs {
+		domains[c.GetDomain()] = c.GetDescriptors()
+	}
+	s.domains.Store(&domains)
+}
+
+func (s *Server) ShouldRateLimit(ctx context.Context, req *rlsv3.RateLimitRequest) (*rlsv3.RateLimitResponse, error) {
+	if req.GetDomain() == "" {
+		return nil, grpcstatus.Error(codes.InvalidArgument, "rate limit domain must not be empty")
+	}
+	hits := uint64(req.GetHitsAddend())
+	if hits == 0 {
+		hits = 1
+	}
+
+	tree := (*s.domains.Load())[req.GetDomain()]
+	resp := &rlsv3.RateLimitResponse{
+		OverallCode: rlsv3.RateLimitResponse_OK,
+	}
+	for _, descriptor := range req.GetDescriptors() {
+		status, err := s.check(ctx, req.GetDomain(), tree, descriptor, hits)
+		if err != nil {
+			return nil, grpcstatus.Errorf(codes.Unavailable, "failed to update rate limit counter: %v", err)
+		}
+		if status.GetCode() == rlsv3.RateLimitResponse_OVER_LIMIT {
+			resp.OverallCode = rlsv3.RateLimitResponse_OVER_LIMIT
+		}
+		resp.Statuses = append(resp.Statuses, status)
+	}
+	return resp, nil
+}
+
+func (s *Server) check(
+	ctx context.Context,
+	domain string,
+	tree []*rlconfv3.RateLimitDescriptor,
+	descriptor *ratelimitv3.RateLimitDescriptor,
+	hits uint64,
+) (*rlsv3.RateLimitResponse_DescriptorStatus, error) {
+	limit := match(tree, descriptor.GetEntries())
+	unit := config.UnitSeconds(limit.GetUnit())
+	if limit == nil || limit.GetUnlimited() || unit == 0 {
+		return &rlsv3.RateLimitResponse_DescriptorStatus{Code: rlsv3.RateLimitResponse_OK}, nil
+	}
+	if descriptor.GetHitsAddend() != nil {
+		hits = descriptor.GetHitsAddend().GetValue()
+	}
+
+	now := s.now()
+	windowStart := now.Unix() / unit * unit
+	reset := time.Unix(windowStart+unit, 0)
+
+	count, err := s.storage.Increment(ctx, counterKey(domain, descriptor.GetEntries(), windowStart), hits, reset.Sub(now))
+	if err != nil {
+		return nil, err
+	}
+
+	status := &rlsv3.RateLimitResponse_DescriptorStatus{
+		Code: rlsv3.RateLimitResponse_OK,
+		CurrentLimit: &rlsv3.RateLimitResponse_RateLimit{
+			Name:            limit.GetName(),
+			RequestsPerUnit: limit.GetRequestsPerUnit(),
+			// the units of the configuration and the service share their values
+			Unit: rlsv3.RateLimitResponse_RateLimit_Unit(limit.GetUnit()),
+		},
+		DurationUntilReset: durationpb.New(reset.Sub(now)),
+	}
+	if count > uint64(limit.GetRequestsPerUnit()) {
+		status.Code = rlsv3.RateLimitResponse_OVER_LIMIT
+	} else {
+		status.LimitRemaining = limit.GetRequestsPerUnit() - uint32(count) //nolint:gosec // G115: count is at most the limit
+	}
+	return status, nil
+}
+
+// match returns the limit of the descriptor matching the entries. At each level, a descriptor
+// with the key and value of the entry is preferred over a descriptor matching any value.
+func match(tree []*rlconfv3.RateLimitDescriptor, entries []*ratelimitv3.RateLimitDescriptor_Entry) *rlconfv3.RateLimitPolicy {
+	var node *rlconfv3.RateLimitDescriptor
+	level := tree
+	for _, entry := range entries {
+		var anyValue *rlconfv3.RateLimitDescriptor
+		node = nil
+		for _, d := range level {
+			if d.GetKey() != entry.GetKey() {
+				continue
+			}
+			if d.GetValue() == entry.GetValue() {
+				node = d
+				break
+			}
+			if d.GetValue() == "" {
+				anyValue = d
+			}
+		}
+		if node == nil {
+			node = anyValue
+		}
+		if node == nil {
+			return nil
+		}
+		level = node.GetDescriptors()
+	}
+	return node.GetRateLimit()
+}
+
+// counterKey returns the storage key of the counter of a descriptor in a window. The domain and
+// the keys and values of the entries come from the requests, so each of them is length prefixed
+// to keep distinct descriptors from sharing a counter.
+func counterKey(domain string, entries []*ratelimitv3.RateLimitDescriptor_Entry, windowStart int64) string {
+	var b strings.Builder
+	writeField := func(s string) {
+		fmt.Fprintf(&b, "%d:%s|", len(s), s)
+	}
+	writeField(domain)
+	for _, e := range entries {
+		writeField(e.GetKey())
+		writeField(e.GetValue())
+	}
+	b.WriteString(strconv.FormatInt(windowStart, 10))
+	return b.String()
+}
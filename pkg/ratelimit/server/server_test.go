@@ -0,0 +1,212 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	ratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/common/ratelimit/v3"
+	rlsv3 "github.com/envoyproxy/go-control-plane/envoy/service/ratelimit/v3"
+	rlconfv3 "github.com/envoyproxy/go-control-plane/ratelimit/config/ratelimit/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/ratelimit/config"
+	"github.com/kgateway-dev/kgateway/v2/pkg/ratelimit/storage"
+)
+
+var testLimits = config.Limits{
+	Configs: []*rlconfv3.RateLimitConfig{{
+		Name:   "api",
+		Domain: "api",
+		Descriptors: []*rlconfv3.RateLimitDescriptor{
+			{
+				Key: "remote_address",
+				RateLimit: &rlconfv3.RateLimitPolicy{
+					Name:            "default/per-client",
+					Unit:            rlconfv3.RateLimitUnit_MINUTE,
+					RequestsPerUnit: 2,
+				},
+			},
+			{
+				Key: "service",
+				Descriptors: []*rlconfv3.RateLimitDescriptor{
+					{
+						Key:   "x-plan",
+						Value: "gold",
+						RateLimit: &rlconfv3.RateLimitPolicy{
+							Unlimited: true,
+						},
+					},
+					{
+						Key: "x-plan",
+						RateLimit: &rlconfv3.RateLimitPolicy{
+							Name:            "default/per-plan",
+							Unit:            rlconfv3.RateLimitUnit_SECOND,
+							RequestsPerUnit: 1,
+						},
+					},
+				},
+			},
+		},
+	}},
+}
+
+func request(entries ...string) *rlsv3.RateLimitRequest {
+	descriptor := &ratelimitv3.RateLimitDescriptor{}
+	for i := 0; i < len(entries); i += 2 {
+		descriptor.Entries = append(descriptor.Entries, &ratelimitv3.RateLimitDescriptor_Entry{
+			Key:   entries[i],
+			Value: entries[i+1],
+		})
+	}
+	return &rlsv3.RateLimitRequest{
+		Domain:      "api",
+		Descriptors: []*ratelimitv3.RateLimitDescriptor{descriptor},
+	}
+}
+
+func newTestServer() *Server {
+	mem := storage.NewMemory()
+	s := NewServer(mem)
+	now := time.Unix(1000, 0)
+	s.now = func() time.Time { return now }
+	s.SetLimits(testLimits)
+	return s
+}
+
+func TestShouldRateLimit(t *testing.T) {
+	r := require.New(t)
+	s := newTestServer()
+	ctx := context.Background()
+
+	resp, err := s.ShouldRateLimit(ctx, request("remote_address", "10.0.0.1"))
+	r.NoError(err)
+	r.Equal(rlsv3.RateLimitResponse_OK, resp.GetOverallCode())
+	r.Len(resp.GetStatuses(), 1)
+	status := resp.GetStatuses()[0]
+	r.Equal(uint32(1), status.GetLimitRemaining())
+	r.Equal("default/per-client", status.GetCurrentLimit().GetName())
+	r.Equal(rlsv3.RateLimitResponse_RateLimit_MINUTE, status.GetCurrentLimit().GetUnit())
+	// the minute window started at 960
+	r.Equal(20*time.Second, status.GetDurationUntilReset().AsDuration())
+
+	resp, err = s.ShouldRateLimit(ctx, request("remote_address", "10.0.0.1"))
+	r.NoError(err)
+	r.Equal(rlsv3.RateLimitResponse_OK, resp.GetOverallCode())
+	r.Equal(uint32(0), resp.GetStatuses()[0].GetLimitRemaining())
+
+	resp, err = s.ShouldRateLimit(ctx, request("remote_address", "10.0.0.1"))
+	r.NoError(err)
+	r.Equal(rlsv3.RateLimitResponse_OVER_LIMIT, resp.GetOverallCode())
+	r.Equal(rlsv3.RateLimitResponse_OVER_LIMIT, resp.GetStatuses()[0].GetCode())
+
+	// each value has its own counter
+	resp, err = s.ShouldRateLimit(ctx, request("remote_address", "10.0.0.2"))
+	r.NoError(err)
+	r.Equal(rlsv3.RateLimitResponse_OK, resp.GetOverallCode())
+}
+
+func TestShouldRateLimitMatching(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *rlsv3.RateLimitRequest
+		code    rlsv3.RateLimitResponse_Code
+	}{
+		{
+			name:    "exact value preferred over any value",
+			request: request("service", "", "x-plan", "gold"),
+			code:    rlsv3.RateLimitResponse_OK,
+		},
+		{
+			name:    "any value",
+			request: request("service", "", "x-plan", "silver"),
+			code:    rlsv3.RateLimitResponse_OVER_LIMIT,
+		},
+		{
+			name:    "partial match is not limited",
+			request: request("service", ""),
+			code:    rlsv3.RateLimitResponse_OK,
+		},
+		{
+			name:    "unknown key is not limited",
+			request: request("path", "/"),
+			code:    rlsv3.RateLimitResponse_OK,
+		},
+		{
+			name: "unknown domain is not limited",
+			request: &rlsv3.RateLimitRequest{
+				Domain:      "other",
+				Descriptors: request("remote_address", "10.0.0.1").GetDescriptors(),
+			},
+			code: rlsv3.RateLimitResponse_OK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer()
+			// three hits are over any of the limits in the test configuration
+			tt.request.HitsAddend = 3
+			resp, err := s.ShouldRateLimit(context.Background(), tt.request)
+			require.NoError(t, err)
+			require.Equal(t, tt.code, resp.GetOverallCode())
+		})
+	}
+}
+
+func TestShouldRateLimitEmptyDomain(t *testing.T) {
+	s := newTestServer()
+	_, err := s.ShouldRateLimit(context.Background(), &rlsv3.RateLimitRequest{})
+	require.Equal(t, codes.InvalidArgument, grpcstatus.Code(err))
+}
+
+type failingStorage struct{}
+
+func (failingStorage) Increment(context.Context, string, uint64, time.Duration) (uint64, error) {
+	return 0, errors.New("connection refused")
+}
+
+func TestShouldRateLimitStorageError(t *testing.T) {
+	s := NewServer(failingStorage{})
+	s.SetLimits(testLimits)
+	_, err := s.ShouldRateLimit(context.Background(), request("remote_address", "10.0.0.1"))
+	require.Equal(t, codes.Unavailable, grpcstatus.Code(err))
+}
+
+func TestCounterKey(t *testing.T) {
+	type descriptor struct {
+		domain  string
+		entries []string
+	}
+	// each pair shared a counter when the entries were joined without escaping
+	tests := []struct {
+		name string
+		a, b descriptor
+	}{
+		{
+			name: "separator in a value",
+			a:    descriptor{domain: "api", entries: []string{"a", "b|c=d"}},
+			b:    descriptor{domain: "api", entries: []string{"a", "b", "c", "d"}},
+		},
+		{
+			name: "separator in a key",
+			a:    descriptor{domain: "api", entries: []string{"a=b", "c"}},
+			b:    descriptor{domain: "api", entries: []string{"a", "b=c"}},
+		},
+		{
+			name: "separator in the domain",
+			a:    descriptor{domain: "api|a=b"},
+			b:    descriptor{domain: "api", entries: []string{"a", "b"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := func(d descriptor) string {
+				return counterKey(d.domain, request(d.entries...).GetDescriptors()[0].GetEntries(), 1000)
+			}
+			require.NotEqual(t, key(tt.a), key(tt.b))
+		})
+	}
+}
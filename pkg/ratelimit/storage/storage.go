@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Storage holds the counters of the rate limit server.
+type Storage interface {
+	// Increment adds hits to the counter of a key, which expires ttl after it is created, and
+	// returns the new value of the counter.
+	Increment(ctx context.Context, key string, hits uint64, ttl time.Duration) (uint64, error)
+}
+
+// sweepInterval is how often expired counters are removed from the memory storage.
+const sweepInterval = time.Minute
+
+// Memory stores the counters in memory, limiting requests per rate limit server replica.
+type Memory struct {
+	mu        sync.Mutex
+	counters  map[string]*counter
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type counter struct {
+	value   uint64
+	expires time.Time
+}
+
+var _ Storage = &Memory{}
+
+func NewMemory() *Memory {
+	return &Memory{
+		counters: map[string]*counter{},
+		now:      time.Now,
+	}
+}
+
+func (m *Memory) Increment(_ context.Context, key string, hits uint64, ttl time.Duration) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if now.Sub(m.lastSweep) >= sweepInterval {
+		for k, c := range m.counters {
+			if !now.Before(c.expires) {
+				delete(m.counters, k)
+			}
+		}
+		m.lastSweep = now
+	}
+
+	c, ok := m.counters[key]
+	if !ok || !now.Before(c.expires) {
+		c = &counter{expires: now.Add(ttl)}
+		m.counters[key] = c
+	}
+	c.value += hits
+	return c.value, nil
+}
@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryIncrement(t *testing.T) {
+	r := require.New(t)
+	now := time.Unix(1000, 0)
+	m := NewMemory()
+	m.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	count, err := m.Increment(ctx, "a", 1, time.Second)
+	r.NoError(err)
+	r.Equal(uint64(1), count)
+	count, err = m.Increment(ctx, "a", 2, time.Second)
+	r.NoError(err)
+	r.Equal(uint64(3), count)
+	count, err = m.Increment(ctx, "b", 1, time.Minute)
+	r.NoError(err)
+	r.Equal(uint64(1), count)
+
+	// the counter of a restarts once it expired
+	now = now.Add(time.Second)
+	count, err = m.Increment(ctx, "a", 1, time.Second)
+	r.NoError(err)
+	r.Equal(uint64(1), count)
+
+	// expired counters are swept
+	now = now.Add(2 * time.Minute)
+	_, err = m.Increment(ctx, "c", 1, time.Second)
+	r.NoError(err)
+	r.Len(m.counters, 1)
+}
+
+// fakeRedis serves the commands used by the Redis storage.
+type fakeRedis struct {
+	listener net.Listener
+	password string
+
+	mu       sync.Mutex
+	counters map[string]int64
+	ttls     map[string]string
+	conns    int
+}
+
+func newFakeRedis(t *testing.T, password string) *fakeRedis {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	f := &fakeRedis{
+		listener: l,
+		password: password,
+		counters: map[string]int64{},
+		ttls:     map[string]string{},
+	}
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			f.mu.Lock()
+			f.conns++
+			f.mu.Unlock()
+			go f.serve(conn)
+		}
+	}()
+	return f
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	authenticated := f.password == ""
+	for {
+		reply, err := readReply(reader)
+		if err != nil {
+			return
+		}
+		args := reply.([]any)
+		var out string
+		switch cmd := args[0].(string); {
+		case cmd == "AUTH":
+			authenticated = args[1] == f.password
+			out = "+OK\r\n"
+			if !authenticated {
+				out = "-WRONGPASS invalid password\r\n"
+			}
+		case !authenticated:
+			out = "-NOAUTH Authentication required.\r\n"
+		case cmd == "SELECT":
+			out = "+OK\r\n"
+		case cmd == "EVAL":
+			key := args[3].(string)
+			hits, _ := strconv.ParseInt(args[4].(string), 10, 64)
+			f.mu.Lock()
+			f.counters[key] += hits
+			if f.counters[key] == hits {
+				f.ttls[key] = args[5].(string)
+			}
+			out = ":" + strconv.FormatInt(f.counters[key], 10) + "\r\n"
+			f.mu.Unlock()
+		default:
+			out = "-ERR unknown command\r\n"
+		}
+		if _, err := conn.Write([]byte(out)); err != nil {
+			return
+		}
+	}
+}
+
+func TestRedisIncrement(t *testing.T) {
+	r := require.New(t)
+	fake := newFakeRedis(t, "secret")
+	s := NewRedis(RedisOptions{Address: fake.listener.Addr().String(), Password: "secret", DB: 2})
+	defer s.Close()
+	ctx := context.Background()
+
+	count, err := s.Increment(ctx, "a", 1, time.Minute)
+	r.NoError(err)
+	r.Equal(uint64(1), count)
+	count, err = s.Increment(ctx, "a", 2, time.Minute)
+	r.NoError(err)
+	r.Equal(uint64(3), count)
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	r.Equal("60000", fake.ttls["a"])
+	// the connection is reused
+	r.Equal(1, fake.conns)
+}
+
+func TestRedisWrongPassword(t *testing.T) {
+	fake := newFakeRedis(t, "secret")
+	s := NewRedis(RedisOptions{Address: fake.listener.Addr().String(), Password: "other"})
+	defer s.Close()
+
+	_, err := s.Increment(context.Background(), "a", 1, time.Minute)
+	require.ErrorContains(t, err, "failed to authenticate to redis: redis: WRONGPASS")
+}
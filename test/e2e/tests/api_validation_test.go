@@ -46,7 +46,7 @@ spec:
     - host: example.com
       port: 80
 `,
-			wantErrors: []string{"exactly one of the fields in [aws static dynamicForwardProxy] must be set"},
+			wantErrors: []string{"exactly one of the fields in [aws static dynamicForwardProxy redis] must be set"},
 		},
 		{
 			name: "Backend: empty lambda qualifier does not match pattern",
@@ -65,6 +65,173 @@ spec:
 `,
 			wantErrors: []string{"spec.aws.lambda.qualifier in body should match "},
 		},
+		{
+			name: "Backend: lambda functionURL does not support Async",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: Backend
+metadata:
+  name: backend-lambda-url-async
+spec:
+  type: AWS
+  aws:
+    accountId: "000000000000"
+    lambda:
+      functionName: hello-function
+      functionURL: https://abcdefg.lambda-url.us-east-1.on.aws
+      invocationMode: Async
+`,
+			wantErrors: []string{"invocationMode 'Async' is not supported with functionURL"},
+		},
+		{
+			name: "Backend: lambda endpointURL and functionURL are mutually exclusive",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: Backend
+metadata:
+  name: backend-lambda-url-and-endpoint
+spec:
+  type: AWS
+  aws:
+    accountId: "000000000000"
+    lambda:
+      functionName: hello-function
+      endpointURL: http://172.18.0.2:31566
+      functionURL: https://abcdefg.lambda-url.us-east-1.on.aws
+`,
+			wantErrors: []string{"at most one of the fields in [endpointURL functionURL] may be set"},
+		},
+		{
+			name: "Backend: enforce ExactlyOneOf for aws lambda and service",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: Backend
+metadata:
+  name: backend-aws-lambda-and-service
+spec:
+  type: AWS
+  aws:
+    accountId: "000000000000"
+    lambda:
+      functionName: hello-function
+    service:
+      name: s3
+`,
+			wantErrors: []string{"exactly one of the fields in [lambda service] must be set"},
+		},
+		{
+			name: "Backend: aws requires accountId",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: Backend
+metadata:
+  name: backend-lambda-no-account
+spec:
+  type: AWS
+  aws:
+    lambda:
+      functionName: hello-function
+`,
+			wantErrors: []string{"spec.aws.accountId: Required value"},
+		},
+		{
+			name: "Backend: aws execute-api service requires endpointURL",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: Backend
+metadata:
+  name: backend-aws-service-no-endpoint
+spec:
+  type: AWS
+  aws:
+    accountId: "000000000000"
+    region: us-west-2
+    service:
+      name: execute-api
+`,
+			wantErrors: []string{"endpointURL must be specified for the execute-api, es and aoss services"},
+		},
+		{
+			name: "Backend: aws execute-api service with endpointURL",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: Backend
+metadata:
+  name: backend-aws-service
+spec:
+  type: AWS
+  aws:
+    accountId: "000000000000"
+    region: us-west-2
+    service:
+      name: execute-api
+      endpointURL: https://abc123.execute-api.us-west-2.amazonaws.com
+`,
+		},
+		{
+			name: "Backend: redis backend must be specified when type is Redis",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: Backend
+metadata:
+  name: backend-redis-missing
+spec:
+  type: Redis
+  static:
+    hosts:
+    - host: redis.example.com
+      port: 6379
+`,
+			wantErrors: []string{"redis backend must be specified when type is 'Redis'"},
+		},
+		{
+			name: "Backend: redis readPolicy requires cluster",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: Backend
+metadata:
+  name: backend-redis-read-policy
+spec:
+  type: Redis
+  redis:
+    hosts:
+    - host: redis.example.com
+      port: 6379
+    readPolicy: Replica
+`,
+			wantErrors: []string{"readPolicy requires cluster to be set"},
+		},
+		{
+			name: "Backend: redis with prefix routes and mirrors",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: Backend
+metadata:
+  name: backend-redis
+spec:
+  type: Redis
+  redis:
+    hosts:
+    - host: redis.example.com
+      port: 6379
+    cluster:
+      refreshRate: 5s
+    readPolicy: PreferReplica
+    authSecretRef:
+      name: redis-auth
+    prefixRoutes:
+    - prefix: "tenant-a:"
+      removePrefix: true
+      hosts:
+      - host: redis-a.example.com
+        port: 6379
+      mirrors:
+      - hosts:
+        - host: redis-shadow.example.com
+          port: 6379
+        percentage: 10
+`,
+		},
 		{
 			name: "BackendConfigPolicy: enforce AtMostOneOf for HTTP protocol options",
 			input: `---
@@ -215,6 +382,193 @@ spec:
 `,
 			wantErrors: []string{"Aggression, if specified, must be a string representing a number greater than 0.0"},
 		},
+		{
+			name: "BackendConfigPolicy: localZonePreference and localityWeights are mutually exclusive",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: BackendConfigPolicy
+metadata:
+  name: backend-config-zone-aware-and-weights
+spec:
+  targetRefs:
+  - group: ""
+    kind: Service
+    name: test-service
+  loadBalancer:
+    roundRobin: {}
+    localZonePreference:
+      localPercentage: 90
+    localityWeights:
+    - region: us-east-1
+      weight: 1
+`,
+			wantErrors: []string{"localZonePreference and localityWeights are mutually exclusive"},
+		},
+		{
+			name: "BackendConfigPolicy: locality weight subZone requires zone",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: BackendConfigPolicy
+metadata:
+  name: backend-config-locality-weight-subzone
+spec:
+  targetRefs:
+  - group: ""
+    kind: Service
+    name: test-service
+  loadBalancer:
+    roundRobin: {}
+    localityWeights:
+    - region: us-east-1
+      subZone: rack-1
+      weight: 1
+`,
+			wantErrors: []string{"zone must be set when subZone is set"},
+		},
+		{
+			name: "BackendConfigPolicy: health check requires exactly one checker",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: BackendConfigPolicy
+metadata:
+  name: backend-config-hc-multiple-checkers
+spec:
+  targetRefs:
+  - group: ""
+    kind: Service
+    name: test-service
+  healthCheck:
+    timeout: 1s
+    interval: 5s
+    unhealthyThreshold: 2
+    healthyThreshold: 1
+    http:
+      path: /healthz
+    tcp: {}
+`,
+			wantErrors: []string{"exactly one of the fields in [http grpc tcp] must be set"},
+		},
+		{
+			name: "BackendConfigPolicy: invalid health check payload",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: BackendConfigPolicy
+metadata:
+  name: backend-config-hc-invalid-values
+spec:
+  targetRefs:
+  - group: ""
+    kind: Service
+    name: test-service
+  healthCheck:
+    timeout: 1s
+    interval: 5s
+    unhealthyThreshold: 2
+    healthyThreshold: 1
+    tcp:
+      send: PING
+`,
+			wantErrors: []string{"spec.healthCheck.tcp.send: Invalid value"},
+		},
+		{
+			name: "BackendConfigPolicy: invalid health check status range",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: BackendConfigPolicy
+metadata:
+  name: backend-config-hc-invalid-range
+spec:
+  targetRefs:
+  - group: ""
+    kind: Service
+    name: test-service
+  healthCheck:
+    timeout: 1s
+    interval: 5s
+    unhealthyThreshold: 2
+    healthyThreshold: 1
+    http:
+      path: /healthz
+      expectedStatuses:
+      - start: 300
+        end: 200
+`,
+			wantErrors: []string{"start must be less than end"},
+		},
+		{
+			name: "BackendConfigPolicy: consecutiveLocalOriginFailure requires splitExternalLocalOriginErrors",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: BackendConfigPolicy
+metadata:
+  name: backend-config-od-local-origin
+spec:
+  targetRefs:
+  - group: ""
+    kind: Service
+    name: test-service
+  outlierDetection:
+    consecutiveLocalOriginFailure: 2
+`,
+			wantErrors: []string{"consecutiveLocalOriginFailure requires splitExternalLocalOriginErrors to be true"},
+		},
+		{
+			name: "BackendConfigPolicy: maxEjectionTime less than baseEjectionTime",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: BackendConfigPolicy
+metadata:
+  name: backend-config-od-max-ejection-time
+spec:
+  targetRefs:
+  - group: ""
+    kind: Service
+    name: test-service
+  outlierDetection:
+    baseEjectionTime: 1m
+    maxEjectionTime: 30s
+`,
+			wantErrors: []string{"maxEjectionTime must not be less than baseEjectionTime"},
+		},
+		{
+			name: "BackendConfigPolicy: preconnect ratios out of range",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: BackendConfigPolicy
+metadata:
+  name: backend-config-preconnect
+spec:
+  targetRefs:
+  - group: ""
+    kind: Service
+    name: test-service
+  preconnect:
+    perUpstreamRatio: "5"
+    predictiveRatio: "0.5"
+`,
+			wantErrors: []string{
+				"perUpstreamRatio must be a decimal number between 1 and 3",
+				"predictiveRatio must be a decimal number between 1 and 3",
+			},
+		},
+		{
+			name: "BackendConfigPolicy: http2 connection keepalive without interval",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: BackendConfigPolicy
+metadata:
+  name: backend-config-h2-keepalive
+spec:
+  targetRefs:
+  - group: ""
+    kind: Service
+    name: test-service
+  http2ProtocolOptions:
+    connectionKeepalive:
+      timeout: 5s
+`,
+			wantErrors: []string{"at least one of the fields in [interval connectionIdleInterval] must be set"},
+		},
 		{
 			name: "BackendConfigPolicy: invalid durations",
 			input: `---
@@ -313,6 +667,219 @@ spec:
 `,
 			wantErrors: []string{"autoHostRewrite can only be used when targeting HTTPRoute resources"},
 		},
+		{
+			name: "TrafficPolicy: jwt routeMatch can only target HTTPRoute",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: TrafficPolicy
+metadata:
+  name: traffic-policy-jwt-route-match-invalid-target
+spec:
+  targetRefs:
+  - group: gateway.networking.k8s.io
+    kind: Gateway
+    name: test-gateway
+  jwt:
+    extensionRef:
+      name: jwt
+    routeMatch:
+    - path: ["tier"]
+      exact: premium
+`,
+			wantErrors: []string{"jwt.routeMatch can only be used when targeting HTTPRoute resources"},
+		},
+		{
+			name: "TrafficPolicy: jwt claims cannot be set with disable",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: TrafficPolicy
+metadata:
+  name: traffic-policy-jwt-claims-disable
+spec:
+  targetRefs:
+  - group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: test-route
+  jwt:
+    disable: {}
+    requiredScopes: ["read"]
+`,
+			wantErrors: []string{"requiredScopes, claims and routeMatch cannot be set when disable is set"},
+		},
+		{
+			name: "TrafficPolicy: basicAuth verifier cannot be set with disable",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: TrafficPolicy
+metadata:
+  name: traffic-policy-basic-auth-verifier-disable
+spec:
+  targetRefs:
+  - group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: test-route
+  basicAuth:
+    disable: {}
+    verifier: Kgateway
+`,
+			wantErrors: []string{"verifier cannot be set when disable is set"},
+		},
+		{
+			name: "TrafficPolicy: apiKeyAuthentication metadata requires exactly one of label or field",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: TrafficPolicy
+metadata:
+  name: traffic-policy-api-key-metadata-oneof
+spec:
+  targetRefs:
+  - group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: test-route
+  apiKeyAuthentication:
+    keyFormat: SHA256
+    secretRef:
+      name: api-keys
+    metadata:
+    - name: plan
+      label: example.com/plan
+      field: plan
+`,
+			wantErrors: []string{"exactly one of the fields in [label field] must be set"},
+		},
+		{
+			name: "TrafficPolicy: rateLimit APIKeyMetadata entry requires apiKeyMetadata",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: TrafficPolicy
+metadata:
+  name: traffic-policy-ratelimit-api-key-metadata
+spec:
+  targetRefs:
+  - group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: test-route
+  rateLimit:
+    global:
+      extensionRef:
+        name: ratelimit
+      descriptors:
+      - entries:
+        - type: APIKeyMetadata
+`,
+			wantErrors: []string{"exactly one entry type must be specified"},
+		},
+		{
+			name: "TrafficPolicy: extAuth failOpen cannot be set with disable",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: TrafficPolicy
+metadata:
+  name: traffic-policy-extauth-disable-fail-open
+spec:
+  targetRefs:
+  - group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: test-route
+  extAuth:
+    disable: {}
+    failOpen: true
+`,
+			wantErrors: []string{"failOpen and decisionCache cannot be set when disable is set"},
+		},
+		{
+			name: "TrafficPolicy: extAuth decisionCache requires exactly one of ttl or disable",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: TrafficPolicy
+metadata:
+  name: traffic-policy-extauth-decision-cache-oneof
+spec:
+  targetRefs:
+  - group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: test-route
+  extAuth:
+    extensionRef:
+      name: ext-auth
+    decisionCache:
+      ttl: 10s
+      disable: {}
+`,
+			wantErrors: []string{"exactly one of the fields in [ttl disable] must be set"},
+		},
+		{
+			name: "GatewayExtension: extAuth decisionCache ttl must be between 1s and 1h",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: GatewayExtension
+metadata:
+  name: gateway-extension-extauth-decision-cache-ttl
+spec:
+  type: ExtAuth
+  extAuth:
+    grpcService:
+      backendRef:
+        name: ext-authz
+        port: 9000
+    decisionCache:
+      ttl: 2h
+      key:
+        headers:
+        - authorization
+`,
+			wantErrors: []string{"ttl must be between 1s and 1h"},
+		},
+		{
+			name: "APIConsumer: credentials require at least one principal",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: APIConsumer
+metadata:
+  name: api-consumer-no-credentials
+spec:
+  credentials: {}
+  plan:
+    name: gold
+`,
+			wantErrors: []string{"at least one of the fields in [apiKeys jwt basicAuth mtls] must be set"},
+		},
+		{
+			name: "APIConsumer: jwt principal requires subject or clientId",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: APIConsumer
+metadata:
+  name: api-consumer-jwt-issuer-only
+spec:
+  credentials:
+    jwt:
+    - issuer: https://issuer.example.com
+`,
+			wantErrors: []string{"at least one of the fields in [subject clientId] must be set"},
+		},
+		{
+			name: "TrafficPolicy: jwt claim match requires exactly one of exact or contains",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: TrafficPolicy
+metadata:
+  name: traffic-policy-jwt-claim-oneof
+spec:
+  targetRefs:
+  - group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: test-route
+  jwt:
+    extensionRef:
+      name: jwt
+    claims:
+    - path: ["roles"]
+      exact: admin
+      contains: admin
+`,
+			wantErrors: []string{"exactly one of the fields in [exact contains] must be set"},
+		},
 		{
 			name: "HTTPListenerPolicy: valid target references",
 			input: `---
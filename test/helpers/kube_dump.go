@@ -127,6 +127,7 @@ func recordKubeState(ctx context.Context, kubectlCli *kubectl.Cli, f *os.File) {
 		"tlsroutes.gateway.networking.k8s.io",
 		"udproutes.gateway.networking.k8s.io",
 		// kgateway resources
+		"apiconsumers.gateway.kgateway.dev",
 		"backends.gateway.kgateway.dev",
 		"backendconfigpolicies.gateway.kgateway.dev",
 		"directresponses.gateway.kgateway.dev",
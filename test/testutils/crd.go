@@ -31,6 +31,7 @@ var AllCRDs = []schema.GroupVersionResource{
 	gvr.GRPCRoute,
 	gvr.TCPRoute,
 	gvr.TLSRoute,
+	gvr.UDPRoute,
 	gvr.ReferenceGrant,
 	gvr.BackendTLSPolicy,
 	gvr.XListenerSet,
@@ -52,6 +53,7 @@ var AllCRDs = []schema.GroupVersionResource{
 	wellknown.DirectResponseGVR,
 	wellknown.GatewayExtensionGVR,
 	wellknown.GatewayParametersGVR,
+	wellknown.APIConsumerGVR,
 	// agentgateway api
 	wellknown.AgentgatewayBackendGVR,
 	wellknown.AgentgatewayParametersGVR,